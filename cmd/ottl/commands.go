@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// options are the flags shared by all commands.
+type options struct {
+	signal     string
+	context    string
+	statements []string
+	conditions []string
+	config     string
+	input      string
+	errorMode  string
+}
+
+func (o *options) flags(cmd *cobra.Command) {
+	fs := cmd.Flags()
+	fs.StringArrayVarP(&o.statements, "statement", "s", nil, "OTTL statement, can be repeated")
+	fs.StringArrayVarP(&o.conditions, "condition", "c", nil, "OTTL condition, can be repeated. Along with statements, the statements are only executed for the items matching any condition")
+	fs.StringVar(&o.context, "context", "", "OTTL context of the statements and conditions, inferred from their paths when empty")
+	fs.StringVar(&o.signal, "signal", "", "signal of the statements and conditions: traces, metrics or logs. Detected from the input when empty")
+	fs.StringVar(&o.config, "config", "", "transformprocessor configuration file whose trace_statements, metric_statements and log_statements are used")
+	fs.StringVarP(&o.input, "input", "i", "", `OTLP JSON file containing traces, metrics or logs, "-" for stdin`)
+	fs.StringVar(&o.errorMode, "error-mode", string(ottl.PropagateError), "how to handle errors when executing statements: propagate, ignore or silent")
+}
+
+// groups returns the groups defined by the config file and the flags. The signal of the flags
+// defaults to the signal of the data, when given.
+func (o *options) groups(data *internal.Data) ([]internal.Group, error) {
+	var groups []internal.Group
+	if o.config != "" {
+		configGroups, err := internal.ReadTransformConfig(o.config)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, configGroups...)
+	}
+
+	if len(o.statements) > 0 || len(o.conditions) > 0 {
+		var signal internal.Signal
+		switch {
+		case o.signal != "":
+			var err error
+			if signal, err = internal.ParseSignal(o.signal); err != nil {
+				return nil, err
+			}
+		case data != nil:
+			signal = data.Signal
+		default:
+			return nil, errors.New("--signal or --input is required to know the signal of the statements and conditions")
+		}
+		groups = append(groups, internal.Group{
+			Signal:     signal,
+			Context:    o.context,
+			Statements: o.statements,
+			Conditions: o.conditions,
+		})
+	}
+
+	if len(groups) == 0 {
+		return nil, errors.New("no statements or conditions, use --statement, --condition or --config")
+	}
+	return groups, nil
+}
+
+func (o *options) readInput(cmd *cobra.Command, required bool) (*internal.Data, error) {
+	if o.input == "" {
+		if required {
+			return nil, errors.New("--input is required")
+		}
+		return nil, nil
+	}
+	if o.input == "-" {
+		return internal.ReadData(cmd.InOrStdin())
+	}
+	f, err := os.Open(o.input)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return internal.ReadData(f)
+}
+
+// compile reads the input when given, and compiles the statements and conditions. Compilation
+// errors are written to the command error output.
+func (o *options) compile(cmd *cobra.Command, inputRequired bool) (*internal.Program, *internal.Data, error) {
+	data, err := o.readInput(cmd, inputRequired)
+	if err != nil {
+		return nil, nil, err
+	}
+	groups, err := o.groups(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var errorMode ottl.ErrorMode
+	if err = errorMode.UnmarshalText([]byte(o.errorMode)); err != nil {
+		return nil, nil, err
+	}
+
+	program, err := internal.Compile(componenttest.NewNopTelemetrySettings(), groups, errorMode)
+	if err != nil {
+		internal.WriteErrors(cmd.ErrOrStderr(), err)
+		return nil, nil, errors.New("invalid statements or conditions")
+	}
+	return program, data, nil
+}
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "ottl",
+		Short: "ottl validates, evaluates and benchmarks OTTL statements and conditions against OTLP JSON data",
+		Example: `ottl validate --signal logs -s 'set(log.attributes["env"], "prod")'
+ottl eval -i logs.json -s 'set(log.attributes["env"], "prod")'
+ottl eval -i traces.json --config transform.yaml
+ottl bench -i metrics.json --context datapoint -c 'attributes["http.route"] == nil' -n 10000`,
+		SilenceUsage: true,
+	}
+	root.CompletionOptions.DisableDefaultCmd = true
+	root.AddCommand(newValidateCommand(), newEvalCommand(), newBenchCommand())
+	return root
+}
+
+func newValidateCommand() *cobra.Command {
+	var o options
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "Checks that statements and conditions compile, reporting the position of syntax errors",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			program, _, err := o.compile(cmd, false)
+			if err != nil {
+				return err
+			}
+			_, err = fmt.Fprintf(cmd.OutOrStdout(), "%d statements and conditions are valid\n", len(program.Evaluations()))
+			return err
+		},
+	}
+	o.flags(cmd)
+	return cmd
+}
+
+func newEvalCommand() *cobra.Command {
+	var o options
+	var output string
+	var quiet bool
+	cmd := &cobra.Command{
+		Use:   "eval",
+		Short: "Executes statements and conditions against the input, and prints the transformed data",
+		Long: `Executes statements and conditions against the input, and prints the transformed data as OTLP JSON.
+The number of items each statement or condition was evaluated for and matched, and the time spent evaluating it,
+are written to the error output.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			program, data, err := o.compile(cmd, true)
+			if err != nil {
+				return err
+			}
+			results, err := program.Run(cmd.Context(), data)
+			if err != nil {
+				return err
+			}
+
+			var w io.Writer = cmd.OutOrStdout()
+			if output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			if err = data.WriteJSON(w); err != nil {
+				return err
+			}
+			if quiet {
+				return nil
+			}
+			return internal.WriteResults(cmd.ErrOrStderr(), results, 1)
+		},
+	}
+	o.flags(cmd)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "file the transformed data is written to, instead of the standard output")
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "do not write the evaluation statistics")
+	return cmd
+}
+
+func newBenchCommand() *cobra.Command {
+	var o options
+	var iterations int
+	cmd := &cobra.Command{
+		Use:   "bench",
+		Short: "Repeatedly executes statements and conditions against copies of the input, and reports their timing",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if iterations < 1 {
+				return errors.New("--iterations must be positive")
+			}
+			program, data, err := o.compile(cmd, true)
+			if err != nil {
+				return err
+			}
+			results, err := internal.Benchmark(cmd.Context(), program, data, iterations)
+			if err != nil {
+				return err
+			}
+			return internal.WriteResults(cmd.OutOrStdout(), results, iterations)
+		},
+	}
+	o.flags(cmd)
+	cmd.Flags().IntVarP(&iterations, "iterations", "n", 1000, "number of times the statements and conditions are executed")
+	return cmd
+}
@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package main contains the ottl command, to validate, evaluate and benchmark OTTL statements
+// and conditions against OTLP JSON data, outside of a collector.
+package main // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl"
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl/internal"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Group is a list of OTTL statements or conditions of a signal, evaluated together.
+type Group struct {
+	Signal Signal
+	// Context is the OTTL context of the statements and conditions. When empty, it is
+	// inferred from their paths.
+	Context string
+	// Statements are executed in order, for each item of the context.
+	Statements []string
+	// Conditions restrict the items the statements are executed for to the ones matching any
+	// of the conditions, like the conditions of a transformprocessor context group. When the group
+	// has no statements, the conditions are evaluated on their own and their matches are reported.
+	Conditions []string
+}
+
+// transformConfig is the subset of the transformprocessor configuration holding statements.
+type transformConfig struct {
+	TraceStatements  []yaml.Node `yaml:"trace_statements"`
+	MetricStatements []yaml.Node `yaml:"metric_statements"`
+	LogStatements    []yaml.Node `yaml:"log_statements"`
+}
+
+type contextStatements struct {
+	Context    string   `yaml:"context"`
+	Statements []string `yaml:"statements"`
+	Conditions []string `yaml:"conditions"`
+}
+
+// ReadTransformConfig reads the statements of a transformprocessor configuration file, that is the
+// content of a `transform` processor entry of a collector configuration.
+// Statements can either be grouped by context, or be listed as plain strings whose context is inferred.
+func ReadTransformConfig(path string) ([]Group, error) {
+	buf, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg transformConfig
+	if err = yaml.Unmarshal(buf, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to read %q: %w", path, err)
+	}
+
+	var groups []Group
+	for _, section := range []struct {
+		signal Signal
+		nodes  []yaml.Node
+	}{
+		{Traces, cfg.TraceStatements},
+		{Metrics, cfg.MetricStatements},
+		{Logs, cfg.LogStatements},
+	} {
+		for _, node := range section.nodes {
+			group, err := decodeGroup(section.signal, &node)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %q: %w", path, err)
+			}
+			groups = append(groups, group)
+		}
+	}
+	if len(groups) == 0 {
+		return nil, fmt.Errorf("%q does not define any trace_statements, metric_statements or log_statements", path)
+	}
+	return groups, nil
+}
+
+func decodeGroup(signal Signal, node *yaml.Node) (Group, error) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return Group{Signal: signal, Statements: []string{node.Value}}, nil
+	case yaml.MappingNode:
+		var cs contextStatements
+		if err := node.Decode(&cs); err != nil {
+			return Group{}, err
+		}
+		if len(cs.Statements) == 0 {
+			return Group{}, fmt.Errorf("line %d: a context group must define statements", node.Line)
+		}
+		return Group{Signal: signal, Context: cs.Context, Statements: cs.Statements, Conditions: cs.Conditions}, nil
+	default:
+		return Group{}, errors.New("statements must be strings or context groups")
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ReadTransformConfig(t *testing.T) {
+	groups, err := ReadTransformConfig(filepath.Join("..", "testdata", "transform.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, []Group{
+		{
+			Signal:     Traces,
+			Context:    "span",
+			Statements: []string{`set(attributes["health_check"], true)`},
+			Conditions: []string{`attributes["http.route"] == "/health"`},
+		},
+		{
+			Signal:     Traces,
+			Statements: []string{`set(resource.attributes["env"], "prod")`},
+		},
+		{
+			Signal:     Logs,
+			Context:    "log",
+			Statements: []string{`set(attributes["user.email"], "redacted") where attributes["user.email"] != nil`},
+		},
+	}, groups)
+}
+
+func Test_ReadTransformConfig_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "no statements",
+			content: "error_mode: ignore\n",
+			wantErr: "does not define any trace_statements, metric_statements or log_statements",
+		},
+		{
+			name:    "group without statements",
+			content: "log_statements:\n  - context: log\n    conditions: [\"true\"]\n",
+			wantErr: "line 2: a context group must define statements",
+		},
+		{
+			name:    "invalid entry",
+			content: "log_statements:\n  - [a, b]\n",
+			wantErr: "statements must be strings or context groups",
+		},
+		{
+			name:    "invalid yaml",
+			content: "log_statements: [",
+			wantErr: "failed to read",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "transform.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+			_, err := ReadTransformConfig(path)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
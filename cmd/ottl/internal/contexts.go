@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl/internal"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottldatapoint"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlmetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlresource"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlscope"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspanevent"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// Contexts returns the names of the OTTL contexts available for the signal.
+func Contexts(signal Signal) []string {
+	switch signal {
+	case Traces:
+		return []string{ottlresource.ContextName, ottlscope.ContextName, ottlspan.ContextName, ottlspanevent.ContextName}
+	case Metrics:
+		return []string{ottlresource.ContextName, ottlscope.ContextName, ottlmetric.ContextName, ottldatapoint.ContextName}
+	case Logs:
+		return []string{ottlresource.ContextName, ottlscope.ContextName, ottllog.ContextName}
+	default:
+		return nil
+	}
+}
+
+func newParserCollection(settings component.TelemetrySettings, signal Signal, errorMode ottl.ErrorMode) (*ottl.ParserCollection[*compiledGroup], error) {
+	var options []ottl.ParserCollectionOption[*compiledGroup]
+	add := func(option ottl.ParserCollectionOption[*compiledGroup], err error) error {
+		if err != nil {
+			return err
+		}
+		options = append(options, option)
+		return nil
+	}
+
+	rp, err := ottlresource.NewParser(ottlfuncs.StandardFuncs[ottlresource.TransformContext](), settings, ottlresource.EnablePathContextNames())
+	if err = add(withContext(signal, ottlresource.ContextName, rp, resourceWalker(signal), errorMode), err); err != nil {
+		return nil, err
+	}
+	sp, err := ottlscope.NewParser(ottlfuncs.StandardFuncs[ottlscope.TransformContext](), settings, ottlscope.EnablePathContextNames())
+	if err = add(withContext(signal, ottlscope.ContextName, sp, scopeWalker(signal), errorMode), err); err != nil {
+		return nil, err
+	}
+
+	switch signal {
+	case Traces:
+		spanParser, err := ottlspan.NewParser(ottlfuncs.StandardFuncs[ottlspan.TransformContext](), settings, ottlspan.EnablePathContextNames())
+		if err = add(withContext(signal, ottlspan.ContextName, spanParser, walkSpans, errorMode), err); err != nil {
+			return nil, err
+		}
+		spanEventParser, err := ottlspanevent.NewParser(ottlfuncs.StandardFuncs[ottlspanevent.TransformContext](), settings, ottlspanevent.EnablePathContextNames())
+		if err = add(withContext(signal, ottlspanevent.ContextName, spanEventParser, walkSpanEvents, errorMode), err); err != nil {
+			return nil, err
+		}
+	case Metrics:
+		metricParser, err := ottlmetric.NewParser(ottlfuncs.StandardFuncs[ottlmetric.TransformContext](), settings, ottlmetric.EnablePathContextNames())
+		if err = add(withContext(signal, ottlmetric.ContextName, metricParser, walkMetrics, errorMode), err); err != nil {
+			return nil, err
+		}
+		dataPointParser, err := ottldatapoint.NewParser(ottlfuncs.StandardFuncs[ottldatapoint.TransformContext](), settings, ottldatapoint.EnablePathContextNames())
+		if err = add(withContext(signal, ottldatapoint.ContextName, dataPointParser, walkDataPoints, errorMode), err); err != nil {
+			return nil, err
+		}
+	case Logs:
+		logParser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings, ottllog.EnablePathContextNames())
+		if err = add(withContext(signal, ottllog.ContextName, logParser, walkLogs, errorMode), err); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown signal %q", signal)
+	}
+
+	return ottl.NewParserCollection(settings, options...)
+}
+
+func resourceWalker(signal Signal) walker[ottlresource.TransformContext] {
+	return func(d *Data, fn func(ottlresource.TransformContext) error) error {
+		switch signal {
+		case Traces:
+			for _, rs := range d.Traces.ResourceSpans().All() {
+				if err := fn(ottlresource.NewTransformContext(rs.Resource(), rs)); err != nil {
+					return err
+				}
+			}
+		case Metrics:
+			for _, rm := range d.Metrics.ResourceMetrics().All() {
+				if err := fn(ottlresource.NewTransformContext(rm.Resource(), rm)); err != nil {
+					return err
+				}
+			}
+		case Logs:
+			for _, rl := range d.Logs.ResourceLogs().All() {
+				if err := fn(ottlresource.NewTransformContext(rl.Resource(), rl)); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func scopeWalker(signal Signal) walker[ottlscope.TransformContext] {
+	return func(d *Data, fn func(ottlscope.TransformContext) error) error {
+		switch signal {
+		case Traces:
+			for _, rs := range d.Traces.ResourceSpans().All() {
+				for _, ss := range rs.ScopeSpans().All() {
+					if err := fn(ottlscope.NewTransformContext(ss.Scope(), rs.Resource(), ss)); err != nil {
+						return err
+					}
+				}
+			}
+		case Metrics:
+			for _, rm := range d.Metrics.ResourceMetrics().All() {
+				for _, sm := range rm.ScopeMetrics().All() {
+					if err := fn(ottlscope.NewTransformContext(sm.Scope(), rm.Resource(), sm)); err != nil {
+						return err
+					}
+				}
+			}
+		case Logs:
+			for _, rl := range d.Logs.ResourceLogs().All() {
+				for _, sl := range rl.ScopeLogs().All() {
+					if err := fn(ottlscope.NewTransformContext(sl.Scope(), rl.Resource(), sl)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+}
+
+func walkSpans(d *Data, fn func(ottlspan.TransformContext) error) error {
+	for _, rs := range d.Traces.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				if err := fn(ottlspan.NewTransformContext(span, ss.Scope(), rs.Resource(), ss, rs)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkSpanEvents(d *Data, fn func(ottlspanevent.TransformContext) error) error {
+	for _, rs := range d.Traces.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				for _, event := range span.Events().All() {
+					if err := fn(ottlspanevent.NewTransformContext(event, span, ss.Scope(), rs.Resource(), ss, rs)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkLogs(d *Data, fn func(ottllog.TransformContext) error) error {
+	for _, rl := range d.Logs.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				if err := fn(ottllog.NewTransformContext(lr, sl.Scope(), rl.Resource(), sl, rl)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkMetrics(d *Data, fn func(ottlmetric.TransformContext) error) error {
+	for _, rm := range d.Metrics.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				if err := fn(ottlmetric.NewTransformContext(metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkDataPoints(d *Data, fn func(ottldatapoint.TransformContext) error) error {
+	for _, rm := range d.Metrics.ResourceMetrics().All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, metric := range sm.Metrics().All() {
+				each := func(dataPoint any) error {
+					return fn(ottldatapoint.NewTransformContext(dataPoint, metric, sm.Metrics(), sm.Scope(), rm.Resource(), sm, rm))
+				}
+				var err error
+				switch metric.Type() {
+				case pmetric.MetricTypeGauge:
+					err = eachDataPoint[pmetric.NumberDataPoint](metric.Gauge().DataPoints(), each)
+				case pmetric.MetricTypeSum:
+					err = eachDataPoint[pmetric.NumberDataPoint](metric.Sum().DataPoints(), each)
+				case pmetric.MetricTypeHistogram:
+					err = eachDataPoint[pmetric.HistogramDataPoint](metric.Histogram().DataPoints(), each)
+				case pmetric.MetricTypeExponentialHistogram:
+					err = eachDataPoint[pmetric.ExponentialHistogramDataPoint](metric.ExponentialHistogram().DataPoints(), each)
+				case pmetric.MetricTypeSummary:
+					err = eachDataPoint[pmetric.SummaryDataPoint](metric.Summary().DataPoints(), each)
+				}
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+type dataPointSlice[E any] interface {
+	Len() int
+	At(int) E
+}
+
+func eachDataPoint[E any](dataPoints dataPointSlice[E], fn func(any) error) error {
+	for i := 0; i < dataPoints.Len(); i++ {
+		if err := fn(dataPoints.At(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl/internal"
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Signal is the type of telemetry statements are evaluated against.
+type Signal string
+
+const (
+	Traces  Signal = "traces"
+	Metrics Signal = "metrics"
+	Logs    Signal = "logs"
+)
+
+// ParseSignal returns the Signal with the given name.
+func ParseSignal(name string) (Signal, error) {
+	switch s := Signal(name); s {
+	case Traces, Metrics, Logs:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown signal %q, valid options are: %s, %s, %s", name, Traces, Metrics, Logs)
+	}
+}
+
+// Data is telemetry of a single signal, read from OTLP JSON.
+type Data struct {
+	Signal  Signal
+	Traces  ptrace.Traces
+	Metrics pmetric.Metrics
+	Logs    plog.Logs
+}
+
+// ReadData reads OTLP JSON traces, metrics or logs from r. The signal is detected
+// from the top-level field of the document.
+func ReadData(r io.Reader) (*Data, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(buf, &fields); err != nil {
+		return nil, fmt.Errorf("input is not a JSON object: %w", err)
+	}
+
+	switch {
+	case fields["resourceSpans"] != nil:
+		td, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(buf)
+		if err != nil {
+			return nil, err
+		}
+		return &Data{Signal: Traces, Traces: td}, nil
+	case fields["resourceMetrics"] != nil:
+		md, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(buf)
+		if err != nil {
+			return nil, err
+		}
+		return &Data{Signal: Metrics, Metrics: md}, nil
+	case fields["resourceLogs"] != nil:
+		ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(buf)
+		if err != nil {
+			return nil, err
+		}
+		return &Data{Signal: Logs, Logs: ld}, nil
+	default:
+		return nil, errors.New("input must be OTLP JSON containing resourceSpans, resourceMetrics or resourceLogs")
+	}
+}
+
+// Clone returns a deep copy of the data, so statements can be evaluated repeatedly
+// against the same input.
+func (d *Data) Clone() *Data {
+	clone := &Data{Signal: d.Signal}
+	switch d.Signal {
+	case Traces:
+		clone.Traces = ptrace.NewTraces()
+		d.Traces.CopyTo(clone.Traces)
+	case Metrics:
+		clone.Metrics = pmetric.NewMetrics()
+		d.Metrics.CopyTo(clone.Metrics)
+	case Logs:
+		clone.Logs = plog.NewLogs()
+		d.Logs.CopyTo(clone.Logs)
+	}
+	return clone
+}
+
+// WriteJSON writes the data to w as indented OTLP JSON.
+func (d *Data) WriteJSON(w io.Writer) error {
+	var buf []byte
+	var err error
+	switch d.Signal {
+	case Traces:
+		buf, err = (&ptrace.JSONMarshaler{}).MarshalTraces(d.Traces)
+	case Metrics:
+		buf, err = (&pmetric.JSONMarshaler{}).MarshalMetrics(d.Metrics)
+	case Logs:
+		buf, err = (&plog.JSONMarshaler{}).MarshalLogs(d.Logs)
+	}
+	if err != nil {
+		return err
+	}
+
+	var indented bytes.Buffer
+	if err = json.Indent(&indented, buf, "", "  "); err != nil {
+		return err
+	}
+	indented.WriteByte('\n')
+	_, err = indented.WriteTo(w)
+	return err
+}
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+// Kind is the kind of an Evaluation.
+type Kind string
+
+const (
+	StatementKind Kind = "statement"
+	ConditionKind Kind = "condition"
+)
+
+// Evaluation is a compiled statement or condition.
+type Evaluation struct {
+	Signal  Signal
+	Context string
+	Kind    Kind
+	Text    string
+}
+
+// Result holds the statistics of an Evaluation, accumulated over all the items of its context.
+type Result struct {
+	Evaluation
+	// Evaluated is the number of items the statement or condition was evaluated for.
+	Evaluated int
+	// Matched is the number of items for which the condition, or the where clause of the statement, was true.
+	Matched int
+	// Errors is the number of failed evaluations, when errors are ignored.
+	Errors int
+	// Duration is the total time spent evaluating the statement or condition.
+	Duration time.Duration
+}
+
+// compiledGroup is the representation of a Group returned by the ParserCollection.
+// conditions holds the []*ottl.Condition[K] of the context, so they can be used to
+// gate the statements of the group.
+type compiledGroup struct {
+	evaluations []Evaluation
+	conditions  any
+	run         func(ctx context.Context, d *Data, results []Result) error
+}
+
+// Program is a list of compiled Groups.
+type Program struct {
+	groups []*compiledGroup
+}
+
+// Evaluations returns the statements and conditions of the program, in order.
+func (p *Program) Evaluations() []Evaluation {
+	var evaluations []Evaluation
+	for _, g := range p.groups {
+		evaluations = append(evaluations, g.evaluations...)
+	}
+	return evaluations
+}
+
+// Run evaluates the groups of the data signal against the data, modifying it.
+// It returns the results of these groups' statements and conditions.
+func (p *Program) Run(ctx context.Context, d *Data) ([]Result, error) {
+	var results []Result
+	for _, g := range p.groups {
+		if g.evaluations[0].Signal != d.Signal {
+			continue
+		}
+		groupResults := make([]Result, len(g.evaluations))
+		for i, e := range g.evaluations {
+			groupResults[i].Evaluation = e
+		}
+		if err := g.run(ctx, d, groupResults); err != nil {
+			return nil, err
+		}
+		results = append(results, groupResults...)
+	}
+	return results, nil
+}
+
+// Error is the error of a statement or condition that failed to compile.
+type Error struct {
+	Group int
+	Kind  Kind
+	// Text is the statement or condition, empty when the error concerns the whole group.
+	Text string
+	Err  error
+}
+
+func (e *Error) Error() string {
+	if e.Text == "" {
+		return fmt.Sprintf("group %d: %v", e.Group, e.Err)
+	}
+	return fmt.Sprintf("%s %q: %v", e.Kind, e.Text, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Compile compiles the groups using the OTTL standard functions. All the compilation errors are
+// returned, joined, as *Error.
+// With the ignore or silent error modes, failed evaluations are counted in the results instead of
+// interrupting the run.
+func Compile(settings component.TelemetrySettings, groups []Group, errorMode ottl.ErrorMode) (*Program, error) {
+	collections := map[Signal]*ottl.ParserCollection[*compiledGroup]{}
+	var errs []error
+	program := &Program{}
+	for i, group := range groups {
+		pc, ok := collections[group.Signal]
+		if !ok {
+			var err error
+			pc, err = newParserCollection(settings, group.Signal, errorMode)
+			if err != nil {
+				return nil, err
+			}
+			collections[group.Signal] = pc
+		}
+
+		compiled, err := compileGroup(pc, group)
+		if err != nil {
+			errs = append(errs, groupErrors(pc, i, group, err)...)
+			continue
+		}
+		program.groups = append(program.groups, compiled)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return program, nil
+}
+
+func compileGroup(pc *ottl.ParserCollection[*compiledGroup], group Group) (*compiledGroup, error) {
+	getter := &groupGetter{group: group}
+	switch {
+	case len(group.Statements) == 0 && group.Context == "":
+		return pc.ParseConditions(getter)
+	case len(group.Statements) == 0:
+		return pc.ParseConditionsWithContext(group.Context, getter, true)
+	case group.Context == "":
+		return pc.ParseStatements(getter, ottl.WithContextInferenceConditions(group.Conditions))
+	default:
+		return pc.ParseStatementsWithContext(group.Context, getter, true)
+	}
+}
+
+// groupErrors finds which statements or conditions of a group failed to compile, by compiling them
+// one by one. If none fails on its own, the group error is returned.
+func groupErrors(pc *ottl.ParserCollection[*compiledGroup], index int, group Group, groupErr error) []error {
+	var errs []error
+	check := func(kind Kind, text string, single Group) {
+		if _, err := compileGroup(pc, single); err != nil {
+			errs = append(errs, &Error{Group: index, Kind: kind, Text: text, Err: err})
+		}
+	}
+	for _, statement := range group.Statements {
+		check(StatementKind, statement, Group{Signal: group.Signal, Context: group.Context, Statements: []string{statement}})
+	}
+	for _, condition := range group.Conditions {
+		check(ConditionKind, condition, Group{Signal: group.Signal, Context: group.Context, Conditions: []string{condition}})
+	}
+	if len(errs) == 0 {
+		kind := StatementKind
+		if len(group.Statements) == 0 {
+			kind = ConditionKind
+		}
+		errs = append(errs, &Error{Group: index, Kind: kind, Err: groupErr})
+	}
+	return errs
+}
+
+// groupGetter gives the statements and conditions of a Group to the ParserCollection.
+type groupGetter struct {
+	group Group
+}
+
+func (g *groupGetter) GetStatements() []string {
+	return g.group.Statements
+}
+
+func (g *groupGetter) GetConditions() []string {
+	return g.group.Conditions
+}
+
+// walker calls fn for each item of a context within the data.
+type walker[K any] func(d *Data, fn func(tCtx K) error) error
+
+// withContext registers an OTTL context in the ParserCollection, converting parsed statements and
+// conditions to a compiledGroup evaluating them for each item walked.
+func withContext[K any](signal Signal, name string, parser ottl.Parser[K], walk walker[K], errorMode ottl.ErrorMode) ottl.ParserCollectionOption[*compiledGroup] {
+	failed := func(result *Result, err error) error {
+		result.Errors++
+		if errorMode == ottl.PropagateError {
+			return fmt.Errorf("failed to execute %s %q: %w", result.Kind, result.Text, err)
+		}
+		return nil
+	}
+
+	// evalConditions evaluates the conditions, stopping at the first match, and records the results.
+	evalConditions := func(ctx context.Context, tCtx K, conditions []*ottl.Condition[K], results []Result) (bool, error) {
+		for i, condition := range conditions {
+			start := time.Now()
+			matched, err := condition.Eval(ctx, tCtx)
+			results[i].Duration += time.Since(start)
+			results[i].Evaluated++
+			if err != nil {
+				if err = failed(&results[i], err); err != nil {
+					return false, err
+				}
+				continue
+			}
+			if matched {
+				results[i].Matched++
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	evaluations := func(kind Kind, texts []string) []Evaluation {
+		evaluations := make([]Evaluation, len(texts))
+		for i, text := range texts {
+			evaluations[i] = Evaluation{Signal: signal, Context: name, Kind: kind, Text: text}
+		}
+		return evaluations
+	}
+
+	return ottl.WithParserCollectionContext(name, &parser,
+		ottl.WithConditionConverter(func(_ *ottl.ParserCollection[*compiledGroup], getter ottl.ConditionsGetter, conditions []*ottl.Condition[K]) (*compiledGroup, error) {
+			return &compiledGroup{
+				evaluations: evaluations(ConditionKind, getter.GetConditions()),
+				conditions:  conditions,
+				run: func(ctx context.Context, d *Data, results []Result) error {
+					return walk(d, func(tCtx K) error {
+						// Conditions evaluated on their own are all evaluated for every item.
+						for i, condition := range conditions {
+							if _, err := evalConditions(ctx, tCtx, []*ottl.Condition[K]{condition}, results[i:i+1]); err != nil {
+								return err
+							}
+						}
+						return nil
+					})
+				},
+			}, nil
+		}),
+		ottl.WithStatementConverter(func(pc *ottl.ParserCollection[*compiledGroup], getter ottl.StatementsGetter, statements []*ottl.Statement[K]) (*compiledGroup, error) {
+			var gate []*ottl.Condition[K]
+			var gateTexts []string
+			if g, ok := getter.(*groupGetter); ok && len(g.group.Conditions) > 0 {
+				compiled, err := pc.ParseConditionsWithContext(name, ottl.NewConditionsGetter(g.group.Conditions), true)
+				if err != nil {
+					return nil, err
+				}
+				gate = compiled.conditions.([]*ottl.Condition[K])
+				gateTexts = g.group.Conditions
+			}
+
+			return &compiledGroup{
+				evaluations: append(evaluations(ConditionKind, gateTexts), evaluations(StatementKind, getter.GetStatements())...),
+				run: func(ctx context.Context, d *Data, results []Result) error {
+					gateResults, statementResults := results[:len(gate)], results[len(gate):]
+					return walk(d, func(tCtx K) error {
+						if len(gate) > 0 {
+							matched, err := evalConditions(ctx, tCtx, gate, gateResults)
+							if err != nil || !matched {
+								return err
+							}
+						}
+						for i, statement := range statements {
+							start := time.Now()
+							_, matched, err := statement.Execute(ctx, tCtx)
+							statementResults[i].Duration += time.Since(start)
+							statementResults[i].Evaluated++
+							if err != nil {
+								if err = failed(&statementResults[i], err); err != nil {
+									return err
+								}
+								continue
+							}
+							if matched {
+								statementResults[i].Matched++
+							}
+						}
+						return nil
+					})
+				},
+			}, nil
+		}),
+	)
+}
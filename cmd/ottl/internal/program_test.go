@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func readTestData(t *testing.T, name string) *Data {
+	f, err := os.Open(filepath.Join("..", "testdata", name))
+	require.NoError(t, err)
+	defer f.Close()
+	d, err := ReadData(f)
+	require.NoError(t, err)
+	return d
+}
+
+type resultCounts struct {
+	context   string
+	kind      Kind
+	evaluated int
+	matched   int
+	errors    int
+}
+
+func counts(results []Result) []resultCounts {
+	c := make([]resultCounts, len(results))
+	for i, r := range results {
+		c[i] = resultCounts{context: r.Context, kind: r.Kind, evaluated: r.Evaluated, matched: r.Matched, errors: r.Errors}
+	}
+	return c
+}
+
+func Test_Program_Run(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		groups []Group
+		want   []resultCounts
+		check  func(t *testing.T, d *Data)
+	}{
+		{
+			name:  "span statements with inferred context",
+			input: "traces.json",
+			groups: []Group{{
+				Signal:     Traces,
+				Statements: []string{`set(span.attributes["db"], true) where span.attributes["db.system"] != nil`},
+			}},
+			want: []resultCounts{{"span", StatementKind, 2, 1, 0}},
+			check: func(t *testing.T, d *Data) {
+				_, ok := d.Traces.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(1).Attributes().Get("db")
+				assert.True(t, ok)
+			},
+		},
+		{
+			name:  "conditions restricting statements",
+			input: "traces.json",
+			groups: []Group{{
+				Signal:     Traces,
+				Context:    "span",
+				Statements: []string{`set(attributes["health_check"], true)`},
+				Conditions: []string{`attributes["http.route"] == "/health"`, `kind == SPAN_KIND_CLIENT`},
+			}},
+			want: []resultCounts{
+				{"span", ConditionKind, 2, 1, 0},
+				{"span", ConditionKind, 1, 1, 0},
+				{"span", StatementKind, 2, 2, 0},
+			},
+		},
+		{
+			name:  "span events",
+			input: "traces.json",
+			groups: []Group{{
+				Signal:     Traces,
+				Context:    "spanevent",
+				Statements: []string{`set(attributes["span"], span.name)`},
+			}},
+			want: []resultCounts{{"spanevent", StatementKind, 1, 1, 0}},
+		},
+		{
+			name:  "resource statements",
+			input: "logs.json",
+			groups: []Group{{
+				Signal:     Logs,
+				Statements: []string{`set(resource.attributes["env"], "prod")`},
+			}},
+			want: []resultCounts{{"resource", StatementKind, 1, 1, 0}},
+			check: func(t *testing.T, d *Data) {
+				env, _ := d.Logs.ResourceLogs().At(0).Resource().Attributes().Get("env")
+				assert.Equal(t, "prod", env.Str())
+			},
+		},
+		{
+			name:  "scope conditions",
+			input: "logs.json",
+			groups: []Group{{
+				Signal:     Logs,
+				Conditions: []string{`scope.name == "app"`, `scope.name == "other"`},
+			}},
+			want: []resultCounts{
+				{"scope", ConditionKind, 1, 1, 0},
+				{"scope", ConditionKind, 1, 0, 0},
+			},
+		},
+		{
+			name:  "metrics and data points",
+			input: "metrics.json",
+			groups: []Group{
+				{
+					Signal:     Metrics,
+					Context:    "metric",
+					Statements: []string{`set(description, "requests") where name == "http.server.requests"`},
+				},
+				{
+					Signal:     Metrics,
+					Context:    "datapoint",
+					Conditions: []string{`value_int > 5`},
+				},
+			},
+			want: []resultCounts{
+				{"metric", StatementKind, 2, 1, 0},
+				{"datapoint", ConditionKind, 3, 1, 0},
+			},
+		},
+		{
+			name:  "groups of other signals are skipped",
+			input: "logs.json",
+			groups: []Group{
+				{Signal: Traces, Statements: []string{`set(span.name, "a")`}},
+				{Signal: Logs, Statements: []string{`set(log.body, "a")`}},
+			},
+			want: []resultCounts{{"log", StatementKind, 2, 2, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			program, err := Compile(componenttest.NewNopTelemetrySettings(), tt.groups, ottl.PropagateError)
+			require.NoError(t, err)
+
+			d := readTestData(t, tt.input)
+			results, err := program.Run(t.Context(), d)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, counts(results))
+			if tt.check != nil {
+				tt.check(t, d)
+			}
+		})
+	}
+}
+
+func Test_Program_Run_errorMode(t *testing.T) {
+	groups := []Group{{
+		Signal:     Logs,
+		Context:    "log",
+		Statements: []string{`merge_maps(attributes, ParseJSON(body), "upsert")`, `set(attributes["done"], true)`},
+	}}
+
+	program, err := Compile(componenttest.NewNopTelemetrySettings(), groups, ottl.PropagateError)
+	require.NoError(t, err)
+	_, err = program.Run(t.Context(), readTestData(t, "logs.json"))
+	assert.ErrorContains(t, err, `failed to execute statement "merge_maps(attributes, ParseJSON(body), \"upsert\")"`)
+
+	program, err = Compile(componenttest.NewNopTelemetrySettings(), groups, ottl.IgnoreError)
+	require.NoError(t, err)
+	results, err := program.Run(t.Context(), readTestData(t, "logs.json"))
+	require.NoError(t, err)
+	assert.Equal(t, []resultCounts{
+		{"log", StatementKind, 2, 0, 2},
+		{"log", StatementKind, 2, 2, 0},
+	}, counts(results))
+}
+
+func Test_Compile_errors(t *testing.T) {
+	groups := []Group{
+		{Signal: Logs, Statements: []string{`set(log.body, "a")`, `set(log.body, "a"`, `set(log.nope, 1)`}},
+		{Signal: Logs, Context: "log", Conditions: []string{`body == `}},
+		{Signal: Logs, Context: "span", Statements: []string{`set(name, "a")`}},
+	}
+	_, err := Compile(componenttest.NewNopTelemetrySettings(), groups, ottl.PropagateError)
+	require.Error(t, err)
+
+	var errs []*Error
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var compileErr *Error
+		require.ErrorAs(t, e, &compileErr)
+		errs = append(errs, compileErr)
+	}
+	require.Len(t, errs, 4)
+	assert.Equal(t, `set(log.body, "a"`, errs[0].Text)
+	assert.ErrorContains(t, errs[0], "statement has invalid syntax")
+	assert.Equal(t, `set(log.nope, 1)`, errs[1].Text)
+	assert.Equal(t, ConditionKind, errs[2].Kind)
+	assert.Equal(t, `body == `, errs[2].Text)
+	assert.Equal(t, 2, errs[3].Group)
+	assert.ErrorContains(t, errs[3], `unknown context "span"`)
+}
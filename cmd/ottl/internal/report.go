@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/cmd/ottl/internal"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/alecthomas/participle/v2"
+)
+
+// WriteErrors writes the compilation errors to w. Syntax errors are followed by the
+// statement or condition, with a marker under the position of the error.
+func WriteErrors(w io.Writer, err error) {
+	var errs []error
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs = joined.Unwrap()
+	} else {
+		errs = []error{err}
+	}
+
+	for _, err := range errs {
+		_, _ = fmt.Fprintf(w, "error: %v\n", err)
+
+		var compileErr *Error
+		var syntaxErr participle.Error
+		if !errors.As(err, &compileErr) || compileErr.Text == "" || !errors.As(err, &syntaxErr) {
+			continue
+		}
+		pos := syntaxErr.Position()
+		lines := strings.Split(compileErr.Text, "\n")
+		if pos.Line < 1 || pos.Line > len(lines) {
+			continue
+		}
+		line := lines[pos.Line-1]
+		column := min(max(pos.Column, 1), len(line)+1)
+		_, _ = fmt.Fprintf(w, "  %s\n  %s^ %s\n", line, strings.Repeat(" ", column-1), syntaxErr.Message())
+	}
+}
+
+// Benchmark runs the program against copies of the data the given number of times, and returns
+// the accumulated results.
+func Benchmark(ctx context.Context, program *Program, d *Data, iterations int) ([]Result, error) {
+	var total []Result
+	for i := 0; i < iterations; i++ {
+		results, err := program.Run(ctx, d.Clone())
+		if err != nil {
+			return nil, err
+		}
+		if total == nil {
+			total = results
+			continue
+		}
+		for j := range results {
+			total[j].Evaluated += results[j].Evaluated
+			total[j].Matched += results[j].Matched
+			total[j].Errors += results[j].Errors
+			total[j].Duration += results[j].Duration
+		}
+	}
+	return total, nil
+}
+
+// WriteResults writes the results as a table, with the average durations per run of the program
+// and per evaluated item.
+func WriteResults(w io.Writer, results []Result, runs int) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "CONTEXT\tKIND\tEVALUATED\tMATCHED\tERRORS\tTIME/RUN\tTIME/ITEM\tOTTL")
+	var total time.Duration
+	for _, r := range results {
+		total += r.Duration
+		perItem := time.Duration(0)
+		if r.Evaluated > 0 {
+			perItem = r.Duration / time.Duration(r.Evaluated)
+		}
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%d\t%v\t%v\t%s\n",
+			r.Context, r.Kind, r.Evaluated/runs, r.Matched/runs, r.Errors/runs,
+			r.Duration/time.Duration(runs), perItem, r.Text)
+	}
+	_, _ = fmt.Fprintf(tw, "\t\t\t\t\t%v\t\ttotal\n", total/time.Duration(runs))
+	return tw.Flush()
+}
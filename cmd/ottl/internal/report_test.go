@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_WriteErrors(t *testing.T) {
+	_, err := Compile(componenttest.NewNopTelemetrySettings(), []Group{{
+		Signal:     Logs,
+		Context:    "log",
+		Statements: []string{`set(attributes["a"], "b"`, `set(nope, 1)`},
+	}}, ottl.PropagateError)
+	require.Error(t, err)
+
+	var out strings.Builder
+	WriteErrors(&out, err)
+	lines := strings.Split(out.String(), "\n")
+	require.Len(t, lines, 5)
+	assert.Contains(t, lines[0], `error: statement "set(attributes[\"a\"], \"b\""`)
+	assert.Equal(t, `  set(attributes["a"], "b"`, lines[1])
+	assert.True(t, strings.HasPrefix(lines[2], "  "+strings.Repeat(" ", 24)+`^ unexpected token "<EOF>"`), lines[2])
+	assert.Contains(t, lines[3], `error: statement "set(nope, 1)"`)
+	assert.Empty(t, lines[4])
+}
+
+func Test_Benchmark(t *testing.T) {
+	program, err := Compile(componenttest.NewNopTelemetrySettings(), []Group{{
+		Signal:     Logs,
+		Context:    "log",
+		Statements: []string{`set(attributes["count"], 1) where attributes["count"] == nil`},
+	}}, ottl.PropagateError)
+	require.NoError(t, err)
+
+	d := readTestData(t, "logs.json")
+	results, err := Benchmark(t.Context(), program, d, 10)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	// Each iteration runs against a copy of the input, so the where clause always matches.
+	assert.Equal(t, 20, results[0].Evaluated)
+	assert.Equal(t, 20, results[0].Matched)
+	_, ok := d.Logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("count")
+	assert.False(t, ok)
+}
+
+func Test_WriteResults(t *testing.T) {
+	results := []Result{
+		{
+			Evaluation: Evaluation{Signal: Logs, Context: "log", Kind: StatementKind, Text: `set(body, "a")`},
+			Evaluated:  20,
+			Matched:    10,
+			Duration:   20 * time.Microsecond,
+		},
+	}
+	var out strings.Builder
+	require.NoError(t, WriteResults(&out, results, 10))
+	assert.Equal(t, `CONTEXT  KIND       EVALUATED  MATCHED  ERRORS  TIME/RUN  TIME/ITEM  OTTL
+log      statement  2          1        0       2µs       1µs        set(body, "a")
+                                                2µs                  total
+`, out.String())
+}
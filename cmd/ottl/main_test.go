@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func execute(t *testing.T, stdin string, args ...string) (string, string, error) {
+	cmd := newRootCommand()
+	var stdout, stderr bytes.Buffer
+	cmd.SetIn(strings.NewReader(stdin))
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+	err := cmd.ExecuteContext(t.Context())
+	return stdout.String(), stderr.String(), err
+}
+
+func TestValidate(t *testing.T) {
+	stdout, _, err := execute(t, "", "validate", "--signal", "logs",
+		"-s", `set(log.attributes["env"], "prod")`,
+		"-c", `log.severity_text == "ERROR"`)
+	require.NoError(t, err)
+	assert.Equal(t, "2 statements and conditions are valid\n", stdout)
+
+	_, stderr, err := execute(t, "", "validate", "--signal", "logs", "--context", "log", "-s", `set(attributes["env"] "prod")`)
+	require.EqualError(t, err, "invalid statements or conditions")
+	assert.Contains(t, stderr, "statement has invalid syntax")
+	assert.Contains(t, stderr, "  set(attributes[\"env\"] \"prod\")\n                        ^ unexpected token")
+
+	_, _, err = execute(t, "", "validate", "-s", `set(log.body, "a")`)
+	require.EqualError(t, err, "--signal or --input is required to know the signal of the statements and conditions")
+
+	stdout, _, err = execute(t, "", "validate", "--config", filepath.Join("testdata", "transform.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "4 statements and conditions are valid\n", stdout)
+}
+
+func TestEval(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "logs.json"))
+	require.NoError(t, err)
+
+	stdout, stderr, err := execute(t, string(input), "eval", "-i", "-", "--context", "log",
+		"-s", `set(attributes["user.email"], "redacted") where attributes["user.email"] != nil`)
+	require.NoError(t, err)
+
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs([]byte(stdout))
+	require.NoError(t, err)
+	email, ok := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("user.email")
+	require.True(t, ok)
+	assert.Equal(t, "redacted", email.Str())
+
+	lines := strings.Split(stderr, "\n")
+	require.Len(t, lines, 4)
+	assert.Regexp(t, `^log\s+statement\s+2\s+1\s+0\s+\S+\s+\S+\s+set\(attributes\["user.email"\], "redacted"\)`, lines[1])
+
+	output := filepath.Join(t.TempDir(), "out.json")
+	stdout, stderr, err = execute(t, "", "eval", "-q", "-i", filepath.Join("testdata", "logs.json"), "-o", output, "-s", `set(log.body, "a")`)
+	require.NoError(t, err)
+	assert.Empty(t, stdout)
+	assert.Empty(t, stderr)
+	assert.FileExists(t, output)
+
+	_, _, err = execute(t, "", "eval", "-s", `set(log.body, "a")`)
+	require.EqualError(t, err, "--input is required")
+}
+
+func TestBench(t *testing.T) {
+	stdout, _, err := execute(t, "", "bench", "-n", "5", "-i", filepath.Join("testdata", "metrics.json"),
+		"--context", "datapoint", "-c", `value_int > 5`)
+	require.NoError(t, err)
+	lines := strings.Split(stdout, "\n")
+	require.Len(t, lines, 4)
+	assert.Regexp(t, `^datapoint\s+condition\s+3\s+1\s+0\s+`, lines[1])
+
+	_, _, err = execute(t, "", "bench", "-n", "0", "-i", filepath.Join("testdata", "metrics.json"), "-c", `metric.name == "a"`)
+	require.EqualError(t, err, "--iterations must be positive")
+}
@@ -39,6 +39,10 @@ type Config struct {
 	// Table contains the routing table for this processor.
 	// Required.
 	Table []RoutingTableItem `mapstructure:"table"`
+	// Functions declares OTTL functions composed of other OTTL statements, expressions or
+	// conditions, which can be called from the statements and conditions of the routing table.
+	// Optional.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -99,6 +99,31 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configPath: filepath.Join("testdata", "config", "functions.yaml"),
+			id:         component.NewIDWithName(metadata.Type, ""),
+			expected: &Config{
+				DefaultPipelines: []pipeline.ID{
+					pipeline.NewIDWithName(pipeline.SignalLogs, "otlp-all"),
+				},
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:      "IsTenant",
+						Params:    []string{"name"},
+						Condition: `attributes["X-Tenant"] == name`,
+					},
+				},
+				Table: []RoutingTableItem{
+					{
+						Condition: `IsTenant("acme")`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalLogs, "otlp-acme"),
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range testcases {
@@ -375,6 +400,27 @@ func TestValidateConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "invalid function definition",
+			config: &Config{
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:       "IsTenant",
+						Params:     []string{"name"},
+						Statements: []string{`set(attributes["X-Tenant"], name)`},
+					},
+				},
+				Table: []RoutingTableItem{
+					{
+						Condition: `IsTenant("acme")`,
+						Pipelines: []pipeline.ID{
+							pipeline.NewIDWithName(pipeline.SignalTraces, "otlp"),
+						},
+					},
+				},
+			},
+			error: `functions::0: converter "IsTenant" must define either an expression or a condition`,
+		},
 	}
 
 	for _, tt := range tests {
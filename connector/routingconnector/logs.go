@@ -43,7 +43,8 @@ func newLogsConnector(
 		cfg.Table,
 		cfg.DefaultPipelines,
 		lr.Consumer,
-		set.TelemetrySettings)
+		set.TelemetrySettings,
+		cfg.Functions)
 	if err != nil {
 		return nil, err
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/routingconnector/internal/plogutiltest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 )
 
@@ -79,6 +80,47 @@ func TestLogsRegisterConsumersForValidRoute(t *testing.T) {
 	assert.NoError(t, conn.Shutdown(t.Context()))
 }
 
+func TestLogsRoutedWithFunctions(t *testing.T) {
+	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
+	logsAcme := pipeline.NewIDWithName(pipeline.SignalLogs, "acme")
+
+	cfg := &Config{
+		DefaultPipelines: []pipeline.ID{logsDefault},
+		Functions: []ottl.FunctionDefinition{
+			{
+				Name:      "IsTenant",
+				Params:    []string{"name"},
+				Condition: `attributes["X-Tenant"] == name`,
+			},
+		},
+		Table: []RoutingTableItem{
+			{
+				Condition: `IsTenant("acme")`,
+				Pipelines: []pipeline.ID{logsAcme},
+			},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	var defaultSink, acmeSink consumertest.LogsSink
+	router := connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+		logsDefault: &defaultSink,
+		logsAcme:    &acmeSink,
+	})
+
+	conn, err := NewFactory().CreateLogsToLogs(t.Context(),
+		connectortest.NewNopSettings(metadata.Type), cfg, router.(consumer.Logs))
+	require.NoError(t, err)
+
+	l := plog.NewLogs()
+	l.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("X-Tenant", "acme")
+	l.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("X-Tenant", "globex")
+
+	require.NoError(t, conn.ConsumeLogs(t.Context(), l))
+	assert.Len(t, acmeSink.AllLogs(), 1)
+	assert.Len(t, defaultSink.AllLogs(), 1)
+}
+
 func TestLogsAreCorrectlySplitPerResourceAttributeWithOTTL(t *testing.T) {
 	logsDefault := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
 	logs0 := pipeline.NewIDWithName(pipeline.SignalLogs, "0")
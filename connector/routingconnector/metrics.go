@@ -44,7 +44,8 @@ func newMetricsConnector(
 		cfg.Table,
 		cfg.DefaultPipelines,
 		mr.Consumer,
-		set.TelemetrySettings)
+		set.TelemetrySettings,
+		cfg.Functions)
 	if err != nil {
 		return nil, err
 	}
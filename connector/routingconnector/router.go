@@ -52,6 +52,7 @@ func newRouter[C any](
 	defaultPipelineIDs []pipeline.ID,
 	provider consumerProvider[C],
 	settings component.TelemetrySettings,
+	definitions []ottl.FunctionDefinition,
 ) (*router[C], error) {
 	r := &router[C]{
 		logger:           settings.Logger,
@@ -60,7 +61,7 @@ func newRouter[C any](
 		consumerProvider: provider,
 	}
 
-	if err := r.buildParsers(table, settings); err != nil {
+	if err := r.buildParsers(table, settings, definitions); err != nil {
 		return nil, err
 	}
 
@@ -82,7 +83,7 @@ type routingItem[C any] struct {
 	statementContext   string
 }
 
-func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings) error {
+func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.TelemetrySettings, definitions []ottl.FunctionDefinition) error {
 	var buildResource, buildSpan, buildMetric, buildDataPoint, buildLog bool
 	for _, item := range table {
 		switch item.Context {
@@ -104,6 +105,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlresource.NewParser(
 			common.StandardFunctions[ottlresource.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottlresource.TransformContext](definitions),
 		)
 		if err == nil {
 			r.resourceParser = parser
@@ -115,6 +117,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlspan.NewParser(
 			common.SpanFunctions(),
 			settings,
+			ottl.WithFunctionDefinitions[ottlspan.TransformContext](definitions),
 		)
 		if err == nil {
 			r.spanParser = parser
@@ -126,6 +129,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottlmetric.NewParser(
 			common.StandardFunctions[ottlmetric.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottlmetric.TransformContext](definitions),
 		)
 		if err == nil {
 			r.metricParser = parser
@@ -137,6 +141,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottldatapoint.NewParser(
 			common.StandardFunctions[ottldatapoint.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottldatapoint.TransformContext](definitions),
 		)
 		if err == nil {
 			r.dataPointParser = parser
@@ -148,6 +153,7 @@ func (r *router[C]) buildParsers(table []RoutingTableItem, settings component.Te
 		parser, err := ottllog.NewParser(
 			common.StandardFunctions[ottllog.TransformContext](),
 			settings,
+			ottl.WithFunctionDefinitions[ottllog.TransformContext](definitions),
 		)
 		if err == nil {
 			r.logParser = parser
@@ -43,7 +43,8 @@ func newTracesConnector(
 		cfg.Table,
 		cfg.DefaultPipelines,
 		tr.Consumer,
-		set.TelemetrySettings)
+		set.TelemetrySettings,
+		cfg.Functions)
 	if err != nil {
 		return nil, err
 	}
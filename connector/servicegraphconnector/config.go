@@ -35,6 +35,9 @@ type Config struct {
 	// Store contains the config for the in-memory store used to find requests between services by pairing spans.
 	Store StoreConfig `mapstructure:"store"`
 
+	// SpanLinks contains the config to find requests between services by pairing spans with the spans they link to.
+	SpanLinks SpanLinksConfig `mapstructure:"span_links"`
+
 	// CacheLoop is the time to cleans the cache periodically.
 	CacheLoop time.Duration `mapstructure:"cache_loop"`
 
@@ -73,6 +76,18 @@ type StoreConfig struct {
 	_ struct{}
 }
 
+type SpanLinksConfig struct {
+	// Enabled creates edges between consumer or server spans and the producer spans they link to.
+	Enabled bool `mapstructure:"enabled"`
+	// Store contains the config for the in-memory store used to pair spans with the spans they link to.
+	// It is separate from the store of parent-child requests, as linked spans can be received long apart,
+	// e.g. when messages wait in a queue before being consumed in batch.
+	Store StoreConfig `mapstructure:"store"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // Validate checks if the connector configuration is valid.
 func (c *Config) Validate() error {
 	if c.LatencyHistogramBuckets == nil && c.ExponentialHistogramMaxSize < 0 {
@@ -83,5 +98,9 @@ func (c *Config) Validate() error {
 		return errors.New("use either `latency_histogram_buckets` or `exponential_histogram_max_size`")
 	}
 
+	if c.SpanLinks.Enabled && (c.SpanLinks.Store.TTL <= 0 || c.SpanLinks.Store.MaxItems <= 0) {
+		return errors.New("`span_links::store::ttl` and `span_links::store::max_items` must be positive when span links are enabled")
+	}
+
 	return nil
 }
@@ -35,6 +35,13 @@ func TestLoadConfig(t *testing.T) {
 				TTL:      time.Second,
 				MaxItems: 10,
 			},
+			SpanLinks: SpanLinksConfig{
+				Enabled: true,
+				Store: StoreConfig{
+					TTL:      10 * time.Minute,
+					MaxItems: 10000,
+				},
+			},
 			CacheLoop:              time.Minute,
 			StoreExpirationLoop:    2 * time.Second,
 			DatabaseNameAttributes: []string{"db.name"},
@@ -42,3 +49,12 @@ func TestLoadConfig(t *testing.T) {
 		cfg.Connectors[component.NewID(metadata.Type)],
 	)
 }
+
+func TestValidateSpanLinks(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.SpanLinks.Enabled = true
+	require.NoError(t, cfg.Validate())
+
+	cfg.SpanLinks.Store.TTL = 0
+	require.ErrorContains(t, cfg.Validate(), "`span_links::store::ttl` and `span_links::store::max_items` must be positive")
+}
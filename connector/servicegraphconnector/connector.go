@@ -33,6 +33,7 @@ const (
 	clientKind         = "client"
 	serverKind         = "server"
 	virtualNodeLabel   = "virtual_node"
+	edgeTypeLabel      = "edge_type"
 	millisecondsUnit   = "ms"
 	secondsUnit        = "s"
 )
@@ -67,6 +68,8 @@ type serviceGraphConnector struct {
 	metricsConsumer consumer.Metrics
 
 	store *store.Store
+	// linkStore pairs spans with the spans they link to, nil unless span links are enabled.
+	linkStore *store.Store
 
 	startTime time.Time
 
@@ -161,6 +164,9 @@ func newConnector(set component.TelemetrySettings, config component.Config, next
 
 func (p *serviceGraphConnector) Start(context.Context, component.Host) error {
 	p.store = store.NewStore(p.config.Store.TTL, p.config.Store.MaxItems, p.onComplete, p.onExpire)
+	if p.config.SpanLinks.Enabled {
+		p.linkStore = store.NewStore(p.config.SpanLinks.Store.TTL, p.config.SpanLinks.Store.MaxItems, p.onComplete, p.onLinkExpire)
+	}
 
 	go p.metricFlushLoop(*p.config.MetricsFlushInterval)
 
@@ -307,6 +313,10 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 					continue
 				}
 
+				if p.linkStore != nil {
+					p.upsertLinkEdges(ctx, serviceName, connectionType, span, rAttributes)
+				}
+
 				if errors.Is(err, store.ErrTooManyItems) {
 					totalDroppedSpans++
 					p.telemetryBuilder.ConnectorServicegraphDroppedSpans.Add(ctx, 1)
@@ -327,6 +337,53 @@ func (p *serviceGraphConnector) aggregateMetrics(ctx context.Context, td ptrace.
 	return nil
 }
 
+// upsertLinkEdges pairs producer spans with the server and consumer spans linking to them, e.g. the spans of batch
+// consumers which link to the spans which produced the messages, as they don't have a parent-child relationship.
+// Only the producer spans are kept in the link store, client spans are rarely linked to and would fill it.
+func (p *serviceGraphConnector) upsertLinkEdges(ctx context.Context, serviceName string, connectionType store.ConnectionType, span ptrace.Span, rAttributes pcommon.Map) {
+	switch span.Kind() {
+	case ptrace.SpanKindProducer:
+		traceID := span.TraceID()
+		p.upsertLinkEdge(ctx, store.NewKey(traceID, span.SpanID()), func(e *store.Edge) {
+			e.TraceID = traceID
+			e.EdgeType = store.LinkEdge
+			e.ConnectionType = connectionType
+			e.ClientService = serviceName
+			e.ClientLatencySec = spanDuration(span)
+			e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+			p.upsertDimensions(clientKind, e.Dimensions, rAttributes, span.Attributes())
+		})
+	case ptrace.SpanKindConsumer, ptrace.SpanKindServer:
+		links := span.Links()
+		for i := 0; i < links.Len(); i++ {
+			link := links.At(i)
+			// the parent of the span is already paired with the span
+			if link.TraceID() == span.TraceID() && link.SpanID() == span.ParentSpanID() {
+				continue
+			}
+			traceID := link.TraceID()
+			p.upsertLinkEdge(ctx, store.NewKey(traceID, link.SpanID()), func(e *store.Edge) {
+				e.TraceID = traceID
+				e.EdgeType = store.LinkEdge
+				if connectionType != store.Unknown {
+					e.ConnectionType = connectionType
+				}
+				e.ServerService = serviceName
+				e.ServerLatencySec = spanDuration(span)
+				e.Failed = e.Failed || span.Status().Code() == ptrace.StatusCodeError
+				p.upsertDimensions(serverKind, e.Dimensions, rAttributes, span.Attributes())
+			})
+		}
+	}
+}
+
+func (p *serviceGraphConnector) upsertLinkEdge(ctx context.Context, key store.Key, update store.Callback) {
+	// UpsertEdge will only return ErrTooManyItems
+	if _, err := p.linkStore.UpsertEdge(key, update); err != nil {
+		p.telemetryBuilder.ConnectorServicegraphDroppedLinkSpans.Add(ctx, 1)
+	}
+}
+
 func (p *serviceGraphConnector) upsertDimensions(kind string, m map[string]string, resourceAttr, spanAttr pcommon.Map) {
 	for _, dim := range p.config.Dimensions {
 		if v, ok := pdatautil.GetAttributeValue(dim, resourceAttr, spanAttr); ok {
@@ -386,6 +443,10 @@ func (p *serviceGraphConnector) onExpire(e *store.Edge) {
 	}
 }
 
+// onLinkExpire is called for the spans which were not linked to before the end of their TTL. Most client and
+// producer spans are never linked to, so they are not reported as expired edges.
+func (*serviceGraphConnector) onLinkExpire(*store.Edge) {}
+
 func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
 	metricKey := p.buildMetricKey(e.ClientService, e.ServerService, string(e.ConnectionType), strconv.FormatBool(e.Failed), e.Dimensions)
 	dimensions := buildDimensions(e)
@@ -394,6 +455,11 @@ func (p *serviceGraphConnector) aggregateMetricsForEdge(e *store.Edge) {
 		dimensions = addExtraLabel(dimensions, virtualNodeLabel, string(e.VirtualNodeLabel))
 	}
 
+	if p.config.SpanLinks.Enabled {
+		metricKey += metricKeySeparator + string(e.EdgeType)
+		dimensions = addExtraLabel(dimensions, edgeTypeLabel, string(e.EdgeType))
+	}
+
 	p.seriesMutex.Lock()
 	defer p.seriesMutex.Unlock()
 	p.updateSeries(metricKey, dimensions)
@@ -714,6 +780,9 @@ func (p *serviceGraphConnector) storeExpirationLoop(d time.Duration) {
 		select {
 		case <-t.C:
 			p.store.Expire()
+			if p.linkStore != nil {
+				p.linkStore.Expire()
+			}
 		case <-p.shutdownCh:
 			return
 		}
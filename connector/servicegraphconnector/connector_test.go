@@ -601,6 +601,192 @@ func TestValidateOwnTelemetry(t *testing.T) {
 	require.NoError(t, tel.Shutdown(t.Context()))
 }
 
+func TestSpanLinkEdges(t *testing.T) {
+	cfg := &Config{
+		Store: StoreConfig{
+			MaxItems: 10,
+			TTL:      time.Minute,
+		},
+		SpanLinks: SpanLinksConfig{
+			Enabled: true,
+			Store: StoreConfig{
+				MaxItems: 3,
+				TTL:      time.Minute,
+			},
+		},
+		MetricsFlushInterval: ptr(time.Duration(0)),
+	}
+
+	tel := componenttest.NewTelemetry()
+	mockMetricsExporter := newMockMetricsExporter()
+	p, err := newConnector(tel.NewTelemetrySettings(), cfg, mockMetricsExporter)
+	require.NoError(t, err)
+	assert.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+
+	newSpan := func(td ptrace.Traces, serviceName string, kind ptrace.SpanKind, traceID pcommon.TraceID, spanID, parentSpanID pcommon.SpanID) ptrace.Span {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), serviceName)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetKind(kind)
+		span.SetTraceID(traceID)
+		span.SetSpanID(spanID)
+		span.SetParentSpanID(parentSpanID)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+		return span
+	}
+
+	ordersTrace := pcommon.TraceID([16]byte{1})
+	paymentsTrace := pcommon.TraceID([16]byte{2})
+	batchTrace := pcommon.TraceID([16]byte{3})
+
+	// the batch consumer is received before the producers it links to
+	td := ptrace.NewTraces()
+	consumer := newSpan(td, "billing", ptrace.SpanKindConsumer, batchTrace, pcommon.SpanID([8]byte{3, 1}), pcommon.SpanID([8]byte{3}))
+	for _, link := range []struct {
+		traceID pcommon.TraceID
+		spanID  pcommon.SpanID
+	}{
+		{ordersTrace, pcommon.SpanID([8]byte{1})},
+		{paymentsTrace, pcommon.SpanID([8]byte{2})},
+		// the parent of the span is linked as well, and paired as a parent edge
+		{batchTrace, pcommon.SpanID([8]byte{3})},
+	} {
+		l := consumer.Links().AppendEmpty()
+		l.SetTraceID(link.traceID)
+		l.SetSpanID(link.spanID)
+	}
+	newSpan(td, "scheduler", ptrace.SpanKindProducer, batchTrace, pcommon.SpanID([8]byte{3}), pcommon.SpanID{})
+	assert.NoError(t, p.ConsumeTraces(t.Context(), td))
+
+	td = ptrace.NewTraces()
+	newSpan(td, "orders", ptrace.SpanKindProducer, ordersTrace, pcommon.SpanID([8]byte{1}), pcommon.SpanID{})
+	newSpan(td, "payments", ptrace.SpanKindProducer, paymentsTrace, pcommon.SpanID([8]byte{2}), pcommon.SpanID{})
+	newSpan(td, "inventory", ptrace.SpanKindProducer, pcommon.TraceID([16]byte{4}), pcommon.SpanID([8]byte{4}), pcommon.SpanID{})
+	newSpan(td, "inventory", ptrace.SpanKindProducer, pcommon.TraceID([16]byte{5}), pcommon.SpanID([8]byte{5}), pcommon.SpanID{})
+	// the link store is full with the scheduler and inventory spans, this span is dropped from the pairing of span links
+	newSpan(td, "inventory", ptrace.SpanKindProducer, pcommon.TraceID([16]byte{6}), pcommon.SpanID([8]byte{6}), pcommon.SpanID{})
+	assert.NoError(t, p.ConsumeTraces(t.Context(), td))
+
+	// client spans aren't kept in the link store, root consumer spans paired through their links are still kept
+	// in the store of the parent-child requests
+	storeLen, linkStoreLen := p.store.Len(), p.linkStore.Len()
+	td = ptrace.NewTraces()
+	newSpan(td, "gateway", ptrace.SpanKindClient, pcommon.TraceID([16]byte{7}), pcommon.SpanID([8]byte{7}), pcommon.SpanID{})
+	reports := newSpan(td, "reports", ptrace.SpanKindConsumer, pcommon.TraceID([16]byte{8}), pcommon.SpanID([8]byte{8}), pcommon.SpanID{})
+	link := reports.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID([16]byte{4}))
+	link.SetSpanID(pcommon.SpanID([8]byte{4}))
+	assert.NoError(t, p.ConsumeTraces(t.Context(), td))
+	assert.Equal(t, storeLen+2, p.store.Len())
+	assert.Equal(t, linkStoreLen-1, p.linkStore.Len())
+
+	metrics := mockMetricsExporter.GetMetrics()
+	require.NotEmpty(t, metrics)
+	requestTotal := metrics[len(metrics)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "traces_service_graph_request_total", requestTotal.Name())
+
+	edges := map[string]map[string]any{}
+	for _, dp := range requestTotal.Sum().DataPoints().All() {
+		attrs := dp.Attributes().AsRaw()
+		edges[attrs["client"].(string)] = attrs
+		assert.Equal(t, int64(1), dp.IntValue())
+	}
+	assert.Equal(t, map[string]map[string]any{
+		"orders": {
+			"client": "orders", "server": "billing", "connection_type": "messaging_system", "failed": false, "edge_type": "link",
+		},
+		"payments": {
+			"client": "payments", "server": "billing", "connection_type": "messaging_system", "failed": false, "edge_type": "link",
+		},
+		"inventory": {
+			"client": "inventory", "server": "reports", "connection_type": "messaging_system", "failed": false, "edge_type": "link",
+		},
+		"scheduler": {
+			"client": "scheduler", "server": "billing", "connection_type": "messaging_system", "failed": false, "edge_type": "parent",
+		},
+	}, edges)
+
+	assert.NoError(t, p.Shutdown(t.Context()))
+	metadatatest.AssertEqualConnectorServicegraphDroppedLinkSpans(t, tel, []metricdata.DataPoint[int64]{
+		{Value: 1},
+	}, metricdatatest.IgnoreTimestamp())
+	require.NoError(t, tel.Shutdown(t.Context()))
+}
+
+func TestSpanLinkRootSpanVirtualNode(t *testing.T) {
+	cfg := &Config{
+		Store: StoreConfig{
+			MaxItems: 10,
+			TTL:      time.Nanosecond,
+		},
+		SpanLinks: SpanLinksConfig{
+			Enabled: true,
+			Store: StoreConfig{
+				MaxItems: 10,
+				TTL:      time.Minute,
+			},
+		},
+		VirtualNodePeerAttributes: []string{"peer.service"},
+		MetricsFlushInterval:      ptr(time.Duration(0)),
+	}
+
+	p, err := newConnector(componenttest.NewNopTelemetrySettings(), cfg, newMockMetricsExporter())
+	require.NoError(t, err)
+	assert.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+
+	td := ptrace.NewTraces()
+	for _, s := range []struct {
+		serviceName string
+		kind        ptrace.SpanKind
+		traceID     pcommon.TraceID
+		spanID      pcommon.SpanID
+	}{
+		{"orders", ptrace.SpanKindProducer, pcommon.TraceID([16]byte{1}), pcommon.SpanID([8]byte{1})},
+		{"billing", ptrace.SpanKindConsumer, pcommon.TraceID([16]byte{2}), pcommon.SpanID([8]byte{2})},
+	} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(string(semconv.ServiceNameKey), s.serviceName)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetKind(s.kind)
+		span.SetTraceID(s.traceID)
+		span.SetSpanID(s.spanID)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(time.Unix(0, 0)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+	}
+	// the root consumer span links to the producer span
+	link := td.ResourceSpans().At(1).ScopeSpans().At(0).Spans().At(0).Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID([16]byte{1}))
+	link.SetSpanID(pcommon.SpanID([8]byte{1}))
+	assert.NoError(t, p.ConsumeTraces(t.Context(), td))
+
+	// the root consumer span expires without a client, and is reported as a request from the virtual user node
+	time.Sleep(time.Millisecond)
+	p.store.Expire()
+	assert.NoError(t, p.ConsumeTraces(t.Context(), ptrace.NewTraces()))
+
+	metrics := p.metricsConsumer.(*mockMetricsExporter).GetMetrics()
+	require.NotEmpty(t, metrics)
+	requestTotal := metrics[len(metrics)-1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, "traces_service_graph_request_total", requestTotal.Name())
+
+	edges := map[string]map[string]any{}
+	for _, dp := range requestTotal.Sum().DataPoints().All() {
+		attrs := dp.Attributes().AsRaw()
+		edges[attrs["client"].(string)] = attrs
+	}
+	assert.Equal(t, map[string]map[string]any{
+		"orders": {
+			"client": "orders", "server": "billing", "connection_type": "messaging_system", "failed": false, "edge_type": "link",
+		},
+		"user": {
+			"client": "user", "server": "billing", "connection_type": "virtual_node", "failed": false, "edge_type": "parent",
+		},
+	}, edges)
+
+	assert.NoError(t, p.Shutdown(t.Context()))
+}
+
 func TestExtraDimensionsLabels(t *testing.T) {
 	t.Skip("https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/39210")
 	extraDimensions := []string{"db.system", "messaging.system"}
@@ -64,6 +64,12 @@ func createDefaultConfig() component.Config {
 			TTL:      2 * time.Second,
 			MaxItems: 1000,
 		},
+		SpanLinks: SpanLinksConfig{
+			Store: StoreConfig{
+				TTL:      time.Minute,
+				MaxItems: 10000,
+			},
+		},
 		CacheLoop:              time.Minute,
 		StoreExpirationLoop:    2 * time.Second,
 		MetricsTimestampOffset: 0,
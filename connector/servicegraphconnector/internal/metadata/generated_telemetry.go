@@ -23,12 +23,13 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                             metric.Meter
-	mu                                sync.Mutex
-	registrations                     []metric.Registration
-	ConnectorServicegraphDroppedSpans metric.Int64Counter
-	ConnectorServicegraphExpiredEdges metric.Int64Counter
-	ConnectorServicegraphTotalEdges   metric.Int64Counter
+	meter                                 metric.Meter
+	mu                                    sync.Mutex
+	registrations                         []metric.Registration
+	ConnectorServicegraphDroppedLinkSpans metric.Int64Counter
+	ConnectorServicegraphDroppedSpans     metric.Int64Counter
+	ConnectorServicegraphExpiredEdges     metric.Int64Counter
+	ConnectorServicegraphTotalEdges       metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -60,6 +61,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 	}
 	builder.meter = Meter(settings)
 	var err, errs error
+	builder.ConnectorServicegraphDroppedLinkSpans, err = builder.meter.Int64Counter(
+		"otelcol_connector_servicegraph_dropped_link_spans",
+		metric.WithDescription("Number of spans dropped when trying to pair them with the spans they link to"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.ConnectorServicegraphDroppedSpans, err = builder.meter.Int64Counter(
 		"otelcol_connector_servicegraph_dropped_spans",
 		metric.WithDescription("Number of spans dropped when trying to add edges"),
@@ -20,9 +20,13 @@ func TestSetupTelemetry(t *testing.T) {
 	tb, err := metadata.NewTelemetryBuilder(testTel.NewTelemetrySettings())
 	require.NoError(t, err)
 	defer tb.Shutdown()
+	tb.ConnectorServicegraphDroppedLinkSpans.Add(context.Background(), 1)
 	tb.ConnectorServicegraphDroppedSpans.Add(context.Background(), 1)
 	tb.ConnectorServicegraphExpiredEdges.Add(context.Background(), 1)
 	tb.ConnectorServicegraphTotalEdges.Add(context.Background(), 1)
+	AssertEqualConnectorServicegraphDroppedLinkSpans(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualConnectorServicegraphDroppedSpans(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
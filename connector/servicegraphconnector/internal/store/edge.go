@@ -18,6 +18,15 @@ const (
 	VirtualNode     ConnectionType = "virtual_node"
 )
 
+type EdgeType string
+
+const (
+	// ParentEdge is an Edge between a span and its parent span.
+	ParentEdge EdgeType = "parent"
+	// LinkEdge is an Edge between a span and a span it links to.
+	LinkEdge EdgeType = "link"
+)
+
 type VirtualNodeLabel string
 
 const (
@@ -32,6 +41,7 @@ type Edge struct {
 
 	TraceID                            pcommon.TraceID
 	ConnectionType                     ConnectionType
+	EdgeType                           EdgeType
 	ServerService, ClientService       string
 	ServerLatencySec, ClientLatencySec float64
 
@@ -55,6 +65,7 @@ type Edge struct {
 func newEdge(key Key, ttl time.Duration) *Edge {
 	return &Edge{
 		Key:        key,
+		EdgeType:   ParentEdge,
 		Dimensions: make(map[string]string),
 		expiration: time.Now().Add(ttl),
 		Peer:       make(map[string]string),
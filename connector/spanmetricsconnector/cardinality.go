@@ -0,0 +1,282 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package spanmetricsconnector // import "github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+
+import (
+	"container/heap"
+	"context"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
+)
+
+// cardinalityLimiter enforces the cardinality limits of each service.
+type cardinalityLimiter struct {
+	topK            bool
+	overflowValue   string
+	maxSeries       int
+	dimensionLimits map[string]int
+	// expiration is the time after which the state of idle series and services is removed, 0 means never.
+	expiration time.Duration
+	// delta is set when the series are reset at every flush.
+	delta bool
+
+	services         map[string]*serviceLimiter
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+// serviceLimiter holds the values and the series of a service.
+type serviceLimiter struct {
+	dimensions map[string]*valueLimiter
+	// series holds the last time each calls series of the service was seen.
+	series    map[metrics.Key]time.Time
+	lastSeen  time.Time
+	seenSince bool
+	attrs     metric.MeasurementOption
+	dimAttrs  map[string]metric.MeasurementOption
+}
+
+func newCardinalityLimiter(cfg Config, telemetryBuilder *metadata.TelemetryBuilder) *cardinalityLimiter {
+	limits := cfg.CardinalityLimits
+	if limits.MaxSeriesPerService == 0 && len(limits.Dimensions) == 0 {
+		return nil
+	}
+	overflowValue := limits.OverflowValue
+	if overflowValue == "" {
+		overflowValue = defaultOverflowValue
+	}
+	dimensionLimits := make(map[string]int, len(limits.Dimensions))
+	for _, d := range limits.Dimensions {
+		dimensionLimits[d.Name] = d.Limit
+	}
+	return &cardinalityLimiter{
+		topK:             limits.Strategy == topKStrategy,
+		overflowValue:    overflowValue,
+		maxSeries:        limits.MaxSeriesPerService,
+		dimensionLimits:  dimensionLimits,
+		expiration:       cfg.MetricsExpiration,
+		delta:            cfg.AggregationTemporality == delta,
+		services:         make(map[string]*serviceLimiter),
+		telemetryBuilder: telemetryBuilder,
+	}
+}
+
+func (l *cardinalityLimiter) service(serviceName string, now time.Time) *serviceLimiter {
+	s, ok := l.services[serviceName]
+	if !ok {
+		s = &serviceLimiter{
+			dimensions: make(map[string]*valueLimiter, len(l.dimensionLimits)),
+			series:     make(map[metrics.Key]time.Time),
+			attrs:      metric.WithAttributeSet(attribute.NewSet(attribute.String("service_name", serviceName))),
+			dimAttrs:   make(map[string]metric.MeasurementOption, len(l.dimensionLimits)),
+		}
+		for name, limit := range l.dimensionLimits {
+			s.dimensions[name] = newValueLimiter(limit, l.topK)
+			s.dimAttrs[name] = metric.WithAttributeSet(attribute.NewSet(
+				attribute.String("service_name", serviceName),
+				attribute.String("dimension", name),
+			))
+		}
+		l.services[serviceName] = s
+	}
+	s.lastSeen = now
+	s.seenSince = true
+	return s
+}
+
+// limitValue returns the value to record for the dimension, which is the overflow value when the dimension
+// is over its limit.
+func (l *cardinalityLimiter) limitValue(ctx context.Context, s *serviceLimiter, dimension, value string) string {
+	vl, ok := s.dimensions[dimension]
+	if !ok || vl.admit(value) {
+		return value
+	}
+	l.telemetryBuilder.ConnectorSpanmetricsDimensionValuesOverflowed.Add(ctx, 1, s.dimAttrs[dimension])
+	return l.overflowValue
+}
+
+// admitSeries reports whether the calls series identified by key can be recorded, or whether the spans must be
+// recorded in the overflow series because the service reached its series limit.
+func (l *cardinalityLimiter) admitSeries(ctx context.Context, s *serviceLimiter, key metrics.Key, now time.Time) bool {
+	if l.maxSeries == 0 {
+		return true
+	}
+	if _, ok := s.series[key]; ok || len(s.series) < l.maxSeries {
+		s.series[key] = now
+		return true
+	}
+	l.telemetryBuilder.ConnectorSpanmetricsSeriesOverflowed.Add(ctx, 1, s.attrs)
+	return false
+}
+
+// rotate is called after each flush, it ranks the values of the dimensions with the top_k strategy and
+// removes the state of the series and the services which are gone.
+func (l *cardinalityLimiter) rotate(now time.Time) {
+	for name, s := range l.services {
+		if l.delta && !s.seenSince || l.expiration > 0 && now.Sub(s.lastSeen) >= l.expiration {
+			delete(l.services, name)
+			continue
+		}
+		s.seenSince = false
+		for _, vl := range s.dimensions {
+			vl.rotate()
+		}
+		switch {
+		case l.delta:
+			// delta series are reset at every flush
+			clear(s.series)
+		case l.expiration > 0:
+			for key, lastSeen := range s.series {
+				if now.Sub(lastSeen) >= l.expiration {
+					delete(s.series, key)
+				}
+			}
+		}
+	}
+}
+
+// candidatesPerValue is the number of counters used to find the most frequent values over the limit of a
+// dimension, per admitted value. The space-saving algorithm only guarantees to find the values seen on more than
+// 1/candidates of the spans, so a margin is kept for the long tail of values.
+const candidatesPerValue = 10
+
+// valueLimiter limits the number of distinct values of a dimension.
+type valueLimiter struct {
+	limit int
+	// admitted holds the values which are recorded, and with top_k the number of spans they were seen on
+	// during the current flush interval.
+	admitted map[string]uint64
+	// candidates counts the spans of the values over the limit during the current flush interval, with top_k.
+	candidates *spaceSaving
+}
+
+func newValueLimiter(limit int, topK bool) *valueLimiter {
+	vl := &valueLimiter{
+		limit:    limit,
+		admitted: make(map[string]uint64, limit),
+	}
+	if topK {
+		vl.candidates = newSpaceSaving(limit * candidatesPerValue)
+	}
+	return vl
+}
+
+func (vl *valueLimiter) admit(value string) bool {
+	if count, ok := vl.admitted[value]; ok {
+		vl.admitted[value] = count + 1
+		return true
+	}
+	if len(vl.admitted) < vl.limit {
+		vl.admitted[value] = 1
+		return true
+	}
+	if vl.candidates != nil {
+		vl.candidates.add(value)
+	}
+	return false
+}
+
+// rotate keeps the values with the most spans over the last flush interval, for the top_k strategy.
+func (vl *valueLimiter) rotate() {
+	if vl.candidates == nil {
+		return
+	}
+	ranked := make([]valueCount, 0, len(vl.admitted)+len(vl.candidates.counts))
+	for value, count := range vl.admitted {
+		ranked = append(ranked, valueCount{value: value, count: count})
+	}
+	for _, c := range vl.candidates.counts {
+		ranked = append(ranked, *c)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].count != ranked[j].count {
+			return ranked[i].count > ranked[j].count
+		}
+		return ranked[i].value < ranked[j].value
+	})
+	clear(vl.admitted)
+	for _, vc := range ranked[:min(vl.limit, len(ranked))] {
+		vl.admitted[vc.value] = 0
+	}
+	vl.candidates.reset()
+}
+
+type valueCount struct {
+	value string
+	count uint64
+	index int
+}
+
+// spaceSaving approximates the most frequent values of a stream with a bounded number of counters,
+// see "Efficient Computation of Frequent and Top-k Elements in Data Streams" by Metwally et al.
+// When all the counters are used, the least frequent value is replaced by the new one, which inherits
+// its count: the counts are overestimated, but the frequent values are never missed.
+type spaceSaving struct {
+	capacity int
+	index    map[string]*valueCount
+	counts   valueCountHeap
+}
+
+func newSpaceSaving(capacity int) *spaceSaving {
+	return &spaceSaving{
+		capacity: capacity,
+		index:    make(map[string]*valueCount, capacity),
+	}
+}
+
+func (s *spaceSaving) add(value string) {
+	if c, ok := s.index[value]; ok {
+		c.count++
+		heap.Fix(&s.counts, c.index)
+		return
+	}
+	if len(s.counts) < s.capacity {
+		c := &valueCount{value: value, count: 1}
+		heap.Push(&s.counts, c)
+		s.index[value] = c
+		return
+	}
+	c := s.counts[0]
+	delete(s.index, c.value)
+	c.value = value
+	c.count++
+	s.index[value] = c
+	heap.Fix(&s.counts, 0)
+}
+
+func (s *spaceSaving) reset() {
+	clear(s.index)
+	s.counts = s.counts[:0]
+}
+
+// valueCountHeap is a min-heap of value counts.
+type valueCountHeap []*valueCount
+
+func (h valueCountHeap) Len() int           { return len(h) }
+func (h valueCountHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h valueCountHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *valueCountHeap) Push(x any) {
+	c := x.(*valueCount)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *valueCountHeap) Pop() any {
+	old := *h
+	c := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return c
+}
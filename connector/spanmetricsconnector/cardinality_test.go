@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package spanmetricsconnector
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metadatatest"
+)
+
+func TestValueLimiterFirstSeen(t *testing.T) {
+	vl := newValueLimiter(2, false)
+	assert.True(t, vl.admit("/a"))
+	assert.True(t, vl.admit("/b"))
+	assert.False(t, vl.admit("/c"))
+	assert.True(t, vl.admit("/a"))
+
+	// the first values seen are kept over flushes
+	for range 10 {
+		assert.False(t, vl.admit("/c"))
+	}
+	vl.rotate()
+	assert.True(t, vl.admit("/b"))
+	assert.False(t, vl.admit("/c"))
+}
+
+func TestValueLimiterTopK(t *testing.T) {
+	vl := newValueLimiter(2, true)
+	assert.True(t, vl.admit("/a"))
+	assert.True(t, vl.admit("/b"))
+	for range 5 {
+		assert.False(t, vl.admit("/c"))
+	}
+	for i := range 30 {
+		assert.False(t, vl.admit(fmt.Sprintf("/user/%d", i)))
+	}
+	for range 3 {
+		assert.True(t, vl.admit("/a"))
+	}
+
+	// /c and /a had the most spans, /b is folded into the overflow value from now on
+	vl.rotate()
+	assert.True(t, vl.admit("/c"))
+	assert.True(t, vl.admit("/a"))
+	assert.False(t, vl.admit("/b"))
+}
+
+func TestSpaceSaving(t *testing.T) {
+	s := newSpaceSaving(10)
+	for range 10 {
+		s.add("frequent")
+	}
+	for i := range 30 {
+		s.add(fmt.Sprintf("rare-%d", i))
+	}
+	for range 5 {
+		s.add("frequent")
+	}
+
+	assert.Len(t, s.counts, 10)
+	assert.Len(t, s.index, 10)
+	for _, c := range s.counts {
+		assert.Equal(t, c, s.index[c.value])
+	}
+	require.Contains(t, s.index, "frequent")
+	assert.Equal(t, uint64(15), s.index["frequent"].count)
+
+	s.reset()
+	assert.Empty(t, s.counts)
+	assert.Empty(t, s.index)
+}
+
+func TestCardinalityLimiterRotate(t *testing.T) {
+	now := time.Now()
+	cfg := Config{
+		MetricsExpiration: time.Minute,
+		CardinalityLimits: CardinalityLimitsConfig{MaxSeriesPerService: 1},
+	}
+	l := newCardinalityLimiter(cfg, nil)
+
+	s := l.service("checkout", now)
+	assert.True(t, l.admitSeries(t.Context(), s, "series-1", now))
+	l.rotate(now.Add(30 * time.Second))
+	assert.Contains(t, l.services, "checkout")
+	assert.Len(t, s.series, 1)
+
+	// the series and the service expire along with the metrics
+	l.rotate(now.Add(time.Minute))
+	assert.Empty(t, l.services)
+}
+
+func TestConnectorCardinalityLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		limits         CardinalityLimitsConfig
+		expectedRoutes map[string][]string
+		expectedTel    func(t *testing.T, tel *componenttest.Telemetry)
+	}{
+		{
+			name: "dimension limit",
+			limits: CardinalityLimitsConfig{
+				Dimensions: []DimensionLimit{{Name: "http.route", Limit: 2}},
+			},
+			expectedRoutes: map[string][]string{
+				"noisy":    {"/users/0", "/users/1", "other"},
+				"checkout": {"/cart", "/pay"},
+			},
+			expectedTel: func(t *testing.T, tel *componenttest.Telemetry) {
+				metadatatest.AssertEqualConnectorSpanmetricsDimensionValuesOverflowed(t, tel,
+					[]metricdata.DataPoint[int64]{{
+						Attributes: attribute.NewSet(attribute.String("service_name", "noisy"), attribute.String("dimension", "http.route")),
+						Value:      8,
+					}},
+					metricdatatest.IgnoreTimestamp())
+			},
+		},
+		{
+			name: "series limit",
+			limits: CardinalityLimitsConfig{
+				MaxSeriesPerService: 3,
+			},
+			expectedRoutes: map[string][]string{
+				"noisy":    {"/users/0", "/users/1", "/users/2", ""},
+				"checkout": {"/cart", "/pay"},
+			},
+			expectedTel: func(t *testing.T, tel *componenttest.Telemetry) {
+				metadatatest.AssertEqualConnectorSpanmetricsSeriesOverflowed(t, tel,
+					[]metricdata.DataPoint[int64]{{
+						Attributes: attribute.NewSet(attribute.String("service_name", "noisy")),
+						Value:      7,
+					}},
+					metricdatatest.IgnoreTimestamp())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tel := componenttest.NewTelemetry()
+			t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Histogram.Disable = true
+			cfg.Dimensions = []Dimension{{Name: "http.route"}}
+			cfg.CardinalityLimits = tt.limits
+			c, err := newConnector(metadatatest.NewSettings(tel).TelemetrySettings, cfg, clockwork.NewFakeClock())
+			require.NoError(t, err)
+			sink := &consumertest.MetricsSink{}
+			c.metricsConsumer = sink
+
+			traces := ptrace.NewTraces()
+			addSpans := func(service string, routes ...string) {
+				rs := traces.ResourceSpans().AppendEmpty()
+				rs.Resource().Attributes().PutStr(serviceNameKey, service)
+				spans := rs.ScopeSpans().AppendEmpty().Spans()
+				for _, route := range routes {
+					span := spans.AppendEmpty()
+					span.SetName("GET")
+					span.Attributes().PutStr("http.route", route)
+				}
+			}
+			var userRoutes []string
+			for i := range 10 {
+				userRoutes = append(userRoutes, fmt.Sprintf("/users/%d", i))
+			}
+			addSpans("noisy", userRoutes...)
+			addSpans("checkout", "/cart", "/pay", "/cart")
+			require.NoError(t, c.ConsumeTraces(t.Context(), traces))
+			c.exportMetrics(t.Context())
+
+			require.Len(t, sink.AllMetrics(), 1)
+			routes := map[string][]string{}
+			for _, rm := range sink.AllMetrics()[0].ResourceMetrics().All() {
+				service, _ := rm.Resource().Attributes().Get(serviceNameKey)
+				metric := rm.ScopeMetrics().At(0).Metrics().At(0)
+				require.Equal(t, pmetric.MetricTypeSum, metric.Type())
+				for _, dp := range metric.Sum().DataPoints().All() {
+					// the overflow series keep the name of the service
+					dpService, _ := dp.Attributes().Get(serviceNameKey)
+					assert.Equal(t, service.Str(), dpService.Str())
+					route, _ := dp.Attributes().Get("http.route")
+					routes[service.Str()] = append(routes[service.Str()], route.Str())
+				}
+			}
+			for service, expected := range tt.expectedRoutes {
+				assert.ElementsMatch(t, expected, routes[service], service)
+			}
+			tt.expectedTel(t, tel)
+			require.NoError(t, c.Shutdown(t.Context()))
+		})
+	}
+}
+
+func TestCardinalityLimiterDisabled(t *testing.T) {
+	c, err := newConnector(componenttest.NewNopTelemetrySettings(), createDefaultConfig(), clockwork.NewFakeClock())
+	require.NoError(t, err)
+	assert.Nil(t, c.limiter)
+}
@@ -26,6 +26,15 @@ var defaultHistogramBucketsMs = []float64{
 
 var defaultDeltaTimestampCacheSize = 1000
 
+const (
+	// firstSeenStrategy keeps the first values of a dimension seen, up to its limit.
+	firstSeenStrategy = "first_seen"
+	// topKStrategy keeps the values of a dimension with the most spans over the last flush interval.
+	topKStrategy = "top_k"
+
+	defaultOverflowValue = "other"
+)
+
 // Dimension defines the dimension name and optional default value if the Dimension is missing from a span attribute.
 type Dimension struct {
 	Name    string  `mapstructure:"name"`
@@ -93,6 +102,34 @@ type Config struct {
 	IncludeInstrumentationScope []string `mapstructure:"include_instrumentation_scope"`
 
 	AggregationCardinalityLimit int `mapstructure:"aggregation_cardinality_limit"`
+
+	// CardinalityLimits defines the limits applied to the series of each service.
+	CardinalityLimits CardinalityLimitsConfig `mapstructure:"cardinality_limits"`
+}
+
+// CardinalityLimitsConfig defines the limits on the series created for each service, so that a service
+// producing high cardinality dimensions doesn't affect the metrics of the other services.
+type CardinalityLimitsConfig struct {
+	// Strategy selects the values of a dimension which are kept once its limit is reached: first_seen or top_k.
+	// Defaults to first_seen.
+	Strategy string `mapstructure:"strategy"`
+	// OverflowValue replaces the values of a dimension over its limit. Defaults to "other".
+	OverflowValue string `mapstructure:"overflow_value"`
+	// MaxSeriesPerService is the maximum number of calls series of a service, after which the spans of the
+	// service are recorded in the overflow series. A value of 0 means no limit is applied.
+	MaxSeriesPerService int `mapstructure:"max_series_per_service"`
+	// Dimensions defines the maximum number of distinct values of dimensions, per service.
+	Dimensions []DimensionLimit `mapstructure:"dimensions"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// DimensionLimit defines the maximum number of distinct values of a dimension.
+type DimensionLimit struct {
+	Name  string `mapstructure:"name"`
+	Limit int    `mapstructure:"limit"`
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 type HistogramConfig struct {
@@ -172,6 +209,47 @@ func (c Config) Validate() error {
 		return fmt.Errorf("invalid max_per_data_point: %v, the value should be positive", c.Exemplars.MaxPerDataPoint)
 	}
 
+	if err := c.CardinalityLimits.validate(c.limitableDimensions()); err != nil {
+		return fmt.Errorf("failed validating cardinality_limits: %w", err)
+	}
+
+	return nil
+}
+
+// limitableDimensions returns the names of the dimensions whose values can be limited per service:
+// the span name and the configured dimensions of any metric.
+func (c Config) limitableDimensions() map[string]struct{} {
+	names := map[string]struct{}{spanNameKey: {}}
+	for _, dimensions := range [][]Dimension{c.Dimensions, c.CallsDimensions, c.Histogram.Dimensions, c.Events.Dimensions} {
+		for _, d := range dimensions {
+			names[d.Name] = struct{}{}
+		}
+	}
+	return names
+}
+
+func (c CardinalityLimitsConfig) validate(limitable map[string]struct{}) error {
+	switch c.Strategy {
+	case "", firstSeenStrategy, topKStrategy:
+	default:
+		return fmt.Errorf("invalid strategy: %q, must be either %q or %q", c.Strategy, firstSeenStrategy, topKStrategy)
+	}
+	if c.MaxSeriesPerService < 0 {
+		return fmt.Errorf("invalid max_series_per_service: %v, the limit should be positive", c.MaxSeriesPerService)
+	}
+	names := make(map[string]struct{}, len(c.Dimensions))
+	for _, d := range c.Dimensions {
+		if _, ok := names[d.Name]; ok {
+			return fmt.Errorf("duplicate dimension name %s", d.Name)
+		}
+		names[d.Name] = struct{}{}
+		if d.Limit <= 0 {
+			return fmt.Errorf("invalid limit of dimension %s: %v, the limit should be positive", d.Name, d.Limit)
+		}
+		if _, ok := limitable[d.Name]; !ok {
+			return fmt.Errorf("unknown dimension name %s, must be %s or a configured dimension", d.Name, spanNameKey)
+		}
+	}
 	return nil
 }
 
@@ -205,6 +205,37 @@ func TestLoadConfig(t *testing.T) {
 				Namespace: DefaultNamespace,
 			},
 		},
+		{
+			name: "cardinality_limits",
+			id:   component.NewIDWithName(metadata.Type, "cardinality_limits"),
+			expected: &Config{
+				AggregationTemporality: "AGGREGATION_TEMPORALITY_CUMULATIVE",
+				Histogram:              HistogramConfig{Disable: false, Unit: defaultUnit},
+				Dimensions: []Dimension{
+					{Name: "http.route", Default: (*string)(nil)},
+				},
+				ResourceMetricsCacheSize: defaultResourceMetricsCacheSize,
+				MetricsFlushInterval:     60 * time.Second,
+				Exemplars: ExemplarsConfig{
+					MaxPerDataPoint: defaultMaxPerDatapoint,
+				},
+				Namespace: DefaultNamespace,
+				CardinalityLimits: CardinalityLimitsConfig{
+					Strategy:            topKStrategy,
+					OverflowValue:       "overflow",
+					MaxSeriesPerService: 500,
+					Dimensions: []DimensionLimit{
+						{Name: "http.route", Limit: 100},
+						{Name: "span.name", Limit: 200},
+					},
+				},
+			},
+		},
+		{
+			name:         "invalid_cardinality_limits_strategy",
+			id:           component.NewIDWithName(metadata.Type, "invalid_cardinality_limits_strategy"),
+			errorMessage: `failed validating cardinality_limits: invalid strategy: "random", must be either "first_seen" or "top_k"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -421,6 +452,71 @@ func TestConfigValidate(t *testing.T) {
 			},
 			expectedErr: "failed validating event dimensions: no dimensions configured for events",
 		},
+		{
+			name: "invalid max series per service",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				CardinalityLimits: CardinalityLimitsConfig{
+					MaxSeriesPerService: -1,
+				},
+			},
+			expectedErr: "failed validating cardinality_limits: invalid max_series_per_service: -1, the limit should be positive",
+		},
+		{
+			name: "duplicate dimension limit",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				Dimensions:               []Dimension{{Name: "http.route"}},
+				CardinalityLimits: CardinalityLimitsConfig{
+					Dimensions: []DimensionLimit{{Name: "http.route", Limit: 10}, {Name: "http.route", Limit: 20}},
+				},
+			},
+			expectedErr: "failed validating cardinality_limits: duplicate dimension name http.route",
+		},
+		{
+			name: "invalid dimension limit",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				Dimensions:               []Dimension{{Name: "http.route"}},
+				CardinalityLimits: CardinalityLimitsConfig{
+					Dimensions: []DimensionLimit{{Name: "http.route"}},
+				},
+			},
+			expectedErr: "failed validating cardinality_limits: invalid limit of dimension http.route: 0, the limit should be positive",
+		},
+		{
+			name: "unknown dimension limit",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				Dimensions:               []Dimension{{Name: "http.route"}},
+				CardinalityLimits: CardinalityLimitsConfig{
+					Dimensions: []DimensionLimit{{Name: "http.method", Limit: 10}},
+				},
+			},
+			expectedErr: "failed validating cardinality_limits: unknown dimension name http.method, must be span.name or a configured dimension",
+		},
+		{
+			name: "dimension limits of span name and metric dimensions",
+			config: Config{
+				ResourceMetricsCacheSize: 1000,
+				MetricsFlushInterval:     60 * time.Second,
+				CallsDimensions:          []Dimension{{Name: "http.url"}},
+				Histogram:                HistogramConfig{Dimensions: []Dimension{{Name: "http.route"}}},
+				Events:                   EventsConfig{Enabled: true, Dimensions: []Dimension{{Name: "exception.type"}}},
+				CardinalityLimits: CardinalityLimitsConfig{
+					Dimensions: []DimensionLimit{
+						{Name: "span.name", Limit: 10},
+						{Name: "http.url", Limit: 10},
+						{Name: "http.route", Limit: 10},
+						{Name: "exception.type", Limit: 10},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
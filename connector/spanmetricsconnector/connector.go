@@ -21,6 +21,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/cache"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector/internal/metrics"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
 	utilattri "github.com/open-telemetry/opentelemetry-collector-contrib/internal/pdatautil"
@@ -86,6 +87,13 @@ type connectorImp struct {
 
 	// Tracks the last TimestampUnixNano for delta metrics so that they represent an uninterrupted series. Unused for cumulative span metrics.
 	lastDeltaTimestamps *simplelru.LRU[metrics.Key, pcommon.Timestamp]
+
+	// limiter enforces the cardinality limits of each service, nil when none is configured.
+	limiter *cardinalityLimiter
+	// limitedValues holds the values of the dimensions of the current span which are replaced by the overflow value.
+	limitedValues map[string]string
+
+	telemetryBuilder *metadata.TelemetryBuilder
 }
 
 type resourceMetrics struct {
@@ -112,7 +120,8 @@ func newDimensions(cfgDims []Dimension) []utilattri.Dimension {
 	return dims
 }
 
-func newConnector(logger *zap.Logger, config component.Config, clock clockwork.Clock) (*connectorImp, error) {
+func newConnector(set component.TelemetrySettings, config component.Config, clock clockwork.Clock) (*connectorImp, error) {
+	logger := set.Logger
 	logger.Info("Building spanmetrics connector")
 	cfg := config.(*Config)
 	if cfg.DimensionsCacheSize != 0 {
@@ -140,6 +149,11 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		}
 	}
 
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(set)
+	if err != nil {
+		return nil, err
+	}
+
 	return &connectorImp{
 		logger:                       logger,
 		config:                       *cfg,
@@ -155,6 +169,9 @@ func newConnector(logger *zap.Logger, config component.Config, clock clockwork.C
 		callsDimensions:              newDimensions(cfg.CallsDimensions),
 		durationDimensions:           newDimensions(cfg.Histogram.Dimensions),
 		events:                       cfg.Events,
+		limiter:                      newCardinalityLimiter(*cfg, telemetryBuilder),
+		limitedValues:                make(map[string]string, len(cfg.CardinalityLimits.Dimensions)),
+		telemetryBuilder:             telemetryBuilder,
 	}, nil
 }
 
@@ -235,6 +252,7 @@ func (p *connectorImp) Shutdown(context.Context) error {
 			p.done <- struct{}{}
 			p.started = false
 		}
+		p.telemetryBuilder.Shutdown()
 	})
 	return nil
 }
@@ -246,9 +264,9 @@ func (*connectorImp) Capabilities() consumer.Capabilities {
 
 // ConsumeTraces implements the consumer.Traces interface.
 // It aggregates the trace data to generate metrics.
-func (p *connectorImp) ConsumeTraces(_ context.Context, traces ptrace.Traces) error {
+func (p *connectorImp) ConsumeTraces(ctx context.Context, traces ptrace.Traces) error {
 	p.lock.Lock()
-	p.aggregateMetrics(traces)
+	p.aggregateMetrics(ctx, traces)
 	p.lock.Unlock()
 	return nil
 }
@@ -332,6 +350,10 @@ func (p *connectorImp) buildMetrics() pmetric.Metrics {
 }
 
 func (p *connectorImp) resetState() {
+	if p.limiter != nil {
+		p.limiter.rotate(p.clock.Now())
+	}
+
 	// If delta metrics, reset accumulated data
 	if p.config.GetAggregationTemporality() == pmetric.AggregationTemporalityDelta {
 		p.resourceMetrics.Purge()
@@ -371,8 +393,9 @@ func (p *connectorImp) resetState() {
 // Each metric is identified by a key that is built from the service name
 // and span metadata such as name, kind, status_code and any additional
 // dimensions the user has configured.
-func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
-	startTimestamp := pcommon.NewTimestampFromTime(p.clock.Now())
+func (p *connectorImp) aggregateMetrics(ctx context.Context, traces ptrace.Traces) {
+	now := p.clock.Now()
+	startTimestamp := pcommon.NewTimestampFromTime(now)
 	for i := 0; i < traces.ResourceSpans().Len(); i++ {
 		rspans := traces.ResourceSpans().At(i)
 		resourceAttr := rspans.Resource().Attributes()
@@ -388,6 +411,10 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 
 		unitDivider := unitDivider(p.config.Histogram.Unit)
 		serviceName := serviceAttr.Str()
+		var serviceLimiter *serviceLimiter
+		if p.limiter != nil {
+			serviceLimiter = p.limiter.service(serviceName, now)
+		}
 		ilsSlice := rspans.ScopeSpans()
 		for j := 0; j < ilsSlice.Len(); j++ {
 			ils := ilsSlice.At(j)
@@ -404,13 +431,29 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 
 				callsDimensions := p.dimensions
 				callsDimensions = append(callsDimensions, p.callsDimensions...)
+				if serviceLimiter != nil {
+					p.limitDimensionValues(ctx, serviceLimiter, span, resourceAttr)
+				}
 				key := p.buildKey(serviceName, span, callsDimensions, resourceAttr)
 				attributesFun := func() pcommon.Map {
 					return p.buildAttributes(serviceName, span, resourceAttr, callsDimensions, ils.Scope())
 				}
+				// spans of the services over their series limit are all recorded in the overflow series of the service
+				overflow := serviceLimiter != nil && !p.limiter.admitSeries(ctx, serviceLimiter, key, now)
+				var overflowSeriesKey metrics.Key
+				var overflowAttributesFun metrics.BuildAttributesFun
+				if overflow {
+					overflowSeriesKey, overflowAttributesFun = p.buildOverflowSeries(serviceName)
+				}
 
 				// aggregate sums metrics
-				s, limitReached := sums.GetOrCreate(key, attributesFun, startTimestamp)
+				var s *metrics.Sum
+				limitReached := overflow
+				if overflow {
+					s, _ = sums.GetOrCreate(overflowSeriesKey, overflowAttributesFun, startTimestamp)
+				} else {
+					s, limitReached = sums.GetOrCreate(key, attributesFun, startTimestamp)
+				}
 				if !limitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
 					s.AddExemplar(span.TraceID(), span.SpanID(), duration)
 				}
@@ -424,7 +467,13 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 					attributesFun = func() pcommon.Map {
 						return p.buildAttributes(serviceName, span, resourceAttr, durationDimensions, ils.Scope())
 					}
-					h, durationLimitReached := histograms.GetOrCreate(durationKey, attributesFun, startTimestamp)
+					var h metrics.Histogram
+					durationLimitReached := overflow
+					if overflow {
+						h, _ = histograms.GetOrCreate(overflowSeriesKey, overflowAttributesFun, startTimestamp)
+					} else {
+						h, durationLimitReached = histograms.GetOrCreate(durationKey, attributesFun, startTimestamp)
+					}
 					if !durationLimitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
 						p.addExemplar(span, duration, h)
 					}
@@ -452,7 +501,13 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 						attributesFun = func() pcommon.Map {
 							return p.buildAttributes(serviceName, span, rscAndEventAttrs, eDimensions, ils.Scope())
 						}
-						e, eventLimitReached := events.GetOrCreate(eKey, attributesFun, startTimestamp)
+						var e *metrics.Sum
+						eventLimitReached := overflow
+						if overflow {
+							e, _ = events.GetOrCreate(overflowSeriesKey, overflowAttributesFun, startTimestamp)
+						} else {
+							e, eventLimitReached = events.GetOrCreate(eKey, attributesFun, startTimestamp)
+						}
 						if !eventLimitReached && p.config.Exemplars.Enabled && !span.TraceID().IsEmpty() {
 							e.AddExemplar(span.TraceID(), span.SpanID(), duration)
 						}
@@ -464,6 +519,53 @@ func (p *connectorImp) aggregateMetrics(traces ptrace.Traces) {
 	}
 }
 
+// limitDimensionValues records the values of the span's dimensions which are over their limits, so that the
+// overflow value is used instead when building the metric keys and attributes of the span.
+func (p *connectorImp) limitDimensionValues(ctx context.Context, s *serviceLimiter, span ptrace.Span, resourceAttrs pcommon.Map) {
+	clear(p.limitedValues)
+	for name := range s.dimensions {
+		var value string
+		if name == spanNameKey {
+			value = span.Name()
+		} else if v, ok := utilattri.GetDimensionValue(p.limitedDimension(name), span.Attributes(), resourceAttrs); ok {
+			value = v.AsString()
+		} else {
+			continue
+		}
+		if limited := p.limiter.limitValue(ctx, s, name, value); limited != value {
+			p.limitedValues[name] = limited
+		}
+	}
+}
+
+// limitedDimension returns the configured dimension of the given name, so that its default value is used.
+func (p *connectorImp) limitedDimension(name string) utilattri.Dimension {
+	for _, dims := range [][]utilattri.Dimension{p.dimensions, p.callsDimensions, p.durationDimensions, p.eDimensions} {
+		for _, d := range dims {
+			if d.Name == name {
+				return d
+			}
+		}
+	}
+	return utilattri.Dimension{Name: name}
+}
+
+// spanName returns the name of the span, or the overflow value when the span name is over its limit.
+func (p *connectorImp) spanName(span ptrace.Span) string {
+	if v, ok := p.limitedValues[spanNameKey]; ok {
+		return v
+	}
+	return span.Name()
+}
+
+// dimensionValue returns the value of the dimension, or the overflow value when the dimension is over its limit.
+func (p *connectorImp) dimensionValue(d utilattri.Dimension, span ptrace.Span, attrs pcommon.Map) (pcommon.Value, bool) {
+	if v, ok := p.limitedValues[d.Name]; ok {
+		return pcommon.NewValueStr(v), true
+	}
+	return utilattri.GetDimensionValue(d, span.Attributes(), attrs)
+}
+
 func (p *connectorImp) addExemplar(span ptrace.Span, duration float64, h metrics.Histogram) {
 	if !p.config.Exemplars.Enabled {
 		return
@@ -534,7 +636,7 @@ func (p *connectorImp) buildAttributes(
 		attr.PutStr(serviceNameKey, serviceName)
 	}
 	if !contains(p.config.ExcludeDimensions, spanNameKey) {
-		attr.PutStr(spanNameKey, span.Name())
+		attr.PutStr(spanNameKey, p.spanName(span))
 	}
 	if !contains(p.config.ExcludeDimensions, spanKindKey) {
 		attr.PutStr(spanKindKey, traceutil.SpanKindStr(span.Kind()))
@@ -550,14 +652,14 @@ func (p *connectorImp) buildAttributes(
 		}
 	}
 
-	addResourceAttributes(&attr, dimensions, span, resourceAttrs)
+	p.addResourceAttributes(&attr, dimensions, span, resourceAttrs)
 
 	return attr
 }
 
-func addResourceAttributes(attrs *pcommon.Map, dimensions []utilattri.Dimension, span ptrace.Span, resourceAttrs pcommon.Map) {
+func (p *connectorImp) addResourceAttributes(attrs *pcommon.Map, dimensions []utilattri.Dimension, span ptrace.Span, resourceAttrs pcommon.Map) {
 	for _, d := range dimensions {
-		if v, ok := utilattri.GetDimensionValue(d, span.Attributes(), resourceAttrs); ok {
+		if v, ok := p.dimensionValue(d, span, resourceAttrs); ok {
 			v.CopyTo(attrs.PutEmpty(d.Name))
 		}
 	}
@@ -582,7 +684,7 @@ func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDi
 		concatDimensionValue(p.keyBuf, serviceName, false)
 	}
 	if !contains(p.config.ExcludeDimensions, spanNameKey) {
-		concatDimensionValue(p.keyBuf, span.Name(), true)
+		concatDimensionValue(p.keyBuf, p.spanName(span), true)
 	}
 	if !contains(p.config.ExcludeDimensions, spanKindKey) {
 		concatDimensionValue(p.keyBuf, traceutil.SpanKindStr(span.Kind()), true)
@@ -592,7 +694,7 @@ func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDi
 	}
 
 	for _, d := range optionalDims {
-		if v, ok := utilattri.GetDimensionValue(d, span.Attributes(), resourceOrEventAttrs); ok {
+		if v, ok := p.dimensionValue(d, span, resourceOrEventAttrs); ok {
 			concatDimensionValue(p.keyBuf, v.AsString(), true)
 		}
 	}
@@ -600,6 +702,20 @@ func (p *connectorImp) buildKey(serviceName string, span ptrace.Span, optionalDi
 	return metrics.Key(p.keyBuf.String())
 }
 
+// buildOverflowSeries returns the key and the attributes of the series recording the spans of the service over
+// its series limit. The series keeps the service name, so that the overflowing services can be told apart.
+func (p *connectorImp) buildOverflowSeries(serviceName string) (metrics.Key, metrics.BuildAttributesFun) {
+	p.keyBuf.Reset()
+	concatDimensionValue(p.keyBuf, serviceName, false)
+	concatDimensionValue(p.keyBuf, overflowKey, true)
+	return metrics.Key(p.keyBuf.String()), func() pcommon.Map {
+		attr := pcommon.NewMap()
+		attr.PutStr(serviceNameKey, serviceName)
+		attr.PutBool(overflowKey, true)
+		return attr
+	}
+}
+
 // buildMetricName builds the namespace prefix for the metric name.
 func buildMetricName(namespace, name string) string {
 	if namespace != "" {
@@ -59,7 +59,7 @@ func createDefaultConfig() component.Config {
 }
 
 func createTracesToMetricsConnector(ctx context.Context, params connector.Settings, cfg component.Config, nextConsumer consumer.Metrics) (connector.Traces, error) {
-	c, err := newConnector(params.Logger, cfg, clockwork.FromContext(ctx))
+	c, err := newConnector(params.TelemetrySettings, cfg, clockwork.FromContext(ctx))
 	if err != nil {
 		return nil, err
 	}
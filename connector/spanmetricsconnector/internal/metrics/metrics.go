@@ -14,6 +14,13 @@ import (
 // https://github.com/open-telemetry/opentelemetry-go/blob/3ae002c3caf3e44387f0554dfcbbde2c5aab7909/sdk/metric/internal/aggregate/limit.go#L11C36-L11C50
 const overflowKey = "otel.metric.overflow"
 
+// overflowAttributes returns the attributes of the series aggregating the data points over the cardinality limits.
+func overflowAttributes() pcommon.Map {
+	attributes := pcommon.NewMap()
+	attributes.PutBool(overflowKey, true)
+	return attributes
+}
+
 type Key string
 
 type HistogramMetrics interface {
@@ -106,8 +113,7 @@ func (m *explicitHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAttri
 				return h, limitReached
 			}
 
-			attributes = pcommon.NewMap()
-			attributes.PutBool(overflowKey, true)
+			attributes = overflowAttributes()
 		} else {
 			attributes = attributesFun()
 		}
@@ -182,8 +188,7 @@ func (m *exponentialHistogramMetrics) GetOrCreate(key Key, attributesFun BuildAt
 				return h, limitReached
 			}
 
-			attributes = pcommon.NewMap()
-			attributes.PutBool(overflowKey, true)
+			attributes = overflowAttributes()
 		} else {
 			attributes = attributesFun()
 		}
@@ -346,8 +351,7 @@ func (m *SumMetrics) GetOrCreate(key Key, attributesFun BuildAttributesFun, star
 				return s, limitReached
 			}
 
-			attributes = pcommon.NewMap()
-			attributes.PutBool(overflowKey, true)
+			attributes = overflowAttributes()
 		} else {
 			attributes = attributesFun()
 		}
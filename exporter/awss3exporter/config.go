@@ -5,6 +5,7 @@ package awss3exporter // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -72,8 +73,26 @@ const (
 	OtlpJSON     MarshalerType = "otlp_json"
 	SumoIC       MarshalerType = "sumo_ic"
 	Body         MarshalerType = "body"
+	Parquet      MarshalerType = "parquet"
 )
 
+// ParquetConfig contains the options of the parquet marshaler.
+type ParquetConfig struct {
+	// PromotedResourceAttributes are the resource attributes written into their own top-level column, named after
+	// the attribute with a "resource_" prefix, in addition to the resource attributes column.
+	PromotedResourceAttributes []string `mapstructure:"promoted_resource_attributes"`
+	// PromotedAttributes are the log record, span or data point attributes written into their own top-level column,
+	// named after the attribute with an "attribute_" prefix, in addition to the attributes column.
+	PromotedAttributes []string `mapstructure:"promoted_attributes"`
+	// RowGroupSize is the maximum number of rows of a row group. Default is 100000.
+	RowGroupSize int `mapstructure:"row_group_size"`
+	// Compression is the codec used to compress the column chunks.
+	// Valid values are: `none`, `snappy`, `gzip` and `zstd`. Default is `snappy`.
+	Compression string `mapstructure:"compression"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // ResourceAttrsToS3 defines the mapping of S3 uploading configuration values to resource attribute values.
 type ResourceAttrsToS3 struct {
 	// S3Bucket indicates the mapping of the bucket name used for uploading to a specific resource attribute value.
@@ -95,6 +114,8 @@ type Config struct {
 	Encoding              *component.ID     `mapstructure:"encoding"`
 	EncodingFileExtension string            `mapstructure:"encoding_file_extension"`
 	ResourceAttrsToS3     ResourceAttrsToS3 `mapstructure:"resource_attrs_to_s3"`
+	// Parquet contains the options of the parquet marshaler.
+	Parquet ParquetConfig `mapstructure:"parquet"`
 }
 
 func (c *Config) Validate() error {
@@ -143,7 +164,7 @@ func (c *Config) Validate() error {
 			errs = multierr.Append(errs, errors.New("unknown compression type"))
 		}
 
-		if c.MarshalerName == SumoIC {
+		if c.MarshalerName == SumoIC || c.MarshalerName == Parquet {
 			errs = multierr.Append(errs, errors.New("marshaler does not support compression"))
 		}
 	}
@@ -155,5 +176,23 @@ func (c *Config) Validate() error {
 	if c.S3Uploader.UniqueKeyFuncName != "" && !validUniqueKeyFuncs[c.S3Uploader.UniqueKeyFuncName] {
 		errs = multierr.Append(errs, errors.New("invalid UniqueKeyFuncName"))
 	}
+
+	if c.MarshalerName == Parquet && c.Encoding == nil {
+		errs = multierr.Append(errs, c.Parquet.validate())
+	}
+	return errs
+}
+
+func (c *ParquetConfig) validate() error {
+	var errs error
+	if c.RowGroupSize < 0 {
+		errs = multierr.Append(errs, errors.New("parquet row_group_size must not be negative"))
+	}
+	if _, ok := parquetCodecs[c.Compression]; !ok {
+		errs = multierr.Append(errs, fmt.Errorf("unknown parquet compression %q", c.Compression))
+	}
+	if _, err := newParquetPromotedColumns(c.PromotedResourceAttributes, c.PromotedAttributes); err != nil {
+		errs = multierr.Append(errs, err)
+	}
 	return errs
 }
@@ -323,6 +323,58 @@ func TestConfig_Validate(t *testing.T) {
 			}(),
 			errExpected: errors.New("region is required"),
 		},
+		{
+			name: "valid parquet",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.MarshalerName = Parquet
+				c.Parquet.PromotedResourceAttributes = []string{"service.name"}
+				c.Parquet.PromotedAttributes = []string{"service.name"}
+				c.Parquet.Compression = "zstd"
+				return c
+			}(),
+			errExpected: nil,
+		},
+		{
+			name: "parquet with compression",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.S3Uploader.Compression = "gzip"
+				c.MarshalerName = Parquet
+				return c
+			}(),
+			errExpected: errors.New("marshaler does not support compression"),
+		},
+		{
+			name: "invalid parquet options",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.MarshalerName = Parquet
+				c.Parquet.RowGroupSize = -1
+				c.Parquet.Compression = "lz4"
+				c.Parquet.PromotedAttributes = []string{"http.method", "http_method"}
+				return c
+			}(),
+			errExpected: multierr.Combine(
+				errors.New("parquet row_group_size must not be negative"),
+				errors.New(`unknown parquet compression "lz4"`),
+				errors.New(`parquet promoted attributes "http.method" and "http_method" have the same column "attribute_http_method"`),
+			),
+		},
+		{
+			name: "parquet promoted attribute with resource column",
+			config: func() *Config {
+				c := createDefaultConfig().(*Config)
+				c.S3Uploader.S3Bucket = "foo"
+				c.MarshalerName = Parquet
+				c.Parquet.PromotedResourceAttributes = []string{"attributes"}
+				return c
+			}(),
+			errExpected: errors.New(`parquet promoted attribute "attributes" has the column "resource_attributes" of the resource`),
+		},
 	}
 
 	for _, tt := range tests {
@@ -67,6 +67,10 @@ func (e *s3Exporter) start(ctx context.Context, host component.Host) error {
 		if m, err = newMarshalerFromEncoding(e.config.Encoding, e.config.EncodingFileExtension, host, e.logger); err != nil {
 			return err
 		}
+	} else if e.config.MarshalerName == Parquet {
+		if m, err = newParquetS3Marshaler(e.config.Parquet, e.logger); err != nil {
+			return err
+		}
 	} else {
 		if m, err = newMarshaler(e.config.MarshalerName, e.logger); err != nil {
 			return fmt.Errorf("unknown marshaler %q", e.config.MarshalerName)
@@ -37,6 +37,20 @@ func newMarshalerFromEncoding(encoding *component.ID, fileFormat string, host co
 	return marshaler, nil
 }
 
+func newParquetS3Marshaler(cfg ParquetConfig, logger *zap.Logger) (marshaler, error) {
+	parquetMarshaler, err := newParquetMarshaler(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Marshaler{
+		logsMarshaler:    parquetMarshaler,
+		tracesMarshaler:  parquetMarshaler,
+		metricsMarshaler: parquetMarshaler,
+		logger:           logger,
+		fileFormat:       parquetMarshaler.format(),
+	}, nil
+}
+
 func newMarshaler(mType MarshalerType, logger *zap.Logger) (marshaler, error) {
 	marshaler := &s3Marshaler{logger: logger}
 	switch mType {
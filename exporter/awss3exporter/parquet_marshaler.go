@@ -0,0 +1,561 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awss3exporter"
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const defaultParquetRowGroupSize = 100000
+
+var parquetCodecs = map[string]compress.Codec{
+	"":       &parquet.Snappy,
+	"none":   &parquet.Uncompressed,
+	"snappy": &parquet.Snappy,
+	"gzip":   &parquet.Gzip,
+	"zstd":   &parquet.Zstd,
+}
+
+// parquetResourceColumns are the columns of the resource and the scope of a row.
+type parquetResourceColumns struct {
+	ResourceSchemaURL  string            `parquet:"resource_schema_url"`
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ScopeName          string            `parquet:"scope_name"`
+	ScopeVersion       string            `parquet:"scope_version"`
+	ScopeAttributes    map[string]string `parquet:"scope_attributes"`
+}
+
+// parquetLogRow is the row of a log record.
+type parquetLogRow struct {
+	parquetResourceColumns
+	TimeUnixNano           int64             `parquet:"time_unix_nano"`
+	ObservedTimeUnixNano   int64             `parquet:"observed_time_unix_nano"`
+	SeverityNumber         int32             `parquet:"severity_number"`
+	SeverityText           string            `parquet:"severity_text"`
+	Body                   string            `parquet:"body"`
+	EventName              string            `parquet:"event_name"`
+	Attributes             map[string]string `parquet:"attributes"`
+	DroppedAttributesCount int64             `parquet:"dropped_attributes_count"`
+	Flags                  int64             `parquet:"flags"`
+	TraceID                string            `parquet:"trace_id"`
+	SpanID                 string            `parquet:"span_id"`
+}
+
+// parquetSpanRow is the row of a span.
+type parquetSpanRow struct {
+	parquetResourceColumns
+	TraceID                string             `parquet:"trace_id"`
+	SpanID                 string             `parquet:"span_id"`
+	ParentSpanID           string             `parquet:"parent_span_id"`
+	TraceState             string             `parquet:"trace_state"`
+	Flags                  int64              `parquet:"flags"`
+	Name                   string             `parquet:"name"`
+	Kind                   string             `parquet:"kind"`
+	StartTimeUnixNano      int64              `parquet:"start_time_unix_nano"`
+	EndTimeUnixNano        int64              `parquet:"end_time_unix_nano"`
+	DurationNano           int64              `parquet:"duration_nano"`
+	Attributes             map[string]string  `parquet:"attributes"`
+	DroppedAttributesCount int64              `parquet:"dropped_attributes_count"`
+	Events                 []parquetSpanEvent `parquet:"events,list"`
+	DroppedEventsCount     int64              `parquet:"dropped_events_count"`
+	Links                  []parquetSpanLink  `parquet:"links,list"`
+	DroppedLinksCount      int64              `parquet:"dropped_links_count"`
+	StatusCode             string             `parquet:"status_code"`
+	StatusMessage          string             `parquet:"status_message"`
+}
+
+type parquetSpanEvent struct {
+	TimeUnixNano int64             `parquet:"time_unix_nano"`
+	Name         string            `parquet:"name"`
+	Attributes   map[string]string `parquet:"attributes"`
+}
+
+type parquetSpanLink struct {
+	TraceID    string            `parquet:"trace_id"`
+	SpanID     string            `parquet:"span_id"`
+	TraceState string            `parquet:"trace_state"`
+	Attributes map[string]string `parquet:"attributes"`
+}
+
+// parquetDataPointRow is the row of a metric data point. The columns which don't apply to the type of the metric
+// are null or empty.
+type parquetDataPointRow struct {
+	parquetResourceColumns
+	MetricName             string            `parquet:"metric_name"`
+	MetricDescription      string            `parquet:"metric_description"`
+	MetricUnit             string            `parquet:"metric_unit"`
+	MetricType             string            `parquet:"metric_type"`
+	AggregationTemporality string            `parquet:"aggregation_temporality"`
+	IsMonotonic            bool              `parquet:"is_monotonic"`
+	StartTimeUnixNano      int64             `parquet:"start_time_unix_nano"`
+	TimeUnixNano           int64             `parquet:"time_unix_nano"`
+	Attributes             map[string]string `parquet:"attributes"`
+	Flags                  int64             `parquet:"flags"`
+	// Gauge and sum
+	ValueDouble *float64 `parquet:"value_double,optional"`
+	ValueInt    *int64   `parquet:"value_int,optional"`
+	// Histogram, exponential histogram and summary
+	Count *int64   `parquet:"count,optional"`
+	Sum   *float64 `parquet:"sum,optional"`
+	Min   *float64 `parquet:"min,optional"`
+	Max   *float64 `parquet:"max,optional"`
+	// Histogram
+	BucketCounts   []int64   `parquet:"bucket_counts,list"`
+	ExplicitBounds []float64 `parquet:"explicit_bounds,list"`
+	// Exponential histogram
+	Scale                *int32   `parquet:"scale,optional"`
+	ZeroCount            *int64   `parquet:"zero_count,optional"`
+	ZeroThreshold        *float64 `parquet:"zero_threshold,optional"`
+	PositiveOffset       *int32   `parquet:"positive_offset,optional"`
+	PositiveBucketCounts []int64  `parquet:"positive_bucket_counts,list"`
+	NegativeOffset       *int32   `parquet:"negative_offset,optional"`
+	NegativeBucketCounts []int64  `parquet:"negative_bucket_counts,list"`
+	// Summary
+	QuantileValues []parquetQuantileValue `parquet:"quantile_values,list"`
+}
+
+type parquetQuantileValue struct {
+	Quantile float64 `parquet:"quantile"`
+	Value    float64 `parquet:"value"`
+}
+
+// parquetPromotedColumn is an attribute written into its own top-level column.
+type parquetPromotedColumn struct {
+	resource  bool
+	attribute string
+	column    string
+}
+
+type parquetPromotedColumns []parquetPromotedColumn
+
+func newParquetPromotedColumns(resourceAttributes, attributes []string) (parquetPromotedColumns, error) {
+	columns := make(parquetPromotedColumns, 0, len(resourceAttributes)+len(attributes))
+	for _, attribute := range resourceAttributes {
+		columns = append(columns, parquetPromotedColumn{resource: true, attribute: attribute, column: "resource_" + parquetColumnName(attribute)})
+	}
+	for _, attribute := range attributes {
+		columns = append(columns, parquetPromotedColumn{attribute: attribute, column: "attribute_" + parquetColumnName(attribute)})
+	}
+
+	// The columns of the resource are the only ones with the prefix of the promoted columns.
+	names := map[string]string{
+		"resource_schema_url": "",
+		"resource_attributes": "",
+	}
+	for _, c := range columns {
+		if c.attribute == "" {
+			return nil, errors.New("parquet promoted attribute must not be empty")
+		}
+		if attribute, ok := names[c.column]; ok && attribute == "" {
+			return nil, fmt.Errorf("parquet promoted attribute %q has the column %q of the resource", c.attribute, c.column)
+		}
+		if attribute, ok := names[c.column]; ok {
+			return nil, fmt.Errorf("parquet promoted attributes %q and %q have the same column %q", attribute, c.attribute, c.column)
+		}
+		names[c.column] = c.attribute
+	}
+	return columns, nil
+}
+
+// parquetColumnName returns the column name of an attribute: the attribute name in lower case, where the characters
+// other than letters, digits and underscores are replaced by underscores.
+func parquetColumnName(attribute string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(attribute))
+}
+
+// appendValues appends the values of the promoted columns to a row, which holds the values of the first columns.
+func (p parquetPromotedColumns) appendValues(row parquet.Row, firstColumn int, resource, attributes pcommon.Map) parquet.Row {
+	for i, c := range p {
+		attrs := attributes
+		if c.resource {
+			attrs = resource
+		}
+		if v, ok := attrs.Get(c.attribute); ok {
+			row = append(row, parquet.ValueOf(v.AsString()).Level(0, 1, firstColumn+i))
+		} else {
+			row = append(row, parquet.NullValue().Level(0, 0, firstColumn+i))
+		}
+	}
+	return row
+}
+
+// parquetSchema is the schema of the rows of a signal: the columns of its row type, followed by the promoted columns.
+type parquetSchema struct {
+	rows     *parquet.Schema
+	full     *parquet.Schema
+	promoted parquetPromotedColumns
+}
+
+func newParquetSchema(name string, row any, promoted parquetPromotedColumns) parquetSchema {
+	fields := []reflect.StructField{{Name: "Row", Type: reflect.TypeOf(row), Anonymous: true}}
+	for i, c := range promoted {
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Promoted%d", i),
+			Type: reflect.TypeOf((*string)(nil)),
+			Tag:  reflect.StructTag(fmt.Sprintf(`parquet:"%s,optional"`, c.column)),
+		})
+	}
+	full := reflect.New(reflect.StructOf(fields)).Interface()
+
+	return parquetSchema{
+		rows:     parquet.NewSchema(name, parquet.SchemaOf(row)),
+		full:     parquet.NewSchema(name, parquet.SchemaOf(full)),
+		promoted: promoted,
+	}
+}
+
+type parquetMarshaler struct {
+	codec        compress.Codec
+	rowGroupSize int64
+
+	logSchema       parquetSchema
+	spanSchema      parquetSchema
+	dataPointSchema parquetSchema
+}
+
+var (
+	_ plog.Marshaler    = (*parquetMarshaler)(nil)
+	_ ptrace.Marshaler  = (*parquetMarshaler)(nil)
+	_ pmetric.Marshaler = (*parquetMarshaler)(nil)
+)
+
+func newParquetMarshaler(cfg ParquetConfig) (*parquetMarshaler, error) {
+	codec, ok := parquetCodecs[cfg.Compression]
+	if !ok {
+		return nil, fmt.Errorf("unknown parquet compression %q", cfg.Compression)
+	}
+	promoted, err := newParquetPromotedColumns(cfg.PromotedResourceAttributes, cfg.PromotedAttributes)
+	if err != nil {
+		return nil, err
+	}
+	rowGroupSize := int64(cfg.RowGroupSize)
+	if rowGroupSize == 0 {
+		rowGroupSize = defaultParquetRowGroupSize
+	}
+
+	return &parquetMarshaler{
+		codec:           codec,
+		rowGroupSize:    rowGroupSize,
+		logSchema:       newParquetSchema("log", parquetLogRow{}, promoted),
+		spanSchema:      newParquetSchema("span", parquetSpanRow{}, promoted),
+		dataPointSchema: newParquetSchema("data_point", parquetDataPointRow{}, promoted),
+	}, nil
+}
+
+func (*parquetMarshaler) format() string {
+	return string(Parquet)
+}
+
+// parquetFileWriter writes the rows of a signal into a parquet file.
+type parquetFileWriter struct {
+	schema      parquetSchema
+	firstColumn int
+	buf         bytes.Buffer
+	writer      *parquet.Writer
+}
+
+func (m *parquetMarshaler) newFileWriter(schema parquetSchema) *parquetFileWriter {
+	w := &parquetFileWriter{
+		schema:      schema,
+		firstColumn: len(schema.rows.Columns()),
+	}
+	w.writer = parquet.NewWriter(&w.buf, schema.full, parquet.Compression(m.codec), parquet.MaxRowsPerRowGroup(m.rowGroupSize))
+	return w
+}
+
+func (w *parquetFileWriter) write(row any, resource, attributes pcommon.Map) error {
+	r := w.schema.rows.Deconstruct(nil, row)
+	r = w.schema.promoted.appendValues(r, w.firstColumn, resource, attributes)
+	_, err := w.writer.WriteRows([]parquet.Row{r})
+	return err
+}
+
+func (w *parquetFileWriter) close() ([]byte, error) {
+	if err := w.writer.Close(); err != nil {
+		return nil, err
+	}
+	return w.buf.Bytes(), nil
+}
+
+func (m *parquetMarshaler) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	w := m.newFileWriter(m.logSchema)
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			resourceColumns := newParquetResourceColumns(rl.Resource(), rl.SchemaUrl(), sl.Scope())
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				lr := lrs.At(k)
+				row := parquetLogRow{
+					parquetResourceColumns: resourceColumns,
+					TimeUnixNano:           int64(lr.Timestamp()),
+					ObservedTimeUnixNano:   int64(lr.ObservedTimestamp()),
+					SeverityNumber:         int32(lr.SeverityNumber()),
+					SeverityText:           lr.SeverityText(),
+					Body:                   lr.Body().AsString(),
+					EventName:              lr.EventName(),
+					Attributes:             parquetAttributes(lr.Attributes()),
+					DroppedAttributesCount: int64(lr.DroppedAttributesCount()),
+					Flags:                  int64(lr.Flags()),
+					TraceID:                lr.TraceID().String(),
+					SpanID:                 lr.SpanID().String(),
+				}
+				if err := w.write(&row, rl.Resource().Attributes(), lr.Attributes()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return w.close()
+}
+
+func (m *parquetMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	w := m.newFileWriter(m.spanSchema)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			resourceColumns := newParquetResourceColumns(rs.Resource(), rs.SchemaUrl(), ss.Scope())
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				row := parquetSpanRow{
+					parquetResourceColumns: resourceColumns,
+					TraceID:                span.TraceID().String(),
+					SpanID:                 span.SpanID().String(),
+					ParentSpanID:           span.ParentSpanID().String(),
+					TraceState:             span.TraceState().AsRaw(),
+					Flags:                  int64(span.Flags()),
+					Name:                   span.Name(),
+					Kind:                   span.Kind().String(),
+					StartTimeUnixNano:      int64(span.StartTimestamp()),
+					EndTimeUnixNano:        int64(span.EndTimestamp()),
+					DurationNano:           int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					Attributes:             parquetAttributes(span.Attributes()),
+					DroppedAttributesCount: int64(span.DroppedAttributesCount()),
+					DroppedEventsCount:     int64(span.DroppedEventsCount()),
+					DroppedLinksCount:      int64(span.DroppedLinksCount()),
+					StatusCode:             span.Status().Code().String(),
+					StatusMessage:          span.Status().Message(),
+				}
+				for l := 0; l < span.Events().Len(); l++ {
+					event := span.Events().At(l)
+					row.Events = append(row.Events, parquetSpanEvent{
+						TimeUnixNano: int64(event.Timestamp()),
+						Name:         event.Name(),
+						Attributes:   parquetAttributes(event.Attributes()),
+					})
+				}
+				for l := 0; l < span.Links().Len(); l++ {
+					link := span.Links().At(l)
+					row.Links = append(row.Links, parquetSpanLink{
+						TraceID:    link.TraceID().String(),
+						SpanID:     link.SpanID().String(),
+						TraceState: link.TraceState().AsRaw(),
+						Attributes: parquetAttributes(link.Attributes()),
+					})
+				}
+				if err := w.write(&row, rs.Resource().Attributes(), span.Attributes()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return w.close()
+}
+
+func (m *parquetMarshaler) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	w := m.newFileWriter(m.dataPointSchema)
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			resourceColumns := newParquetResourceColumns(rm.Resource(), rm.SchemaUrl(), sm.Scope())
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				metric := metrics.At(k)
+				base := parquetDataPointRow{
+					parquetResourceColumns: resourceColumns,
+					MetricName:             metric.Name(),
+					MetricDescription:      metric.Description(),
+					MetricUnit:             metric.Unit(),
+					MetricType:             metric.Type().String(),
+				}
+				write := func(row *parquetDataPointRow, attributes pcommon.Map) error {
+					return w.write(row, rm.Resource().Attributes(), attributes)
+				}
+				if err := writeParquetDataPoints(metric, base, write); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return w.close()
+}
+
+// writeParquetDataPoints writes a row for each data point of the metric, from the row holding the columns of the
+// metric.
+func writeParquetDataPoints(metric pmetric.Metric, base parquetDataPointRow, write func(*parquetDataPointRow, pcommon.Map) error) error {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		return writeParquetNumberDataPoints(metric.Gauge().DataPoints(), base, write)
+	case pmetric.MetricTypeSum:
+		base.AggregationTemporality = metric.Sum().AggregationTemporality().String()
+		base.IsMonotonic = metric.Sum().IsMonotonic()
+		return writeParquetNumberDataPoints(metric.Sum().DataPoints(), base, write)
+	case pmetric.MetricTypeHistogram:
+		base.AggregationTemporality = metric.Histogram().AggregationTemporality().String()
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := base
+			setParquetDataPointColumns(&row, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			row.Count = parquetPtr(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = parquetPtr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = parquetPtr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = parquetPtr(dp.Max())
+			}
+			row.BucketCounts = parquetCounts(dp.BucketCounts())
+			row.ExplicitBounds = dp.ExplicitBounds().AsRaw()
+			if err := write(&row, dp.Attributes()); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		base.AggregationTemporality = metric.ExponentialHistogram().AggregationTemporality().String()
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := base
+			setParquetDataPointColumns(&row, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			row.Count = parquetPtr(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = parquetPtr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = parquetPtr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = parquetPtr(dp.Max())
+			}
+			row.Scale = parquetPtr(dp.Scale())
+			row.ZeroCount = parquetPtr(int64(dp.ZeroCount()))
+			row.ZeroThreshold = parquetPtr(dp.ZeroThreshold())
+			row.PositiveOffset = parquetPtr(dp.Positive().Offset())
+			row.PositiveBucketCounts = parquetCounts(dp.Positive().BucketCounts())
+			row.NegativeOffset = parquetPtr(dp.Negative().Offset())
+			row.NegativeBucketCounts = parquetCounts(dp.Negative().BucketCounts())
+			if err := write(&row, dp.Attributes()); err != nil {
+				return err
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := base
+			setParquetDataPointColumns(&row, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+			row.Count = parquetPtr(int64(dp.Count()))
+			row.Sum = parquetPtr(dp.Sum())
+			for j := 0; j < dp.QuantileValues().Len(); j++ {
+				qv := dp.QuantileValues().At(j)
+				row.QuantileValues = append(row.QuantileValues, parquetQuantileValue{Quantile: qv.Quantile(), Value: qv.Value()})
+			}
+			if err := write(&row, dp.Attributes()); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func writeParquetNumberDataPoints(dps pmetric.NumberDataPointSlice, base parquetDataPointRow, write func(*parquetDataPointRow, pcommon.Map) error) error {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := base
+		setParquetDataPointColumns(&row, dp.StartTimestamp(), dp.Timestamp(), dp.Attributes(), dp.Flags())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			row.ValueDouble = parquetPtr(dp.DoubleValue())
+		case pmetric.NumberDataPointValueTypeInt:
+			row.ValueInt = parquetPtr(dp.IntValue())
+		}
+		if err := write(&row, dp.Attributes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setParquetDataPointColumns(row *parquetDataPointRow, start, timestamp pcommon.Timestamp, attributes pcommon.Map, flags pmetric.DataPointFlags) {
+	row.StartTimeUnixNano = int64(start)
+	row.TimeUnixNano = int64(timestamp)
+	row.Attributes = parquetAttributes(attributes)
+	row.Flags = int64(flags)
+}
+
+func newParquetResourceColumns(resource pcommon.Resource, schemaURL string, scope pcommon.InstrumentationScope) parquetResourceColumns {
+	return parquetResourceColumns{
+		ResourceSchemaURL:  schemaURL,
+		ResourceAttributes: parquetAttributes(resource.Attributes()),
+		ScopeName:          scope.Name(),
+		ScopeVersion:       scope.Version(),
+		ScopeAttributes:    parquetAttributes(scope.Attributes()),
+	}
+}
+
+// parquetAttributes returns the attributes as strings, where maps and slices are JSON encoded.
+func parquetAttributes(attributes pcommon.Map) map[string]string {
+	if attributes.Len() == 0 {
+		return nil
+	}
+	m := make(map[string]string, attributes.Len())
+	for k, v := range attributes.All() {
+		m[k] = v.AsString()
+	}
+	return m
+}
+
+func parquetCounts(counts pcommon.UInt64Slice) []int64 {
+	if counts.Len() == 0 {
+		return nil
+	}
+	s := make([]int64, counts.Len())
+	for i := range s {
+		s[i] = int64(counts.At(i))
+	}
+	return s
+}
+
+func parquetPtr[T any](v T) *T {
+	return &v
+}
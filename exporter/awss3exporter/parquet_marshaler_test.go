@@ -0,0 +1,366 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3exporter
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// parquetPromotedTestColumns are the promoted columns of the tests.
+type parquetPromotedTestColumns struct {
+	ServiceName *string `parquet:"resource_service_name,optional"`
+	HTTPMethod  *string `parquet:"attribute_http_method,optional"`
+}
+
+func newTestParquetMarshaler(t *testing.T, cfg ParquetConfig) *parquetMarshaler {
+	cfg.PromotedResourceAttributes = []string{"service.name"}
+	cfg.PromotedAttributes = []string{"http.method"}
+	m, err := newParquetMarshaler(cfg)
+	require.NoError(t, err)
+	return m
+}
+
+// parquetTestRow is a row of a parquet file of the tests.
+type parquetTestRow[R any] struct {
+	row      R
+	promoted parquetPromotedTestColumns
+}
+
+// readParquetRows reads the rows of a parquet file. The columns of R and the promoted columns are reconstructed
+// separately, as the readers allocate the null pointers of embedded structs.
+func readParquetRows[R any](t *testing.T, b []byte) []parquetTestRow[R] {
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	var zero parquetTestRow[R]
+	rowSchema := parquet.SchemaOf(zero.row)
+	promotedSchema := parquet.SchemaOf(zero.promoted)
+	numColumns := len(rowSchema.Columns())
+	require.Len(t, f.Schema().Columns(), numColumns+len(promotedSchema.Columns()))
+
+	r := parquet.NewReader(f)
+	defer r.Close()
+	var rows []parquetTestRow[R]
+	buf := make([]parquet.Row, 1)
+	for {
+		n, err := r.ReadRows(buf)
+		if n == 1 {
+			var rowValues, promotedValues parquet.Row
+			for _, v := range buf[0] {
+				if v.Column() < numColumns {
+					rowValues = append(rowValues, v)
+				} else {
+					promotedValues = append(promotedValues, v.Level(v.RepetitionLevel(), v.DefinitionLevel(), v.Column()-numColumns))
+				}
+			}
+			var row parquetTestRow[R]
+			require.NoError(t, rowSchema.Reconstruct(&row.row, rowValues))
+			require.NoError(t, promotedSchema.Reconstruct(&row.promoted, promotedValues))
+			rows = append(rows, row)
+		}
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		require.NoError(t, err)
+	}
+}
+
+func nilIfEmpty[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+func newParquetTestResource(rAttrs pcommon.Map, scope pcommon.InstrumentationScope) {
+	rAttrs.PutStr("service.name", "checkout")
+	rAttrs.PutStr("host.name", "host-1")
+	scope.SetName("test")
+	scope.SetVersion("1.0")
+}
+
+func TestParquetMarshalLogs(t *testing.T) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.SetSchemaUrl("https://opentelemetry.io/schemas/1.26.0")
+	sl := rl.ScopeLogs().AppendEmpty()
+	newParquetTestResource(rl.Resource().Attributes(), sl.Scope())
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(10)
+	lr.SetObservedTimestamp(11)
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("request failed")
+	lr.Attributes().PutStr("http.method", "GET")
+	lr.Attributes().PutInt("http.status_code", 500)
+	lr.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	lr.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	lr = sl.LogRecords().AppendEmpty()
+	lr.Body().SetEmptyMap().PutStr("message", "hello")
+
+	m := newTestParquetMarshaler(t, ParquetConfig{})
+	b, err := m.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	rows := readParquetRows[parquetLogRow](t, b)
+	require.Len(t, rows, 2)
+
+	resource := parquetResourceColumns{
+		ResourceSchemaURL:  "https://opentelemetry.io/schemas/1.26.0",
+		ResourceAttributes: map[string]string{"service.name": "checkout", "host.name": "host-1"},
+		ScopeName:          "test",
+		ScopeVersion:       "1.0",
+		ScopeAttributes:    map[string]string{},
+	}
+	assert.Equal(t, parquetTestRow[parquetLogRow]{
+		row: parquetLogRow{
+			parquetResourceColumns: resource,
+			TimeUnixNano:           10,
+			ObservedTimeUnixNano:   11,
+			SeverityNumber:         int32(plog.SeverityNumberError),
+			SeverityText:           "ERROR",
+			Body:                   "request failed",
+			Attributes:             map[string]string{"http.method": "GET", "http.status_code": "500"},
+			TraceID:                "0102030405060708090a0b0c0d0e0f10",
+			SpanID:                 "0102030405060708",
+		},
+		promoted: parquetPromotedTestColumns{
+			ServiceName: parquetPtr("checkout"),
+			HTTPMethod:  parquetPtr("GET"),
+		},
+	}, rows[0])
+	assert.Equal(t, parquetTestRow[parquetLogRow]{
+		row: parquetLogRow{
+			parquetResourceColumns: resource,
+			Body:                   `{"message":"hello"}`,
+			Attributes:             map[string]string{},
+		},
+		promoted: parquetPromotedTestColumns{
+			ServiceName: parquetPtr("checkout"),
+		},
+	}, rows[1])
+}
+
+func TestParquetMarshalTraces(t *testing.T) {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	ss := rs.ScopeSpans().AppendEmpty()
+	newParquetTestResource(rs.Resource().Attributes(), ss.Scope())
+	span := ss.Spans().AppendEmpty()
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(100)
+	span.SetEndTimestamp(150)
+	span.Attributes().PutStr("http.method", "GET")
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("timeout")
+	event := span.Events().AppendEmpty()
+	event.SetTimestamp(120)
+	event.SetName("retry")
+	event.Attributes().PutInt("attempt", 2)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(pcommon.TraceID{16, 15, 14, 13, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1})
+	link.SetSpanID(pcommon.SpanID{8, 7, 6, 5, 4, 3, 2, 1})
+
+	m := newTestParquetMarshaler(t, ParquetConfig{Compression: "zstd"})
+	b, err := m.MarshalTraces(td)
+	require.NoError(t, err)
+
+	rows := readParquetRows[parquetSpanRow](t, b)
+	require.Len(t, rows, 1)
+	assert.Equal(t, parquetTestRow[parquetSpanRow]{
+		row: parquetSpanRow{
+			parquetResourceColumns: parquetResourceColumns{
+				ResourceAttributes: map[string]string{"service.name": "checkout", "host.name": "host-1"},
+				ScopeName:          "test",
+				ScopeVersion:       "1.0",
+				ScopeAttributes:    map[string]string{},
+			},
+			TraceID:           "0102030405060708090a0b0c0d0e0f10",
+			SpanID:            "0102030405060708",
+			Name:              "GET /cart",
+			Kind:              "Server",
+			StartTimeUnixNano: 100,
+			EndTimeUnixNano:   150,
+			DurationNano:      50,
+			Attributes:        map[string]string{"http.method": "GET"},
+			Events: []parquetSpanEvent{
+				{TimeUnixNano: 120, Name: "retry", Attributes: map[string]string{"attempt": "2"}},
+			},
+			Links: []parquetSpanLink{
+				{TraceID: "100f0e0d0c0b0a090807060504030201", SpanID: "0807060504030201", Attributes: map[string]string{}},
+			},
+			StatusCode:    "Error",
+			StatusMessage: "timeout",
+		},
+		promoted: parquetPromotedTestColumns{
+			ServiceName: parquetPtr("checkout"),
+			HTTPMethod:  parquetPtr("GET"),
+		},
+	}, rows[0])
+}
+
+func TestParquetMarshalMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	newParquetTestResource(rm.Resource().Attributes(), sm.Scope())
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("http.server.requests")
+	sum.SetUnit("{request}")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetIntValue(0)
+	dp.SetStartTimestamp(1)
+	dp.SetTimestamp(2)
+	dp.Attributes().PutStr("http.method", "GET")
+
+	histogram := sm.Metrics().AppendEmpty()
+	histogram.SetName("http.server.duration")
+	histogram.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(2)
+	hdp.SetCount(3)
+	hdp.SetSum(0.6)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2})
+	hdp.ExplicitBounds().FromRaw([]float64{0.1})
+
+	exponential := sm.Metrics().AppendEmpty()
+	exponential.SetName("http.client.duration")
+	exponential.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+	edp := exponential.ExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(2)
+	edp.SetCount(2)
+	edp.SetScale(2)
+	edp.SetZeroCount(1)
+	edp.Positive().SetOffset(-1)
+	edp.Positive().BucketCounts().FromRaw([]uint64{1})
+
+	summary := sm.Metrics().AppendEmpty()
+	summary.SetName("rpc.duration")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(2)
+	sdp.SetCount(4)
+	sdp.SetSum(2)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(0.4)
+
+	// A row group for each data point
+	m := newTestParquetMarshaler(t, ParquetConfig{RowGroupSize: 1})
+	b, err := m.MarshalMetrics(md)
+	require.NoError(t, err)
+
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	require.NoError(t, err)
+	assert.Len(t, f.RowGroups(), 4)
+
+	rows := readParquetRows[parquetDataPointRow](t, b)
+	require.Len(t, rows, 4)
+	// Empty lists are read as empty slices
+	for i := range rows {
+		dp := &rows[i].row
+		dp.BucketCounts = nilIfEmpty(dp.BucketCounts)
+		dp.ExplicitBounds = nilIfEmpty(dp.ExplicitBounds)
+		dp.PositiveBucketCounts = nilIfEmpty(dp.PositiveBucketCounts)
+		dp.NegativeBucketCounts = nilIfEmpty(dp.NegativeBucketCounts)
+		dp.QuantileValues = nilIfEmpty(dp.QuantileValues)
+	}
+
+	resource := parquetResourceColumns{
+		ResourceAttributes: map[string]string{"service.name": "checkout", "host.name": "host-1"},
+		ScopeName:          "test",
+		ScopeVersion:       "1.0",
+		ScopeAttributes:    map[string]string{},
+	}
+	promoted := parquetPromotedTestColumns{ServiceName: parquetPtr("checkout")}
+	assert.Equal(t, []parquetTestRow[parquetDataPointRow]{
+		{
+			row: parquetDataPointRow{
+				parquetResourceColumns: resource,
+				MetricName:             "http.server.requests",
+				MetricUnit:             "{request}",
+				MetricType:             "Sum",
+				AggregationTemporality: "Cumulative",
+				IsMonotonic:            true,
+				StartTimeUnixNano:      1,
+				TimeUnixNano:           2,
+				Attributes:             map[string]string{"http.method": "GET"},
+				ValueInt:               parquetPtr[int64](0),
+			},
+			promoted: parquetPromotedTestColumns{
+				ServiceName: parquetPtr("checkout"),
+				HTTPMethod:  parquetPtr("GET"),
+			},
+		},
+		{
+			row: parquetDataPointRow{
+				parquetResourceColumns: resource,
+				MetricName:             "http.server.duration",
+				MetricType:             "Histogram",
+				AggregationTemporality: "Delta",
+				TimeUnixNano:           2,
+				Attributes:             map[string]string{},
+				Count:                  parquetPtr[int64](3),
+				Sum:                    parquetPtr(0.6),
+				BucketCounts:           []int64{1, 2},
+				ExplicitBounds:         []float64{0.1},
+			},
+			promoted: promoted,
+		},
+		{
+			row: parquetDataPointRow{
+				parquetResourceColumns: resource,
+				MetricName:             "http.client.duration",
+				MetricType:             "ExponentialHistogram",
+				AggregationTemporality: "Delta",
+				TimeUnixNano:           2,
+				Attributes:             map[string]string{},
+				Count:                  parquetPtr[int64](2),
+				Scale:                  parquetPtr[int32](2),
+				ZeroCount:              parquetPtr[int64](1),
+				ZeroThreshold:          parquetPtr[float64](0),
+				PositiveOffset:         parquetPtr[int32](-1),
+				PositiveBucketCounts:   []int64{1},
+				NegativeOffset:         parquetPtr[int32](0),
+			},
+			promoted: promoted,
+		},
+		{
+			row: parquetDataPointRow{
+				parquetResourceColumns: resource,
+				MetricName:             "rpc.duration",
+				MetricType:             "Summary",
+				TimeUnixNano:           2,
+				Attributes:             map[string]string{},
+				Count:                  parquetPtr[int64](4),
+				Sum:                    parquetPtr[float64](2),
+				QuantileValues:         []parquetQuantileValue{{Quantile: 0.5, Value: 0.4}},
+			},
+			promoted: promoted,
+		},
+	}, rows)
+}
+
+func TestNewParquetMarshalerErrors(t *testing.T) {
+	_, err := newParquetMarshaler(ParquetConfig{Compression: "lz4"})
+	assert.EqualError(t, err, `unknown parquet compression "lz4"`)
+
+	_, err = newParquetMarshaler(ParquetConfig{PromotedAttributes: []string{""}})
+	assert.EqualError(t, err, "parquet promoted attribute must not be empty")
+}
@@ -5,6 +5,7 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,8 +14,9 @@ import (
 )
 
 const (
-	rotationFieldName = "rotation"
-	backupsFieldName  = "max_backups"
+	rotationFieldName  = "rotation"
+	partitionFieldName = "partition"
+	backupsFieldName   = "max_backups"
 )
 
 // Config defines configuration for file exporter.
@@ -52,6 +54,11 @@ type Config struct {
 
 	// GroupBy enables writing to separate files based on a resource attribute.
 	GroupBy *GroupBy `mapstructure:"group_by"`
+
+	// Partition enables writing to time-partitioned files, which are only
+	// renamed to their final path once closed. When set, Path is a template
+	// and Rotation must not be set.
+	Partition *Partition `mapstructure:"partition"`
 }
 
 // Rotation an option to rolling log files
@@ -92,6 +99,36 @@ type GroupBy struct {
 	MaxOpenFiles int `mapstructure:"max_open_files"`
 }
 
+// Partition an option to write telemetry to time-partitioned files, which
+// are written under a temporary name and renamed once closed.
+type Partition struct {
+	// MaxMegabytes is the maximum size in megabytes of a file before it gets
+	// closed and a new one is started. The default is not to limit the size
+	// of the files.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// MaxAge is the maximum duration a file is kept open before it gets
+	// closed and a new one is started. The default is to keep a file open
+	// until its time partition ends.
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// TempSuffix is appended to the path of the files while they are being
+	// written. Default is ".tmp".
+	TempSuffix string `mapstructure:"temp_suffix"`
+
+	// SuccessFile defines the _SUCCESS file written in the directory of a
+	// partition once all its files are closed.
+	// Options:
+	// - none[default]: no _SUCCESS file is written.
+	// - empty: an empty _SUCCESS file is written.
+	// - manifest: a _SUCCESS file listing the files of the partition is written.
+	SuccessFile string `mapstructure:"success_file"`
+
+	// LocalTime determines if the time used for formatting the path of the
+	// files is the computer's local time. The default is to use UTC time.
+	LocalTime bool `mapstructure:"localtime"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
@@ -115,6 +152,29 @@ func (cfg *Config) Validate() error {
 		return errors.New("flush_interval must be larger than zero")
 	}
 
+	if cfg.Partition != nil {
+		if cfg.Rotation != nil {
+			return errors.New("rotation and partition enabled at the same time is not supported")
+		}
+		if cfg.Append {
+			return errors.New("append and partition enabled at the same time is not supported")
+		}
+		if !strings.Contains(filepath.Base(cfg.Path), partNumberPlaceholder) {
+			return errors.New("path must contain " + partNumberPlaceholder + " in the file name when partition is enabled")
+		}
+		if cfg.Partition.MaxMegabytes < 0 {
+			return errors.New("partition max_megabytes must not be negative")
+		}
+		if cfg.Partition.MaxAge < 0 {
+			return errors.New("partition max_age must not be negative")
+		}
+		switch cfg.Partition.SuccessFile {
+		case "", successFileNone, successFileEmpty, successFileManifest:
+		default:
+			return errors.New("partition success_file is not supported")
+		}
+	}
+
 	if cfg.GroupBy != nil && cfg.GroupBy.Enabled {
 		pathParts := strings.Split(cfg.Path, "*")
 		if len(pathParts) != 2 {
@@ -149,6 +209,10 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if !componentParser.IsSet(rotationFieldName) {
 		cfg.Rotation = nil
 	}
+	// if partition is present, it is enabled, even if empty.
+	if componentParser.IsSet(partitionFieldName) && cfg.Partition == nil {
+		cfg.Partition = &Partition{}
+	}
 
 	// set flush interval to 1 second if not set.
 	if cfg.FlushInterval == 0 {
@@ -186,6 +186,55 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "group_by_empty_resource_attribute"),
 			errorMessage: "resource_attribute must not be empty when group_by is enabled",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition"),
+			expected: &Config{
+				Path:          "./partition/*/{yyyy}/{mm}/{dd}/{hh}/part-{n}.jsonl.zst",
+				FormatType:    formatTypeJSON,
+				Compression:   compressionZSTD,
+				FlushInterval: time.Second,
+				Partition: &Partition{
+					MaxMegabytes: 64,
+					MaxAge:       15 * time.Minute,
+					TempSuffix:   ".inprogress",
+					SuccessFile:  successFileManifest,
+				},
+				GroupBy: &GroupBy{
+					Enabled:           true,
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: "service.name",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "partition_defaults"),
+			expected: &Config{
+				Path:          "./partition/part-{n}.json",
+				FormatType:    formatTypeJSON,
+				FlushInterval: time.Second,
+				Partition:     &Partition{},
+				GroupBy: &GroupBy{
+					MaxOpenFiles:      defaultMaxOpenFiles,
+					ResourceAttribute: defaultResourceAttribute,
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_with_rotation"),
+			errorMessage: "rotation and partition enabled at the same time is not supported",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_with_append"),
+			errorMessage: "append and partition enabled at the same time is not supported",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_invalid_path"),
+			errorMessage: "path must contain {n} in the file name when partition is enabled",
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "partition_invalid_success_file"),
+			errorMessage: "partition success_file is not supported",
+		},
 	}
 
 	for _, tt := range tests {
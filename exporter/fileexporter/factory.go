@@ -40,6 +40,13 @@ const (
 	defaultMaxOpenFiles = 100
 
 	defaultResourceAttribute = "fileexporter.path_segment"
+
+	// the kind of _SUCCESS file written when a partition is closed
+	successFileNone     = "none"
+	successFileEmpty    = "empty"
+	successFileManifest = "manifest"
+
+	defaultTempSuffix = ".tmp"
 )
 
 type FileExporter interface {
@@ -157,7 +164,8 @@ func getOrCreateFileExporter(cfg component.Config, logger *zap.Logger) FileExpor
 func newFileExporter(conf *Config, logger *zap.Logger) FileExporter {
 	if conf.GroupBy == nil || !conf.GroupBy.Enabled {
 		return &fileExporter{
-			conf: conf,
+			conf:   conf,
+			logger: logger,
 		}
 	}
 
@@ -199,6 +207,15 @@ func newFileWriter(path string, shouldAppend bool, rotation *Rotation, flushInte
 	}, nil
 }
 
+func newPartitionedFileWriter(pathTemplate string, partition *Partition, flushInterval time.Duration, export exportFunc, logger *zap.Logger) *fileWriter {
+	return &fileWriter{
+		path:          pathTemplate,
+		file:          newPartitionedWriter(pathTemplate, partition, logger),
+		exporter:      export,
+		flushInterval: flushInterval,
+	}
+}
+
 // This is the map of already created File exporters for particular configurations.
 // We maintain this map because the Factory is asked trace and metric receivers separately
 // when it gets CreateTraces() and CreateMetrics() but they must not
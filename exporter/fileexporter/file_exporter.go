@@ -11,11 +11,13 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pprofile"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
 type fileExporter struct {
 	conf       *Config
+	logger     *zap.Logger
 	marshaller *marshaller
 	writer     *fileWriter
 }
@@ -61,9 +63,13 @@ func (e *fileExporter) Start(_ context.Context, host component.Host) error {
 	}
 	export := buildExportFunc(e.conf)
 
-	e.writer, err = newFileWriter(e.conf.Path, e.conf.Append, e.conf.Rotation, e.conf.FlushInterval, export)
-	if err != nil {
-		return err
+	if e.conf.Partition != nil {
+		e.writer = newPartitionedFileWriter(e.conf.Path, e.conf.Partition, e.conf.FlushInterval, export, e.logger)
+	} else {
+		e.writer, err = newFileWriter(e.conf.Path, e.conf.Append, e.conf.Rotation, e.conf.FlushInterval, export)
+		if err != nil {
+			return err
+		}
 	}
 	e.writer.start()
 	return nil
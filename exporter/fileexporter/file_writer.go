@@ -23,19 +23,18 @@ type fileWriter struct {
 	flushInterval time.Duration
 	flushTicker   *time.Ticker
 	stopTicker    chan struct{}
+	flusherDone   chan struct{}
 }
 
 func exportMessageAsLine(w *fileWriter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
-	if _, err := w.file.Write(buf); err != nil {
-		return err
-	}
-	if _, err := io.WriteString(w.file, "\n"); err != nil {
-		return err
-	}
-	return nil
+	// write the message and its newline at once, so that rolled files only
+	// contain whole lines.
+	data := make([]byte, 0, len(buf)+1)
+	_, err := w.file.Write(append(append(data, buf...), '\n'))
+	return err
 }
 
 func exportMessageAsBuffer(w *fileWriter, buf []byte) error {
@@ -67,9 +66,11 @@ func (w *fileWriter) startFlusher() {
 
 	// Create the stop channel.
 	w.stopTicker = make(chan struct{})
+	w.flusherDone = make(chan struct{})
 	// Start the ticker.
 	w.flushTicker = time.NewTicker(w.flushInterval)
 	go func() {
+		defer close(w.flusherDone)
 		for {
 			select {
 			case <-w.flushTicker.C:
@@ -93,15 +94,16 @@ func (w *fileWriter) start() {
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
-// It stops the flush ticker if set.
+// It stops the flush ticker if set, and waits for the last flush to complete before closing the file.
 func (w *fileWriter) shutdown() error {
 	// Stop the flush ticker.
-	if w.flushTicker != nil {
+	if w.stopTicker != nil {
 		// Stop the go routine.
-		w.mutex.Lock()
 		close(w.stopTicker)
-		w.mutex.Unlock()
+		<-w.flusherDone
 	}
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
 	return w.file.Close()
 }
 
@@ -214,12 +214,16 @@ func (e *groupingFileExporter) getWriter(pathSegment string) (*fileWriter, error
 		return writer, nil
 	}
 
-	err := os.MkdirAll(path.Dir(fullPath), 0o755)
-	if err != nil {
-		return nil, err
+	// the directories of partitioned files are created by their writer, once
+	// the path is resolved.
+	if e.conf.Partition == nil {
+		err := os.MkdirAll(path.Dir(fullPath), 0o755)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	writer, err = e.newFileWriter(fullPath)
+	writer, err := e.newFileWriter(fullPath)
 	if err != nil {
 		return nil, err
 	}
@@ -298,6 +302,9 @@ func (e *groupingFileExporter) Start(_ context.Context, host component.Host) err
 	e.pathSuffix = pathParts[1]
 	e.maxOpenFiles = e.conf.GroupBy.MaxOpenFiles
 	e.newFileWriter = func(path string) (*fileWriter, error) {
+		if e.conf.Partition != nil {
+			return newPartitionedFileWriter(path, e.conf.Partition, e.conf.FlushInterval, export, e.logger), nil
+		}
 		return newFileWriter(path, e.conf.Append, nil, e.conf.FlushInterval, export)
 	}
 
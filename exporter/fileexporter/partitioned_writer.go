@@ -0,0 +1,403 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// partNumberPlaceholder is replaced with the number of the file in its time partition.
+	partNumberPlaceholder = "{n}"
+
+	successFileName = "_SUCCESS"
+	// pendingSuccessFileName is the _SUCCESS file of a partition closed before the end of its time window, e.g. by
+	// a shutdown, which is renamed once the window ended.
+	pendingSuccessFileName = successFileName + ".pending"
+)
+
+// placeholderPattern matches the placeholders of the path template.
+var placeholderPattern = regexp.MustCompile(`\{(yyyy|mm|dd|hh|n)\}`)
+
+// placeholderValues are the patterns of the values of the placeholders of the path template.
+var placeholderValues = map[string]string{
+	"{yyyy}":              `\d{4}`,
+	"{mm}":                `\d{2}`,
+	"{dd}":                `\d{2}`,
+	"{hh}":                `\d{2}`,
+	partNumberPlaceholder: `\d+`,
+}
+
+// partitionedWriter writes to time-partitioned files. Files are closed when they reach their maximum size or age,
+// or when their time partition ends, and a new one is started. Each file is written under a temporary name and
+// renamed to its final path once closed, so that only complete files are visible under their final path.
+type partitionedWriter struct {
+	pathTemplate string
+	maxBytes     int64
+	maxAge       time.Duration
+	tempSuffix   string
+	successFile  string
+	localTime    bool
+	logger       *zap.Logger
+	now          func() time.Time
+
+	// recovered is set once the files left by a previous run were cleaned up.
+	recovered bool
+	// file is the file being written, nil if none is open.
+	file *partFile
+	// partition is the partition of the last written files, nil if none.
+	partition *filePartition
+	// nextPart is the number of the next file of the time partition nextPartBucket.
+	nextPart       int
+	nextPartBucket string
+}
+
+// partFile is a file being written under its temporary name.
+type partFile struct {
+	path     string
+	bucket   string
+	file     *os.File
+	writer   *bufio.Writer
+	hash     hash.Hash
+	size     int64
+	openedAt time.Time
+}
+
+// filePartition is a directory in which files are written.
+type filePartition struct {
+	dir   string
+	files []manifestFile
+}
+
+// manifest is the content of the _SUCCESS file of a partition, when success_file is manifest.
+type manifest struct {
+	Files []manifestFile `json:"files"`
+}
+
+type manifestFile struct {
+	Name   string `json:"name"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+var _ io.WriteCloser = (*partitionedWriter)(nil)
+
+func newPartitionedWriter(pathTemplate string, partition *Partition, logger *zap.Logger) *partitionedWriter {
+	tempSuffix := partition.TempSuffix
+	if tempSuffix == "" {
+		tempSuffix = defaultTempSuffix
+	}
+	successFile := partition.SuccessFile
+	if successFile == "" {
+		successFile = successFileNone
+	}
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	return &partitionedWriter{
+		pathTemplate: pathTemplate,
+		maxBytes:     int64(partition.MaxMegabytes) * 1024 * 1024,
+		maxAge:       partition.MaxAge,
+		tempSuffix:   tempSuffix,
+		successFile:  successFile,
+		localTime:    partition.LocalTime,
+		logger:       logger,
+		now:          time.Now,
+	}
+}
+
+// Write writes p to the current file, after having closed it if it is due. A file is never closed in the middle
+// of a Write, so each call should contain whole messages.
+func (w *partitionedWriter) Write(p []byte) (int, error) {
+	now := w.now()
+	if err := w.roll(now); err != nil {
+		return 0, err
+	}
+	if w.file != nil && w.maxBytes > 0 && w.file.size > 0 && w.file.size+int64(len(p)) > w.maxBytes {
+		if err := w.closeFile(); err != nil {
+			return 0, err
+		}
+	}
+	if w.file == nil {
+		if err := w.openFile(now); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.writer.Write(p)
+	w.file.hash.Write(p[:n])
+	w.file.size += int64(n)
+	return n, err
+}
+
+// flush flushes the current file, and closes the files and partitions which are due even when nothing is written.
+func (w *partitionedWriter) flush() error {
+	if err := w.roll(w.now()); err != nil {
+		w.logger.Warn("Failed to close partitioned file", zap.Error(err), zap.String("path", w.pathTemplate))
+		return err
+	}
+	if w.file == nil {
+		return nil
+	}
+	return w.file.writer.Flush()
+}
+
+// Close closes the current file and partition.
+func (w *partitionedWriter) Close() error {
+	return errors.Join(w.closeFile(), w.closePartition(w.now()))
+}
+
+// roll closes the current file if it reached its maximum age or if its time partition ended, and the current
+// partition if its directory changed.
+func (w *partitionedWriter) roll(now time.Time) error {
+	if !w.recovered {
+		if err := w.recover(now); err != nil {
+			return err
+		}
+		w.recovered = true
+	}
+	bucket := w.resolve(now)
+	if w.file != nil && (w.file.bucket != bucket || (w.maxAge > 0 && now.Sub(w.file.openedAt) >= w.maxAge)) {
+		if err := w.closeFile(); err != nil {
+			return err
+		}
+	}
+	if w.partition != nil && w.partition.dir != filepath.Dir(bucket) {
+		return w.closePartition(now)
+	}
+	return nil
+}
+
+// recover removes the files left under their temporary name by a crash, and writes the _SUCCESS files of the
+// partitions which were closed before the end of their time window, once it ended.
+func (w *partitionedWriter) recover(now time.Time) error {
+	tempFiles, err := matchTemplate(w.pathTemplate + w.tempSuffix)
+	if err != nil {
+		return err
+	}
+	for _, path := range tempFiles {
+		w.logger.Warn("Removing partitioned file left under its temporary name", zap.String("path", path))
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+
+	if w.successFile == successFileNone {
+		return nil
+	}
+	pending, err := matchTemplate(filepath.Join(filepath.Dir(w.pathTemplate), pendingSuccessFileName))
+	if err != nil {
+		return err
+	}
+	current := filepath.Dir(w.resolve(now))
+	for _, path := range pending {
+		if dir := filepath.Dir(path); dir != current {
+			if err := w.writeSuccessFile(dir, successFileName, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// resolve returns the path template with the time placeholders replaced.
+func (w *partitionedWriter) resolve(now time.Time) string {
+	if !w.localTime {
+		now = now.UTC()
+	}
+	return strings.NewReplacer(
+		"{yyyy}", now.Format("2006"),
+		"{mm}", now.Format("01"),
+		"{dd}", now.Format("02"),
+		"{hh}", now.Format("15"),
+	).Replace(w.pathTemplate)
+}
+
+func (w *partitionedWriter) openFile(now time.Time) error {
+	bucket := w.resolve(now)
+	dir := filepath.Dir(bucket)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	if w.partition == nil {
+		w.partition = &filePartition{dir: dir}
+	}
+	if w.nextPartBucket != bucket {
+		w.nextPart = 0
+		w.nextPartBucket = bucket
+	}
+
+	// skip the numbers of the existing files, e.g. written before a restart.
+	for {
+		path := strings.ReplaceAll(bucket, partNumberPlaceholder, strconv.Itoa(w.nextPart))
+		w.nextPart++
+		if exists(path) {
+			continue
+		}
+		f, err := os.OpenFile(path+w.tempSuffix, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		w.file = &partFile{
+			path:     path,
+			bucket:   bucket,
+			file:     f,
+			writer:   bufio.NewWriter(f),
+			hash:     sha256.New(),
+			openedAt: now,
+		}
+		return nil
+	}
+}
+
+// closeFile closes the current file and renames it to its final path.
+func (w *partitionedWriter) closeFile() error {
+	if w.file == nil {
+		return nil
+	}
+	pf := w.file
+	w.file = nil
+
+	err := pf.writer.Flush()
+	if err == nil {
+		err = pf.file.Sync()
+	}
+	if closeErr := pf.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(pf.path+w.tempSuffix, pf.path); err != nil {
+		return err
+	}
+
+	w.partition.files = append(w.partition.files, manifestFile{
+		Name:   filepath.Base(pf.path),
+		Size:   pf.size,
+		SHA256: hex.EncodeToString(pf.hash.Sum(nil)),
+	})
+	return nil
+}
+
+// closePartition writes the _SUCCESS file of the current partition, if enabled. The _SUCCESS file of a partition
+// closed before the end of its time window, e.g. by a shutdown, is only written as pending, as the partition can
+// still get new files.
+func (w *partitionedWriter) closePartition(now time.Time) error {
+	if w.partition == nil {
+		return nil
+	}
+	p := w.partition
+	w.partition = nil
+
+	if w.successFile == successFileNone {
+		return nil
+	}
+	if p.dir == filepath.Dir(w.resolve(now)) {
+		return w.writeSuccessFile(p.dir, pendingSuccessFileName, p.files)
+	}
+	return w.writeSuccessFile(p.dir, successFileName, p.files)
+}
+
+// writeSuccessFile writes the _SUCCESS file, or the pending one, of the partition in dir. The pending _SUCCESS file
+// is removed once the _SUCCESS file is written.
+func (w *partitionedWriter) writeSuccessFile(dir, name string, files []manifestFile) error {
+	var content []byte
+	if w.successFile == successFileManifest {
+		m := manifest{Files: files}
+		// keep the files listed by the previous manifests of the partition, e.g. written before a restart.
+		for _, previousName := range []string{pendingSuccessFileName, successFileName} {
+			var previous manifest
+			if content, err := os.ReadFile(filepath.Join(dir, previousName)); err == nil && json.Unmarshal(content, &previous) == nil {
+				m.Files = mergeManifestFiles(previous.Files, m.Files)
+			}
+		}
+		var err error
+		if content, err = json.Marshal(m); err != nil {
+			return err
+		}
+	}
+	if err := w.writeAtomically(filepath.Join(dir, name), content); err != nil {
+		return err
+	}
+	if name != successFileName {
+		return nil
+	}
+	if err := os.Remove(filepath.Join(dir, pendingSuccessFileName)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// writeAtomically writes a file under its temporary name, and renames it to its final path.
+func (w *partitionedWriter) writeAtomically(path string, content []byte) error {
+	if err := os.WriteFile(path+w.tempSuffix, content, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(path+w.tempSuffix, path)
+}
+
+func mergeManifestFiles(previous, current []manifestFile) []manifestFile {
+	names := make(map[string]struct{}, len(current))
+	for _, f := range current {
+		names[f.Name] = struct{}{}
+	}
+	var merged []manifestFile
+	for _, f := range previous {
+		if _, ok := names[f.Name]; !ok {
+			merged = append(merged, f)
+		}
+	}
+	return append(merged, current...)
+}
+
+// matchTemplate returns the existing paths matching the path template, whatever the values of its placeholders.
+func matchTemplate(template string) ([]string, error) {
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, loc := range placeholderPattern.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString(placeholderValues[template[loc[0]:loc[1]]])
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	re, err := regexp.Compile(pattern.String())
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := filepath.Glob(placeholderPattern.ReplaceAllString(template, "*"))
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(matches, func(path string) bool {
+		return !re.MatchString(path)
+	}), nil
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fileexporter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func newTestPartitionedWriter(dir string, partition *Partition) (*partitionedWriter, *testClock) {
+	clock := &testClock{now: time.Date(2024, 5, 6, 7, 30, 0, 0, time.UTC)}
+	w := newPartitionedWriter(filepath.Join(dir, "{yyyy}", "{mm}", "{dd}", "{hh}", "part-{n}.jsonl"), partition, zap.NewNop())
+	w.now = clock.Now
+	return w, clock
+}
+
+func readDir(t *testing.T, dir string) []string {
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestPartitionedWriter_rollOnSize(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := newTestPartitionedWriter(dir, &Partition{MaxMegabytes: 1})
+	hourDir := filepath.Join(dir, "2024", "05", "06", "07")
+
+	half := make([]byte, 512*1024)
+	for range 3 {
+		_, err := w.Write(half)
+		require.NoError(t, err)
+	}
+
+	// The first file is closed and renamed, the second one is still being written
+	assert.Equal(t, []string{"part-0.jsonl", "part-1.jsonl.tmp"}, readDir(t, hourDir))
+	content, err := os.ReadFile(filepath.Join(hourDir, "part-0.jsonl"))
+	require.NoError(t, err)
+	assert.Len(t, content, 1024*1024)
+
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"part-0.jsonl", "part-1.jsonl"}, readDir(t, hourDir))
+}
+
+func TestPartitionedWriter_rollOnTime(t *testing.T) {
+	dir := t.TempDir()
+	w, clock := newTestPartitionedWriter(dir, &Partition{MaxAge: 10 * time.Minute, SuccessFile: successFileEmpty})
+	hourDir := filepath.Join(dir, "2024", "05", "06", "07")
+
+	_, err := w.Write([]byte("a\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.flush())
+	assert.Equal(t, []string{"part-0.jsonl.tmp"}, readDir(t, hourDir))
+
+	// Files are closed by the flush once they reach their maximum age
+	clock.now = clock.now.Add(10 * time.Minute)
+	require.NoError(t, w.flush())
+	assert.Equal(t, []string{"part-0.jsonl"}, readDir(t, hourDir))
+
+	_, err = w.Write([]byte("b\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"part-0.jsonl", "part-1.jsonl.tmp"}, readDir(t, hourDir))
+
+	// Files and partitions are closed when their time partition ends
+	clock.now = clock.now.Add(25 * time.Minute)
+	require.NoError(t, w.flush())
+	assert.Equal(t, []string{"_SUCCESS", "part-0.jsonl", "part-1.jsonl"}, readDir(t, hourDir))
+
+	// The _SUCCESS file of a partition closed before the end of its time window is pending
+	_, err = w.Write([]byte("c\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	nextHourDir := filepath.Join(dir, "2024", "05", "06", "08")
+	assert.Equal(t, []string{"_SUCCESS.pending", "part-0.jsonl"}, readDir(t, nextHourDir))
+	content, err := os.ReadFile(filepath.Join(nextHourDir, "part-0.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, "c\n", string(content))
+
+	// and is written once the window ended, by the next writer of the partitions
+	w, clock = newTestPartitionedWriter(dir, &Partition{SuccessFile: successFileEmpty})
+	clock.now = time.Date(2024, 5, 6, 9, 0, 0, 0, time.UTC)
+	require.NoError(t, w.flush())
+	assert.Equal(t, []string{"_SUCCESS", "part-0.jsonl"}, readDir(t, nextHourDir))
+}
+
+func TestPartitionedWriter_manifest(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := newTestPartitionedWriter(dir, &Partition{SuccessFile: successFileManifest, TempSuffix: ".inprogress"})
+	hourDir := filepath.Join(dir, "2024", "05", "06", "07")
+
+	_, err := w.Write([]byte("a\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"part-0.jsonl.inprogress"}, readDir(t, hourDir))
+	require.NoError(t, w.Close())
+
+	assert.Equal(t, []string{"_SUCCESS.pending", "part-0.jsonl"}, readDir(t, hourDir))
+
+	// A restart doesn't overwrite the existing files, and keeps them in the manifest
+	w, clock := newTestPartitionedWriter(dir, &Partition{SuccessFile: successFileManifest, TempSuffix: ".inprogress"})
+	_, err = w.Write([]byte("bb\n"))
+	require.NoError(t, err)
+	clock.now = clock.now.Add(time.Hour)
+	require.NoError(t, w.flush())
+	assert.Equal(t, []string{"_SUCCESS", "part-0.jsonl", "part-1.jsonl"}, readDir(t, hourDir))
+
+	content, err := os.ReadFile(filepath.Join(hourDir, successFileName))
+	require.NoError(t, err)
+	var m manifest
+	require.NoError(t, json.Unmarshal(content, &m))
+	assert.Equal(t, manifest{Files: []manifestFile{
+		{Name: "part-0.jsonl", Size: 2, SHA256: sha256Hex("a\n")},
+		{Name: "part-1.jsonl", Size: 3, SHA256: sha256Hex("bb\n")},
+	}}, m)
+}
+
+func TestPartitionedWriter_removeTempFiles(t *testing.T) {
+	dir := t.TempDir()
+	w, _ := newTestPartitionedWriter(dir, &Partition{})
+	previousHourDir := filepath.Join(dir, "2024", "05", "06", "06")
+	require.NoError(t, os.MkdirAll(previousHourDir, 0o755))
+	for _, name := range []string{"part-3.jsonl.tmp", "part-3.jsonl", "other-0.jsonl.tmp"} {
+		require.NoError(t, os.WriteFile(filepath.Join(previousHourDir, name), []byte("a\n"), 0o600))
+	}
+
+	// The files left under their temporary name by a crash are removed, other files are kept
+	_, err := w.Write([]byte("b\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"other-0.jsonl.tmp", "part-3.jsonl"}, readDir(t, previousHourDir))
+	assert.Equal(t, []string{"part-0.jsonl"}, readDir(t, filepath.Join(dir, "2024", "05", "06", "07")))
+}
+
+func TestPartitionedWriter_localTime(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	dir := t.TempDir()
+	w, clock := newTestPartitionedWriter(dir, &Partition{LocalTime: true})
+	clock.now = clock.now.In(loc)
+
+	_, err := w.Write([]byte("a\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"part-0.jsonl"}, readDir(t, filepath.Join(dir, "2024", "05", "06", "09")))
+}
+
+func TestPartitionedWriter_flushDuringShutdown(t *testing.T) {
+	// run with -race: the flusher rolls the files of the partition while the writer is shut down
+	for range 20 {
+		dir := t.TempDir()
+		pw, _ := newTestPartitionedWriter(dir, &Partition{SuccessFile: successFileEmpty})
+		w := &fileWriter{
+			path:          pw.pathTemplate,
+			file:          pw,
+			exporter:      exportMessageAsLine,
+			flushInterval: time.Microsecond,
+		}
+		w.start()
+		require.NoError(t, w.export([]byte("{}")))
+		time.Sleep(time.Millisecond)
+		require.NoError(t, w.shutdown())
+
+		hourDir := filepath.Join(dir, "2024", "05", "06", "07")
+		assert.Equal(t, []string{"_SUCCESS.pending", "part-0.jsonl"}, readDir(t, hourDir))
+	}
+}
+
+func TestPartitionedFileExporter(t *testing.T) {
+	dir := t.TempDir()
+	conf := &Config{
+		Path:          filepath.Join(dir, "*", "{yyyy}", "part-{n}.jsonl"),
+		FormatType:    formatTypeJSON,
+		FlushInterval: time.Second,
+		Partition:     &Partition{SuccessFile: successFileEmpty},
+		GroupBy: &GroupBy{
+			Enabled:           true,
+			ResourceAttribute: "service.name",
+			MaxOpenFiles:      defaultMaxOpenFiles,
+		},
+	}
+	fe := newFileExporter(conf, zap.NewNop())
+	require.NoError(t, fe.Start(t.Context(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	ld.ResourceLogs().At(0).Resource().Attributes().PutStr("service.name", "checkout")
+	require.NoError(t, fe.consumeLogs(t.Context(), ld))
+	require.NoError(t, fe.Shutdown(t.Context()))
+
+	// the year isn't over, the partition can still get new files after a restart
+	yearDir := filepath.Join(dir, "checkout", time.Now().UTC().Format("2006"))
+	assert.Equal(t, []string{"_SUCCESS.pending", "part-0.jsonl"}, readDir(t, yearDir))
+	content, err := os.ReadFile(filepath.Join(yearDir, "part-0.jsonl"))
+	require.NoError(t, err)
+	unmarshaler := &plog.JSONUnmarshaler{}
+	got, err := unmarshaler.UnmarshalLogs(content[:len(content)-1])
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.LogRecordCount())
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
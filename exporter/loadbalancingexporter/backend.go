@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/service/hostcapabilities"
+)
+
+const (
+	defaultEndpointKey = "endpoint"
+	defaultHTTPScheme  = "http"
+	// placeholderEndpoint is used to validate the configuration of the backend exporter when starting
+	placeholderEndpoint = "placeholder:4317"
+)
+
+// backendFactory creates the exporters for the backends of a signal, according to the configured protocol
+type backendFactory struct {
+	params exporter.Settings
+	cfg    *Config
+	signal pipeline.Signal
+
+	// factory is resolved from the host on start when a generic exporter is configured
+	factory exporter.Factory
+}
+
+func newBackendFactory(params exporter.Settings, cfg *Config, signal pipeline.Signal) *backendFactory {
+	b := &backendFactory{
+		params: params,
+		cfg:    cfg,
+		signal: signal,
+	}
+	switch {
+	case cfg.Protocol.OTLPHTTP.HasValue():
+		b.factory = otlphttpexporter.NewFactory()
+	case cfg.Protocol.Exporter.HasValue():
+		// only known once the host is available
+	default:
+		b.factory = otlpexporter.NewFactory()
+	}
+	return b
+}
+
+// start looks up the factory of the generic exporter, if configured, and validates its configuration
+func (b *backendFactory) start(host component.Host) error {
+	if !b.cfg.Protocol.Exporter.HasValue() {
+		return nil
+	}
+
+	protocol := b.cfg.Protocol.Exporter.Get()
+	typ, err := component.NewType(protocol.Type)
+	if err != nil {
+		return fmt.Errorf("invalid exporter type: %w", err)
+	}
+	factoryHost, ok := host.(hostcapabilities.ComponentFactory)
+	if !ok {
+		return errors.New("the host does not provide access to the exporter factories, use the otlp or otlphttp protocols instead")
+	}
+	factory, ok := factoryHost.GetFactory(component.KindExporter, typ).(exporter.Factory)
+	if !ok {
+		return fmt.Errorf("exporter %q is not available in this collector", typ)
+	}
+	if stabilityFor(factory, b.signal) == component.StabilityLevelUndefined {
+		return fmt.Errorf("exporter %q does not support %s", typ, b.signal)
+	}
+	if _, err := buildGenericExporterConfig(factory, protocol, placeholderEndpoint); err != nil {
+		return err
+	}
+
+	b.factory = factory
+	return nil
+}
+
+// create is the componentFactory building the exporter for the given endpoint
+func (b *backendFactory) create(ctx context.Context, endpoint string) (component.Component, error) {
+	if b.factory == nil {
+		return nil, errors.New("the exporter factory is not available, has the load balancer been started?")
+	}
+
+	var cfg component.Config
+	switch {
+	case b.cfg.Protocol.OTLPHTTP.HasValue():
+		oCfg := buildOTLPHTTPExporterConfig(b.cfg, endpoint)
+		cfg = &oCfg
+	case b.cfg.Protocol.Exporter.HasValue():
+		var err error
+		if cfg, err = buildGenericExporterConfig(b.factory, b.cfg.Protocol.Exporter.Get(), endpoint); err != nil {
+			return nil, err
+		}
+	default:
+		oCfg := buildExporterConfig(b.cfg, endpoint)
+		cfg = &oCfg
+	}
+
+	params := buildExporterSettings(b.factory.Type(), b.params, endpoint)
+	switch b.signal {
+	case pipeline.SignalTraces:
+		return b.factory.CreateTraces(ctx, params, cfg)
+	case pipeline.SignalMetrics:
+		return b.factory.CreateMetrics(ctx, params, cfg)
+	case pipeline.SignalLogs:
+		return b.factory.CreateLogs(ctx, params, cfg)
+	default:
+		return nil, fmt.Errorf("unsupported signal %s", b.signal)
+	}
+}
+
+// buildOTLPHTTPExporterConfig returns the OTLP/HTTP exporter configuration for the given endpoint. The scheme of the
+// endpoint in the template is kept, e.g. to use https, otherwise http is used.
+func buildOTLPHTTPExporterConfig(cfg *Config, endpoint string) otlphttpexporter.Config {
+	oCfg := *cfg.Protocol.OTLPHTTP.Get()
+	scheme := defaultHTTPScheme
+	if s, _, found := strings.Cut(oCfg.ClientConfig.Endpoint, "://"); found {
+		scheme = s
+	}
+	oCfg.ClientConfig.Endpoint = fmt.Sprintf("%s://%s", scheme, endpoint)
+
+	return oCfg
+}
+
+// buildGenericExporterConfig returns the configuration of the generic exporter for the given endpoint, i.e. its
+// default configuration overridden with the configured values and the endpoint
+func buildGenericExporterConfig(factory exporter.Factory, protocol *ExporterProtocol, endpoint string) (component.Config, error) {
+	endpointKey := protocol.EndpointKey
+	if endpointKey == "" {
+		endpointKey = defaultEndpointKey
+	}
+
+	conf := confmap.NewFromStringMap(protocol.Config)
+	if err := conf.Merge(confmap.NewFromStringMap(map[string]any{endpointKey: endpoint})); err != nil {
+		return nil, fmt.Errorf("failed to set the endpoint of the %s exporter: %w", factory.Type(), err)
+	}
+	cfg := factory.CreateDefaultConfig()
+	if err := conf.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the configuration of the %s exporter: %w", factory.Type(), err)
+	}
+	if err := xconfmap.Validate(cfg); err != nil {
+		return nil, fmt.Errorf("invalid configuration of the %s exporter: %w", factory.Type(), err)
+	}
+	return cfg, nil
+}
+
+func stabilityFor(factory exporter.Factory, signal pipeline.Signal) component.StabilityLevel {
+	switch signal {
+	case pipeline.SignalTraces:
+		return factory.TracesStability()
+	case pipeline.SignalMetrics:
+		return factory.MetricsStability()
+	case pipeline.SignalLogs:
+		return factory.LogsStability()
+	default:
+		return component.StabilityLevelUndefined
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+)
+
+// factoryHost is a host giving access to a fixed set of exporter factories
+type factoryHost struct {
+	component.Host
+	factories map[component.Type]exporter.Factory
+}
+
+func (h factoryHost) GetFactory(kind component.Kind, componentType component.Type) component.Factory {
+	if kind != component.KindExporter {
+		return nil
+	}
+	if f, ok := h.factories[componentType]; ok {
+		return f
+	}
+	return nil
+}
+
+func newFactoryHost(factories ...exporter.Factory) factoryHost {
+	h := factoryHost{Host: componenttest.NewNopHost(), factories: map[component.Type]exporter.Factory{}}
+	for _, f := range factories {
+		h.factories[f.Type()] = f
+	}
+	return h
+}
+
+func TestBackendFactoryOTLP(t *testing.T) {
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+		t.Run(signal.String(), func(t *testing.T) {
+			// prepare
+			cfg := createDefaultConfig().(*Config)
+			b := newBackendFactory(exportertest.NewNopSettings(metadata.Type), cfg, signal)
+			require.NoError(t, b.start(componenttest.NewNopHost()))
+
+			// test
+			exp, err := b.create(t.Context(), "endpoint-1:4317")
+
+			// verify
+			require.NoError(t, err)
+			assert.NotNil(t, exp)
+			assert.Equal(t, "otlp", b.factory.Type().String())
+		})
+	}
+}
+
+func TestBackendFactoryOTLPHTTP(t *testing.T) {
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+		t.Run(signal.String(), func(t *testing.T) {
+			// prepare
+			cfg := createDefaultConfig().(*Config)
+			cfg.Protocol.OTLPHTTP = configoptional.Some(*otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config))
+			b := newBackendFactory(exportertest.NewNopSettings(metadata.Type), cfg, signal)
+			require.NoError(t, b.start(componenttest.NewNopHost()))
+
+			// test
+			exp, err := b.create(t.Context(), "endpoint-1:4318")
+
+			// verify
+			require.NoError(t, err)
+			assert.NotNil(t, exp)
+			assert.Equal(t, "otlphttp", b.factory.Type().String())
+		})
+	}
+}
+
+func TestBuildOTLPHTTPExporterConfig(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{
+			name:     "no endpoint",
+			expected: "http://endpoint-1:4318",
+		},
+		{
+			name:     "endpoint without scheme",
+			template: "placeholder:4318",
+			expected: "http://endpoint-1:4318",
+		},
+		{
+			name:     "https",
+			template: "https://placeholder:4318",
+			expected: "https://endpoint-1:4318",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			oCfg := otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config)
+			oCfg.ClientConfig.Endpoint = tt.template
+			cfg := createDefaultConfig().(*Config)
+			cfg.Protocol.OTLPHTTP = configoptional.Some(*oCfg)
+
+			assert.Equal(t, tt.expected, buildOTLPHTTPExporterConfig(cfg, "endpoint-1:4318").ClientConfig.Endpoint)
+		})
+	}
+}
+
+func TestBackendFactoryExporter(t *testing.T) {
+	// prepare
+	cfg := createDefaultConfig().(*Config)
+	cfg.Protocol.Exporter = configoptional.Some(ExporterProtocol{
+		Type: "otlphttp",
+		Config: map[string]any{
+			"compression": "zstd",
+		},
+	})
+	b := newBackendFactory(exportertest.NewNopSettings(metadata.Type), cfg, pipeline.SignalTraces)
+
+	// test
+	require.NoError(t, b.start(newFactoryHost(otlphttpexporter.NewFactory())))
+	exp, err := b.create(t.Context(), "http://endpoint-1:4318")
+
+	// verify
+	require.NoError(t, err)
+	assert.NotNil(t, exp)
+	eCfg, err := buildGenericExporterConfig(b.factory, cfg.Protocol.Exporter.Get(), "http://endpoint-1:4318")
+	require.NoError(t, err)
+	oCfg := eCfg.(*otlphttpexporter.Config)
+	assert.Equal(t, "http://endpoint-1:4318", oCfg.ClientConfig.Endpoint)
+	assert.EqualValues(t, "zstd", oCfg.ClientConfig.Compression)
+}
+
+func TestBuildGenericExporterConfigNestedEndpointKey(t *testing.T) {
+	// prepare
+	type nestedConfig struct {
+		Client struct {
+			Endpoint string `mapstructure:"endpoint"`
+		} `mapstructure:"client"`
+	}
+	factory := exporter.NewFactory(
+		component.MustNewType("nested"),
+		func() component.Config { return &nestedConfig{} },
+	)
+
+	// test
+	cfg, err := buildGenericExporterConfig(factory, &ExporterProtocol{EndpointKey: "client::endpoint"}, "endpoint-1:4317")
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-1:4317", cfg.(*nestedConfig).Client.Endpoint)
+}
+
+func TestBackendFactoryExporterErrors(t *testing.T) {
+	tracesOnly := exporter.NewFactory(
+		component.MustNewType("tracesonly"),
+		func() component.Config { return &struct{}{} },
+		exporter.WithTraces(func(context.Context, exporter.Settings, component.Config) (exporter.Traces, error) {
+			return nil, nil
+		}, component.StabilityLevelDevelopment),
+	)
+
+	for _, tt := range []struct {
+		name     string
+		protocol ExporterProtocol
+		host     component.Host
+		expected string
+	}{
+		{
+			name:     "host without factories",
+			protocol: ExporterProtocol{Type: "otlphttp"},
+			host:     componenttest.NewNopHost(),
+			expected: "the host does not provide access to the exporter factories, use the otlp or otlphttp protocols instead",
+		},
+		{
+			name:     "unknown exporter",
+			protocol: ExporterProtocol{Type: "otelarrow"},
+			host:     newFactoryHost(otlphttpexporter.NewFactory()),
+			expected: `exporter "otelarrow" is not available in this collector`,
+		},
+		{
+			name:     "unsupported signal",
+			protocol: ExporterProtocol{Type: "tracesonly"},
+			host:     newFactoryHost(tracesOnly),
+			expected: `exporter "tracesonly" does not support logs`,
+		},
+		{
+			name:     "invalid configuration",
+			protocol: ExporterProtocol{Type: "otlphttp", Config: map[string]any{"unknown": true}},
+			host:     newFactoryHost(otlphttpexporter.NewFactory()),
+			expected: "failed to unmarshal the configuration of the otlphttp exporter",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Protocol.Exporter = configoptional.Some(tt.protocol)
+			b := newBackendFactory(exportertest.NewNopSettings(metadata.Type), cfg, pipeline.SignalLogs)
+
+			err := b.start(tt.host)
+			require.ErrorContains(t, err, tt.expected)
+
+			_, err = b.create(t.Context(), "endpoint-1:4317")
+			require.Error(t, err)
+		})
+	}
+}
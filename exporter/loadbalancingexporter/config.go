@@ -4,13 +4,19 @@
 package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/service/servicediscovery/types"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
 )
 
 type routingKey int
@@ -50,11 +56,80 @@ type Config struct {
 	// Supports all attributes available (both resource and span), as well as the pseudo attributes "span.kind" and
 	// "span.name".
 	RoutingAttributes []string `mapstructure:"routing_attributes"`
+
+	// Balancing defines how the routing keys are distributed over the backends, and how they are moved
+	// when the list of backends changes.
+	Balancing BalancingSettings `mapstructure:"balancing"`
+}
+
+// BalancingSettings defines the configuration for the distribution of the routing keys over the backends
+type BalancingSettings struct {
+	// LoadFactor enables consistent hashing with bounded loads: no backend gets more than LoadFactor times the
+	// average number of in-flight routing keys. Must be 0, which disables it, or at least 1.
+	LoadFactor float64 `mapstructure:"load_factor"`
+	// DrainPeriod is how long routing keys moved by a change of the backends keep being sent to their previous
+	// backend while they are in-flight. Removed backends are kept around for this period as well.
+	DrainPeriod time.Duration `mapstructure:"drain_period"`
+	// KeyIdleTimeout is how long a routing key is considered in-flight after its data was last seen. Only used
+	// when LoadFactor or DrainPeriod are set.
+	KeyIdleTimeout time.Duration `mapstructure:"key_idle_timeout"`
+	// MaxKeys is the maximum number of in-flight routing keys that are tracked. Further keys are routed by plain
+	// consistent hashing until tracked keys expire. Only used when LoadFactor or DrainPeriod are set.
+	MaxKeys int `mapstructure:"max_keys"`
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
-// Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
+// Validate checks if the exporter configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Balancing.LoadFactor != 0 && cfg.Balancing.LoadFactor < 1 {
+		return errors.New("balancing::load_factor must be either 0 or at least 1")
+	}
+	if cfg.Balancing.DrainPeriod < 0 {
+		return errors.New("balancing::drain_period must not be negative")
+	}
+	if cfg.Balancing.KeyIdleTimeout < 0 {
+		return errors.New("balancing::key_idle_timeout must not be negative")
+	}
+	if cfg.Balancing.MaxKeys < 0 {
+		return errors.New("balancing::max_keys must not be negative")
+	}
+	if cfg.Protocol.OTLPHTTP.HasValue() && cfg.Protocol.Exporter.HasValue() {
+		return errors.New("only one of protocol::otlphttp and protocol::exporter should be specified")
+	}
+	if cfg.Protocol.Exporter.HasValue() {
+		typ, err := component.NewType(cfg.Protocol.Exporter.Get().Type)
+		if err != nil {
+			return fmt.Errorf("invalid protocol::exporter::type: %w", err)
+		}
+		if typ == metadata.Type {
+			return errors.New("protocol::exporter::type cannot be the loadbalancing exporter itself")
+		}
+	}
+	return nil
+}
+
+// Protocol holds the individual protocol-specific settings. OTLP over gRPC is used unless another protocol is set.
 type Protocol struct {
 	OTLP otlpexporter.Config `mapstructure:"otlp"`
+	// OTLPHTTP is the template for OTLP over HTTP backends
+	OTLPHTTP configoptional.Optional[otlphttpexporter.Config] `mapstructure:"otlphttp"`
+	// Exporter is the template for backends reached through any exporter available in the collector
+	Exporter configoptional.Optional[ExporterProtocol] `mapstructure:"exporter"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// ExporterProtocol defines the configuration for backends using an arbitrary exporter, e.g. otelarrow.
+// The factory of the exporter is looked up in the collector when the load balancer starts.
+type ExporterProtocol struct {
+	// Type is the type of the exporter, e.g. otelarrow
+	Type string `mapstructure:"type"`
+	// Config is the configuration of the exporter, applied on top of its default configuration
+	Config map[string]any `mapstructure:"config"`
+	// EndpointKey is the key of the exporter configuration that is set to the backend endpoint,
+	// nested keys are separated by "::". Defaults to "endpoint".
+	EndpointKey string `mapstructure:"endpoint_key"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -65,6 +140,8 @@ type ResolverSettings struct {
 	DNS         configoptional.Optional[DNSResolver]         `mapstructure:"dns"`
 	K8sSvc      configoptional.Optional[K8sSvcResolver]      `mapstructure:"k8s"`
 	AWSCloudMap configoptional.Optional[AWSCloudMapResolver] `mapstructure:"aws_cloud_map"`
+	SRV         configoptional.Optional[SRVResolver]         `mapstructure:"srv"`
+	File        configoptional.Optional[FileResolver]        `mapstructure:"file"`
 	// prevent unkeyed literal initialization
 	_ struct{}
 }
@@ -86,6 +163,26 @@ type DNSResolver struct {
 	_ struct{}
 }
 
+// SRVResolver defines the configuration for the resolver using DNS SRV records
+type SRVResolver struct {
+	// Name is the name of the SRV records, e.g. _otlp._tcp.backends.service.consul
+	Name     string        `mapstructure:"name"`
+	Interval time.Duration `mapstructure:"interval"`
+	Timeout  time.Duration `mapstructure:"timeout"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// FileResolver defines the configuration for the resolver reading the backends from a file
+type FileResolver struct {
+	// Path is the path of the YAML or JSON file with the backends
+	Path string `mapstructure:"path"`
+	// Interval is how often the file is re-read, in addition to watching it for changes
+	Interval time.Duration `mapstructure:"interval"`
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // K8sSvcResolver defines the configuration for the DNS resolver
 type K8sSvcResolver struct {
 	Service         string        `mapstructure:"service"`
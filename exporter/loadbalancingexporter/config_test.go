@@ -6,10 +6,14 @@ package loadbalancingexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
 )
@@ -24,4 +28,120 @@ func TestLoadConfig(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, sub.Unmarshal(cfg))
 	require.NotNil(t, cfg)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "6").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	assert.Equal(t, BalancingSettings{
+		LoadFactor:     1.25,
+		DrainPeriod:    30 * time.Second,
+		KeyIdleTimeout: 10 * time.Second,
+		MaxKeys:        50000,
+	}, cfg.(*Config).Balancing)
+	assert.False(t, cfg.(*Config).Protocol.OTLPHTTP.HasValue())
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "9").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.True(t, cfg.(*Config).Resolver.SRV.HasValue())
+	assert.Equal(t, "_otlp._tcp.backends.service.consul", cfg.(*Config).Resolver.SRV.Get().Name)
+	assert.Equal(t, 10*time.Second, cfg.(*Config).Resolver.SRV.Get().Interval)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "10").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.True(t, cfg.(*Config).Resolver.File.HasValue())
+	assert.Equal(t, "/etc/otelcol/backends.yaml", cfg.(*Config).Resolver.File.Get().Path)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "7").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.True(t, cfg.(*Config).Protocol.OTLPHTTP.HasValue())
+	assert.Equal(t, "https://placeholder", cfg.(*Config).Protocol.OTLPHTTP.Get().ClientConfig.Endpoint)
+
+	cfg = factory.CreateDefaultConfig()
+	sub, err = cm.Sub(component.NewIDWithName(metadata.Type, "8").String())
+	require.NoError(t, err)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.True(t, cfg.(*Config).Protocol.Exporter.HasValue())
+	assert.Equal(t, "otelarrow", cfg.(*Config).Protocol.Exporter.Get().Type)
+	assert.Equal(t, map[string]any{"arrow": map[string]any{"num_streams": 2}}, cfg.(*Config).Protocol.Exporter.Get().Config)
+}
+
+func TestConfigValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		balancing BalancingSettings
+		protocol  func(*Protocol)
+		expected  string
+	}{
+		{
+			name: "defaults",
+		},
+		{
+			name:      "bounded loads and drain period",
+			balancing: BalancingSettings{LoadFactor: 1.25, DrainPeriod: time.Minute, KeyIdleTimeout: 30 * time.Second},
+		},
+		{
+			name:      "load factor below 1",
+			balancing: BalancingSettings{LoadFactor: 0.5},
+			expected:  "balancing::load_factor must be either 0 or at least 1",
+		},
+		{
+			name:      "negative drain period",
+			balancing: BalancingSettings{DrainPeriod: -time.Second},
+			expected:  "balancing::drain_period must not be negative",
+		},
+		{
+			name: "otlphttp and exporter protocols",
+			protocol: func(p *Protocol) {
+				p.OTLPHTTP = configoptional.Some(otlphttpexporter.Config{})
+				p.Exporter = configoptional.Some(ExporterProtocol{Type: "otelarrow"})
+			},
+			expected: "only one of protocol::otlphttp and protocol::exporter should be specified",
+		},
+		{
+			name: "exporter protocol without type",
+			protocol: func(p *Protocol) {
+				p.Exporter = configoptional.Some(ExporterProtocol{})
+			},
+			expected: "invalid protocol::exporter::type: id must not be empty",
+		},
+		{
+			name: "exporter protocol with loadbalancing type",
+			protocol: func(p *Protocol) {
+				p.Exporter = configoptional.Some(ExporterProtocol{Type: "loadbalancing"})
+			},
+			expected: "protocol::exporter::type cannot be the loadbalancing exporter itself",
+		},
+		{
+			name:      "negative key idle timeout",
+			balancing: BalancingSettings{KeyIdleTimeout: -time.Second},
+			expected:  "balancing::key_idle_timeout must not be negative",
+		},
+		{
+			name:      "negative max keys",
+			balancing: BalancingSettings{MaxKeys: -1},
+			expected:  "balancing::max_keys must not be negative",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Balancing = tt.balancing
+			if tt.protocol != nil {
+				tt.protocol(&cfg.Protocol)
+			}
+
+			err := cfg.Validate()
+			if tt.expected == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.expected)
+			}
+		})
+	}
 }
@@ -39,18 +39,77 @@ func newHashRing(endpoints []string) *hashRing {
 	}
 }
 
+// newWeightedHashRing builds a new immutable consistent hash ring where each endpoint gets a number of positions
+// proportional to its relative weight, an endpoint with the average weight getting the default number of positions.
+// Endpoints without weight, or with a weight of 0, get a single position.
+func newWeightedHashRing(endpoints []string, weights map[string]int) *hashRing {
+	total := 0
+	for _, endpoint := range endpoints {
+		total += weights[endpoint]
+	}
+	if total <= 0 {
+		return newHashRing(endpoints)
+	}
+
+	items := positionsForWeightedEndpoints(endpoints, func(endpoint string) int {
+		return max(defaultWeight*weights[endpoint]*len(endpoints)/total, 1)
+	})
+	return &hashRing{
+		items: items,
+	}
+}
+
 // endpointFor calculates which backend is responsible for the given traceID
 func (h *hashRing) endpointFor(identifier []byte) string {
 	if h == nil {
 		// perhaps the ring itself couldn't get initialized yet?
 		return ""
 	}
+	return h.findEndpoint(positionForIdentifier(identifier))
+}
+
+// positionForIdentifier calculates the position of the given identifier in the ring
+func positionForIdentifier(identifier []byte) position {
 	hasher := crc32.NewIEEE()
 	hasher.Write(identifier)
 	hash := hasher.Sum32()
-	pos := hash % maxPositions
+	return position(hash % maxPositions)
+}
 
-	return h.findEndpoint(position(pos))
+// walk calls fn for each distinct endpoint in the ring, starting with the endpoint responsible for the given
+// identifier and moving clockwise, until fn returns false or all endpoints have been visited
+func (h *hashRing) walk(identifier []byte, fn func(endpoint string) bool) {
+	if h == nil || len(h.items) == 0 {
+		return
+	}
+	pos := positionForIdentifier(identifier)
+	// the first item at or after the position is the responsible one, wrapping around to the first item of the ring
+	start := sort.Search(len(h.items), func(i int) bool {
+		return h.items[i].pos >= pos
+	})
+	visited := map[string]bool{}
+	for i := range h.items {
+		item := h.items[(start+i)%len(h.items)]
+		if visited[item.endpoint] {
+			continue
+		}
+		visited[item.endpoint] = true
+		if !fn(item.endpoint) {
+			return
+		}
+	}
+}
+
+// endpoints returns the distinct endpoints in the ring
+func (h *hashRing) endpoints() map[string]bool {
+	endpoints := map[string]bool{}
+	if h == nil {
+		return endpoints
+	}
+	for _, item := range h.items {
+		endpoints[item.endpoint] = true
+	}
+	return endpoints
 }
 
 // findEndpoint returns the "next" endpoint starting from the given position, or an empty string in case no endpoints are available
@@ -121,11 +180,18 @@ func positionsFor(endpoint string, numPoints int) []position {
 
 // positionsForEndpoints calculates all the positions for all the given endpoints
 func positionsForEndpoints(endpoints []string, weight int) []ringItem {
+	return positionsForWeightedEndpoints(endpoints, func(string) int {
+		return weight
+	})
+}
+
+// positionsForWeightedEndpoints calculates all the positions for all the given endpoints, where the number of
+// positions of each endpoint is given by weightFor
+func positionsForWeightedEndpoints(endpoints []string, weightFor func(endpoint string) int) []ringItem {
 	var items []ringItem
 	positions := map[position]bool{} // tracking the used positions
 	for _, endpoint := range endpoints {
-		// for this initial implementation, we don't allow endpoints to have custom weights
-		for _, pos := range positionsFor(endpoint, weight) {
+		for _, pos := range positionsFor(endpoint, weightFor(endpoint)) {
 			// if this position is occupied already, look ahead in the array for a free position
 			actualPos := pos
 			positionsProbed := 0
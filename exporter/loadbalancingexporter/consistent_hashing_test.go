@@ -46,6 +46,78 @@ func TestEndpointFor(t *testing.T) {
 	}
 }
 
+func TestNewWeightedHashRing(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		weights  map[string]int
+		expected map[string]int
+	}{
+		{
+			name:     "proportional",
+			weights:  map[string]int{"endpoint-1": 30, "endpoint-2": 10},
+			expected: map[string]int{"endpoint-1": 150, "endpoint-2": 50},
+		},
+		{
+			name:     "zero weight",
+			weights:  map[string]int{"endpoint-1": 10, "endpoint-2": 0},
+			expected: map[string]int{"endpoint-1": 200, "endpoint-2": 1},
+		},
+		{
+			name:     "no weights",
+			weights:  map[string]int{},
+			expected: map[string]int{"endpoint-1": defaultWeight, "endpoint-2": defaultWeight},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// test
+			ring := newWeightedHashRing([]string{"endpoint-1", "endpoint-2"}, tt.weights)
+
+			// verify
+			positions := map[string]int{}
+			for _, item := range ring.items {
+				positions[item.endpoint]++
+			}
+			assert.Equal(t, tt.expected, positions)
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3"}
+	ring := newHashRing(endpoints)
+
+	for _, id := range [][]byte{{1, 2, 0, 0}, {128, 128, 0, 0}, []byte("ad-service-7"), []byte("get-recommendations-1")} {
+		t.Run(fmt.Sprintf("Walk for id %s", string(id)), func(t *testing.T) {
+			// test
+			var visited []string
+			ring.walk(id, func(endpoint string) bool {
+				visited = append(visited, endpoint)
+				return true
+			})
+
+			// verify
+			assert.ElementsMatch(t, endpoints, visited)
+			assert.Equal(t, ring.endpointFor(id), visited[0])
+		})
+	}
+}
+
+func TestWalkStops(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2", "endpoint-3"})
+
+	// test
+	calls := 0
+	ring.walk([]byte("ad-service-7"), func(string) bool {
+		calls++
+		return false
+	})
+
+	// verify
+	assert.Equal(t, 1, calls)
+}
+
 func TestPositionsFor(t *testing.T) {
 	// prepare
 	endpoint := "host1"
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// keyAssignment is the endpoint an in-flight routing key has been assigned to
+type keyAssignment struct {
+	endpoint string
+	// lastSeen is the time the key was last routed, in nanoseconds since the epoch. It is updated without holding
+	// the write lock, so that routing keys which are already assigned do not serialize the exporters.
+	lastSeen atomic.Int64
+	// drainUntil is set when the ring changed in a way that moves the key to another endpoint. Until then,
+	// the key keeps being routed to its previous endpoint, as long as it stays in-flight.
+	drainUntil time.Time
+}
+
+// keyAssignments keeps track of the endpoints of the routing keys that are in-flight, i.e. that have been seen
+// within the idle timeout, so that all the data for a key keeps reaching the same endpoint while the load of the
+// endpoints or the ring itself changes.
+//
+// With a load factor, keys are placed following "consistent hashing with bounded loads" (Mirrokni et al.): no
+// endpoint is assigned more than ceil(loadFactor * keys / endpoints) of the in-flight keys, the keys exceeding
+// the capacity of their endpoint are assigned to the next endpoint in the ring with spare capacity.
+//
+// With a drain period, keys that are moved by a ring change keep being routed to their previous endpoint for
+// the drain period, or until they are no longer in-flight, whichever comes first.
+//
+// The loads and assignments are local to this exporter. Several load-balancing collectors see different keys
+// and loads, and may therefore place a key that exceeds the capacity of its ring position, or that is draining,
+// on different endpoints. At most maxKeys keys are tracked, further keys are placed by plain consistent hashing
+// until tracked keys expire.
+type keyAssignments struct {
+	loadFactor  float64
+	drainPeriod time.Duration
+	idleTimeout time.Duration
+	maxKeys     int
+
+	// mu guards the maps and numEndpoints. Routing a key which is already assigned only takes the read lock.
+	mu sync.RWMutex
+	// keys holds the assignments of the in-flight keys
+	keys map[string]*keyAssignment
+	// loads holds the number of in-flight keys assigned to each endpoint
+	loads map[string]int
+	// numEndpoints is the number of distinct endpoints in the current ring
+	numEndpoints int
+}
+
+func newKeyAssignments(loadFactor float64, drainPeriod, idleTimeout time.Duration, maxKeys int) *keyAssignments {
+	return &keyAssignments{
+		loadFactor:  loadFactor,
+		drainPeriod: drainPeriod,
+		idleTimeout: idleTimeout,
+		maxKeys:     maxKeys,
+		keys:        map[string]*keyAssignment{},
+		loads:       map[string]int{},
+	}
+}
+
+// endpointFor returns the endpoint for the given identifier, keeping the current assignment of the key if it
+// is still valid. The available function reports whether there is an exporter for an endpoint.
+func (k *keyAssignments) endpointFor(ring *hashRing, identifier []byte, now time.Time, available func(endpoint string) bool) string {
+	key := string(identifier)
+
+	k.mu.RLock()
+	if a, ok := k.keys[key]; ok && !k.expired(a, now) && available(a.endpoint) {
+		a.lastSeen.Store(now.UnixNano())
+		k.mu.RUnlock()
+		return a.endpoint
+	}
+	k.mu.RUnlock()
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// the key may have been assigned in the meantime
+	if a, ok := k.keys[key]; ok {
+		if !k.expired(a, now) && available(a.endpoint) {
+			a.lastSeen.Store(now.UnixNano())
+			return a.endpoint
+		}
+		k.unassign(key, a)
+	}
+
+	if len(k.keys) >= k.maxKeys {
+		return ring.endpointFor(identifier)
+	}
+	endpoint := k.place(ring, identifier)
+	if endpoint == "" {
+		return ""
+	}
+	a := &keyAssignment{endpoint: endpoint}
+	a.lastSeen.Store(now.UnixNano())
+	k.keys[key] = a
+	k.loads[endpoint]++
+	return endpoint
+}
+
+// place determines the endpoint for a key that is not assigned yet
+func (k *keyAssignments) place(ring *hashRing, identifier []byte) string {
+	if k.loadFactor == 0 || k.numEndpoints == 0 {
+		return ring.endpointFor(identifier)
+	}
+
+	capacity := int(math.Ceil(k.loadFactor * float64(len(k.keys)+1) / float64(k.numEndpoints)))
+	var endpoint string
+	ring.walk(identifier, func(candidate string) bool {
+		if k.loads[candidate] < capacity {
+			endpoint = candidate
+			return false
+		}
+		return true
+	})
+	if endpoint == "" {
+		// can only happen if the ring changed in between, fall back to plain consistent hashing
+		return ring.endpointFor(identifier)
+	}
+	return endpoint
+}
+
+// onRingChange updates the assignments for the new ring. Without a drain period, all keys are reassigned right
+// away. Otherwise, the keys which are now due to another endpoint start draining from their current endpoint.
+func (k *keyAssignments) onRingChange(ring *hashRing, now time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.numEndpoints = len(ring.endpoints())
+	if k.drainPeriod == 0 {
+		k.keys = map[string]*keyAssignment{}
+		k.loads = map[string]int{}
+		return
+	}
+
+	for key, a := range k.keys {
+		if a.drainUntil.IsZero() && ring.endpointFor([]byte(key)) != a.endpoint {
+			a.drainUntil = now.Add(k.drainPeriod)
+		}
+	}
+}
+
+// expire removes the assignments of the keys that are no longer in-flight or that are done draining
+func (k *keyAssignments) expire(now time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	for key, a := range k.keys {
+		if k.expired(a, now) {
+			k.unassign(key, a)
+		}
+	}
+}
+
+// len returns the number of in-flight keys
+func (k *keyAssignments) len() int {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return len(k.keys)
+}
+
+func (k *keyAssignments) expired(a *keyAssignment, now time.Time) bool {
+	if now.Sub(time.Unix(0, a.lastSeen.Load())) >= k.idleTimeout {
+		return true
+	}
+	return !a.drainUntil.IsZero() && !now.Before(a.drainUntil)
+}
+
+func (k *keyAssignments) unassign(key string, a *keyAssignment) {
+	delete(k.keys, key)
+	k.loads[a.endpoint]--
+	if k.loads[a.endpoint] <= 0 {
+		delete(k.loads, a.endpoint)
+	}
+}
@@ -0,0 +1,217 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allAvailable(string) bool { return true }
+
+func TestKeyAssignmentsBoundedLoads(t *testing.T) {
+	// prepare
+	endpoints := []string{"endpoint-1", "endpoint-2", "endpoint-3", "endpoint-4"}
+	ring := newHashRing(endpoints)
+	loadFactor := 1.25
+	k := newKeyAssignments(loadFactor, 0, time.Minute, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+
+	// test
+	numKeys := 1000
+	for i := 0; i < numKeys; i++ {
+		require.NotEmpty(t, k.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), now, allAvailable))
+	}
+
+	// verify
+	capacity := int(math.Ceil(loadFactor * float64(numKeys) / float64(len(endpoints))))
+	require.Len(t, k.loads, len(endpoints))
+	for endpoint, load := range k.loads {
+		assert.LessOrEqual(t, load, capacity, "endpoint %s is over capacity", endpoint)
+	}
+	assert.Equal(t, numKeys, k.len())
+}
+
+func TestKeyAssignmentsBoundedLoadsSpillOver(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	k := newKeyAssignments(1, 0, time.Minute, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+
+	// find two keys which plain consistent hashing sends to the same endpoint
+	first := []byte("key-0")
+	var second []byte
+	for i := 1; second == nil; i++ {
+		if candidate := []byte(fmt.Sprintf("key-%d", i)); ring.endpointFor(candidate) == ring.endpointFor(first) {
+			second = candidate
+		}
+	}
+
+	// test
+	firstEndpoint := k.endpointFor(ring, first, now, allAvailable)
+	secondEndpoint := k.endpointFor(ring, second, now, allAvailable)
+
+	// verify
+	assert.Equal(t, ring.endpointFor(first), firstEndpoint)
+	assert.NotEqual(t, firstEndpoint, secondEndpoint, "the second key should spill over to the next endpoint")
+}
+
+func TestKeyAssignmentsMaxKeys(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	k := newKeyAssignments(1, 0, 10*time.Second, 10)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		k.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), now, allAvailable)
+	}
+
+	// test
+	// further keys are placed by plain consistent hashing, without being tracked
+	for i := 10; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key-%d", i))
+		assert.Equal(t, ring.endpointFor(key), k.endpointFor(ring, key, now, allAvailable))
+	}
+	assert.Equal(t, 10, k.len())
+
+	// verify
+	// once the tracked keys expire, new keys are tracked again
+	k.expire(now.Add(10 * time.Second))
+	k.endpointFor(ring, []byte("key-100"), now.Add(10*time.Second), allAvailable)
+	assert.Equal(t, 1, k.len())
+	assert.Contains(t, k.keys, "key-100")
+}
+
+func TestKeyAssignmentsSticky(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	k := newKeyAssignments(1, 0, time.Minute, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+	key := []byte("key-0")
+	endpoint := k.endpointFor(ring, key, now, allAvailable)
+
+	// test
+	// fill the ring with keys, so that the capacity of the endpoint of the key is reached
+	for i := 1; i < 100; i++ {
+		k.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), now, allAvailable)
+	}
+
+	// verify
+	assert.Equal(t, endpoint, k.endpointFor(ring, key, now.Add(time.Second), allAvailable))
+}
+
+func TestKeyAssignmentsIdleTimeout(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	k := newKeyAssignments(1.5, 0, 10*time.Second, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+	k.endpointFor(ring, []byte("key-0"), now, allAvailable)
+	k.endpointFor(ring, []byte("key-1"), now.Add(5*time.Second), allAvailable)
+
+	// test
+	k.expire(now.Add(10 * time.Second))
+
+	// verify
+	assert.Equal(t, 1, k.len())
+	assert.Contains(t, k.keys, "key-1")
+}
+
+func TestKeyAssignmentsUnavailableEndpoint(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	k := newKeyAssignments(2, 0, time.Minute, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+	key := []byte("key-0")
+	endpoint := k.endpointFor(ring, key, now, allAvailable)
+
+	// test
+	// the key is reassigned, and as the endpoint is still in the ring it is placed on it again
+	reassigned := k.endpointFor(ring, key, now, func(candidate string) bool { return candidate != endpoint })
+
+	// verify
+	assert.Equal(t, endpoint, reassigned)
+	assert.Equal(t, 1, k.loads[endpoint])
+	assert.Equal(t, 1, k.len())
+}
+
+func TestKeyAssignmentsRingChangeWithoutDrain(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1"})
+	k := newKeyAssignments(1, 0, time.Minute, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		k.endpointFor(ring, []byte(fmt.Sprintf("key-%d", i)), now, allAvailable)
+	}
+
+	// test
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	k.onRingChange(newRing, now)
+
+	// verify
+	assert.Equal(t, 0, k.len())
+	assert.Empty(t, k.loads)
+	assert.Equal(t, 2, k.numEndpoints)
+}
+
+func TestKeyAssignmentsDrain(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1"})
+	drainPeriod := 30 * time.Second
+	k := newKeyAssignments(0, drainPeriod, 10*time.Second, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+
+	// find a key which is moved to the new endpoint once it is added
+	newRing := newHashRing([]string{"endpoint-1", "endpoint-2"})
+	var moved []byte
+	for i := 0; moved == nil; i++ {
+		if candidate := []byte(fmt.Sprintf("key-%d", i)); newRing.endpointFor(candidate) == "endpoint-2" {
+			moved = candidate
+		}
+	}
+	require.Equal(t, "endpoint-1", k.endpointFor(ring, moved, now, allAvailable))
+
+	// test
+	k.onRingChange(newRing, now)
+
+	// verify
+	// the key keeps being routed to its previous endpoint as long as it is in-flight...
+	for elapsed := 5 * time.Second; elapsed < drainPeriod; elapsed += 5 * time.Second {
+		assert.Equal(t, "endpoint-1", k.endpointFor(newRing, moved, now.Add(elapsed), allAvailable))
+	}
+	// ...until the drain period is over
+	assert.Equal(t, "endpoint-2", k.endpointFor(newRing, moved, now.Add(drainPeriod), allAvailable))
+
+	// new keys are routed following the new ring right away
+	assert.Equal(t, newRing.endpointFor([]byte("new-key")), k.endpointFor(newRing, []byte("new-key"), now, allAvailable))
+}
+
+func TestKeyAssignmentsDrainIdleKey(t *testing.T) {
+	// prepare
+	ring := newHashRing([]string{"endpoint-1"})
+	k := newKeyAssignments(0, time.Minute, 10*time.Second, defaultMaxKeys)
+	k.onRingChange(ring, time.Now())
+	now := time.Now()
+	newRing := newHashRing([]string{"endpoint-2"})
+	k.endpointFor(ring, []byte("key-0"), now, allAvailable)
+
+	// test
+	k.onRingChange(newRing, now)
+
+	// verify
+	// the key is no longer in-flight, so it is moved before the end of the drain period
+	assert.Equal(t, "endpoint-1", k.endpointFor(newRing, []byte("key-0"), now.Add(5*time.Second), allAvailable))
+	assert.Equal(t, "endpoint-2", k.endpointFor(newRing, []byte("key-0"), now.Add(20*time.Second), allAvailable))
+}
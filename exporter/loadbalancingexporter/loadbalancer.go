@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.uber.org/zap"
@@ -17,7 +18,9 @@ import (
 )
 
 const (
-	defaultPort = "4317"
+	defaultPort           = "4317"
+	defaultKeyIdleTimeout = 30 * time.Second
+	defaultMaxKeys        = 100_000
 )
 
 var (
@@ -37,10 +40,27 @@ type loadBalancer struct {
 	componentFactory componentFactory
 	exporters        map[string]*wrappedExporter
 
+	// assignments tracks the in-flight routing keys, only set when bounded loads or draining are enabled
+	assignments *keyAssignments
+	drainPeriod time.Duration
+	// draining holds the exporters of the removed endpoints, until the drain period is over
+	draining map[string]*drainingExporter
+	// sweepInterval is how often expired assignments and drained exporters are cleaned up
+	sweepInterval time.Duration
+	stopSweep     chan struct{}
+	sweepDone     chan struct{}
+
 	stopped    bool
 	updateLock sync.RWMutex
 }
 
+// drainingExporter is the exporter of an endpoint which is no longer part of the ring, kept until the in-flight
+// routing keys have been drained from it
+type drainingExporter struct {
+	exporter *wrappedExporter
+	until    time.Time
+}
+
 // Create new load balancer
 func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory componentFactory, telemetry *metadata.TelemetryBuilder) (*loadBalancer, error) {
 	oCfg := cfg.(*Config)
@@ -58,6 +78,12 @@ func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory component
 	if oCfg.Resolver.K8sSvc.HasValue() {
 		count++
 	}
+	if oCfg.Resolver.SRV.HasValue() {
+		count++
+	}
+	if oCfg.Resolver.File.HasValue() {
+		count++
+	}
 	if count > 1 {
 		return nil, errMultipleResolversProvided
 	}
@@ -131,32 +157,131 @@ func newLoadBalancer(logger *zap.Logger, cfg component.Config, factory component
 		}
 	}
 
+	if oCfg.Resolver.SRV.HasValue() {
+		srvLogger := logger.With(zap.String("resolver", "srv"))
+
+		var err error
+		srvResolver := oCfg.Resolver.SRV.Get()
+		res, err = newSRVResolver(
+			srvLogger,
+			srvResolver.Name,
+			srvResolver.Interval,
+			srvResolver.Timeout,
+			telemetry,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if oCfg.Resolver.File.HasValue() {
+		fileLogger := logger.With(zap.String("resolver", "file"))
+
+		var err error
+		fileResolver := oCfg.Resolver.File.Get()
+		res, err = newFileResolver(
+			fileLogger,
+			fileResolver.Path,
+			fileResolver.Interval,
+			telemetry,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if res == nil {
 		return nil, errNoResolver
 	}
 
-	return &loadBalancer{
+	lb := &loadBalancer{
 		logger:           logger,
 		res:              res,
 		componentFactory: factory,
 		exporters:        map[string]*wrappedExporter{},
-	}, nil
+		draining:         map[string]*drainingExporter{},
+		drainPeriod:      oCfg.Balancing.DrainPeriod,
+	}
+
+	if oCfg.Balancing.LoadFactor > 0 || oCfg.Balancing.DrainPeriod > 0 {
+		idleTimeout := oCfg.Balancing.KeyIdleTimeout
+		if idleTimeout <= 0 {
+			idleTimeout = defaultKeyIdleTimeout
+		}
+		maxKeys := oCfg.Balancing.MaxKeys
+		if maxKeys <= 0 {
+			maxKeys = defaultMaxKeys
+		}
+		lb.assignments = newKeyAssignments(oCfg.Balancing.LoadFactor, oCfg.Balancing.DrainPeriod, idleTimeout, maxKeys)
+		lb.sweepInterval = idleTimeout / 2
+		if lb.drainPeriod > 0 {
+			lb.sweepInterval = min(lb.sweepInterval, lb.drainPeriod/2)
+		}
+	}
+
+	return lb, nil
 }
 
 func (lb *loadBalancer) Start(ctx context.Context, host component.Host) error {
 	lb.res.onChange(lb.onBackendChanges)
 	lb.host = host
+	if lb.assignments != nil {
+		lb.stopSweep = make(chan struct{})
+		lb.sweepDone = make(chan struct{})
+		go lb.sweep()
+	}
 	return lb.res.start(ctx)
 }
 
+// sweep periodically removes the assignments of the keys which are no longer in-flight,
+// and shuts down the exporters which are done draining
+func (lb *loadBalancer) sweep() {
+	defer close(lb.sweepDone)
+	ticker := time.NewTicker(lb.sweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			lb.assignments.expire(now)
+			lb.removeDrainedExporters(context.Background(), now)
+		case <-lb.stopSweep:
+			return
+		}
+	}
+}
+
+func (lb *loadBalancer) removeDrainedExporters(ctx context.Context, now time.Time) {
+	lb.updateLock.Lock()
+	defer lb.updateLock.Unlock()
+
+	for endpoint, d := range lb.draining {
+		if now.Before(d.until) {
+			continue
+		}
+		lb.logger.Debug("endpoint drained, shutting down its exporter", zap.String("endpoint", endpoint))
+		exp := d.exporter
+		go func() {
+			_ = exp.Shutdown(ctx)
+		}()
+		delete(lb.draining, endpoint)
+	}
+}
+
 func (lb *loadBalancer) onBackendChanges(resolved []string) {
-	newRing := newHashRing(resolved)
+	var newRing *hashRing
+	if wr, ok := lb.res.(weightedResolver); ok {
+		newRing = newWeightedHashRing(resolved, wr.weights())
+	} else {
+		newRing = newHashRing(resolved)
+	}
 
 	if !newRing.equal(lb.ring) {
 		lb.updateLock.Lock()
 		defer lb.updateLock.Unlock()
 
 		lb.ring = newRing
+		if lb.assignments != nil {
+			lb.assignments.onRingChange(newRing, time.Now())
+		}
 
 		// TODO: set a timeout?
 		ctx := context.Background()
@@ -171,6 +296,13 @@ func (lb *loadBalancer) addMissingExporters(ctx context.Context, endpoints []str
 	for _, endpoint := range endpoints {
 		endpoint = endpointWithPort(endpoint)
 
+		if d, draining := lb.draining[endpoint]; draining {
+			// the endpoint is back before it was fully drained, so its exporter can be used right away
+			lb.exporters[endpoint] = d.exporter
+			delete(lb.draining, endpoint)
+			continue
+		}
+
 		if _, exists := lb.exporters[endpoint]; !exists {
 			exp, err := lb.componentFactory(ctx, endpoint)
 			if err != nil {
@@ -202,11 +334,16 @@ func (lb *loadBalancer) removeExtraExporters(ctx context.Context, endpoints []st
 	for existing := range lb.exporters {
 		if !endpointFound(existing, endpointsWithPort) {
 			exp := lb.exporters[existing]
+			delete(lb.exporters, existing)
+			if lb.drainPeriod > 0 {
+				// keep the exporter for the in-flight keys, it is shut down once the drain period is over
+				lb.draining[existing] = &drainingExporter{exporter: exp, until: time.Now().Add(lb.drainPeriod)}
+				continue
+			}
 			// Shutdown the exporter asynchronously to avoid blocking the resolver
 			go func() {
 				_ = exp.Shutdown(ctx)
 			}()
-			delete(lb.exporters, existing)
 		}
 	}
 }
@@ -225,21 +362,49 @@ func (lb *loadBalancer) Shutdown(ctx context.Context) error {
 	err := lb.res.shutdown(ctx)
 	lb.stopped = true
 
+	if lb.stopSweep != nil {
+		close(lb.stopSweep)
+		<-lb.sweepDone
+	}
+
 	for _, e := range lb.exporters {
 		err = errors.Join(err, e.Shutdown(ctx))
 	}
+	for _, d := range lb.draining {
+		err = errors.Join(err, d.exporter.Shutdown(ctx))
+	}
 	return err
 }
 
 // exporterAndEndpoint returns the exporter and the endpoint for the given identifier.
 func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter, string, error) {
+	return lb.exporterAndEndpointFor(identifier, true)
+}
+
+// untrackedExporterAndEndpoint returns the exporter and the endpoint for an identifier which is not meant to route
+// any other data, such as the random identifiers of logs without trace ID. The identifier is placed by plain
+// consistent hashing, without being tracked as an in-flight routing key.
+func (lb *loadBalancer) untrackedExporterAndEndpoint(identifier []byte) (*wrappedExporter, string, error) {
+	return lb.exporterAndEndpointFor(identifier, false)
+}
+
+func (lb *loadBalancer) exporterAndEndpointFor(identifier []byte, track bool) (*wrappedExporter, string, error) {
 	// NOTE: make rolling updates of next tier of collectors work. currently, this may cause
 	// data loss because the latest batches sent to outdated backend will never find their way out.
 	// for details: https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/1690
 	lb.updateLock.RLock()
 	defer lb.updateLock.RUnlock()
-	endpoint := lb.ring.endpointFor(identifier)
-	exp, found := lb.exporters[endpointWithPort(endpoint)]
+
+	var endpoint string
+	if track && lb.assignments != nil {
+		endpoint = lb.assignments.endpointFor(lb.ring, identifier, time.Now(), func(candidate string) bool {
+			_, found := lb.exporterFor(candidate)
+			return found
+		})
+	} else {
+		endpoint = lb.ring.endpointFor(identifier)
+	}
+	exp, found := lb.exporterFor(endpoint)
 	if !found {
 		// something is really wrong... how come we couldn't find the exporter??
 		return nil, "", fmt.Errorf("couldn't find the exporter for the endpoint %q", endpoint)
@@ -247,3 +412,16 @@ func (lb *loadBalancer) exporterAndEndpoint(identifier []byte) (*wrappedExporter
 
 	return exp, endpoint, nil
 }
+
+// exporterFor returns the exporter for the given endpoint, including the exporters of endpoints being drained.
+// The caller must hold the update lock.
+func (lb *loadBalancer) exporterFor(endpoint string) (*wrappedExporter, bool) {
+	endpoint = endpointWithPort(endpoint)
+	if exp, found := lb.exporters[endpoint]; found {
+		return exp, true
+	}
+	if d, found := lb.draining[endpoint]; found {
+		return d.exporter, true
+	}
+	return nil, false
+}
@@ -6,7 +6,10 @@ package loadbalancingexporter
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -452,6 +455,201 @@ func TestNewLoadBalancerInvalidServiceAwsResolver(t *testing.T) {
 	assert.True(t, clientcmd.IsConfigurationInvalid(err) || errors.Is(err, errNoServiceName))
 }
 
+func TestRemoveExtraExportersWithDrainPeriod(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := simpleConfig()
+	cfg.Balancing.DrainPeriod = time.Minute
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+
+	p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	p.addMissingExporters(t.Context(), []string{"endpoint-1", "endpoint-2"})
+	removed := p.exporters[endpointWithPort("endpoint-2")]
+
+	// test
+	p.removeExtraExporters(t.Context(), []string{"endpoint-1"})
+
+	// verify
+	assert.Len(t, p.exporters, 1)
+	require.Len(t, p.draining, 1)
+	exp, found := p.exporterFor("endpoint-2")
+	assert.True(t, found)
+	assert.Same(t, removed, exp)
+
+	// the endpoint comes back before it is drained, and its exporter is reused
+	p.addMissingExporters(t.Context(), []string{"endpoint-1", "endpoint-2"})
+	assert.Len(t, p.exporters, 2)
+	assert.Empty(t, p.draining)
+	assert.Same(t, removed, p.exporters[endpointWithPort("endpoint-2")])
+
+	// the endpoint is removed again, and shut down once drained
+	p.removeExtraExporters(t.Context(), []string{"endpoint-1"})
+	p.removeDrainedExporters(t.Context(), time.Now().Add(time.Minute))
+	assert.Empty(t, p.draining)
+	_, found = p.exporterFor("endpoint-2")
+	assert.False(t, found)
+}
+
+func TestDrainKeepsRoutingInFlightKeys(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	cfg := simpleConfig()
+	cfg.Resolver.Static = configoptional.Some(StaticResolver{Hostnames: []string{"endpoint-1", "endpoint-2"}})
+	cfg.Balancing.DrainPeriod = time.Minute
+	cfg.Balancing.KeyIdleTimeout = time.Minute
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+
+	p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, p.Shutdown(t.Context())) }()
+
+	// this trace ID will reach the endpoint-2 -- see the consistent hashing tests for more info
+	traceID := []byte{1, 2, 0, 0}
+	_, endpoint, err := p.exporterAndEndpoint(traceID)
+	require.NoError(t, err)
+	require.Equal(t, "endpoint-2", endpoint)
+
+	// test
+	p.onBackendChanges([]string{"endpoint-1"})
+
+	// verify
+	_, endpoint, err = p.exporterAndEndpoint(traceID)
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-2", endpoint)
+
+	// new keys only reach the remaining endpoint
+	_, endpoint, err = p.exporterAndEndpoint([]byte("get-recommendations-2"))
+	require.NoError(t, err)
+	assert.Equal(t, "endpoint-1", endpoint)
+}
+
+func TestNewLoadBalancerBalancing(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	for _, tt := range []struct {
+		name          string
+		balancing     BalancingSettings
+		assignments   bool
+		sweepInterval time.Duration
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:          "bounded loads",
+			balancing:     BalancingSettings{LoadFactor: 1.25},
+			assignments:   true,
+			sweepInterval: defaultKeyIdleTimeout / 2,
+		},
+		{
+			name:          "drain period",
+			balancing:     BalancingSettings{DrainPeriod: 10 * time.Second, KeyIdleTimeout: time.Minute},
+			assignments:   true,
+			sweepInterval: 5 * time.Second,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := simpleConfig()
+			cfg.Balancing = tt.balancing
+
+			p, err := newLoadBalancer(ts.Logger, cfg, nil, tb)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.assignments, p.assignments != nil)
+			assert.Equal(t, tt.sweepInterval, p.sweepInterval)
+		})
+	}
+}
+
+func TestNewLoadBalancerSRVAndFileResolvers(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	for _, tt := range []struct {
+		name     string
+		resolver ResolverSettings
+		expected error
+	}{
+		{
+			name:     "srv",
+			resolver: ResolverSettings{SRV: configoptional.Some(SRVResolver{Name: "_otlp._tcp.backends.service.consul"})},
+		},
+		{
+			name:     "srv without name",
+			resolver: ResolverSettings{SRV: configoptional.Some(SRVResolver{})},
+			expected: errNoSRVName,
+		},
+		{
+			name:     "file",
+			resolver: ResolverSettings{File: configoptional.Some(FileResolver{Path: "backends.yaml"})},
+		},
+		{
+			name:     "file without path",
+			resolver: ResolverSettings{File: configoptional.Some(FileResolver{})},
+			expected: errNoPath,
+		},
+		{
+			name: "srv and file",
+			resolver: ResolverSettings{
+				SRV:  configoptional.Some(SRVResolver{Name: "_otlp._tcp.backends.service.consul"}),
+				File: configoptional.Some(FileResolver{Path: "backends.yaml"}),
+			},
+			expected: errMultipleResolversProvided,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := newLoadBalancer(ts.Logger, &Config{Resolver: tt.resolver}, nil, tb)
+			if tt.expected != nil {
+				assert.Nil(t, p)
+				assert.Equal(t, tt.expected, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, p.res)
+		})
+	}
+}
+
+func TestOnBackendChangesWeighted(t *testing.T) {
+	// prepare
+	ts, tb := getTelemetryAssets(t)
+	path := filepath.Join(t.TempDir(), "backends.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+- endpoint: endpoint-1
+  weight: 3
+- endpoint: endpoint-2
+  weight: 1
+`), 0o600))
+	cfg := &Config{
+		Resolver: ResolverSettings{
+			File: configoptional.Some(FileResolver{Path: path}),
+		},
+	}
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newNopMockExporter(), nil
+	}
+	p, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, p.Shutdown(t.Context())) }()
+
+	// verify
+	positions := map[string]int{}
+	for _, item := range p.ring.items {
+		positions[item.endpoint]++
+	}
+	assert.Equal(t, map[string]int{"endpoint-1": 150, "endpoint-2": 50}, positions)
+	assert.Len(t, p.exporters, 2)
+}
+
 func newNopMockExporter() *wrappedExporter {
 	return newWrappedExporter(mockComponent{}, "mock")
 }
@@ -12,9 +12,9 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -27,6 +27,7 @@ var _ exporter.Logs = (*logExporterImp)(nil)
 
 type logExporterImp struct {
 	loadBalancer *loadBalancer
+	backends     *backendFactory
 
 	logger     *zap.Logger
 	started    bool
@@ -40,21 +41,15 @@ func newLogsExporter(params exporter.Settings, cfg component.Config) (*logExport
 	if err != nil {
 		return nil, err
 	}
-	exporterFactory := otlpexporter.NewFactory()
-	cfFunc := func(ctx context.Context, endpoint string) (component.Component, error) {
-		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
-		oParams := buildExporterSettings(exporterFactory.Type(), params, endpoint)
-
-		return exporterFactory.CreateLogs(ctx, oParams, &oCfg)
-	}
-
-	lb, err := newLoadBalancer(params.Logger, cfg, cfFunc, telemetry)
+	backends := newBackendFactory(params, cfg.(*Config), pipeline.SignalLogs)
+	lb, err := newLoadBalancer(params.Logger, cfg, backends.create, telemetry)
 	if err != nil {
 		return nil, err
 	}
 
 	return &logExporterImp{
 		loadBalancer: lb,
+		backends:     backends,
 		telemetry:    telemetry,
 		logger:       params.Logger,
 	}, nil
@@ -65,6 +60,9 @@ func (*logExporterImp) Capabilities() consumer.Capabilities {
 }
 
 func (e *logExporterImp) Start(ctx context.Context, host component.Host) error {
+	if err := e.backends.start(host); err != nil {
+		return err
+	}
 	e.started = true
 	return e.loadBalancer.Start(ctx, host)
 }
@@ -90,16 +88,17 @@ func (e *logExporterImp) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
 }
 
 func (e *logExporterImp) consumeLog(ctx context.Context, ld plog.Logs) error {
-	traceID := traceIDFromLogs(ld)
-	balancingKey := traceID
-	if traceID == pcommon.NewTraceIDEmpty() {
+	var le *wrappedExporter
+	var err error
+	if traceID := traceIDFromLogs(ld); traceID != pcommon.NewTraceIDEmpty() {
+		le, _, err = e.loadBalancer.exporterAndEndpoint(traceID[:])
+	} else {
 		// every log may not contain a traceID
 		// generate a random traceID as balancingKey
 		// so the log can be routed to a random backend
-		balancingKey = random()
+		balancingKey := random()
+		le, _, err = e.loadBalancer.untrackedExporterAndEndpoint(balancingKey[:])
 	}
-
-	le, _, err := e.loadBalancer.exporterAndEndpoint(balancingKey[:])
 	if err != nil {
 		return err
 	}
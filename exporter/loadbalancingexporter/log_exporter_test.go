@@ -299,6 +299,42 @@ func TestLogsWithoutTraceID(t *testing.T) {
 	assert.Len(t, sink.AllLogs(), 1)
 }
 
+func TestLogsWithoutTraceIDAreNotTracked(t *testing.T) {
+	ts, tb := getTelemetryAssets(t)
+	sink := new(consumertest.LogsSink)
+	componentFactory := func(_ context.Context, _ string) (component.Component, error) {
+		return newMockLogsExporter(sink.ConsumeLogs), nil
+	}
+	cfg := simpleConfig()
+	cfg.Balancing.LoadFactor = 1.25
+	lb, err := newLoadBalancer(ts.Logger, cfg, componentFactory, tb)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newLogsExporter(ts, cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.addMissingExporters(t.Context(), []string{"endpoint-1"})
+	p.loadBalancer = lb
+
+	err = p.Start(t.Context(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, p.Shutdown(t.Context()))
+	}()
+
+	// test
+	require.NoError(t, p.ConsumeLogs(t.Context(), simpleLogWithoutID()))
+	require.NoError(t, p.ConsumeLogs(t.Context(), simpleLogs()))
+
+	// verify
+	// the random routing keys of logs without trace ID are not tracked, unlike trace IDs
+	assert.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 1, lb.assignments.len())
+}
+
 // this test validates that exporter is can concurrently change the endpoints while consuming logs.
 func TestConsumeLogs_ConcurrentResolverChange(t *testing.T) {
 	ts, tb := getTelemetryAssets(t)
@@ -13,8 +13,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/otel/metric"
 	conventions "go.opentelemetry.io/otel/semconv/v1.27.0"
 	"go.uber.org/multierr"
@@ -29,6 +29,7 @@ var _ exporter.Metrics = (*metricExporterImp)(nil)
 
 type metricExporterImp struct {
 	loadBalancer *loadBalancer
+	backends     *backendFactory
 	routingKey   routingKey
 
 	logger     *zap.Logger
@@ -42,21 +43,15 @@ func newMetricsExporter(params exporter.Settings, cfg component.Config) (*metric
 	if err != nil {
 		return nil, err
 	}
-	exporterFactory := otlpexporter.NewFactory()
-	cfFunc := func(ctx context.Context, endpoint string) (component.Component, error) {
-		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
-		oParams := buildExporterSettings(exporterFactory.Type(), params, endpoint)
-
-		return exporterFactory.CreateMetrics(ctx, oParams, &oCfg)
-	}
-
-	lb, err := newLoadBalancer(params.Logger, cfg, cfFunc, telemetry)
+	backends := newBackendFactory(params, cfg.(*Config), pipeline.SignalMetrics)
+	lb, err := newLoadBalancer(params.Logger, cfg, backends.create, telemetry)
 	if err != nil {
 		return nil, err
 	}
 
 	metricExporter := metricExporterImp{
 		loadBalancer: lb,
+		backends:     backends,
 		routingKey:   svcRouting,
 		telemetry:    telemetry,
 		logger:       params.Logger,
@@ -83,6 +78,9 @@ func (*metricExporterImp) Capabilities() consumer.Capabilities {
 }
 
 func (e *metricExporterImp) Start(ctx context.Context, host component.Host) error {
+	if err := e.backends.start(host); err != nil {
+		return err
+	}
 	return e.loadBalancer.Start(ctx, host)
 }
 
@@ -23,3 +23,11 @@ type resolver interface {
 	// Make sure to register the callbacks before starting the exporter.
 	onChange(func([]string))
 }
+
+// weightedResolver is implemented by the resolvers which know the relative weights of the endpoints
+type weightedResolver interface {
+	resolver
+
+	// weights returns the relative weights of the endpoints last passed to the callbacks, keyed by endpoint.
+	weights() map[string]int
+}
@@ -0,0 +1,299 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+)
+
+var _ weightedResolver = (*fileResolver)(nil)
+
+const (
+	defaultFileResInterval = 30 * time.Second
+	defaultFileWeight      = 1
+	// fileSettleDelay is how long the file has to be left alone after a change before it is re-read, so that
+	// a file which is written in several steps is not read while partially written
+	fileSettleDelay = 200 * time.Millisecond
+	// kubernetesDataDir is the symbolic link which is swapped when a ConfigMap or Secret volume is updated
+	kubernetesDataDir = "..data"
+)
+
+var (
+	errNoPath    = errors.New("no path specified for the file with the backends")
+	errEmptyFile = errors.New("the file with the backends is empty")
+
+	fileResolverAttr           = attribute.String("resolver", "file")
+	fileResolverAttrSet        = attribute.NewSet(fileResolverAttr)
+	fileResolverSuccessAttrSet = attribute.NewSet(fileResolverAttr, attribute.Bool("success", true))
+	fileResolverFailureAttrSet = attribute.NewSet(fileResolverAttr, attribute.Bool("success", false))
+)
+
+// fileResolver reads the backends from a YAML or JSON file, which is reloaded whenever it changes.
+// The file is watched for changes, and re-read periodically in case a change is missed.
+type fileResolver struct {
+	logger *zap.Logger
+
+	path        string
+	resInterval time.Duration
+
+	endpoints         []string
+	endpointWeights   map[string]int
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+	telemetry          *metadata.TelemetryBuilder
+}
+
+// fileBackends is the content of the file with the backends. Besides an object with the "endpoints" key,
+// the file can also contain the list of endpoints directly.
+type fileBackends struct {
+	Endpoints []fileBackend `yaml:"endpoints"`
+}
+
+// fileBackend is an endpoint in the file with the backends, either a plain string, or an object with its
+// endpoint and relative weight
+type fileBackend struct {
+	Endpoint string `yaml:"endpoint"`
+	Weight   *int   `yaml:"weight"`
+}
+
+func (b *fileBackend) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&b.Endpoint)
+	}
+	type plain fileBackend
+	return node.Decode((*plain)(b))
+}
+
+func newFileResolver(
+	logger *zap.Logger,
+	path string,
+	interval time.Duration,
+	tb *metadata.TelemetryBuilder,
+) (*fileResolver, error) {
+	if path == "" {
+		return nil, errNoPath
+	}
+	if interval == 0 {
+		interval = defaultFileResInterval
+	}
+
+	return &fileResolver{
+		logger:      logger,
+		path:        filepath.Clean(path),
+		resInterval: interval,
+		stopCh:      make(chan struct{}),
+		telemetry:   tb,
+	}, nil
+}
+
+func (r *fileResolver) start(ctx context.Context) error {
+	if _, err := r.resolve(ctx); err != nil {
+		return fmt.Errorf("failed to read the backends from %q: %w", r.path, err)
+	}
+
+	// the directory is watched instead of the file itself, as the file is often replaced instead of written to,
+	// e.g. when it's renamed into place or when it's mounted from a Kubernetes ConfigMap
+	watcher, err := fsnotify.NewWatcher()
+	if err == nil {
+		err = watcher.Add(filepath.Dir(r.path))
+	}
+	if err != nil {
+		r.logger.Warn("failed to watch the file with the backends, it will only be re-read periodically", zap.Error(err))
+		if watcher != nil {
+			_ = watcher.Close()
+			watcher = nil
+		}
+	}
+
+	r.shutdownWg.Add(1)
+	go r.watch(watcher)
+
+	r.logger.Debug("file resolver started",
+		zap.String("path", r.path), zap.Duration("interval", r.resInterval))
+	return nil
+}
+
+func (r *fileResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+// watch re-reads the file once it settled after a change, as well as periodically
+func (r *fileResolver) watch(watcher *fsnotify.Watcher) {
+	defer r.shutdownWg.Done()
+	ticker := time.NewTicker(r.resInterval)
+	defer ticker.Stop()
+	settle := time.NewTimer(fileSettleDelay)
+	settle.Stop()
+	defer settle.Stop()
+
+	// receiving from nil channels blocks forever, which disables the watching if the watcher couldn't be created
+	var events <-chan fsnotify.Event
+	var watchErrs <-chan error
+	if watcher != nil {
+		defer watcher.Close()
+		events = watcher.Events
+		watchErrs = watcher.Errors
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			if r.affects(event) {
+				settle.Reset(fileSettleDelay)
+			}
+		case <-settle.C:
+			r.reload()
+		case err, ok := <-watchErrs:
+			if !ok {
+				watchErrs = nil
+				continue
+			}
+			r.logger.Warn("failed to watch the file with the backends", zap.Error(err))
+		case <-ticker.C:
+			r.reload()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+// affects reports whether the event is about the file with the backends, rather than another file in its directory.
+// When the file is mounted from a Kubernetes volume, it is updated by swapping the link to the directory with the
+// data, so that the file itself is not part of the events.
+func (r *fileResolver) affects(event fsnotify.Event) bool {
+	name := filepath.Clean(event.Name)
+	return name == r.path || filepath.Base(name) == kubernetesDataDir
+}
+
+func (r *fileResolver) reload() {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve, keeping the previous backends", zap.Error(err))
+	} else {
+		r.logger.Debug("resolved successfully")
+	}
+}
+
+func (r *fileResolver) resolve(ctx context.Context) ([]string, error) {
+	backends, weights, err := readFileBackends(r.path)
+	if err != nil {
+		r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(fileResolverFailureAttrSet))
+		return nil, err
+	}
+
+	r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(fileResolverSuccessAttrSet))
+
+	r.updateLock.Lock()
+	if equalStringSlice(r.endpoints, backends) && maps.Equal(r.endpointWeights, weights) {
+		r.updateLock.Unlock()
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	r.endpointWeights = weights
+	r.updateLock.Unlock()
+	r.telemetry.LoadbalancerNumBackends.Record(ctx, int64(len(backends)), metric.WithAttributeSet(fileResolverAttrSet))
+	r.telemetry.LoadbalancerNumBackendUpdates.Add(ctx, 1, metric.WithAttributeSet(fileResolverAttrSet))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+func (r *fileResolver) weights() map[string]int {
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+	return maps.Clone(r.endpointWeights)
+}
+
+func (r *fileResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
+
+// readFileBackends reads the sorted list of endpoints and their weights from the file at the given path
+func readFileBackends(path string) ([]string, map[string]int, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(bytes.TrimSpace(content)) == 0 {
+		// most likely the file is being written, an empty list of backends has to be explicit
+		return nil, nil, errEmptyFile
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(content, &node); err != nil {
+		return nil, nil, err
+	}
+	var parsed fileBackends
+	if len(node.Content) > 0 && node.Content[0].Kind == yaml.SequenceNode {
+		err = node.Content[0].Decode(&parsed.Endpoints)
+	} else {
+		err = node.Decode(&parsed)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	weights := map[string]int{}
+	backends := make([]string, 0, len(parsed.Endpoints))
+	for _, backend := range parsed.Endpoints {
+		if backend.Endpoint == "" {
+			return nil, nil, errors.New("backends must have an endpoint")
+		}
+		weight := defaultFileWeight
+		if backend.Weight != nil {
+			weight = *backend.Weight
+		}
+		if weight < 0 {
+			return nil, nil, fmt.Errorf("the weight of %q must not be negative", backend.Endpoint)
+		}
+		if _, found := weights[backend.Endpoint]; !found {
+			backends = append(backends, backend.Endpoint)
+		}
+		weights[backend.Endpoint] += weight
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+	return backends, weights, nil
+}
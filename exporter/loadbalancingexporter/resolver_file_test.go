@@ -0,0 +1,233 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReadFileBackends(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		content  string
+		expected []string
+		weights  map[string]int
+		err      string
+	}{
+		{
+			name: "yaml object",
+			content: `endpoints:
+  - backend-2:4317
+  - backend-1:4317
+`,
+			expected: []string{"backend-1:4317", "backend-2:4317"},
+			weights:  map[string]int{"backend-1:4317": 1, "backend-2:4317": 1},
+		},
+		{
+			name: "yaml list with weights",
+			content: `- endpoint: backend-1:4317
+  weight: 3
+- endpoint: backend-2:4317
+  weight: 0
+- backend-3:4317
+`,
+			expected: []string{"backend-1:4317", "backend-2:4317", "backend-3:4317"},
+			weights:  map[string]int{"backend-1:4317": 3, "backend-2:4317": 0, "backend-3:4317": 1},
+		},
+		{
+			name:     "json",
+			content:  `{"endpoints": ["backend-1:4317", {"endpoint": "backend-2:4317", "weight": 2}]}`,
+			expected: []string{"backend-1:4317", "backend-2:4317"},
+			weights:  map[string]int{"backend-1:4317": 1, "backend-2:4317": 2},
+		},
+		{
+			name:     "duplicate endpoints",
+			content:  `["backend-1:4317", "backend-1:4317"]`,
+			expected: []string{"backend-1:4317"},
+			weights:  map[string]int{"backend-1:4317": 2},
+		},
+		{
+			name:     "empty",
+			content:  `endpoints: []`,
+			expected: []string{},
+			weights:  map[string]int{},
+		},
+		{
+			name:    "empty file",
+			content: " \n",
+			err:     "the file with the backends is empty",
+		},
+		{
+			name:    "missing endpoint",
+			content: `[{"weight": 2}]`,
+			err:     "backends must have an endpoint",
+		},
+		{
+			name:    "negative weight",
+			content: `[{"endpoint": "backend-1:4317", "weight": -1}]`,
+			err:     `the weight of "backend-1:4317" must not be negative`,
+		},
+		{
+			name:    "invalid",
+			content: `endpoints: backend-1:4317`,
+			err:     "cannot unmarshal",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "backends.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			backends, weights, err := readFileBackends(path)
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, backends)
+			assert.Equal(t, tt.weights, weights)
+		})
+	}
+}
+
+func TestErrNoPath(t *testing.T) {
+	// test
+	_, tb := getTelemetryAssets(t)
+	res, err := newFileResolver(zap.NewNop(), "", 5*time.Second, tb)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoPath, err)
+}
+
+func TestFileResolverMissingFile(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	res, err := newFileResolver(zap.NewNop(), filepath.Join(t.TempDir(), "missing.yaml"), 5*time.Second, tb)
+	require.NoError(t, err)
+
+	// test
+	err = res.start(t.Context())
+
+	// verify
+	assert.ErrorContains(t, err, "failed to read the backends from")
+}
+
+func TestFileResolverWatch(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		interval time.Duration
+		update   func(t *testing.T, path string, content []byte)
+	}{
+		{
+			name:     "write",
+			interval: time.Hour,
+			update: func(t *testing.T, path string, content []byte) {
+				require.NoError(t, os.WriteFile(path, content, 0o600))
+			},
+		},
+		{
+			name:     "rename",
+			interval: time.Hour,
+			update: func(t *testing.T, path string, content []byte) {
+				tmp := path + ".tmp"
+				require.NoError(t, os.WriteFile(tmp, content, 0o600))
+				require.NoError(t, os.Rename(tmp, path))
+			},
+		},
+		{
+			name:     "periodically",
+			interval: 10 * time.Millisecond,
+			update: func(t *testing.T, path string, content []byte) {
+				require.NoError(t, os.WriteFile(path, content, 0o600))
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			_, tb := getTelemetryAssets(t)
+			path := filepath.Join(t.TempDir(), "backends.yaml")
+			require.NoError(t, os.WriteFile(path, []byte("endpoints: [backend-1:4317]"), 0o600))
+
+			res, err := newFileResolver(zap.NewNop(), path, tt.interval, tb)
+			require.NoError(t, err)
+
+			changed := make(chan []string, 10)
+			res.onChange(func(endpoints []string) {
+				changed <- endpoints
+			})
+			require.NoError(t, res.start(t.Context()))
+			defer func() {
+				require.NoError(t, res.shutdown(t.Context()))
+			}()
+			assert.Equal(t, []string{"backend-1:4317"}, <-changed)
+
+			// test
+			tt.update(t, path, []byte("endpoints: [backend-1:4317, backend-2:4317]"))
+
+			// verify
+			select {
+			case endpoints := <-changed:
+				assert.Equal(t, []string{"backend-1:4317", "backend-2:4317"}, endpoints)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "the file resolver didn't pick up the change")
+			}
+		})
+	}
+}
+
+func TestFileResolverAffects(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "backends.yaml")
+	res, err := newFileResolver(zap.NewNop(), path, time.Hour, tb)
+	require.NoError(t, err)
+
+	// test and verify
+	assert.True(t, res.affects(fsnotify.Event{Name: path, Op: fsnotify.Write}))
+	assert.True(t, res.affects(fsnotify.Event{Name: path, Op: fsnotify.Create}))
+	assert.True(t, res.affects(fsnotify.Event{Name: filepath.Join(dir, "..data"), Op: fsnotify.Create}))
+	assert.False(t, res.affects(fsnotify.Event{Name: path + ".tmp", Op: fsnotify.Write}))
+	assert.False(t, res.affects(fsnotify.Event{Name: filepath.Join(dir, "other.yaml"), Op: fsnotify.Write}))
+}
+
+func TestFileResolverKeepsBackendsOnInvalidFile(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	path := filepath.Join(t.TempDir(), "backends.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("endpoints: [backend-1:4317]"), 0o600))
+
+	res, err := newFileResolver(zap.NewNop(), path, time.Hour, tb)
+	require.NoError(t, err)
+	counter := 0
+	res.onChange(func([]string) {
+		counter++
+	})
+	_, err = res.resolve(t.Context())
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, os.WriteFile(path, []byte("endpoints: {"), 0o600))
+	_, err = res.resolve(t.Context())
+
+	// verify
+	require.Error(t, err)
+	assert.Equal(t, 1, counter)
+	assert.Equal(t, []string{"backend-1:4317"}, res.endpoints)
+
+	// a file which is truncated before being written again is not taken as an empty list of backends
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	_, err = res.resolve(t.Context())
+	require.ErrorIs(t, err, errEmptyFile)
+	assert.Equal(t, 1, counter)
+	assert.Equal(t, []string{"backend-1:4317"}, res.endpoints)
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter/internal/metadata"
+)
+
+var _ weightedResolver = (*srvResolver)(nil)
+
+var (
+	errNoSRVName = errors.New("no name specified to resolve the SRV records of the backends")
+
+	srvResolverAttr           = attribute.String("resolver", "srv")
+	srvResolverAttrSet        = attribute.NewSet(srvResolverAttr)
+	srvResolverSuccessAttrSet = attribute.NewSet(srvResolverAttr, attribute.Bool("success", true))
+	srvResolverFailureAttrSet = attribute.NewSet(srvResolverAttr, attribute.Bool("success", false))
+)
+
+// srvResolver resolves the backends from DNS SRV records, as published by Consul or Nomad for instance.
+// Each record yields an endpoint made of its target and port. Only the records with the lowest priority are
+// used, and their weights are used as the relative weights of the endpoints.
+type srvResolver struct {
+	logger *zap.Logger
+
+	name        string
+	resolver    srvLookuper
+	resInterval time.Duration
+	resTimeout  time.Duration
+
+	endpoints         []string
+	endpointWeights   map[string]int
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan struct{}
+	updateLock         sync.Mutex
+	shutdownWg         sync.WaitGroup
+	changeCallbackLock sync.RWMutex
+	telemetry          *metadata.TelemetryBuilder
+}
+
+type srvLookuper interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+func newSRVResolver(
+	logger *zap.Logger,
+	name string,
+	interval time.Duration,
+	timeout time.Duration,
+	tb *metadata.TelemetryBuilder,
+) (*srvResolver, error) {
+	if name == "" {
+		return nil, errNoSRVName
+	}
+	if interval == 0 {
+		interval = defaultResInterval
+	}
+	if timeout == 0 {
+		timeout = defaultResTimeout
+	}
+
+	return &srvResolver{
+		logger:      logger,
+		name:        name,
+		resolver:    &net.Resolver{},
+		resInterval: interval,
+		resTimeout:  timeout,
+		stopCh:      make(chan struct{}),
+		telemetry:   tb,
+	}, nil
+}
+
+func (r *srvResolver) start(ctx context.Context) error {
+	if _, err := r.resolve(ctx); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+
+	r.shutdownWg.Add(1)
+	go r.periodicallyResolve()
+
+	r.logger.Debug("SRV resolver started",
+		zap.String("name", r.name),
+		zap.Duration("interval", r.resInterval), zap.Duration("timeout", r.resTimeout))
+	return nil
+}
+
+func (r *srvResolver) shutdown(_ context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.shutdownWg.Wait()
+	return nil
+}
+
+func (r *srvResolver) periodicallyResolve() {
+	ticker := time.NewTicker(r.resInterval)
+	defer r.shutdownWg.Done()
+
+	for {
+		select {
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), r.resTimeout)
+			if _, err := r.resolve(ctx); err != nil {
+				r.logger.Warn("failed to resolve", zap.Error(err))
+			} else {
+				r.logger.Debug("resolved successfully")
+			}
+			cancel()
+		case <-r.stopCh:
+			return
+		}
+	}
+}
+
+func (r *srvResolver) resolve(ctx context.Context) ([]string, error) {
+	// with an empty service and proto, the name is looked up directly, e.g. _otlp._tcp.backends.service.consul
+	_, records, err := r.resolver.LookupSRV(ctx, "", "", r.name)
+	if err != nil {
+		r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(srvResolverFailureAttrSet))
+		return nil, err
+	}
+
+	r.telemetry.LoadbalancerNumResolutions.Add(ctx, 1, metric.WithAttributeSet(srvResolverSuccessAttrSet))
+
+	// only the records with the lowest priority value are used, there is no failover to the ones with a higher
+	// value as the health of the backends is not known here
+	var lowestPriority uint16
+	for i, record := range records {
+		if i == 0 || record.Priority < lowestPriority {
+			lowestPriority = record.Priority
+		}
+	}
+
+	weights := map[string]int{}
+	backends := make([]string, 0, len(records))
+	for _, record := range records {
+		if record.Priority != lowestPriority {
+			continue
+		}
+		backend := net.JoinHostPort(strings.TrimSuffix(record.Target, "."), strconv.Itoa(int(record.Port)))
+		if _, found := weights[backend]; !found {
+			backends = append(backends, backend)
+		}
+		weights[backend] += int(record.Weight)
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	r.updateLock.Lock()
+	if equalStringSlice(r.endpoints, backends) && maps.Equal(r.endpointWeights, weights) {
+		r.updateLock.Unlock()
+		return backends, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	r.endpointWeights = weights
+	r.updateLock.Unlock()
+	r.telemetry.LoadbalancerNumBackends.Record(ctx, int64(len(backends)), metric.WithAttributeSet(srvResolverAttrSet))
+	r.telemetry.LoadbalancerNumBackendUpdates.Add(ctx, 1, metric.WithAttributeSet(srvResolverAttrSet))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(backends)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return backends, nil
+}
+
+func (r *srvResolver) weights() map[string]int {
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+	return maps.Clone(r.endpointWeights)
+}
+
+func (r *srvResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
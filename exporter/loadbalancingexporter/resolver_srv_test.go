@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInitialSRVResolution(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.backends.service.consul", 5*time.Second, 1*time.Second, tb)
+	require.NoError(t, err)
+
+	var lookedUp string
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			lookedUp = service + proto + name
+			return "", []*net.SRV{
+				{Target: "node-2.node.dc1.consul.", Port: 24317, Priority: 1, Weight: 10},
+				{Target: "node-1.node.dc1.consul.", Port: 21234, Priority: 1, Weight: 30},
+				{Target: "::1", Port: 4317, Priority: 1, Weight: 20},
+				// ignored, with a higher priority value
+				{Target: "node-3.node.dc2.consul.", Port: 4317, Priority: 2, Weight: 100},
+			}, nil
+		},
+	}
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(t.Context()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+
+	// verify
+	assert.Equal(t, "_otlp._tcp.backends.service.consul", lookedUp)
+	assert.Equal(t, []string{"[::1]:4317", "node-1.node.dc1.consul:21234", "node-2.node.dc1.consul:24317"}, resolved)
+	assert.Equal(t, map[string]int{
+		"[::1]:4317":                   20,
+		"node-1.node.dc1.consul:21234": 30,
+		"node-2.node.dc1.consul:24317": 10,
+	}, res.weights())
+}
+
+func TestSRVResolutionDuplicateTargets(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.backends.service.consul", 5*time.Second, 1*time.Second, tb)
+	require.NoError(t, err)
+
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+			return "", []*net.SRV{
+				{Target: "node-1.", Port: 4317, Weight: 10},
+				{Target: "node-1.", Port: 4317, Weight: 5},
+			}, nil
+		},
+	}
+
+	// test
+	resolved, err := res.resolve(t.Context())
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node-1:4317"}, resolved)
+	assert.Equal(t, map[string]int{"node-1:4317": 15}, res.weights())
+}
+
+func TestErrNoSRVName(t *testing.T) {
+	// test
+	_, tb := getTelemetryAssets(t)
+	res, err := newSRVResolver(zap.NewNop(), "", 5*time.Second, 1*time.Second, tb)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSRVName, err)
+}
+
+func TestCantResolveSRV(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.backends.service.consul", 5*time.Second, 1*time.Second, tb)
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected error")
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+			return "", nil, expectedErr
+		},
+	}
+
+	// test
+	_, err = res.resolve(t.Context())
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+	require.NoError(t, res.start(t.Context()))
+	assert.NoError(t, res.shutdown(t.Context()))
+}
+
+func TestSRVOnChange(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.backends.service.consul", 5*time.Second, 1*time.Second, tb)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	records := []*net.SRV{{Target: "node-1.", Port: 4317, Weight: 10}}
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return "", records, nil
+		},
+	}
+
+	counter := 0
+	res.onChange(func([]string) {
+		counter++
+	})
+
+	// test
+	_, err = res.resolve(t.Context())
+	require.NoError(t, err)
+	_, err = res.resolve(t.Context())
+	require.NoError(t, err)
+
+	// the weight changes, but not the endpoints
+	mu.Lock()
+	records = []*net.SRV{{Target: "node-1.", Port: 4317, Weight: 20}}
+	mu.Unlock()
+	_, err = res.resolve(t.Context())
+	require.NoError(t, err)
+
+	// verify
+	assert.Equal(t, 2, counter)
+	assert.Equal(t, map[string]int{"node-1:4317": 20}, res.weights())
+}
+
+func TestSRVPeriodicallyResolve(t *testing.T) {
+	// prepare
+	_, tb := getTelemetryAssets(t)
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.backends.service.consul", 10*time.Millisecond, 1*time.Second, tb)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	lookups := 0
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			lookups++
+			if lookups < 3 {
+				return "", []*net.SRV{{Target: "node-1.", Port: 4317}}, nil
+			}
+			return "", []*net.SRV{{Target: "node-1.", Port: 4317}, {Target: "node-2.", Port: 4317}}, nil
+		},
+	}
+
+	changed := make(chan []string, 10)
+	res.onChange(func(endpoints []string) {
+		changed <- endpoints
+	})
+
+	// test
+	require.NoError(t, res.start(t.Context()))
+	defer func() {
+		require.NoError(t, res.shutdown(t.Context()))
+	}()
+
+	// verify
+	assert.Equal(t, []string{"node-1:4317"}, <-changed)
+	assert.Equal(t, []string{"node-1:4317", "node-2:4317"}, <-changed)
+}
+
+type mockSRVResolver struct {
+	onLookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+func (m *mockSRVResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if m.onLookupSRV != nil {
+		return m.onLookupSRV(ctx, service, proto, name)
+	}
+	return "", nil, nil
+}
@@ -14,8 +14,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/exporter/otlpexporter"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -35,6 +35,7 @@ type exporterTraces map[*wrappedExporter]ptrace.Traces
 
 type traceExporterImp struct {
 	loadBalancer *loadBalancer
+	backends     *backendFactory
 	routingKey   routingKey
 	routingAttrs []string
 
@@ -51,21 +52,15 @@ func newTracesExporter(params exporter.Settings, cfg component.Config) (*traceEx
 		return nil, err
 	}
 
-	exporterFactory := otlpexporter.NewFactory()
-	cfFunc := func(ctx context.Context, endpoint string) (component.Component, error) {
-		oCfg := buildExporterConfig(cfg.(*Config), endpoint)
-		oParams := buildExporterSettings(exporterFactory.Type(), params, endpoint)
-
-		return exporterFactory.CreateTraces(ctx, oParams, &oCfg)
-	}
-
-	lb, err := newLoadBalancer(params.Logger, cfg, cfFunc, telemetry)
+	backends := newBackendFactory(params, cfg.(*Config), pipeline.SignalTraces)
+	lb, err := newLoadBalancer(params.Logger, cfg, backends.create, telemetry)
 	if err != nil {
 		return nil, err
 	}
 
 	traceExporter := traceExporterImp{
 		loadBalancer: lb,
+		backends:     backends,
 		routingKey:   traceIDRouting,
 		telemetry:    telemetry,
 		logger:       params.Logger,
@@ -89,6 +84,9 @@ func (*traceExporterImp) Capabilities() consumer.Capabilities {
 }
 
 func (e *traceExporterImp) Start(ctx context.Context, host component.Host) error {
+	if err := e.backends.start(host); err != nil {
+		return err
+	}
 	return e.loadBalancer.Start(ctx, host)
 }
 
@@ -33,6 +33,7 @@ type fileStorageClient struct {
 	logger          *zap.Logger
 	compactionMutex sync.RWMutex
 	db              *bbolt.DB
+	codec           *valueCodec
 	compactionCfg   *CompactionConfig
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
@@ -48,10 +49,16 @@ func bboltOptions(timeout time.Duration, noSync bool) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, noSync bool, enc valueEncoding) (*fileStorageClient, error) {
+	codec, err := newValueCodec(enc.compression, enc.key)
+	if err != nil {
+		return nil, err
+	}
+
 	options := bboltOptions(timeout, noSync)
 	db, err := bbolt.Open(filePath, 0o600, options)
 	if err != nil {
+		codec.close()
 		return nil, err
 	}
 
@@ -61,10 +68,24 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 	}
 	if err := db.Update(initBucket); err != nil {
 		_ = db.Close()
+		codec.close()
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	migrationStart := time.Now()
+	migrated, err := migrateEncoding(db, codec, enc)
+	if err != nil {
+		_ = db.Close()
+		codec.close()
+		return nil, fmt.Errorf("failed to migrate the encoding of %s: %w", filePath, err)
+	}
+	if migrated {
+		logger.Info("migrated the encoding of stored values",
+			zap.String(directoryKey, filePath),
+			zap.Duration(elapsedKey, time.Since(migrationStart)))
+	}
+
+	client := &fileStorageClient{logger: logger, db: db, codec: codec, compactionCfg: compactionCfg, openTimeout: timeout}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -95,6 +116,18 @@ func (c *fileStorageClient) Delete(ctx context.Context, key string) error {
 
 // Batch executes the specified operations in order. Get operation results are updated in place
 func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation) error {
+	// encode values before starting the transaction, to keep it as short as possible
+	encoded := make([][]byte, len(ops))
+	for i, op := range ops {
+		if op.Type != storage.Set {
+			continue
+		}
+		var err error
+		if encoded[i], err = c.codec.encode(op.Key, op.Value); err != nil {
+			return err
+		}
+	}
+
 	batch := func(tx *bbolt.Tx) error {
 		bucket := tx.Bucket(defaultBucket)
 		if bucket == nil {
@@ -102,20 +135,24 @@ func (c *fileStorageClient) Batch(_ context.Context, ops ...*storage.Operation)
 		}
 
 		var err error
-		for _, op := range ops {
+		for i, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case len(value) == 0 || c.codec.isPlain():
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
+				default:
+					// decoding always allocates a new value
+					op.Value, err = c.codec.decode(op.Key, value)
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				err = bucket.Put([]byte(op.Key), encoded[i])
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -144,6 +181,7 @@ func (c *fileStorageClient) Close(_ context.Context) error {
 		c.cancel()
 	}
 	c.closed = true
+	c.codec.close()
 	return c.db.Close()
 }
 
@@ -0,0 +1,369 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"go.etcd.io/bbolt"
+)
+
+const (
+	compressionNone   = "none"
+	compressionZstd   = "zstd"
+	compressionSnappy = "snappy"
+
+	encryptionAESGCM = "aes-gcm"
+)
+
+var (
+	metadataBucket = []byte(`metadata`)
+	encodingKey    = []byte(`encoding`)
+
+	// keyCheckPlaintext is encrypted with the database key and stored alongside the encoding,
+	// so that a wrong key is reported when the database is opened instead of on every read
+	keyCheckPlaintext = []byte(`filestorage`)
+)
+
+// valueEncoding holds the resolved compression and encryption settings applied to the values of every database
+type valueEncoding struct {
+	compression string
+	key         []byte
+	previousKey []byte
+}
+
+func newValueEncoding(cfg *Config) (valueEncoding, error) {
+	enc := valueEncoding{compression: cfg.Compression}
+	if enc.compression == compressionNone {
+		enc.compression = ""
+	}
+	if cfg.Encryption == nil {
+		return enc, nil
+	}
+
+	var err error
+	if cfg.Encryption.KeyFile != "" || cfg.Encryption.KeyEnv != "" {
+		if enc.key, err = readKey(cfg.Encryption.KeyFile, cfg.Encryption.KeyEnv); err != nil {
+			return valueEncoding{}, fmt.Errorf("failed to read encryption key: %w", err)
+		}
+	}
+	if cfg.Encryption.PreviousKeyFile != "" || cfg.Encryption.PreviousKeyEnv != "" {
+		if enc.previousKey, err = readKey(cfg.Encryption.PreviousKeyFile, cfg.Encryption.PreviousKeyEnv); err != nil {
+			return valueEncoding{}, fmt.Errorf("failed to read previous encryption key: %w", err)
+		}
+	}
+	return enc, nil
+}
+
+// readKey reads a base64 encoded AES key from either a file or an environment variable
+func readKey(file, env string) ([]byte, error) {
+	var encoded string
+	if file != "" {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		encoded = string(content)
+	} else {
+		var ok bool
+		if encoded, ok = os.LookupEnv(env); !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", env)
+		}
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key must be base64 encoded: %w", err)
+	}
+	switch len(key) {
+	case 16, 24, 32:
+		return key, nil
+	default:
+		return nil, fmt.Errorf("key must be 16, 24 or 32 bytes long but got %d", len(key))
+	}
+}
+
+// encodingHeader describes how the values of a database are encoded. It is persisted in the metadata bucket,
+// databases without one store their values as is.
+type encodingHeader struct {
+	Compression string `json:"compression,omitempty"`
+	Encryption  string `json:"encryption,omitempty"`
+	KeyCheck    []byte `json:"key_check,omitempty"`
+}
+
+func (h encodingHeader) isPlain() bool {
+	return h.Compression == "" && h.Encryption == ""
+}
+
+// valueCodec compresses and then encrypts values before they are written, and reverses it when they are read.
+// Encrypted values are bound to their key, so that they cannot be swapped around in the database.
+type valueCodec struct {
+	compression string
+	aead        cipher.AEAD
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+}
+
+func newValueCodec(compression string, key []byte) (*valueCodec, error) {
+	codec := &valueCodec{compression: compression}
+	switch compression {
+	case "", compressionSnappy:
+	case compressionZstd:
+		var err error
+		if codec.zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1)); err != nil {
+			return nil, err
+		}
+		if codec.zstdDecoder, err = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1)); err != nil {
+			_ = codec.zstdEncoder.Close()
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", compression)
+	}
+
+	if key != nil {
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			codec.close()
+			return nil, err
+		}
+		if codec.aead, err = cipher.NewGCM(block); err != nil {
+			codec.close()
+			return nil, err
+		}
+	}
+	return codec, nil
+}
+
+func (c *valueCodec) header() (encodingHeader, error) {
+	header := encodingHeader{Compression: c.compression}
+	if c.aead != nil {
+		keyCheck, err := c.seal(nil, keyCheckPlaintext)
+		if err != nil {
+			return encodingHeader{}, err
+		}
+		header.Encryption = encryptionAESGCM
+		header.KeyCheck = keyCheck
+	}
+	return header, nil
+}
+
+// matches reports whether values encoded as described by header can be decoded by this codec
+func (c *valueCodec) matches(header encodingHeader) bool {
+	if header.Compression != c.compression {
+		return false
+	}
+	if c.aead == nil || header.Encryption == "" {
+		return c.aead == nil && header.Encryption == ""
+	}
+	return c.verify(header)
+}
+
+// verify reports whether the key of this codec is the one the header's key check was encrypted with
+func (c *valueCodec) verify(header encodingHeader) bool {
+	plaintext, err := c.open(nil, header.KeyCheck)
+	return err == nil && bytes.Equal(plaintext, keyCheckPlaintext)
+}
+
+func (c *valueCodec) isPlain() bool {
+	return c.compression == "" && c.aead == nil
+}
+
+func (c *valueCodec) encode(key string, value []byte) ([]byte, error) {
+	// empty values are stored as is, an encoded value is never empty
+	if len(value) == 0 {
+		return value, nil
+	}
+
+	switch c.compression {
+	case compressionZstd:
+		value = c.zstdEncoder.EncodeAll(value, nil)
+	case compressionSnappy:
+		value = snappy.Encode(nil, value)
+	}
+
+	if c.aead == nil {
+		return value, nil
+	}
+	return c.seal([]byte(key), value)
+}
+
+func (c *valueCodec) decode(key string, value []byte) ([]byte, error) {
+	if len(value) == 0 {
+		return value, nil
+	}
+
+	var err error
+	if c.aead != nil {
+		if value, err = c.open([]byte(key), value); err != nil {
+			return nil, fmt.Errorf("failed to decrypt value of key %q: %w", key, err)
+		}
+	}
+
+	switch c.compression {
+	case compressionZstd:
+		value, err = c.zstdDecoder.DecodeAll(value, nil)
+	case compressionSnappy:
+		value, err = snappy.Decode(nil, value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress value of key %q: %w", key, err)
+	}
+	return value, nil
+}
+
+// seal encrypts plaintext and returns it prefixed with a random nonce
+func (c *valueCodec) seal(additionalData, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize(), c.aead.NonceSize()+len(plaintext)+c.aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return c.aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func (c *valueCodec) open(additionalData, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < c.aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, ciphertext := ciphertext[:c.aead.NonceSize()], ciphertext[c.aead.NonceSize():]
+	return c.aead.Open(nil, nonce, ciphertext, additionalData)
+}
+
+func (c *valueCodec) close() {
+	if c.zstdEncoder != nil {
+		_ = c.zstdEncoder.Close()
+	}
+	if c.zstdDecoder != nil {
+		c.zstdDecoder.Close()
+	}
+}
+
+func readEncodingHeader(tx *bbolt.Tx) (encodingHeader, error) {
+	var header encodingHeader
+	bucket := tx.Bucket(metadataBucket)
+	if bucket == nil {
+		return header, nil
+	}
+	value := bucket.Get(encodingKey)
+	if value == nil {
+		return header, nil
+	}
+	if err := json.Unmarshal(value, &header); err != nil {
+		return header, fmt.Errorf("invalid encoding metadata: %w", err)
+	}
+	return header, nil
+}
+
+func writeEncodingHeader(tx *bbolt.Tx, header encodingHeader) error {
+	if header.isPlain() {
+		if tx.Bucket(metadataBucket) == nil {
+			return nil
+		}
+		return tx.DeleteBucket(metadataBucket)
+	}
+	bucket, err := tx.CreateBucketIfNotExists(metadataBucket)
+	if err != nil {
+		return err
+	}
+	value, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(encodingKey, value)
+}
+
+// migrateEncoding re-encodes all values of the database when they are not encoded as the codec expects,
+// e.g. when compression or encryption is enabled for an existing database or the encryption key is rotated.
+// The migration runs in a single transaction, so a database is never left partially migrated.
+func migrateEncoding(db *bbolt.DB, codec *valueCodec, enc valueEncoding) (bool, error) {
+	var migrated bool
+	err := db.Update(func(tx *bbolt.Tx) error {
+		stored, err := readEncodingHeader(tx)
+		if err != nil {
+			return err
+		}
+		if codec.matches(stored) {
+			return nil
+		}
+
+		source, err := sourceCodec(stored, enc)
+		if err != nil {
+			return err
+		}
+		defer source.close()
+
+		bucket := tx.Bucket(defaultBucket)
+		var keys, values [][]byte
+		err = bucket.ForEach(func(k, v []byte) error {
+			key := string(k)
+			decoded, err := source.decode(key, v)
+			if err != nil {
+				return err
+			}
+			encoded, err := codec.encode(key, decoded)
+			if err != nil {
+				return err
+			}
+			// the bucket cannot be modified while iterating over it
+			keys = append(keys, k)
+			values = append(values, encoded)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for i, k := range keys {
+			if err := bucket.Put(k, values[i]); err != nil {
+				return err
+			}
+		}
+
+		header, err := codec.header()
+		if err != nil {
+			return err
+		}
+		migrated = true
+		return writeEncodingHeader(tx, header)
+	})
+	return migrated, err
+}
+
+// sourceCodec returns a codec able to decode values encoded as described by the stored header
+func sourceCodec(stored encodingHeader, enc valueEncoding) (*valueCodec, error) {
+	if stored.Encryption == "" {
+		return newValueCodec(stored.Compression, nil)
+	}
+	if stored.Encryption != encryptionAESGCM {
+		return nil, fmt.Errorf("unsupported encryption %q", stored.Encryption)
+	}
+
+	for _, key := range [][]byte{enc.key, enc.previousKey} {
+		if key == nil {
+			continue
+		}
+		codec, err := newValueCodec(stored.Compression, key)
+		if err != nil {
+			return nil, err
+		}
+		if codec.verify(stored) {
+			return codec, nil
+		}
+		codec.close()
+	}
+	if enc.key == nil && enc.previousKey == nil {
+		return nil, errors.New("database is encrypted, configure the key it was encrypted with to read it")
+	}
+	return nil, errors.New("database is encrypted with an unknown key, configure the key it was encrypted with as previous key to migrate it")
+}
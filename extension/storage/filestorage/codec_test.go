@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package filestorage
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+)
+
+func newTestKey(t *testing.T, size int) []byte {
+	key := make([]byte, size)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func TestValueCodecRoundTrip(t *testing.T) {
+	value := bytes.Repeat([]byte("a compressible value "), 64)
+
+	tests := []struct {
+		name        string
+		compression string
+		key         []byte
+	}{
+		{name: "plain"},
+		{name: "zstd", compression: compressionZstd},
+		{name: "snappy", compression: compressionSnappy},
+		{name: "aes-128", key: newTestKey(t, 16)},
+		{name: "aes-256", key: newTestKey(t, 32)},
+		{name: "zstd and aes-192", compression: compressionZstd, key: newTestKey(t, 24)},
+		{name: "snappy and aes-256", compression: compressionSnappy, key: newTestKey(t, 32)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := newValueCodec(tt.compression, tt.key)
+			require.NoError(t, err)
+			defer codec.close()
+
+			encoded, err := codec.encode("key", value)
+			require.NoError(t, err)
+			if tt.compression != "" && tt.key == nil {
+				assert.Less(t, len(encoded), len(value))
+			}
+			if tt.key != nil {
+				assert.NotContains(t, string(encoded), "compressible")
+			}
+
+			decoded, err := codec.decode("key", encoded)
+			require.NoError(t, err)
+			assert.Equal(t, value, decoded)
+
+			empty, err := codec.encode("key", []byte{})
+			require.NoError(t, err)
+			assert.Empty(t, empty)
+			decoded, err = codec.decode("key", empty)
+			require.NoError(t, err)
+			assert.Empty(t, decoded)
+		})
+	}
+}
+
+func TestValueCodecBindsValuesToKeys(t *testing.T) {
+	codec, err := newValueCodec("", newTestKey(t, 32))
+	require.NoError(t, err)
+	defer codec.close()
+
+	encoded, err := codec.encode("key", []byte("value"))
+	require.NoError(t, err)
+
+	_, err = codec.decode("other", encoded)
+	assert.ErrorContains(t, err, `failed to decrypt value of key "other"`)
+
+	encoded[len(encoded)-1] ^= 0xff
+	_, err = codec.decode("key", encoded)
+	assert.ErrorContains(t, err, `failed to decrypt value of key "key"`)
+}
+
+func TestReadKey(t *testing.T) {
+	key := newTestKey(t, 32)
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600))
+	t.Setenv("FILE_STORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(key))
+	t.Setenv("FILE_STORAGE_TEST_SHORT_KEY", base64.StdEncoding.EncodeToString(key[:10]))
+	t.Setenv("FILE_STORAGE_TEST_INVALID_KEY", "not base64!")
+
+	read, err := readKey(keyFile, "")
+	require.NoError(t, err)
+	assert.Equal(t, key, read)
+
+	read, err = readKey("", "FILE_STORAGE_TEST_KEY")
+	require.NoError(t, err)
+	assert.Equal(t, key, read)
+
+	_, err = readKey("", "FILE_STORAGE_TEST_SHORT_KEY")
+	assert.EqualError(t, err, "key must be 16, 24 or 32 bytes long but got 10")
+
+	_, err = readKey("", "FILE_STORAGE_TEST_INVALID_KEY")
+	assert.ErrorContains(t, err, "key must be base64 encoded")
+
+	_, err = readKey("", "FILE_STORAGE_TEST_MISSING_KEY")
+	assert.EqualError(t, err, "environment variable FILE_STORAGE_TEST_MISSING_KEY is not set")
+
+	_, err = readKey(filepath.Join(t.TempDir(), "missing"), "")
+	assert.Error(t, err)
+}
+
+func TestClientEncodingMigration(t *testing.T) {
+	ctx := t.Context()
+	dbFile := filepath.Join(t.TempDir(), "my_db")
+	key := newTestKey(t, 32)
+	rotatedKey := newTestKey(t, 16)
+	values := map[string][]byte{
+		"a": []byte("first value"),
+		"b": bytes.Repeat([]byte("second value "), 32),
+		"c": {},
+	}
+
+	open := func(enc valueEncoding) *fileStorageClient {
+		client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, enc)
+		require.NoError(t, err)
+		return client
+	}
+	assertValues := func(client *fileStorageClient) {
+		for k, v := range values {
+			got, err := client.Get(ctx, k)
+			require.NoError(t, err)
+			assert.Equal(t, v, got, k)
+		}
+	}
+	assertRaw := func(contains bool) {
+		db, err := bbolt.Open(dbFile, 0o600, &bbolt.Options{Timeout: time.Second, ReadOnly: true})
+		require.NoError(t, err)
+		defer db.Close()
+		require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+			assert.Equal(t, contains, bytes.Contains(tx.Bucket(defaultBucket).Get([]byte("a")), values["a"]))
+			return nil
+		}))
+	}
+
+	// existing databases store plain values
+	client := open(valueEncoding{})
+	for k, v := range values {
+		require.NoError(t, client.Set(ctx, k, v))
+	}
+	require.NoError(t, client.Close(ctx))
+	assertRaw(true)
+
+	// enabling compression and encryption migrates the existing values
+	client = open(valueEncoding{compression: compressionZstd, key: key})
+	assertValues(client)
+	require.NoError(t, client.Close(ctx))
+	assertRaw(false)
+
+	// a database cannot be opened without its key, or with a wrong one
+	_, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, valueEncoding{compression: compressionZstd})
+	assert.ErrorContains(t, err, "database is encrypted, configure the key it was encrypted with to read it")
+	_, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, valueEncoding{compression: compressionZstd, key: rotatedKey})
+	assert.ErrorContains(t, err, "database is encrypted with an unknown key")
+
+	// rotating the key and changing the compression
+	client = open(valueEncoding{compression: compressionSnappy, key: rotatedKey, previousKey: key})
+	assertValues(client)
+	require.NoError(t, client.Close(ctx))
+
+	// the previous key is not needed anymore once migrated
+	client = open(valueEncoding{compression: compressionSnappy, key: rotatedKey})
+	assertValues(client)
+	require.NoError(t, client.Close(ctx))
+
+	// decrypting with only the previous key restores plain values
+	client = open(valueEncoding{previousKey: rotatedKey})
+	assertValues(client)
+	require.NoError(t, client.Close(ctx))
+	assertRaw(true)
+
+	db, err := bbolt.Open(dbFile, 0o600, &bbolt.Options{Timeout: time.Second, ReadOnly: true})
+	require.NoError(t, err)
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		assert.Nil(t, tx.Bucket(metadataBucket))
+		return nil
+	}))
+	require.NoError(t, db.Close())
+}
+
+func TestClientEncodingSurvivesCompaction(t *testing.T) {
+	ctx := t.Context()
+	tempDir := t.TempDir()
+	dbFile := filepath.Join(tempDir, "my_db")
+	enc := valueEncoding{compression: compressionZstd, key: newTestKey(t, 32)}
+
+	client, err := newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, enc)
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("value")))
+	require.NoError(t, client.Compact(tempDir, time.Second, 65536))
+
+	value, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.NoError(t, client.Close(ctx))
+
+	// the encoding metadata is compacted along with the values, so no migration is needed on reopen
+	client, err = newClient(zap.NewNop(), dbFile, time.Second, &CompactionConfig{}, false, enc)
+	require.NoError(t, err)
+	value, err = client.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value"), value)
+	require.NoError(t, client.Close(ctx))
+}
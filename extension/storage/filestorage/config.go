@@ -33,6 +33,27 @@ type Config struct {
 	directoryPermissionsParsed int64  `mapstructure:"-,omitempty"`
 
 	Recreate bool `mapstructure:"recreate,omitempty"`
+
+	// Compression specifies the algorithm used to compress stored values: none (default), zstd or snappy.
+	Compression string `mapstructure:"compression,omitempty"`
+
+	// Encryption configures the encryption of stored values. Values are stored in plain text when not set.
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+}
+
+// EncryptionConfig defines configuration for optional AES-GCM encryption of stored values.
+// Keys are base64 encoded and must decode to 16, 24 or 32 bytes, selecting AES-128, AES-192 or AES-256.
+// Setting only a previous key decrypts existing databases, which are then stored in plain text.
+type EncryptionConfig struct {
+	// KeyFile is the path of a file holding the encryption key
+	KeyFile string `mapstructure:"key_file,omitempty"`
+	// KeyEnv is the name of an environment variable holding the encryption key
+	KeyEnv string `mapstructure:"key_env,omitempty"`
+	// PreviousKeyFile is the path of a file holding the key existing databases were encrypted with.
+	// It is only needed while rotating keys, databases are re-encrypted with the current key when opened.
+	PreviousKeyFile string `mapstructure:"previous_key_file,omitempty"`
+	// PreviousKeyEnv is the name of an environment variable holding the key existing databases were encrypted with
+	PreviousKeyEnv string `mapstructure:"previous_key_env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -104,5 +125,24 @@ func (cfg *Config) Validate() error {
 		cfg.directoryPermissionsParsed = permissions
 	}
 
+	switch cfg.Compression {
+	case "", compressionNone, compressionZstd, compressionSnappy:
+	default:
+		return fmt.Errorf("unsupported compression %q, must be one of %q, %q or %q", cfg.Compression, compressionNone, compressionZstd, compressionSnappy)
+	}
+
+	return nil
+}
+
+func (cfg *EncryptionConfig) Validate() error {
+	if cfg.KeyFile != "" && cfg.KeyEnv != "" {
+		return errors.New("key_file and key_env cannot both be set")
+	}
+	if cfg.PreviousKeyFile != "" && cfg.PreviousKeyEnv != "" {
+		return errors.New("previous_key_file and previous_key_env cannot both be set")
+	}
+	if cfg.KeyFile == "" && cfg.KeyEnv == "" && cfg.PreviousKeyFile == "" && cfg.PreviousKeyEnv == "" {
+		return errors.New("encryption requires one of key_file or key_env")
+	}
 	return nil
 }
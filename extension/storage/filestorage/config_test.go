@@ -56,6 +56,19 @@ func TestLoadConfig(t *testing.T) {
 				DirectoryPermissions: "0750",
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "encrypted"),
+			expected: func() component.Config {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.Compression = "zstd"
+				ret.Encryption = &EncryptionConfig{
+					KeyFile:        "/etc/otelcol/file_storage.key",
+					PreviousKeyEnv: "FILE_STORAGE_PREVIOUS_KEY",
+				}
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -272,3 +285,64 @@ func TestCompactionDirectory(t *testing.T) {
 		})
 	}
 }
+
+func TestEncodingConfig(t *testing.T) {
+	f := NewFactory()
+	tests := []struct {
+		name   string
+		config func(*Config)
+		err    string
+	}{
+		{
+			name:   "no compression",
+			config: func(cfg *Config) { cfg.Compression = "none" },
+		},
+		{
+			name:   "snappy compression",
+			config: func(cfg *Config) { cfg.Compression = "snappy" },
+		},
+		{
+			name:   "unsupported compression",
+			config: func(cfg *Config) { cfg.Compression = "gzip" },
+			err:    `unsupported compression "gzip", must be one of "none", "zstd" or "snappy"`,
+		},
+		{
+			name:   "key from env",
+			config: func(cfg *Config) { cfg.Encryption = &EncryptionConfig{KeyEnv: "KEY"} },
+		},
+		{
+			name:   "only previous key",
+			config: func(cfg *Config) { cfg.Encryption = &EncryptionConfig{PreviousKeyFile: "key"} },
+		},
+		{
+			name:   "no key",
+			config: func(cfg *Config) { cfg.Encryption = &EncryptionConfig{} },
+			err:    "encryption: encryption requires one of key_file or key_env",
+		},
+		{
+			name:   "key from both file and env",
+			config: func(cfg *Config) { cfg.Encryption = &EncryptionConfig{KeyFile: "key", KeyEnv: "KEY"} },
+			err:    "encryption: key_file and key_env cannot both be set",
+		},
+		{
+			name: "previous key from both file and env",
+			config: func(cfg *Config) {
+				cfg.Encryption = &EncryptionConfig{KeyFile: "key", PreviousKeyFile: "key", PreviousKeyEnv: "KEY"}
+			},
+			err: "encryption: previous_key_file and previous_key_env cannot both be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := f.CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			tt.config(cfg)
+			err := xconfmap.Validate(cfg)
+			if tt.err == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
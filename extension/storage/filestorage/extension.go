@@ -18,8 +18,9 @@ import (
 )
 
 type localFileStorage struct {
-	cfg    *Config
-	logger *zap.Logger
+	cfg      *Config
+	logger   *zap.Logger
+	encoding valueEncoding
 }
 
 // Ensure this storage extension implements the appropriate interface
@@ -39,9 +40,14 @@ func newLocalFileStorage(logger *zap.Logger, config *Config) (extension.Extensio
 			}
 		}
 	}
+	enc, err := newValueEncoding(config)
+	if err != nil {
+		return nil, err
+	}
 	return &localFileStorage{
-		cfg:    config,
-		logger: logger,
+		cfg:      config,
+		logger:   logger,
+		encoding: enc,
 	}, nil
 }
 
@@ -76,7 +82,7 @@ func (lfs *localFileStorage) GetClient(_ context.Context, kind component.Kind, e
 			return nil, fmt.Errorf("error renaming the database. Please remove %s manually: %w", absoluteName, err)
 		}
 	}
-	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync)
+	client, err := newClient(lfs.logger, absoluteName, lfs.cfg.Timeout, lfs.cfg.Compaction, !lfs.cfg.FSync, lfs.encoding)
 	if err != nil {
 		return nil, err
 	}
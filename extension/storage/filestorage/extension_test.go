@@ -4,6 +4,7 @@
 package filestorage
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -689,3 +690,42 @@ func TestRecreate(t *testing.T) {
 		require.NoError(t, ext.Shutdown(ctx))
 	}
 }
+
+func TestEncryption(t *testing.T) {
+	ctx := t.Context()
+	f := NewFactory()
+
+	config := f.CreateDefaultConfig().(*Config)
+	config.Directory = t.TempDir()
+	config.Compression = compressionSnappy
+	config.Encryption = &EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_KEY"}
+
+	// the key is read when the extension is created
+	_, err := f.Create(ctx, extensiontest.NewNopSettings(f.Type()), config)
+	require.EqualError(t, err, "failed to read encryption key: environment variable FILE_STORAGE_TEST_KEY is not set")
+
+	t.Setenv("FILE_STORAGE_TEST_KEY", base64.StdEncoding.EncodeToString(newTestKey(t, 32)))
+	ext, err := f.Create(ctx, extensiontest.NewNopSettings(f.Type()), config)
+	require.NoError(t, err)
+	se, ok := ext.(storage.Extension)
+	require.True(t, ok)
+
+	client, err := se.GetClient(ctx, component.KindReceiver, component.MustNewID("filelog"), "")
+	require.NoError(t, err)
+	require.NoError(t, client.Set(ctx, "key", []byte("val")))
+	val, err := client.Get(ctx, "key")
+	require.NoError(t, err)
+	require.Equal(t, []byte("val"), val)
+	require.NoError(t, client.Close(ctx))
+	require.NoError(t, ext.Shutdown(ctx))
+
+	// the database cannot be read once the key is removed from the configuration
+	config.Encryption = nil
+	ext, err = f.Create(ctx, extensiontest.NewNopSettings(f.Type()), config)
+	require.NoError(t, err)
+	se, ok = ext.(storage.Extension)
+	require.True(t, ok)
+	_, err = se.GetClient(ctx, component.KindReceiver, component.MustNewID("filelog"), "")
+	require.ErrorContains(t, err, "database is encrypted, configure the key it was encrypted with to read it")
+	require.NoError(t, ext.Shutdown(ctx))
+}
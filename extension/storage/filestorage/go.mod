@@ -3,7 +3,9 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/extension/stora
 go 1.24
 
 require (
+	github.com/golang/snappy v1.0.0
 	github.com/google/uuid v1.6.0
+	github.com/klauspost/compress v1.18.0
 	github.com/stretchr/testify v1.10.0
 	go.etcd.io/bbolt v1.4.2
 	go.opentelemetry.io/collector/component v1.38.0
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	cefPrefix      = "CEF:"
+	cefHeaderCount = 7
+)
+
+// Keys of the map returned by ParseCEF
+const (
+	CEFVersion            = "version"
+	CEFDeviceVendor       = "device_vendor"
+	CEFDeviceProduct      = "device_product"
+	CEFDeviceVersion      = "device_version"
+	CEFDeviceEventClassID = "device_event_class_id"
+	CEFName               = "name"
+	CEFSeverity           = "severity"
+	CEFExtensions         = "extensions"
+	CEFSyslogHeader       = "syslog_header"
+)
+
+var cefHeaderKeys = [cefHeaderCount]string{
+	CEFVersion,
+	CEFDeviceVendor,
+	CEFDeviceProduct,
+	CEFDeviceVersion,
+	CEFDeviceEventClassID,
+	CEFName,
+	CEFSeverity,
+}
+
+// ParseCEF parses a message in the ArcSight Common Event Format, i.e.
+// CEF:Version|Device Vendor|Device Product|Device Version|Device Event Class ID|Name|Severity|Extension
+// The header fields are returned as strings, and the key value pairs of the extension as a map of strings.
+// Pipes and backslashes are unescaped in the header, equal signs, backslashes and line breaks in the extension.
+// The message may be preceded by a syslog header, which is returned as is.
+func ParseCEF(value string) (map[string]any, error) {
+	syslogHeader, rest, ok := cutMessagePrefix(value, cefPrefix)
+	if !ok {
+		return nil, fmt.Errorf("message does not contain %q", cefPrefix)
+	}
+
+	header, extension, err := splitHeader(rest, cefHeaderCount)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := make(map[string]any, cefHeaderCount+1)
+	for i, key := range cefHeaderKeys {
+		parsed[key] = header[i]
+	}
+	extensions, err := parseCEFExtension(extension)
+	if err != nil {
+		return nil, err
+	}
+	parsed[CEFExtensions] = extensions
+	if syslogHeader != "" {
+		parsed[CEFSyslogHeader] = syslogHeader
+	}
+	return parsed, nil
+}
+
+// cutMessagePrefix finds the prefix starting a CEF or LEEF message, either at the start of the value or
+// after the header added by syslog, e.g. "<134>Feb 10 12:00:00 host CEF:0|...". It returns the trimmed
+// text before the prefix and the remainder of the value after it.
+func cutMessagePrefix(value, prefix string) (string, string, bool) {
+	for start := 0; start < len(value); {
+		i := strings.Index(value[start:], prefix)
+		if i < 0 {
+			break
+		}
+		i += start
+		// the prefix must start a word, so that it is not taken from within e.g. a host name
+		if i == 0 || value[i-1] == ' ' || value[i-1] == '\t' {
+			return strings.TrimSpace(value[:i]), value[i+len(prefix):], true
+		}
+		start = i + 1
+	}
+	return "", "", false
+}
+
+// splitHeader splits the first count pipe separated fields off the value, unescaping pipes and backslashes,
+// and returns them along with the remainder of the value
+func splitHeader(value string, count int) ([]string, string, error) {
+	fields := make([]string, 0, count)
+	var field strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; {
+		case c == '\\' && i+1 < len(value) && (value[i+1] == '|' || value[i+1] == '\\'):
+			i++
+			field.WriteByte(value[i])
+		case c == '|':
+			fields = append(fields, field.String())
+			field.Reset()
+			if len(fields) == count {
+				return fields, value[i+1:], nil
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	return nil, "", fmt.Errorf("expected %d header fields separated by '|' but got %d", count, len(fields))
+}
+
+// parseCEFExtension parses the space separated key value pairs of a CEF extension. Values may contain
+// spaces, so a value ends at the last space before the next key, i.e. before the next unescaped equal sign
+// which is preceded by a valid key.
+func parseCEFExtension(extension string) (map[string]any, error) {
+	extensions := make(map[string]any)
+	extension = strings.TrimSpace(extension)
+	if extension == "" {
+		return extensions, nil
+	}
+
+	separators := unescapedEquals(extension)
+	if len(separators) == 0 || !isCEFKey(extension[:separators[0]]) {
+		return nil, errors.New("extension must start with a key followed by '='")
+	}
+
+	key := extension[:separators[0]]
+	valueStart := separators[0] + 1
+	for _, separator := range separators[1:] {
+		keyStart := strings.LastIndexByte(extension[:separator], ' ') + 1
+		if keyStart <= valueStart || !isCEFKey(extension[keyStart:separator]) {
+			// the equal sign is part of the value
+			continue
+		}
+		extensions[key] = unescapeCEFValue(strings.TrimRight(extension[valueStart:keyStart], " "))
+		key = extension[keyStart:separator]
+		valueStart = separator + 1
+	}
+	extensions[key] = unescapeCEFValue(extension[valueStart:])
+	return extensions, nil
+}
+
+// unescapedEquals returns the indexes of the equal signs in s which are not escaped by a backslash
+func unescapedEquals(s string) []int {
+	var indexes []int
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '=':
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func isCEFKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, c := range key {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_', c == '.', c == '-', c == '[', c == ']':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func unescapeCEFValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i+1 == len(value) {
+			b.WriteByte(value[i])
+			continue
+		}
+		switch value[i+1] {
+		case '=', '\\', '|':
+			b.WriteByte(value[i+1])
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		default:
+			// unknown escape sequences are kept as is
+			b.WriteByte('\\')
+			b.WriteByte(value[i+1])
+		}
+		i++
+	}
+	return b.String()
+}
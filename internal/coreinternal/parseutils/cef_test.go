@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseCEF(t *testing.T) {
+	header := func(extensions map[string]any) map[string]any {
+		return map[string]any{
+			CEFVersion:            "0",
+			CEFDeviceVendor:       "Security",
+			CEFDeviceProduct:      "threatmanager",
+			CEFDeviceVersion:      "1.0",
+			CEFDeviceEventClassID: "100",
+			CEFName:               "worm successfully stopped",
+			CEFSeverity:           "10",
+			CEFExtensions:         extensions,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "basic",
+			input: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232",
+			expected: header(map[string]any{
+				"src": "10.0.0.1",
+				"dst": "2.1.2.2",
+				"spt": "1232",
+			}),
+		},
+		{
+			name:     "no extension",
+			input:    "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|",
+			expected: header(map[string]any{}),
+		},
+		{
+			name:  "values with spaces",
+			input: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|msg=Detected a threat. No action needed.  cs1Label=Rule Name cs1=Block all",
+			expected: header(map[string]any{
+				"msg":      "Detected a threat. No action needed.",
+				"cs1Label": "Rule Name",
+				"cs1":      "Block all",
+			}),
+		},
+		{
+			name:  "escaped extension values",
+			input: `CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|request=http://example.com/?a\=1&b\=2 msg=line1\nline2\rend path=C:\\Windows act=a|b note=unknown\tescape`,
+			expected: header(map[string]any{
+				"request": "http://example.com/?a=1&b=2",
+				"msg":     "line1\nline2\rend",
+				"path":    `C:\Windows`,
+				"act":     "a|b",
+				"note":    `unknown\tescape`,
+			}),
+		},
+		{
+			name:  "unescaped equal sign in value",
+			input: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|query=a=b&c=d src=10.0.0.1",
+			expected: header(map[string]any{
+				"query": "a=b&c=d",
+				"src":   "10.0.0.1",
+			}),
+		},
+		{
+			name:  "escaped header fields",
+			input: `CEF:1|Sec\|urity|threat\\manager|1.0|100|name with = sign|High|src=10.0.0.1`,
+			expected: map[string]any{
+				CEFVersion:            "1",
+				CEFDeviceVendor:       "Sec|urity",
+				CEFDeviceProduct:      `threat\manager`,
+				CEFDeviceVersion:      "1.0",
+				CEFDeviceEventClassID: "100",
+				CEFName:               "name with = sign",
+				CEFSeverity:           "High",
+				CEFExtensions:         map[string]any{"src": "10.0.0.1"},
+			},
+		},
+		{
+			name:  "leading whitespace and empty value",
+			input: " CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|suser= duser=bob",
+			expected: header(map[string]any{
+				"suser": "",
+				"duser": "bob",
+			}),
+		},
+		{
+			name:  "syslog header",
+			input: "<134>Feb 18 10:00:00 host CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1",
+			expected: func() map[string]any {
+				expected := header(map[string]any{"src": "10.0.0.1"})
+				expected[CEFSyslogHeader] = "<134>Feb 18 10:00:00 host"
+				return expected
+			}(),
+		},
+		{
+			name:  "prefix within a word",
+			input: "<134>Feb 18 10:00:00 hostCEF:1 CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|",
+			expected: func() map[string]any {
+				expected := header(map[string]any{})
+				expected[CEFSyslogHeader] = "<134>Feb 18 10:00:00 hostCEF:1"
+				return expected
+			}(),
+		},
+		{
+			name:        "missing prefix",
+			input:       "<134>Feb 18 10:00:00 host 0|Security|threatmanager|1.0|100|stopped|10|",
+			expectedErr: `message does not contain "CEF:"`,
+		},
+		{
+			name:        "prefix only within a word",
+			input:       "<134>Feb 18 10:00:00 hostCEF:0|Security|threatmanager|1.0|100|stopped|10|",
+			expectedErr: `message does not contain "CEF:"`,
+		},
+		{
+			name:        "missing header fields",
+			input:       "CEF:0|Security|threatmanager|1.0|100|stopped",
+			expectedErr: "expected 7 header fields separated by '|' but got 5",
+		},
+		{
+			name:        "extension without key",
+			input:       "CEF:0|Security|threatmanager|1.0|100|stopped|10|just some text",
+			expectedErr: "extension must start with a key followed by '='",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseCEF(tt.input)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	leefPrefix           = "LEEF:"
+	leefHeaderCount      = 5
+	leefDefaultDelimiter = "\t"
+)
+
+// Keys of the map returned by ParseLEEF
+const (
+	LEEFVersion        = "version"
+	LEEFVendor         = "vendor"
+	LEEFProduct        = "product"
+	LEEFProductVersion = "product_version"
+	LEEFEventID        = "event_id"
+	LEEFAttributes     = "attributes"
+	LEEFSyslogHeader   = "syslog_header"
+)
+
+var leefHeaderKeys = [leefHeaderCount]string{
+	LEEFVersion,
+	LEEFVendor,
+	LEEFProduct,
+	LEEFProductVersion,
+	LEEFEventID,
+}
+
+// ParseLEEF parses a message in the IBM Log Event Extended Format, i.e.
+// LEEF:1.0|Vendor|Product|Version|EventID|Attributes or
+// LEEF:2.0|Vendor|Product|Version|EventID|Delimiter|Attributes
+// The header fields are returned as strings, and the key value pairs of the attributes as a map of strings.
+// Attributes are separated by tabs, unless LEEF 2.0 specifies another delimiter, either as a
+// single character or as its hex code, e.g. x5E or 0x5E.
+// The message may be preceded by a syslog header, which is returned as is.
+func ParseLEEF(value string) (map[string]any, error) {
+	syslogHeader, rest, ok := cutMessagePrefix(value, leefPrefix)
+	if !ok {
+		return nil, fmt.Errorf("message does not contain %q", leefPrefix)
+	}
+
+	header, attributes, err := splitHeader(rest, leefHeaderCount)
+	if err != nil {
+		return nil, err
+	}
+
+	delimiter := leefDefaultDelimiter
+	if strings.HasPrefix(header[0], "2.") {
+		// the delimiter is optional, in which case the attributes directly follow the header
+		if spec, remainder, ok := strings.Cut(attributes, "|"); ok && !strings.Contains(spec, "=") {
+			if delimiter, err = parseLEEFDelimiter(spec); err != nil {
+				return nil, err
+			}
+			attributes = remainder
+		}
+	}
+
+	parsed := make(map[string]any, leefHeaderCount+1)
+	for i, key := range leefHeaderKeys {
+		parsed[key] = header[i]
+	}
+	parsed[LEEFAttributes] = parseLEEFAttributes(attributes, delimiter)
+	if syslogHeader != "" {
+		parsed[LEEFSyslogHeader] = syslogHeader
+	}
+	return parsed, nil
+}
+
+func parseLEEFDelimiter(spec string) (string, error) {
+	switch {
+	case spec == "":
+		return leefDefaultDelimiter, nil
+	case len(spec) == 1:
+		return spec, nil
+	}
+
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(spec, "0"), "x"), "X")
+	if hex == spec || hex == "" {
+		return "", fmt.Errorf("invalid delimiter %q, must be a single character or its hex code", spec)
+	}
+	code, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return "", fmt.Errorf("invalid delimiter %q, must be a single character or its hex code: %w", spec, err)
+	}
+	return string(rune(code)), nil
+}
+
+// parseLEEFAttributes parses the key value pairs of LEEF attributes. Values may contain equal signs,
+// and pairs without an equal sign are ignored.
+func parseLEEFAttributes(attributes, delimiter string) map[string]any {
+	parsed := make(map[string]any)
+	for _, pair := range strings.Split(attributes, delimiter) {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		parsed[key] = strings.TrimRight(value, "\r\n")
+	}
+	return parsed
+}
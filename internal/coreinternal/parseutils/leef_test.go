@@ -0,0 +1,123 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package parseutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ParseLEEF(t *testing.T) {
+	header := func(version string, attributes map[string]any) map[string]any {
+		return map[string]any{
+			LEEFVersion:        version,
+			LEEFVendor:         "Microsoft",
+			LEEFProduct:        "MSExchange",
+			LEEFProductVersion: "4.0 SP1",
+			LEEFEventID:        "15345",
+			LEEFAttributes:     attributes,
+		}
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		expected    map[string]any
+		expectedErr string
+	}{
+		{
+			name:  "version 1.0",
+			input: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tsev=5\tusrName=Joe Smith",
+			expected: header("1.0", map[string]any{
+				"src":     "192.0.2.0",
+				"dst":     "172.50.123.1",
+				"sev":     "5",
+				"usrName": "Joe Smith",
+			}),
+		},
+		{
+			name:  "version 2.0 with character delimiter",
+			input: "LEEF:2.0|Microsoft|MSExchange|4.0 SP1|15345|^|src=192.0.2.0^dst=172.50.123.1^url=http://example.com/?a=b",
+			expected: header("2.0", map[string]any{
+				"src": "192.0.2.0",
+				"dst": "172.50.123.1",
+				"url": "http://example.com/?a=b",
+			}),
+		},
+		{
+			name:  "version 2.0 with hex delimiter",
+			input: "LEEF:2.0|Microsoft|MSExchange|4.0 SP1|15345|0x5E|src=192.0.2.0^dst=172.50.123.1",
+			expected: header("2.0", map[string]any{
+				"src": "192.0.2.0",
+				"dst": "172.50.123.1",
+			}),
+		},
+		{
+			name:  "version 2.0 with short hex delimiter",
+			input: "LEEF:2.0|Microsoft|MSExchange|4.0 SP1|15345|x09|src=192.0.2.0\tdst=172.50.123.1",
+			expected: header("2.0", map[string]any{
+				"src": "192.0.2.0",
+				"dst": "172.50.123.1",
+			}),
+		},
+		{
+			name:  "version 2.0 without delimiter",
+			input: "LEEF:2.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tpolicy=a|b",
+			expected: header("2.0", map[string]any{
+				"src":    "192.0.2.0",
+				"policy": "a|b",
+			}),
+		},
+		{
+			name:  "empty and malformed attributes",
+			input: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\t\tnot a pair\tusrName=\r\n",
+			expected: header("1.0", map[string]any{
+				"src":     "192.0.2.0",
+				"usrName": "",
+			}),
+		},
+		{
+			name:     "no attributes",
+			input:    "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|",
+			expected: header("1.0", map[string]any{}),
+		},
+		{
+			name:  "syslog header",
+			input: "<13>Jan 18 11:07:53 192.0.2.1 LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|",
+			expected: func() map[string]any {
+				expected := header("1.0", map[string]any{})
+				expected[LEEFSyslogHeader] = "<13>Jan 18 11:07:53 192.0.2.1"
+				return expected
+			}(),
+		},
+		{
+			name:        "missing prefix",
+			input:       "CEF:0|Microsoft|MSExchange|4.0 SP1|15345|",
+			expectedErr: `message does not contain "LEEF:"`,
+		},
+		{
+			name:        "missing header fields",
+			input:       "LEEF:1.0|Microsoft|MSExchange",
+			expectedErr: "expected 5 header fields separated by '|' but got 2",
+		},
+		{
+			name:        "invalid delimiter",
+			input:       "LEEF:2.0|Microsoft|MSExchange|4.0 SP1|15345|xZZ|src=192.0.2.0",
+			expectedErr: `invalid delimiter "xZZ", must be a single character or its hex code: strconv.ParseUint: parsing "ZZ": invalid syntax`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseLEEF(tt.input)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, parsed)
+		})
+	}
+}
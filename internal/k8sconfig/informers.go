@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sconfig // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// InformerKey describes a shared informer. Components acquiring an informer with the same key, e.g. the
+// k8sattributes processors of several pipelines, get the same informer, so the watched objects are only
+// listed, watched and cached once per collector.
+type InformerKey struct {
+	// APIConfig is the configuration of the connection to the API server.
+	APIConfig APIConfig
+	// Resource is the watched resource, e.g. pods.
+	Resource schema.GroupVersionResource
+	// Namespace limits the watched objects to a namespace, all namespaces are watched when empty.
+	Namespace string
+	// LabelSelector and FieldSelector limit the watched objects to the ones matching them.
+	LabelSelector string
+	FieldSelector string
+	// Transform names the transformation applied to the objects before they are cached, e.g. to only keep the
+	// fields a component needs. It is empty when the objects are cached as they are received. Components using
+	// the same name must apply the same transformation.
+	Transform string
+}
+
+var sharedInformers = &informerRegistry{informers: map[InformerKey]*sharedInformer{}}
+
+// informerRegistry holds the shared informers which are referenced by at least one component.
+type informerRegistry struct {
+	mu        sync.Mutex
+	informers map[InformerKey]*sharedInformer
+}
+
+type sharedInformer struct {
+	informer cache.SharedInformer
+	refs     int
+	runOnce  sync.Once
+	stopCh   chan struct{}
+}
+
+// SharedInformer is a reference to an informer shared with the other components using the same InformerKey.
+// It is used like the informer itself, except that:
+//   - Run starts the shared informer unless it is already running, and returns once the given channel is closed
+//     or the reference is released, without stopping the shared informer;
+//   - the event handlers added through the reference are removed from the shared informer when it is released.
+//
+// The shared informer is stopped once all references to it are released.
+type SharedInformer struct {
+	cache.SharedInformer
+
+	key    InformerKey
+	shared *sharedInformer
+
+	mu            sync.Mutex
+	registrations []cache.ResourceEventHandlerRegistration
+	released      chan struct{}
+}
+
+// AcquireSharedInformer returns a reference to the informer for the key. The informer is created by newInformer,
+// including its transformation, if no other component references it yet. Every reference must be released with
+// Release once it is no longer used.
+func AcquireSharedInformer(key InformerKey, newInformer func() (cache.SharedInformer, error)) (*SharedInformer, error) {
+	return sharedInformers.acquire(key, newInformer)
+}
+
+func (r *informerRegistry) acquire(key InformerKey, newInformer func() (cache.SharedInformer, error)) (*SharedInformer, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shared, ok := r.informers[key]
+	if !ok {
+		informer, err := newInformer()
+		if err != nil {
+			return nil, err
+		}
+		shared = &sharedInformer{informer: informer, stopCh: make(chan struct{})}
+		r.informers[key] = shared
+	}
+	shared.refs++
+	return &SharedInformer{
+		SharedInformer: shared.informer,
+		key:            key,
+		shared:         shared,
+		released:       make(chan struct{}),
+	}, nil
+}
+
+func (r *informerRegistry) release(key InformerKey, shared *sharedInformer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shared.refs--
+	if shared.refs > 0 {
+		return
+	}
+	// a new informer may have been acquired for the key in the meantime, only forget about this one
+	if r.informers[key] == shared {
+		delete(r.informers, key)
+	}
+	close(shared.stopCh)
+}
+
+// AddEventHandler adds an event handler to the shared informer, which is removed when the reference is released.
+func (i *SharedInformer) AddEventHandler(handler cache.ResourceEventHandler) (cache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.SharedInformer.AddEventHandler(handler)
+	i.track(registration, err)
+	return registration, err
+}
+
+// AddEventHandlerWithResyncPeriod adds an event handler to the shared informer, which is removed when the
+// reference is released.
+func (i *SharedInformer) AddEventHandlerWithResyncPeriod(handler cache.ResourceEventHandler, resyncPeriod time.Duration) (cache.ResourceEventHandlerRegistration, error) {
+	registration, err := i.SharedInformer.AddEventHandlerWithResyncPeriod(handler, resyncPeriod)
+	i.track(registration, err)
+	return registration, err
+}
+
+func (i *SharedInformer) track(registration cache.ResourceEventHandlerRegistration, err error) {
+	if err != nil {
+		return
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.registrations = append(i.registrations, registration)
+}
+
+// Run starts the shared informer unless it is already running, and blocks until stopCh is closed or the reference
+// is released.
+func (i *SharedInformer) Run(stopCh <-chan struct{}) {
+	i.shared.runOnce.Do(func() {
+		go i.shared.informer.Run(i.shared.stopCh)
+	})
+	select {
+	case <-stopCh:
+	case <-i.released:
+	}
+}
+
+// Release removes the event handlers added through the reference, and stops the shared informer if no other
+// reference to it is left. Calling Release more than once has no effect.
+func (i *SharedInformer) Release() {
+	i.mu.Lock()
+	select {
+	case <-i.released:
+		i.mu.Unlock()
+		return
+	default:
+	}
+	close(i.released)
+	registrations := i.registrations
+	i.registrations = nil
+	i.mu.Unlock()
+
+	for _, registration := range registrations {
+		// the handlers can only fail to be removed if the informer is stopped, in which case they don't run anymore
+		_ = i.SharedInformer.RemoveEventHandler(registration)
+	}
+	sharedInformers.release(i.key, i.shared)
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sconfig
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newTestPodInformer(t *testing.T, created *atomic.Int32) (*fake.Clientset, func() (cache.SharedInformer, error)) {
+	client := fake.NewClientset()
+	return client, func() (cache.SharedInformer, error) {
+		created.Add(1)
+		return cache.NewSharedInformer(
+			&cache.ListWatch{
+				ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+					return client.CoreV1().Pods("").List(t.Context(), opts)
+				},
+				WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+					return client.CoreV1().Pods("").Watch(t.Context(), opts)
+				},
+			},
+			&api_v1.Pod{},
+			0,
+		), nil
+	}
+}
+
+func TestSharedInformer(t *testing.T) {
+	// prepare
+	var created atomic.Int32
+	client, newInformer := newTestPodInformer(t, &created)
+	key := InformerKey{
+		APIConfig: APIConfig{AuthType: AuthTypeNone},
+		Resource:  api_v1.SchemeGroupVersion.WithResource("pods"),
+		Transform: t.Name(),
+	}
+
+	first, err := AcquireSharedInformer(key, newInformer)
+	require.NoError(t, err)
+	second, err := AcquireSharedInformer(key, newInformer)
+	require.NoError(t, err)
+	other, err := AcquireSharedInformer(InformerKey{Resource: key.Resource, Transform: t.Name() + "/other"}, newInformer)
+	require.NoError(t, err)
+	defer other.Release()
+
+	// the informer is only created once per key
+	assert.Equal(t, int32(2), created.Load())
+	assert.Same(t, first.shared, second.shared)
+	assert.NotSame(t, first.shared, other.shared)
+
+	var firstAdds, secondAdds atomic.Int32
+	firstReg, err := first.AddEventHandler(cache.ResourceEventHandlerFuncs{AddFunc: func(any) { firstAdds.Add(1) }})
+	require.NoError(t, err)
+	secondReg, err := second.AddEventHandler(cache.ResourceEventHandlerFuncs{AddFunc: func(any) { secondAdds.Add(1) }})
+	require.NoError(t, err)
+
+	firstStop := make(chan struct{})
+	go first.Run(firstStop)
+	go second.Run(make(chan struct{}))
+	require.True(t, cache.WaitForCacheSync(t.Context().Done(), firstReg.HasSynced, secondReg.HasSynced))
+
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), &api_v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-1"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return firstAdds.Load() == 1 && secondAdds.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	// test
+	// stopping the run of a reference does not stop the shared informer, releasing it removes its handlers
+	close(firstStop)
+	first.Release()
+	first.Release()
+	_, err = client.CoreV1().Pods("default").Create(context.Background(), &api_v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod-2"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool { return secondAdds.Load() == 2 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, int32(1), firstAdds.Load())
+	assert.False(t, second.IsStopped())
+
+	// the informer is stopped once the last reference is released, and a new one is created when acquired again
+	second.Release()
+	assert.Eventually(t, second.IsStopped, 5*time.Second, 10*time.Millisecond)
+	third, err := AcquireSharedInformer(key, newInformer)
+	require.NoError(t, err)
+	defer third.Release()
+	assert.Equal(t, int32(3), created.Load())
+	assert.NotSame(t, second.shared, third.shared)
+}
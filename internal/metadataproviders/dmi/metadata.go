@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dmi provides the hardware information read by the Linux kernel from the DMI/SMBIOS tables,
+// see https://www.dmtf.org/standards/smbios.
+package dmi // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultDirectory is the directory the Linux kernel exposes the DMI information in.
+const DefaultDirectory = "/sys/class/dmi/id"
+
+// ErrNotAvailable is returned when the DMI information is not exposed, e.g. on other operating systems.
+var ErrNotAvailable = errors.New("DMI information is not available")
+
+// Provider gets the DMI information of the host.
+type Provider interface {
+	Metadata(context.Context) (*Metadata, error)
+}
+
+// Metadata is the DMI information of the host. Fields are empty when the information is not set
+// by the manufacturer or not readable, as the serial numbers and UUID are only readable by root.
+type Metadata struct {
+	SysVendor      string
+	ProductName    string
+	ProductVersion string
+	ProductSerial  string
+	ProductUUID    string
+	ChassisVendor  string
+	ChassisType    string
+	ChassisSerial  string
+	BIOSVendor     string
+	BIOSVersion    string
+}
+
+type dmiProviderImpl struct {
+	directory string
+}
+
+// NewProvider creates a new DMI provider reading the information from the given directory,
+// usually DefaultDirectory, or the same directory of the host file system mounted in a container.
+func NewProvider(directory string) Provider {
+	return &dmiProviderImpl{directory: directory}
+}
+
+// Metadata reads the DMI information of the host.
+func (p *dmiProviderImpl) Metadata(_ context.Context) (*Metadata, error) {
+	if _, err := os.Stat(p.directory); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotAvailable
+		}
+		return nil, fmt.Errorf("failed to read DMI information: %w", err)
+	}
+
+	return &Metadata{
+		SysVendor:      p.read("sys_vendor"),
+		ProductName:    p.read("product_name"),
+		ProductVersion: p.read("product_version"),
+		ProductSerial:  p.read("product_serial"),
+		ProductUUID:    strings.ToLower(p.read("product_uuid")),
+		ChassisVendor:  p.read("chassis_vendor"),
+		ChassisType:    ChassisTypeName(p.read("chassis_type")),
+		ChassisSerial:  p.read("chassis_serial"),
+		BIOSVendor:     p.read("bios_vendor"),
+		BIOSVersion:    p.read("bios_version"),
+	}, nil
+}
+
+// read returns the value of a DMI field, or an empty string if it is not readable or holds one
+// of the placeholders left by manufacturers.
+func (p *dmiProviderImpl) read(field string) string {
+	content, err := os.ReadFile(filepath.Join(p.directory, field))
+	if err != nil {
+		return ""
+	}
+	value := strings.TrimSpace(string(content))
+	if isPlaceholder(value) {
+		return ""
+	}
+	return value
+}
+
+// placeholders are values commonly found in the DMI tables of hardware whose manufacturer did
+// not fill the information.
+var placeholders = map[string]struct{}{
+	"":                                     {},
+	"0123456789":                           {},
+	"03000200-0400-0500-0006-000700080009": {},
+	"chassis manufacturer":                 {},
+	"chassis serial number":                {},
+	"default string":                       {},
+	"n/a":                                  {},
+	"none":                                 {},
+	"not applicable":                       {},
+	"not available":                        {},
+	"not specified":                        {},
+	"o.e.m.":                               {},
+	"oem":                                  {},
+	"system manufacturer":                  {},
+	"system product name":                  {},
+	"system serial number":                 {},
+	"system version":                       {},
+	"to be filled by o.e.m.":               {},
+	"to be filled by oem":                  {},
+	"type1productconfigid":                 {},
+	"unknown":                              {},
+}
+
+func isPlaceholder(value string) bool {
+	_, ok := placeholders[strings.ToLower(value)]
+	return ok
+}
+
+// chassisTypes are the names of the chassis types, as defined by the SMBIOS specification (DSP0134, 7.4.1).
+var chassisTypes = []string{
+	1:  "Other",
+	2:  "Unknown",
+	3:  "Desktop",
+	4:  "Low Profile Desktop",
+	5:  "Pizza Box",
+	6:  "Mini Tower",
+	7:  "Tower",
+	8:  "Portable",
+	9:  "Laptop",
+	10: "Notebook",
+	11: "Hand Held",
+	12: "Docking Station",
+	13: "All in One",
+	14: "Sub Notebook",
+	15: "Space-saving",
+	16: "Lunch Box",
+	17: "Main Server Chassis",
+	18: "Expansion Chassis",
+	19: "SubChassis",
+	20: "Bus Expansion Chassis",
+	21: "Peripheral Chassis",
+	22: "RAID Chassis",
+	23: "Rack Mount Chassis",
+	24: "Sealed-case PC",
+	25: "Multi-system chassis",
+	26: "Compact PCI",
+	27: "Advanced TCA",
+	28: "Blade",
+	29: "Blade Enclosure",
+	30: "Tablet",
+	31: "Convertible",
+	32: "Detachable",
+	33: "IoT Gateway",
+	34: "Embedded PC",
+	35: "Mini PC",
+	36: "Stick PC",
+}
+
+// ChassisTypeName returns the name of a chassis type, given its SMBIOS code as exposed by the kernel.
+// Unknown codes are returned as is, and the "Unknown" type is returned as an empty string.
+func ChassisTypeName(code string) string {
+	n, err := strconv.Atoi(code)
+	if err != nil || n <= 0 || n >= len(chassisTypes) {
+		return code
+	}
+	if n == 2 {
+		return ""
+	}
+	return chassisTypes[n]
+}
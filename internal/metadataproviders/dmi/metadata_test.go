@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeDMI(t *testing.T, fields map[string]string) string {
+	dir := t.TempDir()
+	for field, value := range fields {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, field), []byte(value+"\n"), 0o600))
+	}
+	return dir
+}
+
+func TestMetadata(t *testing.T) {
+	dir := writeDMI(t, map[string]string{
+		"sys_vendor":      "Dell Inc.",
+		"product_name":    "PowerEdge R640",
+		"product_version": "Not Specified",
+		"product_serial":  "ABC1234",
+		"product_uuid":    "4C4C4544-0042-3510-8052-B4C04F4A4E32",
+		"chassis_vendor":  "Dell Inc.",
+		"chassis_type":    "23",
+		"chassis_serial":  "To Be Filled By O.E.M.",
+		"bios_vendor":     "Dell Inc.",
+		"bios_version":    "2.19.1",
+	})
+
+	meta, err := NewProvider(dir).Metadata(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		SysVendor:     "Dell Inc.",
+		ProductName:   "PowerEdge R640",
+		ProductSerial: "ABC1234",
+		ProductUUID:   "4c4c4544-0042-3510-8052-b4c04f4a4e32",
+		ChassisVendor: "Dell Inc.",
+		ChassisType:   "Rack Mount Chassis",
+		BIOSVendor:    "Dell Inc.",
+		BIOSVersion:   "2.19.1",
+	}, meta)
+}
+
+func TestMetadataUnreadableFields(t *testing.T) {
+	// only the vendor and product are readable by unprivileged users
+	dir := writeDMI(t, map[string]string{
+		"sys_vendor":   "QEMU",
+		"product_name": "Standard PC (i440FX + PIIX, 1996)",
+	})
+
+	meta, err := NewProvider(dir).Metadata(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		SysVendor:   "QEMU",
+		ProductName: "Standard PC (i440FX + PIIX, 1996)",
+	}, meta)
+}
+
+func TestMetadataNotAvailable(t *testing.T) {
+	_, err := NewProvider(filepath.Join(t.TempDir(), "missing")).Metadata(t.Context())
+	assert.ErrorIs(t, err, ErrNotAvailable)
+}
+
+func TestChassisTypeName(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{code: "1", expected: "Other"},
+		{code: "2", expected: ""},
+		{code: "3", expected: "Desktop"},
+		{code: "17", expected: "Main Server Chassis"},
+		{code: "36", expected: "Stick PC"},
+		{code: "37", expected: "37"},
+		{code: "0", expected: "0"},
+		{code: "", expected: ""},
+		{code: "rack", expected: "rack"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ChassisTypeName(tt.code))
+		})
+	}
+}
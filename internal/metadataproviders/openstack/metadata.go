@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// OpenStack metadata service endpoint, see https://docs.openstack.org/nova/latest/user/metadata.html
+	metadataEndpoint = "http://169.254.169.254"
+
+	metadataPath = "/openstack/latest/meta_data.json"
+	// the flavor of the instance is only exposed by the EC2 compatible metadata
+	instanceTypePath = "/latest/meta-data/instance-type"
+)
+
+// Provider gets metadata from the OpenStack metadata service.
+type Provider interface {
+	// Metadata returns the metadata of the instance.
+	Metadata(context.Context) (*Metadata, error)
+	// InstanceType returns the name of the flavor of the instance.
+	InstanceType(context.Context) (string, error)
+}
+
+type openstackProviderImpl struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewProvider creates a new metadata provider querying the metadata service with the given client
+func NewProvider(client *http.Client) Provider {
+	return &openstackProviderImpl{
+		endpoint: metadataEndpoint,
+		client:   client,
+	}
+}
+
+// Metadata is the OpenStack metadata service response format
+type Metadata struct {
+	UUID             string            `json:"uuid"`
+	Name             string            `json:"name"`
+	Hostname         string            `json:"hostname"`
+	AvailabilityZone string            `json:"availability_zone"`
+	ProjectID        string            `json:"project_id"`
+	Meta             map[string]string `json:"meta"`
+}
+
+// Metadata queries the metadata service and parses the metadata of the instance
+func (p *openstackProviderImpl) Metadata(ctx context.Context) (*Metadata, error) {
+	respBody, err := p.get(ctx, metadataPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata *Metadata
+	if err = json.Unmarshal(respBody, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode OpenStack metadata service reply: %w", err)
+	}
+	if metadata == nil || metadata.UUID == "" {
+		return nil, fmt.Errorf("OpenStack metadata service reply has no instance uuid: %s", respBody)
+	}
+	return metadata, nil
+}
+
+// InstanceType queries the EC2 compatible metadata for the flavor of the instance
+func (p *openstackProviderImpl) InstanceType(ctx context.Context) (string, error) {
+	respBody, err := p.get(ctx, instanceTypePath)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(respBody)), nil
+}
+
+func (p *openstackProviderImpl) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query OpenStack metadata service: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("OpenStack metadata service replied with status code: %s", resp.Status)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OpenStack metadata service reply: %w", err)
+	}
+	return respBody, nil
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `{
+  "uuid": "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+  "name": "web-1",
+  "hostname": "web-1.novalocal",
+  "availability_zone": "nova",
+  "project_id": "f7ac731cc11f40efbc03a9f9e1d1d21f",
+  "launch_index": 0,
+  "meta": {"team": "payments", "env": "prod"},
+  "devices": []
+}`
+
+func newTestProvider(t *testing.T, handler http.Handler) Provider {
+	ts := httptest.NewServer(handler)
+	t.Cleanup(ts.Close)
+	return &openstackProviderImpl{
+		endpoint: ts.URL,
+		client:   &http.Client{},
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	client := &http.Client{}
+	provider := NewProvider(client)
+	assert.NotNil(t, provider)
+	assert.Same(t, client, provider.(*openstackProviderImpl).client)
+}
+
+func TestQueryEndpointCorrect(t *testing.T) {
+	provider := newTestProvider(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case metadataPath:
+			_, err := fmt.Fprint(w, testMetadata)
+			assert.NoError(t, err)
+		case instanceTypePath:
+			_, err := fmt.Fprintln(w, "m1.small")
+			assert.NoError(t, err)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	meta, err := provider.Metadata(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, &Metadata{
+		UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		Name:             "web-1",
+		Hostname:         "web-1.novalocal",
+		AvailabilityZone: "nova",
+		ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		Meta:             map[string]string{"team": "payments", "env": "prod"},
+	}, meta)
+
+	instanceType, err := provider.InstanceType(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "m1.small", instanceType)
+}
+
+func TestQueryEndpointFailed(t *testing.T) {
+	provider := newTestProvider(t, http.NotFoundHandler())
+
+	_, err := provider.Metadata(t.Context())
+	assert.ErrorContains(t, err, "OpenStack metadata service replied with status code: 404 Not Found")
+	_, err = provider.InstanceType(t.Context())
+	assert.Error(t, err)
+}
+
+func TestQueryEndpointMalformed(t *testing.T) {
+	provider := newTestProvider(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, "{")
+		assert.NoError(t, err)
+	}))
+
+	_, err := provider.Metadata(t.Context())
+	assert.ErrorContains(t, err, "failed to decode OpenStack metadata service reply")
+}
+
+func TestQueryEndpointNotOpenStack(t *testing.T) {
+	provider := newTestProvider(t, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := fmt.Fprintln(w, `{"instance": "i-1234"}`)
+		assert.NoError(t, err)
+	}))
+
+	_, err := provider.Metadata(t.Context())
+	assert.ErrorContains(t, err, "OpenStack metadata service reply has no instance uuid")
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package vmware provides the guestinfo variables set on VMware virtual machines, read through
+// the RPC tool of VMware Tools or open-vm-tools.
+package vmware // import "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/vmware"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// DefaultRPCToolPath is the RPC tool looked up in the PATH when none is configured.
+const DefaultRPCToolPath = "vmware-rpctool"
+
+// guestInfoPrefix is the prefix of the variables readable by the guest.
+const guestInfoPrefix = "guestinfo."
+
+// ErrGuestInfoNotFound is returned when a guestinfo variable is not set on the virtual machine.
+var ErrGuestInfoNotFound = errors.New("guestinfo variable not found")
+
+// Provider gets the guestinfo variables of the virtual machine.
+type Provider interface {
+	// GuestInfo returns the value of a guestinfo variable, given its key with or without the
+	// "guestinfo." prefix.
+	GuestInfo(ctx context.Context, key string) (string, error)
+}
+
+type vmwareProviderImpl struct {
+	rpcToolPath string
+	run         func(ctx context.Context, name string, args ...string) (stdout, stderr []byte, err error)
+}
+
+// NewProvider creates a new guestinfo provider running the given RPC tool, usually DefaultRPCToolPath.
+func NewProvider(rpcToolPath string) Provider {
+	return &vmwareProviderImpl{
+		rpcToolPath: rpcToolPath,
+		run:         runCommand,
+	}
+}
+
+func runCommand(ctx context.Context, name string, args ...string) (stdout, stderr []byte, err error) {
+	var outBuf, errBuf bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	err = cmd.Run()
+	return outBuf.Bytes(), errBuf.Bytes(), err
+}
+
+// GuestInfo reads a guestinfo variable with the RPC tool
+func (p *vmwareProviderImpl) GuestInfo(ctx context.Context, key string) (string, error) {
+	if !strings.HasPrefix(key, guestInfoPrefix) {
+		key = guestInfoPrefix + key
+	}
+
+	stdout, stderr, err := p.run(ctx, p.rpcToolPath, "info-get "+key)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			// the RPC tool fails with "No value found" when the variable is not set
+			return "", fmt.Errorf("%w: %s", ErrGuestInfoNotFound, key)
+		}
+		return "", fmt.Errorf("failed to read %s with %s: %w: %s", key, p.rpcToolPath, err, bytes.TrimSpace(stderr))
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
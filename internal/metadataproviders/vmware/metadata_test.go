@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vmware
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestInfo(t *testing.T) {
+	var args []string
+	p := &vmwareProviderImpl{
+		rpcToolPath: "/usr/bin/vmware-rpctool",
+		run: func(_ context.Context, name string, arg ...string) ([]byte, []byte, error) {
+			args = append([]string{name}, arg...)
+			return []byte("datacenter-1\n"), nil, nil
+		},
+	}
+
+	value, err := p.GuestInfo(t.Context(), "datacenter")
+	require.NoError(t, err)
+	assert.Equal(t, "datacenter-1", value)
+	assert.Equal(t, []string{"/usr/bin/vmware-rpctool", "info-get guestinfo.datacenter"}, args)
+
+	_, err = p.GuestInfo(t.Context(), "guestinfo.datacenter")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"/usr/bin/vmware-rpctool", "info-get guestinfo.datacenter"}, args)
+}
+
+func TestGuestInfoNotFound(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("relies on the false command")
+	}
+	falsePath, err := exec.LookPath("false")
+	if err != nil {
+		t.Skip("false command not available")
+	}
+
+	_, err = NewProvider(falsePath).GuestInfo(t.Context(), "datacenter")
+	assert.ErrorIs(t, err, ErrGuestInfoNotFound)
+}
+
+func TestGuestInfoRPCToolMissing(t *testing.T) {
+	_, err := NewProvider(filepath.Join(t.TempDir(), "vmware-rpctool")).GuestInfo(t.Context(), "datacenter")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrGuestInfoNotFound)
+}
+
+func TestGuestInfoError(t *testing.T) {
+	p := &vmwareProviderImpl{
+		rpcToolPath: DefaultRPCToolPath,
+		run: func(context.Context, string, ...string) ([]byte, []byte, error) {
+			return nil, []byte("permission denied\n"), errors.New("failed")
+		},
+	}
+
+	_, err := p.GuestInfo(t.Context(), "datacenter")
+	assert.EqualError(t, err, "failed to read guestinfo.datacenter with vmware-rpctool: failed: permission denied")
+}
@@ -289,10 +289,7 @@ func (*priorityContextInferrer) getStatementsHints(statements []string) ([]prior
 			return nil, err
 		}
 		visitor := newGrammarContextInferrerVisitor()
-		parsed.Editor.accept(&visitor)
-		if parsed.WhereClause != nil {
-			parsed.WhereClause.accept(&visitor)
-		}
+		parsed.accept(&visitor)
 		hints = append(hints, visitor)
 	}
 	return hints, nil
@@ -334,6 +331,8 @@ func newGrammarContextInferrerVisitor() priorityContextInferrerHints {
 
 func (*priorityContextInferrerHints) visitMathExprLiteral(*mathExprLiteral) {}
 
+func (*priorityContextInferrerHints) visitLambda(*lambda) {}
+
 func (v *priorityContextInferrerHints) visitEditor(e *editor) {
 	v.functions[e.Function] = struct{}{}
 }
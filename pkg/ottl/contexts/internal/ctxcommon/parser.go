@@ -18,19 +18,12 @@ func NewParser[K any](
 	enumParser ottl.EnumParser,
 	options ...ottl.Option[K],
 ) (ottl.Parser[K], error) {
-	p, err := ottl.NewParser(
+	return ottl.NewParser(
 		functions,
 		pathExpressionParser,
 		telemetrySettings,
-		ottl.WithEnumParser[K](enumParser),
+		append([]ottl.Option[K]{ottl.WithEnumParser[K](enumParser)}, options...)...,
 	)
-	if err != nil {
-		return ottl.Parser[K]{}, err
-	}
-	for _, opt := range options {
-		opt(&p)
-	}
-	return p, nil
 }
 
 func PathExpressionParser[K any](
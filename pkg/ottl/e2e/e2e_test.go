@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -849,6 +850,36 @@ func Test_e2e_converters(t *testing.T) {
 				m.AppendEmpty().SetStr("value2")
 			},
 		},
+		{
+			statement: `set(attributes["test"], ParseCEF("CEF:0|Security|threatmanager|1.0|100|worm stopped|10|src=10.0.0.1 msg=a b"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("version", "0")
+				m.PutStr("device_vendor", "Security")
+				m.PutStr("device_product", "threatmanager")
+				m.PutStr("device_version", "1.0")
+				m.PutStr("device_event_class_id", "100")
+				m.PutStr("name", "worm stopped")
+				m.PutStr("severity", "10")
+				e := m.PutEmptyMap("extensions")
+				e.PutStr("src", "10.0.0.1")
+				e.PutStr("msg", "a b")
+			},
+		},
+		{
+			statement: `set(attributes["test"], ParseLEEF("LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5"))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("test")
+				m.PutStr("version", "2.0")
+				m.PutStr("vendor", "Lancope")
+				m.PutStr("product", "StealthWatch")
+				m.PutStr("product_version", "1.0")
+				m.PutStr("event_id", "41")
+				a := m.PutEmptyMap("attributes")
+				a.PutStr("src", "10.0.1.8")
+				a.PutStr("dst", "10.0.0.5")
+			},
+		},
 		{
 			statement: `set(attributes["test"], ParseKeyValue("k1=v1 k2=v2"))`,
 			want: func(tCtx ottllog.TransformContext) {
@@ -1520,6 +1551,163 @@ func Test_e2e_ottl_statement_sequence(t *testing.T) {
 	}
 }
 
+func Test_e2e_function_definitions(t *testing.T) {
+	definitions := []ottl.FunctionDefinition{
+		{
+			Name:       "redact",
+			Params:     []string{"target"},
+			Statements: []string{`replace_pattern(target, "[0-9]{3}$", "***")`},
+		},
+		{
+			Name:      "IsHealthCheck",
+			Params:    []string{"path"},
+			Condition: `IsMatch(path, "^/health")`,
+		},
+		{
+			Name: "normalize_http",
+			Statements: []string{
+				`set(log.attributes["http.method"], ToUpperCase(log.attributes["http.method"]))`,
+				`redact(log.attributes["total.string"]) where IsHealthCheck(log.attributes["http.path"])`,
+			},
+		},
+	}
+
+	tests := []struct {
+		statement string
+		want      func(tCtx ottllog.TransformContext)
+	}{
+		{
+			statement: `redact(attributes["total.string"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("total.string", "123456***")
+			},
+		},
+		{
+			statement: `set(attributes["health"], true) where IsHealthCheck(attributes["http.path"])`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("health", true)
+			},
+		},
+		{
+			statement: `normalize_http()`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutStr("http.method", "GET")
+				tCtx.GetLogRecord().Attributes().PutStr("total.string", "123456***")
+			},
+		},
+	}
+
+	settings := componenttest.NewNopTelemetrySettings()
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings, ottllog.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottllog.TransformContext](definitions))
+	require.NoError(t, err)
+	pc, err := ottl.NewParserCollection(settings,
+		ottl.WithParserCollectionContext[ottllog.TransformContext, *ottl.Statement[ottllog.TransformContext]](
+			ottllog.ContextName,
+			&parser,
+			ottl.WithStatementConverter(func(_ *ottl.ParserCollection[*ottl.Statement[ottllog.TransformContext]], _ ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottllog.TransformContext]) (*ottl.Statement[ottllog.TransformContext], error) {
+				return parsedStatements[0], nil
+			})))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			statement, err := pc.ParseStatementsWithContext(ottllog.ContextName, ottl.NewStatementsGetter([]string{tt.statement}), true)
+			require.NoError(t, err)
+
+			tCtx := constructLogTransformContext()
+			_, _, err = statement.Execute(t.Context(), tCtx)
+			require.NoError(t, err)
+
+			exTCtx := constructLogTransformContext()
+			tt.want(exTCtx)
+
+			assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+		})
+	}
+}
+
+func Test_e2e_loops_and_lambdas(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      func(tCtx ottllog.TransformContext)
+	}{
+		{
+			statement: `for each k, v in attributes["foo"]: set(v, "redacted") where k == "bar"`,
+			want: func(tCtx ottllog.TransformContext) {
+				m, _ := tCtx.GetLogRecord().Attributes().Get("foo")
+				m.Map().PutStr("bar", "redacted")
+			},
+		},
+		{
+			statement: `for each v in attributes["things"]: delete_key(v, "value")`,
+			want: func(tCtx ottllog.TransformContext) {
+				s, _ := tCtx.GetLogRecord().Attributes().Get("things")
+				s.Slice().At(0).Map().Remove("value")
+				s.Slice().At(1).Map().Remove("value")
+			},
+		},
+		{
+			statement: `set(attributes["names"], Map(attributes["things"], t => t["name"]))`,
+			want: func(tCtx ottllog.TransformContext) {
+				s := tCtx.GetLogRecord().Attributes().PutEmptySlice("names")
+				s.AppendEmpty().SetStr("foo")
+				s.AppendEmpty().SetStr("bar")
+			},
+		},
+		{
+			statement: `set(attributes["big_things"], Filter(attributes["things"], t => t["value"] > 3))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptySlice("big_things").AppendEmpty().SetEmptyMap()
+				m.PutStr("name", "bar")
+				m.PutInt("value", 5)
+			},
+		},
+		{
+			statement: `set(attributes["has_foo"], true) where Any(attributes["things"], t => t["name"] == "foo")`,
+			want: func(tCtx ottllog.TransformContext) {
+				tCtx.GetLogRecord().Attributes().PutBool("has_foo", true)
+			},
+		},
+		{
+			statement: `set(attributes["http"], Filter(attributes, (v, k) => IsMatch(k, "^http\\.")))`,
+			want: func(tCtx ottllog.TransformContext) {
+				m := tCtx.GetLogRecord().Attributes().PutEmptyMap("http")
+				m.PutStr("http.method", "get")
+				m.PutStr("http.path", "/health")
+				m.PutStr("http.url", "http://localhost/health")
+			},
+		},
+	}
+
+	settings := componenttest.NewNopTelemetrySettings()
+	parser, err := ottllog.NewParser(ottlfuncs.StandardFuncs[ottllog.TransformContext](), settings, ottllog.EnablePathContextNames())
+	require.NoError(t, err)
+	pc, err := ottl.NewParserCollection(settings,
+		ottl.WithParserCollectionContext[ottllog.TransformContext, *ottl.Statement[ottllog.TransformContext]](
+			ottllog.ContextName,
+			&parser,
+			ottl.WithStatementConverter(func(_ *ottl.ParserCollection[*ottl.Statement[ottllog.TransformContext]], _ ottl.StatementsGetter, parsedStatements []*ottl.Statement[ottllog.TransformContext]) (*ottl.Statement[ottllog.TransformContext], error) {
+				return parsedStatements[0], nil
+			})))
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			statement, err := pc.ParseStatementsWithContext(ottllog.ContextName, ottl.NewStatementsGetter([]string{tt.statement}), true)
+			require.NoError(t, err)
+
+			tCtx := constructLogTransformContext()
+			_, _, err = statement.Execute(t.Context(), tCtx)
+			require.NoError(t, err)
+
+			exTCtx := constructLogTransformContext()
+			tt.want(exTCtx)
+
+			assert.NoError(t, plogtest.CompareResourceLogs(newResourceLogs(exTCtx), newResourceLogs(tCtx)))
+		})
+	}
+}
+
 func Test_e2e_ottl_value_expressions(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -317,6 +317,9 @@ func (p *Parser[K]) parsePath(ip *basePath[K]) (GetSetter[K], error) {
 func (p *Parser[K]) newFunctionCall(ed editor) (Expr[K], error) {
 	f, ok := p.functions[ed.Function]
 	if !ok {
+		if err, isDefinition := p.functionDefinitionErrs[ed.Function]; isDefinition {
+			return Expr[K]{}, fmt.Errorf("function %q is not available: %w", ed.Function, err)
+		}
 		return Expr[K]{}, fmt.Errorf("undefined function %q", ed.Function)
 	}
 	defaultArgs := f.CreateDefaultArguments()
@@ -405,6 +408,13 @@ func (p *Parser[K]) buildArgs(ed editor, argsVal reflect.Value) error {
 		}
 
 		switch {
+		case strings.HasPrefix(fieldType.Name(), "LambdaGetter"):
+			if arg.Lambda == nil {
+				return fmt.Errorf("invalid argument at position %v: must be a lambda", i)
+			}
+			val, err = p.newLambdaGetter(arg.Lambda)
+		case arg.Lambda != nil:
+			return fmt.Errorf("invalid argument at position %v: lambdas can only be given to lambda parameters", i)
 		case strings.HasPrefix(fieldType.Name(), "FunctionGetter"):
 			var name string
 			switch {
@@ -536,6 +546,9 @@ func (p *Parser[K]) buildSliceArg(argVal value, argType reflect.Type) (any, erro
 }
 
 func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
+	if variable, ok, err := p.newVariableReference(path); ok {
+		return variable, err
+	}
 	np, err := p.newPath(path)
 	if err != nil {
 		return nil, err
@@ -551,6 +564,8 @@ func (p *Parser[K]) buildGetSetterFromPath(path *path) (GetSetter[K], error) {
 func (p *Parser[K]) buildArg(argVal value, argType reflect.Type) (any, error) {
 	name := argType.Name()
 	switch {
+	case strings.HasPrefix(name, "parameterArgument"):
+		return p.newParameterArgument(argVal)
 	case strings.HasPrefix(name, "Setter"),
 		strings.HasPrefix(name, "GetSetter"):
 		if argVal.Literal != nil && argVal.Literal.Path != nil {
@@ -13,6 +13,7 @@ import (
 
 // parsedStatement represents a parsed statement. It is the entry point into the statement DSL.
 type parsedStatement struct {
+	Loop   *loop  `parser:"@@?"`
 	Editor editor `parser:"(@@"`
 	// If converter is matched then return error
 	Converter   *converter         `parser:"|@@)"`
@@ -24,13 +25,52 @@ func (p *parsedStatement) checkForCustomError() error {
 	if p.Converter != nil {
 		validator.add(fmt.Errorf("editor names must start with a lowercase letter but got '%v'", p.Converter.Function))
 	}
+	if p.Loop != nil && p.Loop.Key != nil && *p.Loop.Key == p.Loop.Value {
+		validator.add(fmt.Errorf("loop variables must have different names but got '%v' twice", p.Loop.Value))
+	}
+
+	p.accept(validator)
+	return validator.join()
+}
 
-	p.Editor.accept(validator)
+func (p *parsedStatement) accept(v grammarVisitor) {
+	if p.Loop != nil {
+		p.Loop.Collection.accept(v)
+	}
+	p.Editor.accept(v)
 	if p.WhereClause != nil {
-		p.WhereClause.accept(validator)
+		p.WhereClause.accept(v)
 	}
+}
 
-	return validator.join()
+// markVariables flags the paths referencing loop variables and lambda parameters,
+// so they are not mistaken for telemetry paths.
+func (p *parsedStatement) markVariables() {
+	var names []string
+	if p.Loop != nil {
+		p.Loop.Collection.accept(newGrammarVariablesVisitor(nil))
+		names = p.Loop.variables()
+	}
+	marker := newGrammarVariablesVisitor(names)
+	p.Editor.accept(marker)
+	if p.WhereClause != nil {
+		p.WhereClause.accept(marker)
+	}
+}
+
+// loop represents the iteration of a statement over the elements of a list or map.
+type loop struct {
+	Key        *string `parser:"'for' 'each' ( @Lowercase ',' )?"`
+	Value      string  `parser:"@Lowercase 'in'"`
+	Collection value   `parser:"@@ ':'"`
+}
+
+// variables returns the names of the loop variables, the value first.
+func (l *loop) variables() []string {
+	if l.Key == nil {
+		return []string{l.Value}
+	}
+	return []string{l.Value, *l.Key}
 }
 
 type constExpr struct {
@@ -223,14 +263,60 @@ func (c *converter) accept(v grammarVisitor) {
 
 type argument struct {
 	Name         string  `parser:"(@(Lowercase(Uppercase | Lowercase)*) Equal)?"`
-	Value        value   `parser:"( @@"`
+	Lambda       *lambda `parser:"( @@"`
+	Value        value   `parser:"| @@"`
 	FunctionName *string `parser:"| @(Uppercase(Uppercase | Lowercase)*) )"`
 }
 
 func (a *argument) accept(v grammarVisitor) {
+	if a.Lambda != nil {
+		a.Lambda.accept(v)
+		return
+	}
 	a.Value.accept(v)
 }
 
+// lambda represents an anonymous function given as argument, such as `x => x * 2`.
+type lambda struct {
+	Params []string   `parser:"( @Lowercase | '(' @Lowercase ( ',' @Lowercase )* ')' ) Arrow"`
+	Body   lambdaBody `parser:"@@"`
+}
+
+func (l *lambda) accept(v grammarVisitor) {
+	v.visitLambda(l)
+	l.Body.accept(v)
+}
+
+// lambdaBody is either a boolean expression or a value. Boolean expressions are
+// only matched when they make the whole body, so values such as `x * 2` are not
+// mistaken for the left side of a comparison.
+type lambdaBody struct {
+	Condition *booleanExpression `parser:"( @@ (?= ',' | ')')"`
+	Value     *value             `parser:"| @@ )"`
+}
+
+func (b *lambdaBody) accept(v grammarVisitor) {
+	if b.Condition != nil {
+		b.Condition.accept(v)
+	}
+	if b.Value != nil {
+		b.Value.accept(v)
+	}
+}
+
+// converter returns the converter making the whole body when the body was matched
+// as a boolean expression, so it can be used as a value instead.
+func (b *lambdaBody) converter() *converter {
+	if b.Condition == nil || len(b.Condition.Right) > 0 || len(b.Condition.Left.Right) > 0 {
+		return nil
+	}
+	bv := b.Condition.Left.Left
+	if bv.Negation != nil || bv.ConstExpr == nil {
+		return nil
+	}
+	return bv.ConstExpr.Converter
+}
+
 // value represents a part of a parsed statement which is resolved to a value of some sort. This can be a telemetry path
 // mathExpression, function call, or literal.
 type value struct {
@@ -274,10 +360,15 @@ type path struct {
 	Pos     lexer.Position
 	Context string  `parser:"(@Lowercase '.')?"`
 	Fields  []field `parser:"@@ ( '.' @@ )*"`
+
+	// variable is set when the path references a loop variable or a lambda parameter.
+	variable bool
 }
 
 func (p *path) accept(v grammarVisitor) {
-	v.visitPath(p)
+	if !p.variable {
+		v.visitPath(p)
+	}
 	for _, field := range p.Fields {
 		field.accept(v)
 	}
@@ -507,6 +598,7 @@ func buildLexer() *lexer.StatefulDefinition {
 		{Name: `OpNot`, Pattern: `\b(not)\b`},
 		{Name: `OpOr`, Pattern: `\b(or)\b`},
 		{Name: `OpAnd`, Pattern: `\b(and)\b`},
+		{Name: `Arrow`, Pattern: `=>`},
 		{Name: `OpComparison`, Pattern: `==|!=|>=|<=|>|<`},
 		{Name: `OpAddSub`, Pattern: `\+|\-`},
 		{Name: `OpMultDiv`, Pattern: `\/|\*`},
@@ -559,6 +651,7 @@ type grammarVisitor interface {
 	visitConverter(v *converter)
 	visitValue(v *value)
 	visitMathExprLiteral(v *mathExprLiteral)
+	visitLambda(v *lambda)
 }
 
 // grammarCustomErrorsVisitor is used to execute custom validations on the grammar AST.
@@ -583,6 +676,16 @@ func (*grammarCustomErrorsVisitor) visitValue(*value) {}
 
 func (*grammarCustomErrorsVisitor) visitConverter(*converter) {}
 
+func (g *grammarCustomErrorsVisitor) visitLambda(v *lambda) {
+	seen := make(map[string]struct{}, len(v.Params))
+	for _, param := range v.Params {
+		if _, ok := seen[param]; ok {
+			g.add(fmt.Errorf("lambda parameters must have different names but got '%v' twice", param))
+		}
+		seen[param] = struct{}{}
+	}
+}
+
 func (g *grammarCustomErrorsVisitor) visitEditor(v *editor) {
 	if v.Keys != nil {
 		g.add(fmt.Errorf("only paths and converters may be indexed, not editors, but got %s%s", v.Function, buildOriginalKeysText(v.Keys)))
@@ -594,3 +697,35 @@ func (g *grammarCustomErrorsVisitor) visitMathExprLiteral(v *mathExprLiteral) {
 		g.add(fmt.Errorf("converter names must start with an uppercase letter but got '%v'", v.Editor.Function))
 	}
 }
+
+// grammarVariablesVisitor marks the paths referencing the given variables. Lambdas found
+// while visiting are marked with their own parameters, as they may shadow the variables.
+type grammarVariablesVisitor struct {
+	names map[string]struct{}
+}
+
+func newGrammarVariablesVisitor(names []string) *grammarVariablesVisitor {
+	v := &grammarVariablesVisitor{names: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		v.names[name] = struct{}{}
+	}
+	return v
+}
+
+func (*grammarVariablesVisitor) visitEditor(*editor)                   {}
+func (*grammarVariablesVisitor) visitConverter(*converter)             {}
+func (*grammarVariablesVisitor) visitValue(*value)                     {}
+func (*grammarVariablesVisitor) visitMathExprLiteral(*mathExprLiteral) {}
+
+func (g *grammarVariablesVisitor) visitPath(v *path) {
+	if v.Context != "" || len(v.Fields) != 1 {
+		return
+	}
+	if _, ok := g.names[v.Fields[0].Name]; ok {
+		v.variable = true
+	}
+}
+
+func (*grammarVariablesVisitor) visitLambda(v *lambda) {
+	v.Body.accept(newGrammarVariablesVisitor(v.Params))
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"fmt"
+)
+
+// LambdaGetter is a function argument given as a lambda, such as `x => x * 2` or `(value, key) => IsMatch(key, "^http")`.
+// The body of a lambda is either a value or a boolean expression, and can reference its parameters by name.
+type LambdaGetter[K any] interface {
+	// Get evaluates the body of the lambda, with its parameters bound to the given arguments, in order.
+	// An error is returned if fewer arguments than the lambda parameters are given.
+	Get(ctx context.Context, tCtx K, args ...any) (any, error)
+}
+
+// StandardLambdaGetter is a basic implementation of LambdaGetter
+type StandardLambdaGetter[K any] struct {
+	Getter func(ctx context.Context, tCtx K, args ...any) (any, error)
+}
+
+// Get evaluates the lambda with the given arguments.
+func (g StandardLambdaGetter[K]) Get(ctx context.Context, tCtx K, args ...any) (any, error) {
+	return g.Getter(ctx, tCtx, args...)
+}
+
+type lambdaGetter[K any] struct {
+	scope  *variableScope
+	params int
+	body   Getter[K]
+}
+
+func (l *lambdaGetter[K]) Get(ctx context.Context, tCtx K, args ...any) (any, error) {
+	if len(args) < l.params {
+		return nil, fmt.Errorf("the lambda expects %d arguments but got %d", l.params, len(args))
+	}
+	variables := make([]boundVariable[K], l.params)
+	for i := range variables {
+		variables[i] = boundVariable[K]{ctx: ctx, getSetter: variableValue[K]{value: args[i]}}
+	}
+	return l.body.Get(bindVariables(ctx, l.scope, variables), tCtx)
+}
+
+func (p *Parser[K]) newLambdaGetter(l *lambda) (LambdaGetter[K], error) {
+	bodyParser := p.withVariables(l.Params)
+
+	var body Getter[K]
+	var err error
+	switch {
+	case l.Body.converter() != nil:
+		body, err = bodyParser.newGetterFromConverter(*l.Body.converter())
+	case l.Body.Condition != nil:
+		var condition BoolExpr[K]
+		condition, err = bodyParser.newBoolExpr(l.Body.Condition)
+		body = &StandardGetSetter[K]{
+			Getter: func(ctx context.Context, tCtx K) (any, error) {
+				return condition.Eval(ctx, tCtx)
+			},
+		}
+	default:
+		body, err = bodyParser.newGetter(*l.Body.Value)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &lambdaGetter[K]{
+		scope:  bodyParser.scope,
+		params: len(l.Params),
+		body:   body,
+	}, nil
+}
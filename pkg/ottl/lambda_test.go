@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type lambdaTestApplyArguments struct {
+	Target Getter[map[string]any]
+	Fn     LambdaGetter[map[string]any]
+}
+
+type lambdaTestValueArguments struct {
+	Value Getter[map[string]any]
+}
+
+// lambdaTestFunctions returns the set editor, an Apply converter calling its lambda with the
+// elements of a list and their index, and a Value converter returning its argument.
+func lambdaTestFunctions() map[string]Factory[map[string]any] {
+	functions := userFunctionsTestFunctions()
+	functions["Apply"] = NewFactory("Apply", &lambdaTestApplyArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[map[string]any], error) {
+		a := args.(*lambdaTestApplyArguments)
+		return func(ctx context.Context, tCtx map[string]any) (any, error) {
+			val, err := a.Target.Get(ctx, tCtx)
+			if err != nil {
+				return nil, err
+			}
+			list := val.([]any)
+			result := make([]any, len(list))
+			for i, elem := range list {
+				if result[i], err = a.Fn.Get(ctx, tCtx, elem, int64(i)); err != nil {
+					return nil, err
+				}
+			}
+			return result, nil
+		}, nil
+	})
+	functions["Value"] = NewFactory("Value", &lambdaTestValueArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[map[string]any], error) {
+		return args.(*lambdaTestValueArguments).Value.Get, nil
+	})
+	return functions
+}
+
+func Test_Lambda(t *testing.T) {
+	p, err := NewParser(lambdaTestFunctions(), userFunctionsTestPath, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		statement string
+		want      any
+	}{
+		{
+			name:      "value",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => x * 2))`,
+			want:      []any{int64(2), int64(4), int64(6)},
+		},
+		{
+			name:      "condition",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => x > 1 and x < 3))`,
+			want:      []any{false, true, false},
+		},
+		{
+			name:      "converter",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => Value(x)))`,
+			want:      []any{int64(1), int64(2), int64(3)},
+		},
+		{
+			name:      "multiple parameters",
+			statement: `set(attributes["result"], Apply(attributes["list"], (x, i) => x + i))`,
+			want:      []any{int64(1), int64(3), int64(5)},
+		},
+		{
+			name:      "paths",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => x + attributes["offset"]))`,
+			want:      []any{int64(11), int64(12), int64(13)},
+		},
+		{
+			name:      "unused parameters",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => "constant"))`,
+			want:      []any{"constant", "constant", "constant"},
+		},
+		{
+			name:      "indexed parameter",
+			statement: `set(attributes["result"], Apply(attributes["maps"], m => m["name"]))`,
+			want:      []any{"a", "b"},
+		},
+		{
+			name:      "nested lambdas",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => Apply(attributes["list"], y => x * y)))`,
+			want: []any{
+				[]any{int64(1), int64(2), int64(3)},
+				[]any{int64(2), int64(4), int64(6)},
+				[]any{int64(3), int64(6), int64(9)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			tCtx := map[string]any{
+				"list":   []any{int64(1), int64(2), int64(3)},
+				"maps":   []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}},
+				"offset": int64(10),
+			}
+			_, _, err = statement.Execute(t.Context(), tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tCtx["result"])
+		})
+	}
+}
+
+func Test_Lambda_invalid(t *testing.T) {
+	p, err := NewParser(lambdaTestFunctions(), userFunctionsTestPath, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "lambda given to another parameter",
+			statement: `set(attributes["result"], x => x)`,
+			wantErr:   "lambdas can only be given to lambda parameters",
+		},
+		{
+			name:      "lambda parameter without a lambda",
+			statement: `set(attributes["result"], Apply(attributes["list"], attributes["list"]))`,
+			wantErr:   "must be a lambda",
+		},
+		{
+			name:      "duplicate parameters",
+			statement: `set(attributes["result"], Apply(attributes["list"], (x, x) => x))`,
+			wantErr:   "lambda parameters must have different names but got 'x' twice",
+		},
+		{
+			name:      "parameter used outside of the lambda",
+			statement: `set(attributes["result"], Apply(attributes["list"], x => x)) where x == 1`,
+			wantErr:   `invalid path "x"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseStatement(tt.statement)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
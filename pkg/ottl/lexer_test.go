@@ -46,6 +46,13 @@ func Test_lexer(t *testing.T) {
 			{"OpComparison", "!="},
 			{"Float", "4.9"},
 		}},
+		{"lambda_arrow", "x => x >= 4", false, []result{
+			{"Lowercase", "x"},
+			{"Arrow", "=>"},
+			{"Lowercase", "x"},
+			{"OpComparison", ">="},
+			{"Int", "4"},
+		}},
 		{"unambiguous_names", "foo bar BAZZ", false, []result{
 			{"Lowercase", "foo"},
 			{"Lowercase", "bar"},
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+// statementLoop executes a statement for each element of a list or map.
+// The value of the element is bound to the first loop variable, and can be set to
+// modify the element. Its index, or key for maps, is bound to the optional second
+// loop variable.
+type statementLoop[K any] struct {
+	collection Getter[K]
+	scope      *variableScope
+	hasKey     bool
+}
+
+// newStatementLoop returns the statementLoop and the Parser the statement body must be compiled with,
+// so it can reference the loop variables.
+func (p *Parser[K]) newStatementLoop(l *loop) (*statementLoop[K], *Parser[K], error) {
+	collection, err := p.newGetter(l.Collection)
+	if err != nil {
+		return nil, nil, err
+	}
+	bodyParser := p.withVariables(l.variables())
+	return &statementLoop[K]{
+		collection: collection,
+		scope:      bodyParser.scope,
+		hasKey:     l.Key != nil,
+	}, bodyParser, nil
+}
+
+func (l *statementLoop[K]) execute(ctx context.Context, tCtx K, body func(context.Context, K) (any, bool, error)) (any, bool, error) {
+	val, err := l.collection.Get(ctx, tCtx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var result any
+	var matched bool
+	each := func(value GetSetter[K], key any) error {
+		variables := []boundVariable[K]{{ctx: ctx, getSetter: value}}
+		if l.hasKey {
+			variables = append(variables, boundVariable[K]{ctx: ctx, getSetter: variableValue[K]{value: key}})
+		}
+		r, m, err := body(bindVariables(ctx, l.scope, variables), tCtx)
+		if err != nil {
+			return fmt.Errorf("failed at element %v: %w", key, err)
+		}
+		if m {
+			result = r
+			matched = true
+		}
+		return nil
+	}
+
+	switch c := val.(type) {
+	case nil:
+		// Missing lists and maps have no elements to loop over.
+	case pcommon.Slice:
+		for i := 0; i < c.Len(); i++ {
+			if err = each(pcommonSliceElement[K](c, i), int64(i)); err != nil {
+				return nil, matched, err
+			}
+		}
+	case []any:
+		for i := range c {
+			if err = each(sliceElement[K](c, i), int64(i)); err != nil {
+				return nil, matched, err
+			}
+		}
+	case pcommon.Map:
+		// Keys are collected first, so the statement can add or remove entries.
+		keys := make([]string, 0, c.Len())
+		for k := range c.All() {
+			keys = append(keys, k)
+		}
+		for _, k := range keys {
+			if _, ok := c.Get(k); !ok {
+				continue
+			}
+			if err = each(pcommonMapEntry[K](c, k), k); err != nil {
+				return nil, matched, err
+			}
+		}
+	case map[string]any:
+		for _, k := range slices.Sorted(maps.Keys(c)) {
+			if err = each(mapEntry[K](c, k), k); err != nil {
+				return nil, matched, err
+			}
+		}
+	default:
+		return nil, false, fmt.Errorf("cannot loop over a value of type %T, it must be a list or a map", val)
+	}
+	return result, matched, nil
+}
+
+func pcommonSliceElement[K any](s pcommon.Slice, i int) GetSetter[K] {
+	return &StandardGetSetter[K]{
+		Getter: func(context.Context, K) (any, error) {
+			if i >= s.Len() {
+				return nil, nil
+			}
+			return ottlcommon.GetValue(s.At(i)), nil
+		},
+		Setter: func(_ context.Context, _ K, val any) error {
+			if i >= s.Len() {
+				return fmt.Errorf("index %d out of bounds", i)
+			}
+			return ottlcommon.SetValue(s.At(i), val)
+		},
+	}
+}
+
+func sliceElement[K any](s []any, i int) GetSetter[K] {
+	return &StandardGetSetter[K]{
+		Getter: func(context.Context, K) (any, error) {
+			return s[i], nil
+		},
+		Setter: func(_ context.Context, _ K, val any) error {
+			s[i] = val
+			return nil
+		},
+	}
+}
+
+func pcommonMapEntry[K any](m pcommon.Map, key string) GetSetter[K] {
+	return &StandardGetSetter[K]{
+		Getter: func(context.Context, K) (any, error) {
+			v, ok := m.Get(key)
+			if !ok {
+				return nil, nil
+			}
+			return ottlcommon.GetValue(v), nil
+		},
+		Setter: func(_ context.Context, _ K, val any) error {
+			v, ok := m.Get(key)
+			if !ok {
+				v = m.PutEmpty(key)
+			}
+			return ottlcommon.SetValue(v, val)
+		},
+	}
+}
+
+func mapEntry[K any](m map[string]any, key string) GetSetter[K] {
+	return &StandardGetSetter[K]{
+		Getter: func(context.Context, K) (any, error) {
+			return m[key], nil
+		},
+		Setter: func(_ context.Context, _ K, val any) error {
+			m[key] = val
+			return nil
+		},
+	}
+}
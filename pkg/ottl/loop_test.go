@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func Test_StatementLoop(t *testing.T) {
+	p := newUserFunctionsTestParser(t, nil)
+
+	tests := []struct {
+		name        string
+		statement   string
+		tCtx        map[string]any
+		want        map[string]any
+		wantMatched bool
+	}{
+		{
+			name:        "set list elements",
+			statement:   `for each v in attributes["list"]: set(v, "x") where v == "a"`,
+			tCtx:        map[string]any{"list": []any{"a", "b", "a"}},
+			want:        map[string]any{"list": []any{"x", "b", "x"}},
+			wantMatched: true,
+		},
+		{
+			name:      "no element matched",
+			statement: `for each v in attributes["list"]: set(v, "x") where v == "c"`,
+			tCtx:      map[string]any{"list": []any{"a", "b"}},
+			want:      map[string]any{"list": []any{"a", "b"}},
+		},
+		{
+			name:        "list index",
+			statement:   `for each i, v in attributes["list"]: set(attributes["last"], i) where v == "b"`,
+			tCtx:        map[string]any{"list": []any{"a", "b", "c"}},
+			want:        map[string]any{"list": []any{"a", "b", "c"}, "last": int64(1)},
+			wantMatched: true,
+		},
+		{
+			name:        "map keys",
+			statement:   `for each k, v in attributes["map"]: set(v, k)`,
+			tCtx:        map[string]any{"map": map[string]any{"a": int64(1), "b": int64(2)}},
+			want:        map[string]any{"map": map[string]any{"a": "a", "b": "b"}},
+			wantMatched: true,
+		},
+		{
+			name:        "map iteration is ordered",
+			statement:   `for each k, v in attributes["map"]: set(attributes["last"], k)`,
+			tCtx:        map[string]any{"map": map[string]any{"c": "", "a": "", "b": ""}},
+			want:        map[string]any{"map": map[string]any{"c": "", "a": "", "b": ""}, "last": "c"},
+			wantMatched: true,
+		},
+		{
+			name:      "missing collection",
+			statement: `for each v in attributes["list"]: set(v, "x")`,
+			tCtx:      map[string]any{},
+			want:      map[string]any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			_, matched, err := statement.Execute(t.Context(), tt.tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMatched, matched)
+			assert.Equal(t, tt.want, tt.tCtx)
+		})
+	}
+}
+
+func Test_StatementLoop_pdata(t *testing.T) {
+	p := newUserFunctionsTestParser(t, nil)
+
+	list := pcommon.NewSlice()
+	list.AppendEmpty().SetStr("a")
+	list.AppendEmpty().SetInt(1)
+	m := pcommon.NewMap()
+	m.PutStr("a", "b")
+	m.PutEmptySlice("c").AppendEmpty().SetStr("d")
+
+	statement, err := p.ParseStatement(`for each v in attributes["list"]: set(v, "x")`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(t.Context(), map[string]any{"list": list})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"x", "x"}, list.AsRaw())
+
+	statement, err = p.ParseStatement(`for each k, v in attributes["map"]: set(v, k)`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(t.Context(), map[string]any{"map": m})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "a", "c": "c"}, m.AsRaw())
+}
+
+func Test_StatementLoop_errors(t *testing.T) {
+	p := newUserFunctionsTestParser(t, nil)
+
+	tests := []struct {
+		name      string
+		statement string
+		tCtx      map[string]any
+		wantErr   string
+	}{
+		{
+			name:      "not a collection",
+			statement: `for each v in attributes["list"]: set(v, "x")`,
+			tCtx:      map[string]any{"list": "a"},
+			wantErr:   "cannot loop over a value of type string, it must be a list or a map",
+		},
+		{
+			name:      "key cannot be set",
+			statement: `for each i, v in attributes["list"]: set(i, 1)`,
+			tCtx:      map[string]any{"list": []any{"a"}},
+			wantErr:   `failed at element 0: cannot set variable "i": the variable is read-only`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+			_, _, err = statement.Execute(t.Context(), tt.tCtx)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_StatementLoop_invalid(t *testing.T) {
+	p := newUserFunctionsTestParser(t, nil)
+
+	tests := []struct {
+		name      string
+		statement string
+		wantErr   string
+	}{
+		{
+			name:      "duplicate variables",
+			statement: `for each v, v in attributes["list"]: set(v, "x")`,
+			wantErr:   "loop variables must have different names but got 'v' twice",
+		},
+		{
+			name:      "nested loops",
+			statement: `for each v in attributes["a"]: for each w in attributes["b"]: set(w, v)`,
+			wantErr:   "statement has invalid syntax",
+		},
+		{
+			name:      "variable used outside of the loop",
+			statement: `for each v in v: set(attributes["a"], 1)`,
+			wantErr:   `invalid path "v"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseStatement(tt.statement)
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type AnyArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.LambdaGetter[K]
+}
+
+func NewAnyFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Any", &AnyArguments[K]{}, createAnyFunction[K])
+}
+
+func createAnyFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*AnyArguments[K])
+
+	if !ok {
+		return nil, errors.New("AnyFactory args must be of type *AnyArguments[K]")
+	}
+
+	return anyElement(args.Target, args.Predicate), nil
+}
+
+func anyElement[K any](target ottl.Getter[K], predicate ottl.LambdaGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		collection, err := lambdaTarget(val)
+		if err != nil {
+			return nil, err
+		}
+
+		switch c := collection.(type) {
+		case pcommon.Slice:
+			for i, v := range c.All() {
+				match, err := evaluatePredicate(ctx, tCtx, predicate, ottlcommon.GetValue(v), int64(i))
+				if err != nil || match {
+					return match, err
+				}
+			}
+		default:
+			for k, v := range c.(pcommon.Map).All() {
+				match, err := evaluatePredicate(ctx, tCtx, predicate, ottlcommon.GetValue(v), k)
+				if err != nil || match {
+					return match, err
+				}
+			}
+		}
+		return false, nil
+	}
+}
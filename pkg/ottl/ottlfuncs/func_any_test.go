@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Any(t *testing.T) {
+	isB := ottl.StandardLambdaGetter[any]{
+		Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+			return args[0] == "b", nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		target   any
+		expected bool
+	}{
+		{
+			name:     "match in list",
+			target:   []any{"a", "b"},
+			expected: true,
+		},
+		{
+			name:     "no match in list",
+			target:   []any{"a", "c"},
+			expected: false,
+		},
+		{
+			name:     "empty list",
+			target:   []any{},
+			expected: false,
+		},
+		{
+			name:     "match in map",
+			target:   map[string]any{"x": "a", "y": "b"},
+			expected: true,
+		},
+		{
+			name:     "no match in map",
+			target:   map[string]any{"b": "a"},
+			expected: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := anyElement[any](target, isB)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func Test_Any_Error(t *testing.T) {
+	target := ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}
+	predicate := ottl.StandardLambdaGetter[any]{
+		Getter: func(context.Context, any, ...any) (any, error) {
+			return int64(1), nil
+		},
+	}
+	exprFunc := anyElement[any](target, predicate)
+	_, err := exprFunc(t.Context(), nil)
+	assert.ErrorContains(t, err, "the predicate must return a bool but got int64")
+}
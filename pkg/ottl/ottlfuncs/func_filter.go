@@ -0,0 +1,117 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type FilterArguments[K any] struct {
+	Target    ottl.Getter[K]
+	Predicate ottl.LambdaGetter[K]
+}
+
+func NewFilterFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Filter", &FilterArguments[K]{}, createFilterFunction[K])
+}
+
+func createFilterFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*FilterArguments[K])
+
+	if !ok {
+		return nil, errors.New("FilterFactory args must be of type *FilterArguments[K]")
+	}
+
+	return filter(args.Target, args.Predicate), nil
+}
+
+func filter[K any](target ottl.Getter[K], predicate ottl.LambdaGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		collection, err := lambdaTarget(val)
+		if err != nil {
+			return nil, err
+		}
+
+		switch c := collection.(type) {
+		case pcommon.Slice:
+			output := pcommon.NewSlice()
+			for i, v := range c.All() {
+				keep, err := evaluatePredicate(ctx, tCtx, predicate, ottlcommon.GetValue(v), int64(i))
+				if err != nil {
+					return nil, err
+				}
+				if keep {
+					v.CopyTo(output.AppendEmpty())
+				}
+			}
+			return output, nil
+		default:
+			m := c.(pcommon.Map)
+			output := pcommon.NewMap()
+			for k, v := range m.All() {
+				keep, err := evaluatePredicate(ctx, tCtx, predicate, ottlcommon.GetValue(v), k)
+				if err != nil {
+					return nil, err
+				}
+				if keep {
+					v.CopyTo(output.PutEmpty(k))
+				}
+			}
+			return output, nil
+		}
+	}
+}
+
+// lambdaTarget converts the target of the functions applying a lambda to each element
+// of a list or map, to a pcommon.Slice or a pcommon.Map.
+func lambdaTarget(val any) (any, error) {
+	switch v := val.(type) {
+	case pcommon.Slice, pcommon.Map:
+		return v, nil
+	case []any:
+		s := pcommon.NewSlice()
+		if err := s.FromRaw(v); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case map[string]any:
+		m := pcommon.NewMap()
+		if err := m.FromRaw(v); err != nil {
+			return nil, err
+		}
+		return m, nil
+	case pcommon.Value:
+		switch v.Type() {
+		case pcommon.ValueTypeSlice:
+			return v.Slice(), nil
+		case pcommon.ValueTypeMap:
+			return v.Map(), nil
+		}
+	}
+	return nil, fmt.Errorf("expected a list or a map but got %T", val)
+}
+
+// evaluatePredicate evaluates a lambda that must return a boolean.
+func evaluatePredicate[K any](ctx context.Context, tCtx K, predicate ottl.LambdaGetter[K], args ...any) (bool, error) {
+	result, err := predicate.Get(ctx, tCtx, args...)
+	if err != nil {
+		return false, err
+	}
+	b, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("the predicate must return a bool but got %T", result)
+	}
+	return b, nil
+}
@@ -0,0 +1,146 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Filter(t *testing.T) {
+	hasPrefixA := ottl.StandardLambdaGetter[any]{
+		Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+			s, ok := args[0].(string)
+			return ok && strings.HasPrefix(s, "a"), nil
+		},
+	}
+	oddIndex := ottl.StandardLambdaGetter[any]{
+		Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+			return args[1].(int64)%2 == 1, nil
+		},
+	}
+	keyHasPrefixB := ottl.StandardLambdaGetter[any]{
+		Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+			return strings.HasPrefix(args[1].(string), "b"), nil
+		},
+	}
+
+	tests := []struct {
+		name      string
+		target    any
+		predicate ottl.LambdaGetter[any]
+		expected  any
+	}{
+		{
+			name:      "list",
+			target:    []any{"a1", "b", "a2", int64(1)},
+			predicate: hasPrefixA,
+			expected:  []any{"a1", "a2"},
+		},
+		{
+			name: "pcommon slice",
+			target: func() pcommon.Slice {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetStr("b")
+				s.AppendEmpty().SetStr("a")
+				return s
+			}(),
+			predicate: hasPrefixA,
+			expected:  []any{"a"},
+		},
+		{
+			name:      "using the index",
+			target:    []any{"a", "b", "c", "d"},
+			predicate: oddIndex,
+			expected:  []any{"b", "d"},
+		},
+		{
+			name:      "map",
+			target:    map[string]any{"a": "a1", "b": "b1", "c": "a2"},
+			predicate: hasPrefixA,
+			expected:  map[string]any{"a": "a1", "c": "a2"},
+		},
+		{
+			name:      "using the key",
+			target:    map[string]any{"a": int64(1), "b": int64(2), "bb": int64(3)},
+			predicate: keyHasPrefixB,
+			expected:  map[string]any{"b": int64(2), "bb": int64(3)},
+		},
+		{
+			name:      "empty list",
+			target:    []any{},
+			predicate: hasPrefixA,
+			expected:  []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := filter[any](target, tt.predicate)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				assert.Fail(t, "unexpected result type", "%T", result)
+			}
+		})
+	}
+}
+
+func Test_Filter_Error(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    any
+		predicate ottl.LambdaGetter[any]
+		expected  string
+	}{
+		{
+			name:   "invalid target",
+			target: "not a list",
+			predicate: ottl.StandardLambdaGetter[any]{
+				Getter: func(context.Context, any, ...any) (any, error) {
+					return true, nil
+				},
+			},
+			expected: "expected a list or a map but got string",
+		},
+		{
+			name:   "predicate not returning a bool",
+			target: []any{"a"},
+			predicate: ottl.StandardLambdaGetter[any]{
+				Getter: func(context.Context, any, ...any) (any, error) {
+					return "true", nil
+				},
+			},
+			expected: "the predicate must return a bool but got string",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := filter[any](target, tt.predicate)
+			_, err := exprFunc(t.Context(), nil)
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
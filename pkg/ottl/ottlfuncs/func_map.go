@@ -0,0 +1,76 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/internal/ottlcommon"
+)
+
+type MapArguments[K any] struct {
+	Target ottl.Getter[K]
+	Mapper ottl.LambdaGetter[K]
+}
+
+func NewMapFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("Map", &MapArguments[K]{}, createMapFunction[K])
+}
+
+func createMapFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*MapArguments[K])
+
+	if !ok {
+		return nil, errors.New("MapFactory args must be of type *MapArguments[K]")
+	}
+
+	return mapElements(args.Target, args.Mapper), nil
+}
+
+func mapElements[K any](target ottl.Getter[K], mapper ottl.LambdaGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		val, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+		collection, err := lambdaTarget(val)
+		if err != nil {
+			return nil, err
+		}
+
+		switch c := collection.(type) {
+		case pcommon.Slice:
+			output := pcommon.NewSlice()
+			output.EnsureCapacity(c.Len())
+			for i, v := range c.All() {
+				result, err := mapper.Get(ctx, tCtx, ottlcommon.GetValue(v), int64(i))
+				if err != nil {
+					return nil, err
+				}
+				if err = ottlcommon.SetValue(output.AppendEmpty(), result); err != nil {
+					return nil, err
+				}
+			}
+			return output, nil
+		default:
+			m := c.(pcommon.Map)
+			output := pcommon.NewMap()
+			output.EnsureCapacity(m.Len())
+			for k, v := range m.All() {
+				result, err := mapper.Get(ctx, tCtx, ottlcommon.GetValue(v), k)
+				if err != nil {
+					return nil, err
+				}
+				if err = ottlcommon.SetValue(output.PutEmpty(k), result); err != nil {
+					return nil, err
+				}
+			}
+			return output, nil
+		}
+	}
+}
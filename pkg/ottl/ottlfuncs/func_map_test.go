@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_Map(t *testing.T) {
+	toUpper := ottl.StandardLambdaGetter[any]{
+		Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+			return strings.ToUpper(args[0].(string)), nil
+		},
+	}
+
+	tests := []struct {
+		name     string
+		target   any
+		mapper   ottl.LambdaGetter[any]
+		expected any
+	}{
+		{
+			name:     "list",
+			target:   []any{"a", "b"},
+			mapper:   toUpper,
+			expected: []any{"A", "B"},
+		},
+		{
+			name:   "using the index",
+			target: []any{"a", "b"},
+			mapper: ottl.StandardLambdaGetter[any]{
+				Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+					return args[1], nil
+				},
+			},
+			expected: []any{int64(0), int64(1)},
+		},
+		{
+			name: "pcommon slice",
+			target: func() pcommon.Slice {
+				s := pcommon.NewSlice()
+				s.AppendEmpty().SetStr("a")
+				return s
+			}(),
+			mapper:   toUpper,
+			expected: []any{"A"},
+		},
+		{
+			name:     "map",
+			target:   map[string]any{"x": "a", "y": "b"},
+			mapper:   toUpper,
+			expected: map[string]any{"x": "A", "y": "B"},
+		},
+		{
+			name:   "map to other types",
+			target: []any{"a", "bb"},
+			mapper: ottl.StandardLambdaGetter[any]{
+				Getter: func(_ context.Context, _ any, args ...any) (any, error) {
+					return map[string]any{"length": int64(len(args[0].(string)))}, nil
+				},
+			},
+			expected: []any{map[string]any{"length": int64(1)}, map[string]any{"length": int64(2)}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardGetSetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := mapElements[any](target, tt.mapper)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			switch r := result.(type) {
+			case pcommon.Slice:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			case pcommon.Map:
+				assert.Equal(t, tt.expected, r.AsRaw())
+			default:
+				assert.Fail(t, "unexpected result type", "%T", result)
+			}
+		})
+	}
+}
+
+func Test_Map_Error(t *testing.T) {
+	target := ottl.StandardGetSetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return []any{"a"}, nil
+		},
+	}
+	mapper := ottl.StandardLambdaGetter[any]{
+		Getter: func(context.Context, any, ...any) (any, error) {
+			return nil, errors.New("mapper error")
+		},
+	}
+	exprFunc := mapElements[any](target, mapper)
+	_, err := exprFunc(t.Context(), nil)
+	assert.ErrorContains(t, err, "mapper error")
+}
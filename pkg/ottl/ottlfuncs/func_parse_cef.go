@@ -0,0 +1,52 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/parseutils"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+type ParseCEFArguments[K any] struct {
+	Target ottl.StringGetter[K]
+}
+
+func NewParseCEFFactory[K any]() ottl.Factory[K] {
+	return ottl.NewFactory("ParseCEF", &ParseCEFArguments[K]{}, createParseCEFFunction[K])
+}
+
+func createParseCEFFunction[K any](_ ottl.FunctionContext, oArgs ottl.Arguments) (ottl.ExprFunc[K], error) {
+	args, ok := oArgs.(*ParseCEFArguments[K])
+
+	if !ok {
+		return nil, errors.New("ParseCEFFactory args must be of type *ParseCEFArguments[K]")
+	}
+
+	return parseCEF(args.Target), nil
+}
+
+// parseCEF returns a `pcommon.Map` that is the result of parsing the target string as an ArcSight Common Event Format (CEF) message
+func parseCEF[K any](target ottl.StringGetter[K]) ottl.ExprFunc[K] {
+	return func(ctx context.Context, tCtx K) (any, error) {
+		source, err := target.Get(ctx, tCtx)
+		if err != nil {
+			return nil, err
+		}
+
+		parsed, err := parseutils.ParseCEF(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CEF: %w", err)
+		}
+
+		result := pcommon.NewMap()
+		err = result.FromRaw(parsed)
+		return result, err
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseCEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "simple",
+			target: "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 spt=1232",
+			expected: map[string]any{
+				"version":               "0",
+				"device_vendor":         "Security",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "worm successfully stopped",
+				"severity":              "10",
+				"extensions": map[string]any{
+					"src": "10.0.0.1",
+					"dst": "2.1.2.2",
+					"spt": "1232",
+				},
+			},
+		},
+		{
+			name:   "escaped values with spaces",
+			target: `CEF:0|Sec\|urity|threatmanager|1.0|100|stopped|High|msg=Detected a threat\=worm cs1Label=Rule Name`,
+			expected: map[string]any{
+				"version":               "0",
+				"device_vendor":         "Sec|urity",
+				"device_product":        "threatmanager",
+				"device_version":        "1.0",
+				"device_event_class_id": "100",
+				"name":                  "stopped",
+				"severity":              "High",
+				"extensions": map[string]any{
+					"msg":      "Detected a threat=worm",
+					"cs1Label": "Rule Name",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseCEF[any](target)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			require.IsType(t, pcommon.Map{}, result)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_parseCEF_error(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "CEF:0|Security|threatmanager", nil
+		},
+	}
+	exprFunc := parseCEF[any](target)
+	_, err := exprFunc(t.Context(), nil)
+	assert.EqualError(t, err, "failed to parse CEF: expected 7 header fields separated by '|' but got 2")
+}
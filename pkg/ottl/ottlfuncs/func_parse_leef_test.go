@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottlfuncs
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+)
+
+func Test_parseLEEF(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected map[string]any
+	}{
+		{
+			name:   "version 1.0",
+			target: "LEEF:1.0|Microsoft|MSExchange|4.0 SP1|15345|src=192.0.2.0\tdst=172.50.123.1\tusrName=Joe Smith",
+			expected: map[string]any{
+				"version":         "1.0",
+				"vendor":          "Microsoft",
+				"product":         "MSExchange",
+				"product_version": "4.0 SP1",
+				"event_id":        "15345",
+				"attributes": map[string]any{
+					"src":     "192.0.2.0",
+					"dst":     "172.50.123.1",
+					"usrName": "Joe Smith",
+				},
+			},
+		},
+		{
+			name:   "version 2.0 with delimiter",
+			target: "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5",
+			expected: map[string]any{
+				"version":         "2.0",
+				"vendor":          "Lancope",
+				"product":         "StealthWatch",
+				"product_version": "1.0",
+				"event_id":        "41",
+				"attributes": map[string]any{
+					"src": "10.0.1.8",
+					"dst": "10.0.0.5",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := ottl.StandardStringGetter[any]{
+				Getter: func(context.Context, any) (any, error) {
+					return tt.target, nil
+				},
+			}
+			exprFunc := parseLEEF[any](target)
+			result, err := exprFunc(t.Context(), nil)
+			require.NoError(t, err)
+			require.IsType(t, pcommon.Map{}, result)
+			assert.Equal(t, tt.expected, result.(pcommon.Map).AsRaw())
+		})
+	}
+}
+
+func Test_parseLEEF_error(t *testing.T) {
+	target := ottl.StandardStringGetter[any]{
+		Getter: func(context.Context, any) (any, error) {
+			return "not a LEEF message", nil
+		},
+	}
+	exprFunc := parseLEEF[any](target)
+	_, err := exprFunc(t.Context(), nil)
+	assert.EqualError(t, err, `failed to parse LEEF: message does not contain "LEEF:"`)
+}
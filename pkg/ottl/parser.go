@@ -22,6 +22,7 @@ import (
 type Statement[K any] struct {
 	function          Expr[K]
 	condition         BoolExpr[K]
+	loop              *statementLoop[K]
 	origText          string
 	telemetrySettings component.TelemetrySettings
 }
@@ -30,13 +31,28 @@ type Statement[K any] struct {
 // Returns true if the function was run, returns false otherwise.
 // If the statement contains no condition, the function will run and true will be returned.
 // In addition, the functions return value is always returned.
+// When the statement loops over a list or map, the condition and function are evaluated for each element,
+// true is returned if the function was run for any of them, and the last value returned by the function
+// is returned.
 func (s *Statement[K]) Execute(ctx context.Context, tCtx K) (any, bool, error) {
-	condition, err := s.condition.Eval(ctx, tCtx)
+	var result any
+	var condition bool
+	var err error
 	defer func() {
 		if s.telemetrySettings.Logger.Core().Enabled(zap.DebugLevel) {
 			s.telemetrySettings.Logger.Debug("TransformContext after statement execution", zap.String("statement", s.origText), zap.Bool("condition matched", condition), zap.Any("TransformContext", tCtx))
 		}
 	}()
+	if s.loop != nil {
+		result, condition, err = s.loop.execute(ctx, tCtx, s.execute)
+	} else {
+		result, condition, err = s.execute(ctx, tCtx)
+	}
+	return result, condition, err
+}
+
+func (s *Statement[K]) execute(ctx context.Context, tCtx K) (any, bool, error) {
+	condition, err := s.condition.Eval(ctx, tCtx)
 	if err != nil {
 		return nil, false, err
 	}
@@ -69,6 +85,12 @@ type Parser[K any] struct {
 	enumParser        EnumParser
 	telemetrySettings component.TelemetrySettings
 	pathContextNames  map[string]struct{}
+
+	functionDefinitions    []FunctionDefinition
+	functionDefinitionErrs map[string]error
+	// scope declares the variables available while compiling the body of
+	// a FunctionDefinition, a loop or a lambda.
+	scope *variableScope
 }
 
 // NewParser creates a new Parser
@@ -92,6 +114,9 @@ func NewParser[K any](
 	for _, opt := range options {
 		opt(&p)
 	}
+	if err := p.compileFunctionDefinitions(); err != nil {
+		return Parser[K]{}, err
+	}
 	return p, nil
 }
 
@@ -152,17 +177,26 @@ func (p *Parser[K]) ParseStatement(statement string) (*Statement[K], error) {
 	if err != nil {
 		return nil, err
 	}
-	function, err := p.newFunctionCall(parsed.Editor)
+	var loop *statementLoop[K]
+	bodyParser := p
+	if parsed.Loop != nil {
+		loop, bodyParser, err = p.newStatementLoop(parsed.Loop)
+		if err != nil {
+			return nil, err
+		}
+	}
+	function, err := bodyParser.newFunctionCall(parsed.Editor)
 	if err != nil {
 		return nil, err
 	}
-	expression, err := p.newBoolExpr(parsed.WhereClause)
+	expression, err := bodyParser.newBoolExpr(parsed.WhereClause)
 	if err != nil {
 		return nil, err
 	}
 	return &Statement[K]{
 		function:          function,
 		condition:         expression,
+		loop:              loop,
 		origText:          statement,
 		telemetrySettings: p.telemetrySettings,
 	}, nil
@@ -288,6 +322,7 @@ func parseStatement(raw string) (*parsedStatement, error) {
 	if err != nil {
 		return nil, err
 	}
+	parsed.markVariables()
 
 	return parsed, nil
 }
@@ -301,6 +336,7 @@ func parseCondition(raw string) (*booleanExpression, error) {
 	if err != nil {
 		return nil, err
 	}
+	parsed.accept(newGrammarVariablesVisitor(nil))
 
 	return parsed, nil
 }
@@ -314,6 +350,7 @@ func parseValueExpression(raw string) (*value, error) {
 	if err != nil {
 		return nil, err
 	}
+	parsed.accept(newGrammarVariablesVisitor(nil))
 
 	return parsed, nil
 }
@@ -3024,6 +3024,27 @@ func Test_prependContextToStatementPaths_Success(t *testing.T) {
 			pathContextNames: []string{"log", "resource"},
 			expected:         `set(log.attributes["test"], "pass") where IsMatch(resource.name, "operation[AC]")`,
 		},
+		{
+			name:             "loop variables",
+			statement:        `for each k, v in attributes["list"]: set(v, name) where k == 0 and v != id`,
+			context:          "log",
+			pathContextNames: []string{"log"},
+			expected:         `for each k, v in log.attributes["list"]: set(v, log.name) where k == 0 and v != log.id`,
+		},
+		{
+			name:             "lambda parameters",
+			statement:        `set(attributes["list"], Filter(attributes["list"], (x, i) => x != name and i > 0))`,
+			context:          "log",
+			pathContextNames: []string{"log"},
+			expected:         `set(log.attributes["list"], Filter(log.attributes["list"], (x, i) => x != log.name and i > 0))`,
+		},
+		{
+			name:             "lambda parameter shadowing a loop variable",
+			statement:        `for each v in attributes["list"]: set(v, Map(v, v => id))`,
+			context:          "log",
+			pathContextNames: []string{"log"},
+			expected:         `for each v in log.attributes["list"]: set(v, Map(v, v => log.id))`,
+		},
 	}
 
 	for _, tt := range tests {
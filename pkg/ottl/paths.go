@@ -12,6 +12,7 @@ func (*grammarPathVisitor) visitEditor(*editor)                   {}
 func (*grammarPathVisitor) visitConverter(*converter)             {}
 func (*grammarPathVisitor) visitValue(*value)                     {}
 func (*grammarPathVisitor) visitMathExprLiteral(*mathExprLiteral) {}
+func (*grammarPathVisitor) visitLambda(*lambda)                   {}
 
 func (v *grammarPathVisitor) visitPath(value *path) {
 	v.paths = append(v.paths, *value)
@@ -19,10 +20,7 @@ func (v *grammarPathVisitor) visitPath(value *path) {
 
 func getParsedStatementPaths(ps *parsedStatement) []path {
 	visitor := &grammarPathVisitor{}
-	ps.Editor.accept(visitor)
-	if ps.WhereClause != nil {
-		ps.WhereClause.accept(visitor)
-	}
+	ps.accept(visitor)
 	return visitor.paths
 }
 
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+
+	"github.com/iancoleman/strcase"
+)
+
+var (
+	editorNameRegexp    = regexp.MustCompile(`^[a-z][a-zA-Z0-9_]*$`)
+	converterNameRegexp = regexp.MustCompile(`^[A-Z][a-zA-Z0-9_]*$`)
+	parameterNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+)
+
+// FunctionDefinition declares an OTTL function composed of other OTTL statements or
+// expressions. Once added to a Parser using WithFunctionDefinitions, it can be called
+// like any function registered through a Factory.
+//
+// Editors, whose names start with a lowercase letter, are defined by a list of Statements
+// executed in order. Converters, whose names start with an uppercase letter, are defined
+// either by a value Expression or by a Condition, and return its result.
+//
+// The body references the parameters by their names, as if they were paths. When the
+// caller passes a path as argument, the parameter can also be set, which allows editors
+// to modify the telemetry the caller points them at. Parameters shadow any path with the
+// same name.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+type FunctionDefinition struct {
+	// Name is the name used to call the function.
+	Name string `mapstructure:"name"`
+	// Params are the names of the parameters of the function, in order.
+	// All parameters are required.
+	Params []string `mapstructure:"params"`
+	// Statements is the body of an editor.
+	Statements []string `mapstructure:"statements"`
+	// Expression is the body of a converter returning the value of the expression.
+	Expression string `mapstructure:"expression"`
+	// Condition is the body of a converter returning the result of the condition.
+	Condition string `mapstructure:"condition"`
+}
+
+// Validate checks that the FunctionDefinition is well-formed. It does not parse its body,
+// as a body is only valid for the contexts supporting the paths and functions it uses.
+func (d *FunctionDefinition) Validate() error {
+	switch {
+	case editorNameRegexp.MatchString(d.Name):
+		if len(d.Statements) == 0 {
+			return fmt.Errorf("editor %q must define statements", d.Name)
+		}
+		if d.Expression != "" || d.Condition != "" {
+			return fmt.Errorf("editor %q can only define statements, converter names must start with an uppercase letter", d.Name)
+		}
+	case converterNameRegexp.MatchString(d.Name):
+		if (d.Expression == "") == (d.Condition == "") {
+			return fmt.Errorf("converter %q must define either an expression or a condition", d.Name)
+		}
+		if len(d.Statements) > 0 {
+			return fmt.Errorf("converter %q cannot define statements, editor names must start with a lowercase letter", d.Name)
+		}
+	default:
+		return fmt.Errorf("invalid function name %q", d.Name)
+	}
+
+	fieldNames := make(map[string]struct{}, len(d.Params))
+	for _, param := range d.Params {
+		if !parameterNameRegexp.MatchString(param) {
+			return fmt.Errorf("invalid parameter name %q for function %q, parameter names must be lowercase", param, d.Name)
+		}
+		fieldName := strcase.ToCamel(param)
+		if _, ok := fieldNames[fieldName]; ok {
+			return fmt.Errorf("duplicate parameter %q for function %q", param, d.Name)
+		}
+		fieldNames[fieldName] = struct{}{}
+	}
+	return nil
+}
+
+// WithFunctionDefinitions adds functions declared with a FunctionDefinition to the Parser.
+// The body of each definition is compiled once by NewParser, and can call the Parser functions
+// as well as the definitions declared before it.
+// A definition whose body cannot be compiled for the Parser, for example because it uses paths
+// of another context, is not made available, and calls to it report the compilation error.
+//
+// Experimental: *NOTE* this API is subject to change or removal in the future.
+func WithFunctionDefinitions[K any](definitions []FunctionDefinition) Option[K] {
+	return func(p *Parser[K]) {
+		p.functionDefinitions = append(p.functionDefinitions, definitions...)
+	}
+}
+
+func (p *Parser[K]) compileFunctionDefinitions() error {
+	if len(p.functionDefinitions) == 0 {
+		return nil
+	}
+
+	// The functions map is usually shared between parsers, so it must not be modified.
+	p.functions = maps.Clone(p.functions)
+	if p.functions == nil {
+		p.functions = map[string]Factory[K]{}
+	}
+	p.functionDefinitionErrs = map[string]error{}
+
+	for i := range p.functionDefinitions {
+		definition := &p.functionDefinitions[i]
+		if err := definition.Validate(); err != nil {
+			return err
+		}
+		_, isFunction := p.functions[definition.Name]
+		_, isDefinition := p.functionDefinitionErrs[definition.Name]
+		if isFunction || isDefinition {
+			return fmt.Errorf("function %q is already defined", definition.Name)
+		}
+
+		f, err := p.newFunctionDefinitionFactory(definition)
+		if err != nil {
+			p.functionDefinitionErrs[definition.Name] = err
+			continue
+		}
+		p.functions[definition.Name] = f
+	}
+	return nil
+}
+
+func (p *Parser[K]) newFunctionDefinitionFactory(definition *FunctionDefinition) (Factory[K], error) {
+	bodyParser := p.withVariables(definition.Params)
+
+	var body ExprFunc[K]
+	switch {
+	case len(definition.Statements) > 0:
+		statements, err := bodyParser.ParseStatements(definition.Statements)
+		if err != nil {
+			return nil, err
+		}
+		body = func(ctx context.Context, tCtx K) (any, error) {
+			for _, statement := range statements {
+				if _, _, err := statement.Execute(ctx, tCtx); err != nil {
+					return nil, fmt.Errorf("failed to execute statement: %v, %w", statement.origText, err)
+				}
+			}
+			return nil, nil
+		}
+	case definition.Expression != "":
+		expression, err := bodyParser.ParseValueExpression(definition.Expression)
+		if err != nil {
+			return nil, err
+		}
+		body = expression.Eval
+	default:
+		condition, err := bodyParser.ParseCondition(definition.Condition)
+		if err != nil {
+			return nil, err
+		}
+		body = func(ctx context.Context, tCtx K) (any, error) {
+			return condition.Eval(ctx, tCtx)
+		}
+	}
+
+	fields := make([]reflect.StructField, len(definition.Params))
+	for i, param := range definition.Params {
+		fields[i] = reflect.StructField{
+			Name: strcase.ToCamel(param),
+			Type: reflect.TypeOf(parameterArgument[K]{}),
+		}
+	}
+	args := reflect.New(reflect.StructOf(fields)).Interface()
+
+	return NewFactory(definition.Name, args, func(_ FunctionContext, args Arguments) (ExprFunc[K], error) {
+		argsVal := reflect.ValueOf(args).Elem()
+		arguments := make([]GetSetter[K], argsVal.NumField())
+		for i := range arguments {
+			arguments[i] = argsVal.Field(i).Interface().(parameterArgument[K]).getSetter
+		}
+		return func(ctx context.Context, tCtx K) (any, error) {
+			// Arguments are evaluated with the caller's context, so arguments
+			// referencing the caller's own parameters are resolved correctly.
+			variables := make([]boundVariable[K], len(arguments))
+			for i, argument := range arguments {
+				variables[i] = boundVariable[K]{ctx: ctx, getSetter: argument}
+			}
+			return body(bindVariables(ctx, bodyParser.scope, variables), tCtx)
+		}, nil
+	}), nil
+}
+
+// parameterArgument is the type of the arguments of functions declared with a FunctionDefinition.
+// Paths are kept as GetSetter, so the function body can set them.
+type parameterArgument[K any] struct {
+	getSetter GetSetter[K]
+}
+
+func (p *Parser[K]) newParameterArgument(argVal value) (parameterArgument[K], error) {
+	if argVal.Literal != nil && argVal.Literal.Path != nil {
+		getSetter, err := p.buildGetSetterFromPath(argVal.Literal.Path)
+		if err != nil {
+			return parameterArgument[K]{}, err
+		}
+		return parameterArgument[K]{getSetter: getSetter}, nil
+	}
+
+	getter, err := p.newGetter(argVal)
+	if err != nil {
+		return parameterArgument[K]{}, err
+	}
+	return parameterArgument[K]{getSetter: &StandardGetSetter[K]{
+		Getter: getter.Get,
+		Setter: func(context.Context, K, any) error {
+			return errors.New("the argument is not a path and cannot be set")
+		},
+	}}, nil
+}
@@ -0,0 +1,278 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+)
+
+type userFunctionsSetArguments struct {
+	Target Setter[map[string]any]
+	Value  Getter[map[string]any]
+}
+
+func userFunctionsTestFunctions() map[string]Factory[map[string]any] {
+	return CreateFactoryMap(
+		NewFactory("set", &userFunctionsSetArguments{}, func(_ FunctionContext, args Arguments) (ExprFunc[map[string]any], error) {
+			a := args.(*userFunctionsSetArguments)
+			return func(ctx context.Context, tCtx map[string]any) (any, error) {
+				val, err := a.Value.Get(ctx, tCtx)
+				if err != nil {
+					return nil, err
+				}
+				return nil, a.Target.Set(ctx, tCtx, val)
+			}, nil
+		}),
+	)
+}
+
+// userFunctionsTestPath gives access to the entries of the map[string]any transform context
+// using the attributes["key"] path.
+func userFunctionsTestPath(p Path[map[string]any]) (GetSetter[map[string]any], error) {
+	if p.Name() != "attributes" || len(p.Keys()) != 1 {
+		return nil, fmt.Errorf("invalid path %q", p.String())
+	}
+	key, err := p.Keys()[0].String(context.Background(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if key == nil {
+		return nil, errors.New("key must be a string")
+	}
+	return &StandardGetSetter[map[string]any]{
+		Getter: func(_ context.Context, tCtx map[string]any) (any, error) {
+			return tCtx[*key], nil
+		},
+		Setter: func(_ context.Context, tCtx map[string]any, val any) error {
+			tCtx[*key] = val
+			return nil
+		},
+	}, nil
+}
+
+func newUserFunctionsTestParser(t *testing.T, definitions []FunctionDefinition) Parser[map[string]any] {
+	p, err := NewParser(userFunctionsTestFunctions(), userFunctionsTestPath, componenttest.NewNopTelemetrySettings(), WithFunctionDefinitions[map[string]any](definitions))
+	require.NoError(t, err)
+	return p
+}
+
+func Test_FunctionDefinitions(t *testing.T) {
+	definitions := []FunctionDefinition{
+		{
+			Name:       "mask",
+			Params:     []string{"target"},
+			Statements: []string{`set(target, "***")`},
+		},
+		{
+			Name:       "copy_masked",
+			Params:     []string{"source", "target_attribute"},
+			Statements: []string{`set(target_attribute, source)`, `mask(source)`},
+		},
+		{
+			Name:       "Fallback",
+			Params:     []string{"val", "default"},
+			Expression: `default`,
+		},
+		{
+			Name:      "IsSecret",
+			Params:    []string{"val"},
+			Condition: `val == "secret" or val == "password"`,
+		},
+		{
+			Name:       "mask_secrets",
+			Params:     []string{"target"},
+			Statements: []string{`mask(target) where IsSecret(attributes["kind"])`},
+		},
+	}
+	p := newUserFunctionsTestParser(t, definitions)
+
+	tests := []struct {
+		name      string
+		statement string
+		want      map[string]any
+	}{
+		{
+			name:      "editor",
+			statement: `mask(attributes["password"])`,
+			want:      map[string]any{"kind": "secret", "password": "***", "other": "value"},
+		},
+		{
+			name:      "editor calling another definition",
+			statement: `copy_masked(attributes["password"], attributes["copy"])`,
+			want:      map[string]any{"kind": "secret", "password": "***", "other": "value", "copy": "hunter2"},
+		},
+		{
+			name:      "named arguments",
+			statement: `copy_masked(target_attribute = attributes["copy"], source = attributes["other"])`,
+			want:      map[string]any{"kind": "secret", "password": "hunter2", "other": "***", "copy": "value"},
+		},
+		{
+			name:      "converter expression",
+			statement: `set(attributes["copy"], Fallback(attributes["other"], "default"))`,
+			want:      map[string]any{"kind": "secret", "password": "hunter2", "other": "value", "copy": "default"},
+		},
+		{
+			name:      "converter condition",
+			statement: `mask(attributes["other"]) where IsSecret(attributes["kind"])`,
+			want:      map[string]any{"kind": "secret", "password": "hunter2", "other": "***"},
+		},
+		{
+			name:      "converter condition not met",
+			statement: `mask(attributes["other"]) where IsSecret(attributes["other"])`,
+			want:      map[string]any{"kind": "secret", "password": "hunter2", "other": "value"},
+		},
+		{
+			name:      "definition using paths",
+			statement: `mask_secrets(attributes["other"])`,
+			want:      map[string]any{"kind": "secret", "password": "hunter2", "other": "***"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statement, err := p.ParseStatement(tt.statement)
+			require.NoError(t, err)
+
+			tCtx := map[string]any{"kind": "secret", "password": "hunter2", "other": "value"}
+			_, _, err = statement.Execute(t.Context(), tCtx)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tCtx)
+		})
+	}
+}
+
+func Test_FunctionDefinitions_literalArgumentCannotBeSet(t *testing.T) {
+	p := newUserFunctionsTestParser(t, []FunctionDefinition{
+		{
+			Name:       "mask",
+			Params:     []string{"target"},
+			Statements: []string{`set(target, "***")`},
+		},
+	})
+
+	statement, err := p.ParseStatement(`mask("literal")`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(t.Context(), map[string]any{})
+	assert.ErrorContains(t, err, `cannot set variable "target"`)
+}
+
+func Test_FunctionDefinitions_notAvailable(t *testing.T) {
+	functions := userFunctionsTestFunctions()
+	p, err := NewParser(functions, userFunctionsTestPath, componenttest.NewNopTelemetrySettings(), WithFunctionDefinitions[map[string]any]([]FunctionDefinition{
+		{
+			Name:       "rename",
+			Params:     []string{"target"},
+			Statements: []string{`set(target, name)`},
+		},
+	}))
+	require.NoError(t, err)
+
+	_, err = p.ParseStatement(`rename(attributes["name"])`)
+	assert.ErrorContains(t, err, `function "rename" is not available`)
+	assert.ErrorContains(t, err, `invalid path "name"`)
+
+	// The functions given to the parser are not modified.
+	assert.Len(t, functions, 1)
+}
+
+func Test_FunctionDefinitions_invalid(t *testing.T) {
+	tests := []struct {
+		name       string
+		definition FunctionDefinition
+		wantErr    string
+	}{
+		{
+			name:       "invalid name",
+			definition: FunctionDefinition{Name: "1mask", Statements: []string{`set(attributes["a"], 1)`}},
+			wantErr:    `invalid function name "1mask"`,
+		},
+		{
+			name:       "editor without statements",
+			definition: FunctionDefinition{Name: "mask"},
+			wantErr:    `editor "mask" must define statements`,
+		},
+		{
+			name:       "editor with an expression",
+			definition: FunctionDefinition{Name: "mask", Statements: []string{`set(attributes["a"], 1)`}, Expression: `1`},
+			wantErr:    `editor "mask" can only define statements`,
+		},
+		{
+			name:       "converter without body",
+			definition: FunctionDefinition{Name: "Mask"},
+			wantErr:    `converter "Mask" must define either an expression or a condition`,
+		},
+		{
+			name:       "converter with both bodies",
+			definition: FunctionDefinition{Name: "Mask", Expression: `1`, Condition: `true`},
+			wantErr:    `converter "Mask" must define either an expression or a condition`,
+		},
+		{
+			name:       "converter with statements",
+			definition: FunctionDefinition{Name: "Mask", Expression: `1`, Statements: []string{`set(attributes["a"], 1)`}},
+			wantErr:    `converter "Mask" cannot define statements`,
+		},
+		{
+			name:       "invalid parameter name",
+			definition: FunctionDefinition{Name: "Mask", Params: []string{"Target"}, Expression: `1`},
+			wantErr:    `invalid parameter name "Target" for function "Mask"`,
+		},
+		{
+			name:       "duplicate parameter",
+			definition: FunctionDefinition{Name: "Mask", Params: []string{"target", "target"}, Expression: `1`},
+			wantErr:    `duplicate parameter "target" for function "Mask"`,
+		},
+		{
+			name:       "redefined function",
+			definition: FunctionDefinition{Name: "set", Statements: []string{`set(attributes["a"], 1)`}},
+			wantErr:    `function "set" is already defined`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewParser(userFunctionsTestFunctions(), userFunctionsTestPath, componenttest.NewNopTelemetrySettings(), WithFunctionDefinitions[map[string]any]([]FunctionDefinition{tt.definition}))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_FunctionDefinitions_indexedParameter(t *testing.T) {
+	p := newUserFunctionsTestParser(t, []FunctionDefinition{
+		{
+			Name:       "copy_first",
+			Params:     []string{"source", "target"},
+			Statements: []string{`set(target, source[0]["name"])`},
+		},
+		{
+			Name:       "mask_first",
+			Params:     []string{"target"},
+			Statements: []string{`set(target[0], "***")`},
+		},
+		{
+			Name:       "Dynamic",
+			Params:     []string{"target"},
+			Expression: `target[attributes["key"]]`,
+		},
+	})
+
+	statement, err := p.ParseStatement(`copy_first(attributes["list"], attributes["name"])`)
+	require.NoError(t, err)
+	tCtx := map[string]any{"list": []any{map[string]any{"name": "first"}}}
+	_, _, err = statement.Execute(t.Context(), tCtx)
+	require.NoError(t, err)
+	assert.Equal(t, "first", tCtx["name"])
+
+	statement, err = p.ParseStatement(`mask_first(attributes["list"])`)
+	require.NoError(t, err)
+	_, _, err = statement.Execute(t.Context(), tCtx)
+	assert.ErrorContains(t, err, `cannot set indexed variable "target"`)
+
+	_, err = p.ParseStatement(`set(attributes["a"], Dynamic(attributes["list"]))`)
+	assert.ErrorContains(t, err, `variable "target" can only be indexed with string or int literals`)
+}
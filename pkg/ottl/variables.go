@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ottl // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// variableScope declares the variables available to the expressions compiled by a Parser,
+// such as the parameters of a FunctionDefinition, loop variables and lambda parameters.
+// Variables are referenced by name, as if they were paths, and shadow the variables of
+// the parent scopes. At runtime, the values of the variables are bound in the
+// context.Context, using the scope as key.
+type variableScope struct {
+	names  map[string]int
+	parent *variableScope
+}
+
+// withVariables returns a copy of the Parser compiling expressions in a new
+// scope declaring the given variables.
+func (p *Parser[K]) withVariables(names []string) *Parser[K] {
+	scope := &variableScope{
+		names:  make(map[string]int, len(names)),
+		parent: p.scope,
+	}
+	for i, name := range names {
+		scope.names[name] = i
+	}
+	scoped := *p
+	scoped.scope = scope
+	return &scoped
+}
+
+// boundVariable is the value of a variable, along with the context it must be evaluated with.
+type boundVariable[K any] struct {
+	ctx       context.Context
+	getSetter GetSetter[K]
+}
+
+func bindVariables[K any](ctx context.Context, scope *variableScope, variables []boundVariable[K]) context.Context {
+	return context.WithValue(ctx, scope, variables)
+}
+
+// variableReference is a reference to a variable within an expression.
+type variableReference[K any] struct {
+	scope *variableScope
+	name  string
+	index int
+}
+
+func (p *Parser[K]) newVariableReference(path *path) (GetSetter[K], bool, error) {
+	if p.scope == nil || path.Context != "" || len(path.Fields) != 1 {
+		return nil, false, nil
+	}
+	name := path.Fields[0].Name
+	for scope := p.scope; scope != nil; scope = scope.parent {
+		index, ok := scope.names[name]
+		if !ok {
+			continue
+		}
+		reference := &variableReference[K]{scope: scope, name: name, index: index}
+		keys := path.Fields[0].Keys
+		if len(keys) == 0 {
+			return reference, true, nil
+		}
+		for _, k := range keys {
+			if k.String == nil && k.Int == nil {
+				return nil, true, fmt.Errorf("variable %q can only be indexed with string or int literals", name)
+			}
+		}
+		// Indexed variables are read-only, as the indexed value may be a copy.
+		indexed := exprGetter[K]{expr: Expr[K]{exprFunc: reference.Get}, keys: keys}
+		return &StandardGetSetter[K]{
+			Getter: indexed.Get,
+			Setter: func(context.Context, K, any) error {
+				return fmt.Errorf("cannot set indexed variable %q", name)
+			},
+		}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (r *variableReference[K]) bound(ctx context.Context) (boundVariable[K], error) {
+	variables, ok := ctx.Value(r.scope).([]boundVariable[K])
+	if !ok || r.index >= len(variables) {
+		return boundVariable[K]{}, fmt.Errorf("variable %q is not bound", r.name)
+	}
+	return variables[r.index], nil
+}
+
+func (r *variableReference[K]) Get(ctx context.Context, tCtx K) (any, error) {
+	variable, err := r.bound(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return variable.getSetter.Get(variable.ctx, tCtx)
+}
+
+func (r *variableReference[K]) Set(ctx context.Context, tCtx K, val any) error {
+	variable, err := r.bound(ctx)
+	if err != nil {
+		return err
+	}
+	if err = variable.getSetter.Set(variable.ctx, tCtx, val); err != nil {
+		return fmt.Errorf("cannot set variable %q: %w", r.name, err)
+	}
+	return nil
+}
+
+// variableValue is a read-only variable value.
+type variableValue[K any] struct {
+	value any
+}
+
+func (v variableValue[K]) Get(context.Context, K) (any, error) {
+	return v.value, nil
+}
+
+func (variableValue[K]) Set(context.Context, K, any) error {
+	return errors.New("the variable is read-only")
+}
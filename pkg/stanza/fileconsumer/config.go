@@ -32,6 +32,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/trim"
 )
 
+const (
+	IdentityModeFingerprint = "fingerprint"
+	IdentityModeComposite   = "composite"
+)
+
 const (
 	defaultMaxConcurrentFiles = 1024
 	defaultEncoding           = "utf-8"
@@ -59,10 +64,15 @@ func NewConfig() *Config {
 		MaxConcurrentFiles: defaultMaxConcurrentFiles,
 		StartAt:            "end",
 		FingerprintSize:    fingerprint.DefaultSize,
-		InitialBufferSize:  scanner.DefaultBufferSize,
-		MaxLogSize:         reader.DefaultMaxLogSize,
-		Encoding:           defaultEncoding,
-		FlushPeriod:        reader.DefaultFlushPeriod,
+		Identity: IdentityConfig{
+			Mode:        IdentityModeFingerprint,
+			SampleCount: fingerprint.DefaultSampleCount,
+			SampleSize:  fingerprint.DefaultSampleSize,
+		},
+		InitialBufferSize: scanner.DefaultBufferSize,
+		MaxLogSize:        reader.DefaultMaxLogSize,
+		Encoding:          defaultEncoding,
+		FlushPeriod:       reader.DefaultFlushPeriod,
 		Resolver: attrs.Resolver{
 			IncludeFileName: true,
 		},
@@ -78,6 +88,7 @@ type Config struct {
 	MaxBatches              int             `mapstructure:"max_batches,omitempty"`
 	StartAt                 string          `mapstructure:"start_at,omitempty"`
 	FingerprintSize         helper.ByteSize `mapstructure:"fingerprint_size,omitempty"`
+	Identity                IdentityConfig  `mapstructure:"identity,omitempty"`
 	InitialBufferSize       helper.ByteSize `mapstructure:"initial_buffer_size,omitempty"`
 	MaxLogSize              helper.ByteSize `mapstructure:"max_log_size,omitempty"`
 	Encoding                string          `mapstructure:"encoding,omitempty"`
@@ -93,6 +104,17 @@ type Config struct {
 	AcquireFSLock           bool            `mapstructure:"acquire_fs_lock,omitempty"`
 }
 
+// IdentityConfig configures how files are identified across polls and restarts.
+// In "fingerprint" mode, files are identified by their first 'fingerprint_size' bytes.
+// In "composite" mode, the fingerprint is complemented by the device and inode of the file,
+// its birth time and hashes of 'sample_size' bytes of content at 'sample_count' offsets
+// beyond the fingerprint, which distinguishes files sharing a common header.
+type IdentityConfig struct {
+	Mode        string          `mapstructure:"mode,omitempty"`
+	SampleCount int             `mapstructure:"sample_count,omitempty"`
+	SampleSize  helper.ByteSize `mapstructure:"sample_size,omitempty"`
+}
+
 type HeaderConfig struct {
 	Pattern           string            `mapstructure:"pattern"`
 	MetadataOperators []operator.Config `mapstructure:"metadata_operators"`
@@ -152,11 +174,20 @@ func (c Config) Build(set component.TelemetrySettings, emit emit.Callback, opts
 		return nil, err
 	}
 
+	var identityCfg *fingerprint.IdentityConfig
+	if c.Identity.Mode == IdentityModeComposite {
+		identityCfg = &fingerprint.IdentityConfig{
+			SampleCount: c.Identity.SampleCount,
+			SampleSize:  int(c.Identity.SampleSize),
+		}
+	}
+
 	set.Logger = set.Logger.With(zap.String("component", "fileconsumer"))
 	readerFactory := &reader.Factory{
 		TelemetrySettings:       set,
 		FromBeginning:           startAtBeginning,
 		FingerprintSize:         int(c.FingerprintSize),
+		Identity:                identityCfg,
 		InitialBufferSize:       int(c.InitialBufferSize),
 		MaxLogSize:              int(c.MaxLogSize),
 		Encoding:                enc,
@@ -204,6 +235,19 @@ func (c Config) validate() error {
 		return fmt.Errorf("'fingerprint_size' must be at least %d bytes", fingerprint.MinSize)
 	}
 
+	switch c.Identity.Mode {
+	case IdentityModeFingerprint:
+	case IdentityModeComposite:
+		if c.Identity.SampleCount < 1 || c.Identity.SampleCount > fingerprint.MaxSampleCount {
+			return fmt.Errorf("'identity.sample_count' must be between 1 and %d", fingerprint.MaxSampleCount)
+		}
+		if c.Identity.SampleSize < fingerprint.MinSampleSize {
+			return fmt.Errorf("'identity.sample_size' must be at least %d bytes", fingerprint.MinSampleSize)
+		}
+	default:
+		return fmt.Errorf("invalid 'identity.mode' '%s', must be '%s' or '%s'", c.Identity.Mode, IdentityModeFingerprint, IdentityModeComposite)
+	}
+
 	if c.MaxLogSize <= 0 {
 		return errors.New("'max_log_size' must be positive")
 	}
@@ -30,6 +30,9 @@ func TestNewConfig(t *testing.T) {
 	assert.Equal(t, defaultMaxConcurrentFiles, cfg.MaxConcurrentFiles)
 	assert.Equal(t, "end", cfg.StartAt)
 	assert.Equal(t, fingerprint.DefaultSize, int(cfg.FingerprintSize))
+	assert.Equal(t, IdentityModeFingerprint, cfg.Identity.Mode)
+	assert.Equal(t, fingerprint.DefaultSampleCount, cfg.Identity.SampleCount)
+	assert.Equal(t, fingerprint.DefaultSampleSize, int(cfg.Identity.SampleSize))
 	assert.Equal(t, defaultEncoding, cfg.Encoding)
 	assert.Equal(t, reader.DefaultMaxLogSize, int(cfg.MaxLogSize))
 	assert.Equal(t, reader.DefaultFlushPeriod, cfg.FlushPeriod)
@@ -316,6 +319,16 @@ func TestUnmarshal(t *testing.T) {
 					return newMockOperatorConfig(cfg)
 				}(),
 			},
+			{
+				Name: "identity_composite",
+				Expect: func() *mockOperatorConfig {
+					cfg := NewConfig()
+					cfg.Identity.Mode = IdentityModeComposite
+					cfg.Identity.SampleCount = 8
+					cfg.Identity.SampleSize = helper.ByteSize(256)
+					return newMockOperatorConfig(cfg)
+				}(),
+			},
 			{
 				Name: "multiline_line_start_string",
 				Expect: func() *mockOperatorConfig {
@@ -516,6 +529,45 @@ func TestBuild(t *testing.T) {
 			require.NoError,
 			func(_ *testing.T, _ *Manager) {},
 		},
+		{
+			"CompositeIdentity",
+			func(cfg *Config) {
+				cfg.Identity.Mode = IdentityModeComposite
+			},
+			require.NoError,
+			func(t *testing.T, m *Manager) {
+				require.Equal(t, &fingerprint.IdentityConfig{
+					SampleCount: fingerprint.DefaultSampleCount,
+					SampleSize:  fingerprint.DefaultSampleSize,
+				}, m.readerFactory.Identity)
+			},
+		},
+		{
+			"InvalidIdentityMode",
+			func(cfg *Config) {
+				cfg.Identity.Mode = "inode"
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidIdentitySampleCount",
+			func(cfg *Config) {
+				cfg.Identity.Mode = IdentityModeComposite
+				cfg.Identity.SampleCount = fingerprint.MaxSampleCount + 1
+			},
+			require.Error,
+			nil,
+		},
+		{
+			"InvalidIdentitySampleSize",
+			func(cfg *Config) {
+				cfg.Identity.Mode = IdentityModeComposite
+				cfg.Identity.SampleSize = helper.ByteSize(fingerprint.MinSampleSize - 1)
+			},
+			require.Error,
+			nil,
+		},
 		{
 			"InvalidEncoding",
 			func(cfg *Config) {
@@ -175,14 +175,14 @@ func (m *Manager) consume(ctx context.Context, paths []string) {
 	m.telemetryBuilder.FileconsumerOpenFiles.Add(ctx, int64(0-m.tracker.EndConsume()))
 }
 
-func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.File) {
+func (m *Manager) makeFingerprint(path string, previous *fingerprint.Fingerprint) (*fingerprint.Fingerprint, *os.File) {
 	file, err := os.Open(path) // #nosec - operator must read in files defined by user
 	if err != nil {
 		m.set.Logger.Error("Failed to open file", zap.Error(err))
 		return nil, nil
 	}
 
-	fp, err := m.readerFactory.NewFingerprint(file)
+	fp, err := m.readerFactory.NewFingerprintWithCache(file, previous)
 	if err != nil {
 		if err = file.Close(); err != nil {
 			m.set.Logger.Debug("problem closing file", zap.Error(err))
@@ -204,8 +204,19 @@ func (m *Manager) makeFingerprint(path string) (*fingerprint.Fingerprint, *os.Fi
 // discarding any that have a duplicate fingerprint to other files that have already
 // been read this polling interval
 func (m *Manager) makeReaders(ctx context.Context, paths []string) {
+	// The fingerprints of the previous poll cycle are reused to avoid sampling the content
+	// of the files again for their composite identity
+	var previous map[string]*fingerprint.Fingerprint
+	if m.readerFactory.Identity != nil {
+		previousFiles := m.tracker.PreviousPollFiles()
+		previous = make(map[string]*fingerprint.Fingerprint, len(previousFiles))
+		for _, r := range previousFiles {
+			previous[r.GetFileName()] = r.Fingerprint
+		}
+	}
+
 	for _, path := range paths {
-		fp, file := m.makeFingerprint(path)
+		fp, file := m.makeFingerprint(path, previous[path])
 		if fp == nil {
 			continue
 		}
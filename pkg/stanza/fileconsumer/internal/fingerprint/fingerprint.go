@@ -29,9 +29,11 @@ var DecompressedFingerprintFeatureGate = featuregate.GlobalRegistry().MustRegist
 )
 
 // Fingerprint is used to identify a file
-// A file's fingerprint is the first N bytes of the file
+// A file's fingerprint is the first N bytes of the file,
+// optionally complemented by a composite identity (see IdentityConfig)
 type Fingerprint struct {
 	firstBytes []byte
+	identity   *identity
 }
 
 func New(first []byte) *Fingerprint {
@@ -68,6 +70,30 @@ func NewFromFile(file *os.File, size int, decompressData bool) (*Fingerprint, er
 	return New(buf[:n]), nil
 }
 
+// NewFromFileWithIdentity computes fingerprint of the given file like NewFromFile,
+// and complements it with a composite identity if identityCfg is not nil
+func NewFromFileWithIdentity(file *os.File, size int, decompressData bool, identityCfg *IdentityConfig) (*Fingerprint, error) {
+	return NewFromFileWithCachedIdentity(file, size, decompressData, identityCfg, nil)
+}
+
+// NewFromFileWithCachedIdentity is like NewFromFileWithIdentity, but reuses the composite identity of
+// the previous fingerprint of the same file when it still describes the file, instead of sampling
+// the content of the file again. See identity.reusableFor.
+func NewFromFileWithCachedIdentity(file *os.File, size int, decompressData bool, identityCfg *IdentityConfig, previous *Fingerprint) (*Fingerprint, error) {
+	fp, err := NewFromFile(file, size, decompressData)
+	if err != nil || identityCfg == nil {
+		return fp, err
+	}
+	if previous != nil && bytes.Equal(previous.firstBytes, fp.firstBytes) && previous.identity.reusableFor(file, size, *identityCfg) {
+		fp.identity = previous.identity.copy()
+		return fp, nil
+	}
+	if fp.identity, err = newIdentity(file, size, decompressData, *identityCfg); err != nil {
+		return nil, err
+	}
+	return fp, nil
+}
+
 func hasGzipExtension(filename string) bool {
 	return filepath.Ext(filename) == ".gz"
 }
@@ -76,17 +102,33 @@ func hasGzipExtension(filename string) bool {
 func (f Fingerprint) Copy() *Fingerprint {
 	buf := make([]byte, len(f.firstBytes), cap(f.firstBytes))
 	n := copy(buf, f.firstBytes)
-	return New(buf[:n])
+	return &Fingerprint{firstBytes: buf[:n], identity: f.identity.copy()}
 }
 
 func (f *Fingerprint) Len() int {
 	return len(f.firstBytes)
 }
 
-// Equal returns true if the fingerprints have the same FirstBytes,
-// false otherwise. This does not compare other aspects of the fingerprints
-// because the primary purpose of a fingerprint is to convey a unique
-// identity, and only the FirstBytes field contributes to this goal.
+// SampleCount returns the number of content samples of the composite identity
+func (f *Fingerprint) SampleCount() int {
+	if f.identity == nil {
+		return 0
+	}
+	return len(f.identity.Samples)
+}
+
+// NextSampleEnd returns the length the file must reach for the composite identity to take its next
+// content sample, or false if all samples were taken. A fingerprint whose identity was not computed,
+// e.g. one restored from an older checkpoint, needs to be sampled right away.
+func (f *Fingerprint) NextSampleEnd() (int64, bool) {
+	if f.identity == nil {
+		return 0, true
+	}
+	return f.identity.nextSampleEnd()
+}
+
+// Equal returns true if the fingerprints have the same FirstBytes and,
+// when both have one, matching composite identities, false otherwise.
 func (f Fingerprint) Equal(other *Fingerprint) bool {
 	l0 := len(other.firstBytes)
 	l1 := len(f.firstBytes)
@@ -98,7 +140,7 @@ func (f Fingerprint) Equal(other *Fingerprint) bool {
 			return false
 		}
 	}
-	return true
+	return f.identity.equal(other.identity)
 }
 
 // StartsWith returns true if the fingerprints are the same
@@ -106,7 +148,8 @@ func (f Fingerprint) Equal(other *Fingerprint) bool {
 // This is important functionality for tracking new files,
 // since their initial size is typically less than that of
 // a fingerprint. As the file grows, its fingerprint is updated
-// until it reaches a maximum size, as configured on the operator
+// until it reaches a maximum size, as configured on the operator.
+// When both fingerprints have a composite identity, these must match as well.
 func (f Fingerprint) StartsWith(old *Fingerprint) bool {
 	l0 := len(old.firstBytes)
 	if l0 == 0 {
@@ -116,11 +159,11 @@ func (f Fingerprint) StartsWith(old *Fingerprint) bool {
 	if l0 > l1 {
 		return false
 	}
-	return bytes.Equal(old.firstBytes[:l0], f.firstBytes[:l0])
+	return bytes.Equal(old.firstBytes[:l0], f.firstBytes[:l0]) && f.identity.extends(old.identity)
 }
 
 func (f *Fingerprint) MarshalJSON() ([]byte, error) {
-	m := marshal{FirstBytes: f.firstBytes}
+	m := marshal{FirstBytes: f.firstBytes, Identity: f.identity}
 	return json.Marshal(&m)
 }
 
@@ -130,9 +173,11 @@ func (f *Fingerprint) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	f.firstBytes = m.FirstBytes
+	f.identity = m.Identity
 	return nil
 }
 
 type marshal struct {
-	FirstBytes []byte `json:"first_bytes"`
+	FirstBytes []byte    `json:"first_bytes"`
+	Identity   *identity `json:"identity,omitempty"`
 }
@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	DefaultSampleCount = 4
+	DefaultSampleSize  = 128 // bytes
+
+	MaxSampleCount = 16
+	MinSampleSize  = 16 // bytes
+)
+
+// IdentityConfig configures the composite identity of files.
+// Content samples are taken at offsets fingerprint_size, 2*fingerprint_size, 4*fingerprint_size, and so on.
+type IdentityConfig struct {
+	SampleCount int
+	SampleSize  int
+}
+
+// identity complements the first bytes of a file with the identity of the file on the
+// file system and samples of its content beyond the first bytes
+type identity struct {
+	FileID *fileID `json:"file_id,omitempty"`
+	// FingerprintSize, SampleCount and SampleSize describe where samples are taken
+	FingerprintSize int `json:"fingerprint_size"`
+	SampleCount     int `json:"sample_count"`
+	SampleSize      int `json:"sample_size"`
+	// Extent is the length of the content that was observed, up to the end of the last sample
+	Extent int64 `json:"extent"`
+	// Samples are hashes of the windows at offsets FingerprintSize, 2*FingerprintSize, 4*FingerprintSize, ...
+	Samples []uint64 `json:"samples,omitempty"`
+}
+
+// fileID identifies a file on the file system, i.e. its device and inode (or volume and
+// file index on windows) and, when supported by the file system, its birth time
+type fileID struct {
+	Device    uint64 `json:"device"`
+	Inode     uint64 `json:"inode"`
+	BirthTime int64  `json:"birth_time,omitempty"`
+}
+
+func newIdentity(file *os.File, size int, decompressData bool, cfg IdentityConfig) (*identity, error) {
+	id := &identity{
+		FingerprintSize: size,
+		SampleCount:     cfg.SampleCount,
+		SampleSize:      cfg.SampleSize,
+	}
+	limit := sampleOffset(size, cfg.SampleCount-1) + int64(cfg.SampleSize)
+
+	var content io.ReaderAt = file
+	if DecompressedFingerprintFeatureGate.IsEnabled() && decompressData && hasGzipExtension(file.Name()) {
+		// Samples are taken from the decompressed content, so that a compressed copy of a file is
+		// recognized. The file identity of a compressed copy always differs and is thus not recorded.
+		decompressed, err := decompressedPrefix(file, limit)
+		if err != nil {
+			return nil, err
+		}
+		content = decompressed
+		id.Extent = decompressed.Size()
+	} else {
+		info, err := file.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("stat: %w", err)
+		}
+		id.Extent = min(info.Size(), limit)
+		id.FileID = getFileID(file)
+	}
+
+	buf := make([]byte, cfg.SampleSize)
+	for i := 0; i < cfg.SampleCount; i++ {
+		if sampleOffset(size, i)+int64(cfg.SampleSize) > id.Extent {
+			// only complete windows are sampled, and later windows are even further away
+			break
+		}
+		n, err := content.ReadAt(buf, sampleOffset(size, i))
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("reading content sample: %w", err)
+		}
+		if n < cfg.SampleSize {
+			break
+		}
+		id.Samples = append(id.Samples, hashWindow(buf))
+	}
+	return id, nil
+}
+
+// reusableFor reports whether the identity still describes the given file, so that its content does not
+// need to be sampled again. This is the case when the file is the same on the file system, has not shrunk
+// below the sampled content and has not grown past the end of the next sample since. Compressed files,
+// whose identity does not record the file, are always sampled again.
+//
+// Like the first bytes of a fingerprint, the samples are not read again, so a file truncated in place
+// that grows past the sampled content with the same first bytes between two polls is not detected.
+func (id *identity) reusableFor(file *os.File, size int, cfg IdentityConfig) bool {
+	if id == nil || id.FileID == nil {
+		return false
+	}
+	if id.FingerprintSize != size || id.SampleCount != cfg.SampleCount || id.SampleSize != cfg.SampleSize {
+		return false
+	}
+	info, err := file.Stat()
+	if err != nil || info.Size() < id.Extent {
+		return false
+	}
+	if end, ok := id.nextSampleEnd(); ok && info.Size() >= end {
+		return false
+	}
+	fileID := getFileID(file)
+	return fileID != nil && fileID.equal(id.FileID)
+}
+
+// nextSampleEnd returns the length a file must reach for the next sample to be taken,
+// or false if all samples were taken
+func (id *identity) nextSampleEnd() (int64, bool) {
+	if len(id.Samples) >= id.SampleCount {
+		return 0, false
+	}
+	return sampleOffset(id.FingerprintSize, len(id.Samples)) + int64(id.SampleSize), true
+}
+
+func sampleOffset(size, i int) int64 {
+	return int64(size) << i
+}
+
+func decompressedPrefix(file *os.File, limit int64) (*bytes.Reader, error) {
+	reader, err := gzip.NewReader(io.NewSectionReader(file, 0, 1<<62))
+	if err != nil {
+		return nil, fmt.Errorf("error uncompressing gzip file: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, limit))
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("error uncompressing gzip file: %w", err)
+	}
+	return bytes.NewReader(data), nil
+}
+
+// hashWindow computes a Rabin-Karp polynomial hash of the window
+func hashWindow(window []byte) uint64 {
+	const prime = 1099511628211
+	var hash uint64
+	for _, b := range window {
+		hash = hash*prime + uint64(b) + 1
+	}
+	return hash
+}
+
+// extends reports whether the identity may belong to the same file as old, after it may have grown.
+// Both fingerprints are expected to have matching first bytes.
+//
+// Content is compared first: a file must not have shrunk, and the content sampled for old must not have
+// changed. This recognizes copies of a file, e.g. when rotating with copytruncate, and tells apart files
+// which only share a common header once their content differs. When no content beyond the first bytes
+// was sampled for old, the files must be the same on the file system. This distinguishes new files which
+// so far only contain a common header, as well as new files which reuse the inode of a deleted file, as
+// long as the file system records birth times.
+//
+// Identities computed with different settings are only compared by file, and identities computed before
+// the composite identity was enabled match any other identity.
+func (id *identity) extends(old *identity) bool {
+	if id == nil || old == nil {
+		return true
+	}
+	if !id.sameLayout(old) {
+		return id.sameFile(old)
+	}
+	if id.Extent < old.Extent || len(id.Samples) < len(old.Samples) {
+		return false
+	}
+	for i, hash := range old.Samples {
+		if id.Samples[i] != hash {
+			return false
+		}
+	}
+	return len(old.Samples) > 0 || id.sameFile(old)
+}
+
+// equal reports whether two identities may belong to the same file, without either having grown
+// beyond the other's sampled content. Both fingerprints are expected to have equal first bytes.
+func (id *identity) equal(other *identity) bool {
+	if id == nil || other == nil {
+		return true
+	}
+	if !id.sameLayout(other) {
+		return id.sameFile(other)
+	}
+	if len(id.Samples) != len(other.Samples) {
+		return false
+	}
+	for i, hash := range other.Samples {
+		if id.Samples[i] != hash {
+			return false
+		}
+	}
+	return len(id.Samples) > 0 || id.sameFile(other)
+}
+
+func (id *identity) sameLayout(other *identity) bool {
+	return id.FingerprintSize == other.FingerprintSize &&
+		id.SampleCount == other.SampleCount &&
+		id.SampleSize == other.SampleSize
+}
+
+// sameFile reports whether both identities belong to the same file on the file system,
+// or true if this cannot be determined
+func (id *identity) sameFile(other *identity) bool {
+	if id.FileID == nil || other.FileID == nil {
+		return true
+	}
+	return id.FileID.equal(other.FileID)
+}
+
+func (id *fileID) equal(other *fileID) bool {
+	if id.Device != other.Device || id.Inode != other.Inode {
+		return false
+	}
+	return id.BirthTime == 0 || other.BirthTime == 0 || id.BirthTime == other.BirthTime
+}
+
+func (id *identity) copy() *identity {
+	if id == nil {
+		return nil
+	}
+	c := *id
+	c.Samples = append([]uint64(nil), id.Samples...)
+	if id.FileID != nil {
+		fileID := *id.FileID
+		c.FileID = &fileID
+	}
+	return &c
+}
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build darwin
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func getFileID(file *os.File) *fileID {
+	var stat unix.Stat_t
+	if err := unix.Fstat(int(file.Fd()), &stat); err != nil {
+		return nil
+	}
+	return &fileID{
+		Device:    uint64(stat.Dev),
+		Inode:     stat.Ino,
+		BirthTime: stat.Btim.Nano(),
+	}
+}
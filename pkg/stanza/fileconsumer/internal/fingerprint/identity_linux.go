@@ -0,0 +1,28 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build linux
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+func getFileID(file *os.File) *fileID {
+	var stx unix.Statx_t
+	if err := unix.Statx(int(file.Fd()), "", unix.AT_EMPTY_PATH, unix.STATX_INO|unix.STATX_BTIME, &stx); err != nil {
+		return nil
+	}
+	id := &fileID{
+		Device: unix.Mkdev(stx.Dev_major, stx.Dev_minor),
+		Inode:  stx.Ino,
+	}
+	// not every file system records birth times
+	if stx.Mask&unix.STATX_BTIME != 0 {
+		id.BirthTime = stx.Btime.Sec*1e9 + int64(stx.Btime.Nsec)
+	}
+	return id
+}
@@ -0,0 +1,273 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package fingerprint
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/featuregate"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
+)
+
+var testIdentityConfig = &IdentityConfig{SampleCount: 3, SampleSize: 16}
+
+func newIdentityFromContent(t *testing.T, tempDir, content string) (*os.File, *Fingerprint) {
+	file := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, file, content)
+	fp, err := NewFromFileWithIdentity(file, 32, false, testIdentityConfig)
+	require.NoError(t, err)
+	return file, fp
+}
+
+func TestNewFromFileWithIdentity(t *testing.T) {
+	tempDir := t.TempDir()
+	header := strings.Repeat("h", 32)
+
+	// samples are taken at 32, 64 and 128 bytes, only when the window is complete
+	_, fp := newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 16))
+	require.Equal(t, 32, fp.Len())
+	require.Equal(t, 1, fp.SampleCount())
+	assert.Equal(t, int64(48), fp.identity.Extent)
+	assert.Equal(t, hashWindow([]byte(strings.Repeat("a", 16))), fp.identity.Samples[0])
+
+	_, fp = newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 110))
+	require.Equal(t, 2, fp.SampleCount())
+	assert.Equal(t, int64(142), fp.identity.Extent)
+
+	_, fp = newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 112))
+	require.Equal(t, 3, fp.SampleCount())
+	assert.Equal(t, int64(144), fp.identity.Extent)
+
+	// the extent is limited to the end of the last sample
+	_, fp = newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 200))
+	assert.Equal(t, int64(144), fp.identity.Extent)
+
+	// files shorter than the fingerprint have no samples
+	_, fp = newIdentityFromContent(t, tempDir, "short")
+	require.Equal(t, 0, fp.SampleCount())
+
+	require.NotNil(t, fp.identity.FileID)
+	assert.NotZero(t, fp.identity.FileID.Inode)
+
+	// without a configuration, there is no identity
+	file := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, file, header)
+	fp, err := NewFromFileWithIdentity(file, 32, false, nil)
+	require.NoError(t, err)
+	assert.Nil(t, fp.identity)
+}
+
+func TestIdentityMatches(t *testing.T) {
+	tempDir := t.TempDir()
+	header := strings.Repeat("h", 32)
+
+	// the same file matches itself as it grows
+	file, small := newIdentityFromContent(t, tempDir, header)
+	filetest.WriteString(t, file, strings.Repeat("a", 100))
+	large, err := NewFromFileWithIdentity(file, 32, false, testIdentityConfig)
+	require.NoError(t, err)
+	assert.True(t, large.StartsWith(small))
+	assert.False(t, large.Equal(small), "content was sampled for only one of them")
+
+	// a copy of the file matches by content
+	copyFile := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, copyFile, header+strings.Repeat("a", 100))
+	copied, err := NewFromFileWithIdentity(copyFile, 32, false, testIdentityConfig)
+	require.NoError(t, err)
+	assert.True(t, copied.StartsWith(large))
+	assert.True(t, copied.Equal(large))
+
+	// another file with the same header but different content does not match
+	_, other := newIdentityFromContent(t, tempDir, header+strings.Repeat("b", 100))
+	assert.False(t, other.StartsWith(large))
+	assert.False(t, other.Equal(large))
+
+	// the first bytes still need to match
+	assert.False(t, New([]byte(header)).StartsWith(New([]byte("other"))))
+
+	// when no content was sampled, files only sharing a header are told apart by their file identity
+	_, headerOnly := newIdentityFromContent(t, tempDir, header)
+	assert.False(t, headerOnly.Equal(small))
+	assert.False(t, large.StartsWith(headerOnly))
+
+	// a file which shrunk, e.g. because it was truncated and written again, does not match
+	require.NoError(t, file.Truncate(0))
+	_, err = file.WriteAt([]byte(header+"b"), 0)
+	require.NoError(t, err)
+	truncated, err := NewFromFileWithIdentity(file, 32, false, testIdentityConfig)
+	require.NoError(t, err)
+	assert.False(t, truncated.StartsWith(large))
+	assert.True(t, large.StartsWith(small), "the same file grew")
+}
+
+func TestNewFromFileWithCachedIdentity(t *testing.T) {
+	tempDir := t.TempDir()
+	header := strings.Repeat("h", 32)
+
+	file, fp := newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 200))
+	require.Equal(t, 3, fp.SampleCount())
+
+	// the samples of a known file are not read again, even when the sampled content changed in place
+	_, err := file.WriteAt([]byte(strings.Repeat("b", 16)), 32)
+	require.NoError(t, err)
+	filetest.WriteString(t, file, strings.Repeat("a", 100))
+	cached, err := NewFromFileWithCachedIdentity(file, 32, false, testIdentityConfig, fp)
+	require.NoError(t, err)
+	assert.Equal(t, fp.identity.Samples, cached.identity.Samples)
+	assert.True(t, cached.Equal(fp))
+
+	// the cached identity is a copy
+	cached.identity.Samples[0] = 0
+	assert.NotEqual(t, cached.identity.Samples[0], fp.identity.Samples[0])
+
+	// without a previous fingerprint the file is sampled
+	sampled, err := NewFromFileWithCachedIdentity(file, 32, false, testIdentityConfig, nil)
+	require.NoError(t, err)
+	assert.NotEqual(t, fp.identity.Samples[0], sampled.identity.Samples[0])
+
+	// a copy of the file is another file, its identity is computed
+	copyFile := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, copyFile, header+strings.Repeat("c", 200))
+	copied, err := NewFromFileWithCachedIdentity(copyFile, 32, false, testIdentityConfig, fp)
+	require.NoError(t, err)
+	assert.False(t, copied.Equal(fp))
+
+	// a file which shrunk is sampled again
+	require.NoError(t, file.Truncate(64))
+	truncated, err := NewFromFileWithCachedIdentity(file, 32, false, testIdentityConfig, fp)
+	require.NoError(t, err)
+	assert.Equal(t, 1, truncated.SampleCount())
+
+	// a file whose first bytes changed is sampled again
+	_, err = file.WriteAt([]byte("x"), 0)
+	require.NoError(t, err)
+	rewritten, err := NewFromFileWithCachedIdentity(file, 32, false, testIdentityConfig, truncated)
+	require.NoError(t, err)
+	assert.Equal(t, 1, rewritten.SampleCount())
+	assert.False(t, rewritten.StartsWith(truncated))
+
+	// more samples are needed than the previous identity has
+	more, err := NewFromFileWithCachedIdentity(file, 32, false, &IdentityConfig{SampleCount: 5, SampleSize: 16}, rewritten)
+	require.NoError(t, err)
+	assert.Equal(t, 1, more.SampleCount())
+
+	// an incomplete identity is reused until the file grew past the end of the next sample
+	small, fp := newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 40))
+	require.Equal(t, 1, fp.SampleCount())
+	end, ok := fp.NextSampleEnd()
+	require.True(t, ok)
+	assert.Equal(t, int64(80), end)
+	filetest.WriteString(t, small, strings.Repeat("a", 7))
+	grown, err := NewFromFileWithCachedIdentity(small, 32, false, testIdentityConfig, fp)
+	require.NoError(t, err)
+	assert.Equal(t, 1, grown.SampleCount())
+	assert.Equal(t, fp.identity.Extent, grown.identity.Extent)
+	filetest.WriteString(t, small, "a")
+	grown, err = NewFromFileWithCachedIdentity(small, 32, false, testIdentityConfig, grown)
+	require.NoError(t, err)
+	assert.Equal(t, 2, grown.SampleCount())
+	_, ok = grown.NextSampleEnd()
+	assert.True(t, ok)
+}
+
+func TestIdentityDifferentSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	file, fp := newIdentityFromContent(t, tempDir, strings.Repeat("a", 200))
+
+	// identities computed with other settings are compared by file only
+	reconfigured, err := NewFromFileWithIdentity(file, 32, false, &IdentityConfig{SampleCount: 2, SampleSize: 32})
+	require.NoError(t, err)
+	assert.True(t, reconfigured.StartsWith(fp))
+	assert.True(t, reconfigured.Equal(fp))
+
+	_, other := newIdentityFromContent(t, tempDir, strings.Repeat("a", 200))
+	assert.False(t, reconfigured.StartsWith(other))
+}
+
+func TestIdentityMatchesWithoutIdentity(t *testing.T) {
+	tempDir := t.TempDir()
+	header := strings.Repeat("h", 32)
+
+	// fingerprints computed without identity, e.g. restored from an older checkpoint, match by first bytes only
+	legacy := New([]byte(header))
+	_, fp := newIdentityFromContent(t, tempDir, header+strings.Repeat("a", 100))
+	assert.True(t, fp.StartsWith(legacy))
+	assert.True(t, fp.Equal(legacy))
+	assert.True(t, legacy.Equal(fp))
+}
+
+func TestFileIDEqual(t *testing.T) {
+	id := &fileID{Device: 1, Inode: 2, BirthTime: 3}
+	assert.True(t, id.equal(&fileID{Device: 1, Inode: 2, BirthTime: 3}))
+	assert.True(t, id.equal(&fileID{Device: 1, Inode: 2}), "birth time is not always available")
+	assert.False(t, id.equal(&fileID{Device: 1, Inode: 2, BirthTime: 4}), "inode was reused")
+	assert.False(t, id.equal(&fileID{Device: 1, Inode: 3, BirthTime: 3}))
+	assert.False(t, id.equal(&fileID{Device: 2, Inode: 2, BirthTime: 3}))
+}
+
+func TestIdentityMarshalUnmarshal(t *testing.T) {
+	_, fp := newIdentityFromContent(t, t.TempDir(), strings.Repeat("a", 200))
+	require.Equal(t, 3, fp.SampleCount())
+
+	b, err := json.Marshal(fp)
+	require.NoError(t, err)
+	fp2 := new(Fingerprint)
+	require.NoError(t, json.Unmarshal(b, fp2))
+	require.Equal(t, fp, fp2)
+
+	// fingerprints without identity are marshaled as before
+	b, err = json.Marshal(New([]byte("abc")))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"first_bytes":"YWJj"}`, string(b))
+}
+
+func TestIdentityCopy(t *testing.T) {
+	_, fp := newIdentityFromContent(t, t.TempDir(), strings.Repeat("a", 200))
+	cp := fp.Copy()
+	require.Equal(t, fp, cp)
+
+	cp.identity.Samples[0]++
+	assert.NotEqual(t, fp.identity.Samples[0], cp.identity.Samples[0])
+}
+
+func TestIdentityCompressedCopy(t *testing.T) {
+	require.NoError(t, featuregate.GlobalRegistry().Set(DecompressedFingerprintFeatureGate.ID(), true))
+	defer func() {
+		require.NoError(t, featuregate.GlobalRegistry().Set(DecompressedFingerprintFeatureGate.ID(), false))
+	}()
+	tempDir := t.TempDir()
+	content := strings.Repeat("h", 32) + strings.Repeat("a", 200)
+
+	_, original := newIdentityFromContent(t, tempDir, content)
+
+	compressedFile := filetest.OpenFile(t, filepath.Join(tempDir, "copy.log.gz"))
+	gzipWriter := gzip.NewWriter(compressedFile)
+	_, err := gzipWriter.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, gzipWriter.Close())
+	_, err = compressedFile.Seek(0, io.SeekStart)
+	require.NoError(t, err)
+
+	compressed, err := NewFromFileWithIdentity(compressedFile, 32, true, testIdentityConfig)
+	require.NoError(t, err)
+	require.Equal(t, 3, compressed.SampleCount())
+	assert.Nil(t, compressed.identity.FileID)
+	assert.True(t, compressed.StartsWith(original))
+}
+
+func TestHashWindow(t *testing.T) {
+	assert.Equal(t, hashWindow([]byte("some window")), hashWindow([]byte("some window")))
+	assert.NotEqual(t, hashWindow([]byte("some window")), hashWindow([]byte("some wind0w")))
+	assert.NotEqual(t, hashWindow([]byte("ab")), hashWindow([]byte("ba")))
+	assert.NotEqual(t, hashWindow([]byte{0}), hashWindow([]byte{0, 0}))
+}
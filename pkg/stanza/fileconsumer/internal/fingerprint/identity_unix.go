@@ -0,0 +1,27 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build unix && !linux && !darwin
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"os"
+	"syscall"
+)
+
+func getFileID(file *os.File) *fileID {
+	info, err := file.Stat()
+	if err != nil {
+		return nil
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return nil
+	}
+	// birth times are not portably available on other systems
+	return &fileID{
+		Device: uint64(stat.Dev), //nolint:unconvert // the type of Dev differs between systems
+		Inode:  uint64(stat.Ino), //nolint:unconvert // the type of Ino differs between systems
+	}
+}
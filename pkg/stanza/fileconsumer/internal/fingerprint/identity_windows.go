@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package fingerprint // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/internal/fingerprint"
+
+import (
+	"os"
+	"syscall"
+)
+
+func getFileID(file *os.File) *fileID {
+	var info syscall.ByHandleFileInformation
+	if err := syscall.GetFileInformationByHandle(syscall.Handle(file.Fd()), &info); err != nil {
+		return nil
+	}
+	return &fileID{
+		Device:    uint64(info.VolumeSerialNumber),
+		Inode:     uint64(info.FileIndexHigh)<<32 | uint64(info.FileIndexLow),
+		BirthTime: info.CreationTime.Nanoseconds(),
+	}
+}
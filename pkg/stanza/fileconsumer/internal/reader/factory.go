@@ -36,6 +36,7 @@ type Factory struct {
 	HeaderConfig            *header.Config
 	FromBeginning           bool
 	FingerprintSize         int
+	Identity                *fingerprint.IdentityConfig
 	BufPool                 sync.Pool
 	InitialBufferSize       int
 	MaxLogSize              int
@@ -53,7 +54,13 @@ type Factory struct {
 }
 
 func (f *Factory) NewFingerprint(file *os.File) (*fingerprint.Fingerprint, error) {
-	return fingerprint.NewFromFile(file, f.FingerprintSize, f.Compression != "")
+	return fingerprint.NewFromFileWithIdentity(file, f.FingerprintSize, f.Compression != "", f.Identity)
+}
+
+// NewFingerprintWithCache computes the fingerprint of the file like NewFingerprint, reusing the composite
+// identity of the previous fingerprint of the same file when possible
+func (f *Factory) NewFingerprintWithCache(file *os.File, previous *fingerprint.Fingerprint) (*fingerprint.Fingerprint, error) {
+	return fingerprint.NewFromFileWithCachedIdentity(file, f.FingerprintSize, f.Compression != "", f.Identity, previous)
 }
 
 func (f *Factory) NewReader(file *os.File, fp *fingerprint.Fingerprint) (*Reader, error) {
@@ -85,6 +92,7 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 		file:              file,
 		fileName:          file.Name(),
 		fingerprintSize:   f.FingerprintSize,
+		identity:          f.Identity,
 		bufPool:           &f.BufPool,
 		initialBufferSize: f.InitialBufferSize,
 		maxLogSize:        f.MaxLogSize,
@@ -99,7 +107,7 @@ func (f *Factory) NewReaderFromMetadata(file *os.File, m *Metadata) (r *Reader,
 
 	if r.Fingerprint.Len() > r.fingerprintSize {
 		// User has reconfigured fingerprint_size
-		shorter, rereadErr := fingerprint.NewFromFile(file, r.fingerprintSize, r.compression != "")
+		shorter, rereadErr := r.newFingerprint()
 		if rereadErr != nil {
 			return nil, fmt.Errorf("reread fingerprint: %w", rereadErr)
 		}
@@ -61,6 +61,7 @@ func testFactory(t *testing.T, opts ...testFactoryOpt) (*Factory, *emittest.Sink
 		FlushTimeout:      cfg.flushPeriod,
 		EmitFunc:          sink.Callback,
 		Attributes:        cfg.attributes,
+		Identity:          cfg.identity,
 	}, sink
 }
 
@@ -77,6 +78,7 @@ type testFactoryCfg struct {
 	flushPeriod       time.Duration
 	sinkChanSize      int
 	attributes        attrs.Resolver
+	identity          *fingerprint.IdentityConfig
 }
 
 func withFingerprintSize(size int) testFactoryOpt {
@@ -85,6 +87,12 @@ func withFingerprintSize(size int) testFactoryOpt {
 	}
 }
 
+func withIdentity(cfg *fingerprint.IdentityConfig) testFactoryOpt {
+	return func(c *testFactoryCfg) {
+		c.identity = cfg
+	}
+}
+
 func withSplitConfig(cfg split.Config) testFactoryOpt {
 	return func(c *testFactoryCfg) {
 		c.splitCfg = cfg
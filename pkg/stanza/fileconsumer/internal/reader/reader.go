@@ -46,6 +46,7 @@ type Reader struct {
 	file                   *os.File
 	reader                 io.Reader
 	fingerprintSize        int
+	identity               *fingerprint.IdentityConfig
 	bufPool                *sync.Pool
 	initialBufferSize      int
 	maxLogSize             int
@@ -56,6 +57,7 @@ type Reader struct {
 	emitFunc               emit.Callback
 	deleteAtEOF            bool
 	needsUpdateFingerprint bool
+	readOffset             int64
 	compression            string
 	acquireFSLock          bool
 	maxBatchSize           int
@@ -104,6 +106,7 @@ func (r *Reader) ReadToEnd(ctx context.Context) {
 		r.set.Logger.Error("failed to seek", zap.Error(err))
 		return
 	}
+	r.readOffset = r.Offset
 
 	defer func() {
 		if r.needsUpdateFingerprint {
@@ -201,6 +204,7 @@ func (r *Reader) readHeader(ctx context.Context) (doneReadingFile bool) {
 		r.set.Logger.Error("failed to seek post-header", zap.Error(err))
 		return true
 	}
+	r.readOffset = r.Offset
 
 	return false
 }
@@ -305,16 +309,34 @@ func (r *Reader) close() {
 // Read from the file and update the fingerprint if necessary
 func (r *Reader) Read(dst []byte) (n int, err error) {
 	n, err = r.reader.Read(dst)
+	r.readOffset += int64(n)
 	if n == 0 || err != nil {
 		return
 	}
 
-	if !r.needsUpdateFingerprint && r.Fingerprint.Len() < r.fingerprintSize {
+	if !r.needsUpdateFingerprint && r.fingerprintOutgrown() {
 		r.needsUpdateFingerprint = true
 	}
 	return
 }
 
+// fingerprintOutgrown reports whether the file was read beyond the content covered by its fingerprint,
+// i.e. past its first bytes or, for the composite identity, past the end of the next content sample
+func (r *Reader) fingerprintOutgrown() bool {
+	if r.Fingerprint.Len() < r.fingerprintSize {
+		return true
+	}
+	if r.identity == nil {
+		return false
+	}
+	end, ok := r.Fingerprint.NextSampleEnd()
+	if !ok {
+		return false
+	}
+	// the position in decompressed content is not tracked, so compressed files are sampled again
+	return r.reader != r.file || r.readOffset >= end
+}
+
 func (r *Reader) NameEquals(other *Reader) bool {
 	return r.fileName == other.fileName
 }
@@ -324,7 +346,7 @@ func (r *Reader) Validate() bool {
 	if r.file == nil {
 		return false
 	}
-	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "")
+	refreshedFingerprint, err := r.newFingerprint()
 	if err != nil {
 		return false
 	}
@@ -334,6 +356,10 @@ func (r *Reader) Validate() bool {
 	return false
 }
 
+func (r *Reader) newFingerprint() (*fingerprint.Fingerprint, error) {
+	return fingerprint.NewFromFileWithCachedIdentity(r.file, r.fingerprintSize, r.compression != "", r.identity, r.Fingerprint)
+}
+
 func (r *Reader) GetFileName() string {
 	return r.fileName
 }
@@ -347,7 +373,7 @@ func (r *Reader) updateFingerprint() {
 	if r.file == nil {
 		return
 	}
-	refreshedFingerprint, err := fingerprint.NewFromFile(r.file, r.fingerprintSize, r.compression != "")
+	refreshedFingerprint, err := r.newFingerprint()
 	if err != nil {
 		return
 	}
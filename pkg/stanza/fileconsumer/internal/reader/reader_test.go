@@ -106,6 +106,45 @@ func TestFingerprintGrowsAndStops(t *testing.T) {
 	}
 }
 
+// Test that the composite identity of a small file is not sampled again on every read,
+// but only once the file grew past the end of its next content sample
+func TestFingerprintIdentitySampledWhenGrown(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	temp := filetest.OpenTemp(t, tempDir)
+	tempCopy := filetest.OpenFile(t, temp.Name())
+
+	// the first sample is taken from bytes 16 to 32
+	f, sink := testFactory(t, withFingerprintSize(16), withIdentity(&fingerprint.IdentityConfig{SampleCount: 3, SampleSize: 16}))
+	filetest.WriteString(t, temp, "testlog1\ntestlog2\n")
+	fp, err := f.NewFingerprint(temp)
+	require.NoError(t, err)
+	require.Equal(t, 0, fp.SampleCount())
+
+	reader, err := f.NewReader(tempCopy, fp)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	for i := 0; i < 3; i++ {
+		reader.ReadToEnd(t.Context())
+	}
+	sink.ExpectTokens(t, []byte("testlog1"), []byte("testlog2"))
+	assert.Same(t, fp, reader.Fingerprint)
+
+	filetest.WriteString(t, temp, "log3\n")
+	reader.ReadToEnd(t.Context())
+	sink.ExpectToken(t, []byte("log3"))
+	assert.Same(t, fp, reader.Fingerprint)
+
+	filetest.WriteString(t, temp, "testlog4\n")
+	reader.ReadToEnd(t.Context())
+	sink.ExpectToken(t, []byte("testlog4"))
+	assert.NotSame(t, fp, reader.Fingerprint)
+	assert.Equal(t, 1, reader.Fingerprint.SampleCount())
+	assert.True(t, reader.Fingerprint.StartsWith(fp))
+}
+
 // This is same test like TestFingerprintGrowsAndStops, but with additional check for fingerprint size check
 // Test that a fingerprint:
 // - Starts empty
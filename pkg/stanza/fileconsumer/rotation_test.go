@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -19,6 +20,7 @@ import (
 	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/internal/filetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
 )
 
@@ -495,3 +497,153 @@ func TestFileMovedWhileOff_BigFiles(t *testing.T) {
 	sink2.ExpectTokens(t, log2, log3)
 	require.NoError(t, operator2.Stop())
 }
+
+func compositeIdentityConfig(tempDir string) *Config {
+	cfg := NewConfig().includeDir(tempDir)
+	cfg.StartAt = "beginning"
+	cfg.FingerprintSize = helper.ByteSize(64)
+	cfg.Identity.Mode = IdentityModeComposite
+	cfg.Identity.SampleSize = helper.ByteSize(16)
+	return cfg
+}
+
+// identicalHeader is exactly as long as the fingerprint of compositeIdentityConfig,
+// so that files starting with it cannot be told apart by their fingerprint
+var identicalHeader = strings.Repeat("#", 63) + "\n"
+
+// Files sharing a header longer than the fingerprint are told apart by the composite identity,
+// first by their file identity and then by their content
+func TestCompositeIdentityIdenticalHeaders(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	operator, sink := testManager(t, compositeIdentityConfig(tempDir))
+	operator.persister = testutil.NewUnscopedMockPersister()
+
+	temp1 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp1, identicalHeader+"file1 log1\n")
+	temp2 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp2, identicalHeader+"file2 log1\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t,
+		[]byte(identicalHeader[:63]), []byte("file1 log1"),
+		[]byte(identicalHeader[:63]), []byte("file2 log1"),
+	)
+
+	// once the content is sampled, each file is still only read once
+	filetest.WriteString(t, temp1, "file1 log2 with enough content to be sampled\n")
+	filetest.WriteString(t, temp2, "file2 log2 with enough content to be sampled\n")
+	operator.poll(t.Context())
+	sink.ExpectTokens(t,
+		[]byte("file1 log2 with enough content to be sampled"),
+		[]byte("file2 log2 with enough content to be sampled"),
+	)
+
+	filetest.WriteString(t, temp1, "file1 log3\n")
+	operator.poll(t.Context())
+	sink.ExpectToken(t, []byte("file1 log3"))
+	sink.ExpectNoCalls(t)
+}
+
+// A file created in place of a rotated file with the same header must be read from the beginning,
+// rather than be mistaken for the rotated file and read from its offset
+func TestCompositeIdentityMoveCreateIdenticalHeaders(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Moving files while open is unsupported on Windows")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := compositeIdentityConfig(tempDir)
+	cfg.Include = []string{filepath.Join(tempDir, "*.log")}
+	operator, sink := testManager(t, cfg)
+	operator.persister = testutil.NewUnscopedMockPersister()
+
+	fileName := filepath.Join(tempDir, "app.log")
+	original := filetest.OpenFile(t, fileName)
+	filetest.WriteString(t, original, identicalHeader+"log1\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte(identicalHeader[:63]), []byte("log1"))
+
+	filetest.WriteString(t, original, "log2\n")
+	require.NoError(t, original.Close())
+	require.NoError(t, os.Rename(fileName, filepath.Join(tempDir, "app.1.log")))
+
+	created := filetest.OpenFile(t, fileName)
+	filetest.WriteString(t, created, identicalHeader+"new1\nnew2\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("log2"), []byte(identicalHeader[:63]), []byte("new1"), []byte("new2"))
+	sink.ExpectNoCalls(t)
+}
+
+// A copy of a file is recognized by its content and not read again, while the truncated
+// original is read from the beginning even though it starts with the same header
+func TestCompositeIdentityCopyTruncateIdenticalHeaders(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skip("Rotation tests have been flaky on Windows. See https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/16331")
+	}
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	operator, sink := testManager(t, compositeIdentityConfig(tempDir))
+	operator.persister = testutil.NewUnscopedMockPersister()
+
+	original := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, original, identicalHeader)
+	expected := [][]byte{[]byte(identicalHeader[:63])}
+	for i := 0; i < 50; i++ {
+		line := fmt.Sprintf("original log %d", i)
+		filetest.WriteString(t, original, line+"\n")
+		expected = append(expected, []byte(line))
+	}
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, expected...)
+	operator.wg.Wait()
+
+	// write one more log, then copy the file and truncate the original
+	filetest.WriteString(t, original, "original log 50\n")
+	copied := filetest.OpenTemp(t, tempDir)
+	_, err := original.Seek(0, 0)
+	require.NoError(t, err)
+	_, err = io.Copy(copied, original)
+	require.NoError(t, err)
+	require.NoError(t, original.Truncate(0))
+	_, err = original.Seek(0, 0)
+	require.NoError(t, err)
+	filetest.WriteString(t, original, identicalHeader+"new log\n")
+
+	operator.poll(t.Context())
+	sink.ExpectTokens(t, []byte("original log 50"), []byte(identicalHeader[:63]), []byte("new log"))
+	sink.ExpectNoCalls(t)
+}
+
+// The composite identity is checkpointed, so files are still told apart after a restart
+func TestCompositeIdentityRestart(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	cfg := compositeIdentityConfig(tempDir)
+	persister := testutil.NewUnscopedMockPersister()
+
+	temp1 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp1, identicalHeader+"file1 log1\n")
+
+	operator, sink := testManager(t, cfg)
+	require.NoError(t, operator.Start(persister))
+	sink.ExpectTokens(t, []byte(identicalHeader[:63]), []byte("file1 log1"))
+	require.NoError(t, operator.Stop())
+
+	filetest.WriteString(t, temp1, "file1 log2\n")
+	temp2 := filetest.OpenTemp(t, tempDir)
+	filetest.WriteString(t, temp2, identicalHeader+"file2 log1\n")
+
+	operator2, sink2 := testManager(t, cfg)
+	require.NoError(t, operator2.Start(persister))
+	sink2.ExpectTokens(t, []byte("file1 log2"), []byte(identicalHeader[:63]), []byte("file2 log1"))
+	sink2.ExpectNoCalls(t)
+	require.NoError(t, operator2.Stop())
+}
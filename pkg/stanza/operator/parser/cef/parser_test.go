@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+const testMessage = "CEF:0|Security|threatmanager|1.0|100|worm successfully stopped|10|src=10.0.0.1 dst=2.1.2.2 msg=Detected a threat\\=worm"
+
+func testParsed() map[string]any {
+	return map[string]any{
+		"version":               "0",
+		"device_vendor":         "Security",
+		"device_product":        "threatmanager",
+		"device_version":        "1.0",
+		"device_event_class_id": "100",
+		"name":                  "worm successfully stopped",
+		"severity":              "10",
+		"extensions": map[string]any{
+			"src": "10.0.0.1",
+			"dst": "2.1.2.2",
+			"msg": "Detected a threat=worm",
+		},
+	}
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("cef_parser")
+	require.True(t, ok, "expected cef_parser to be registered")
+	require.Equal(t, "cef_parser", builder().Type())
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: testMessage,
+			},
+			&entry.Entry{
+				Attributes: testParsed(),
+				Body:       testMessage,
+			},
+		},
+		{
+			"parse-from-syslog-message",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewAttributeField("message")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"hostname": "firewall",
+					"message":  testMessage,
+				},
+			},
+			&entry.Entry{
+				Attributes: func() map[string]any {
+					m := testParsed()
+					m["hostname"] = "firewall"
+					m["message"] = testMessage
+					return m
+				}(),
+			},
+		},
+		{
+			"parse-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("cef")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: testMessage,
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"cef": testParsed(),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(t.Context(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
+
+func TestParserParse(t *testing.T) {
+	cases := []struct {
+		name       string
+		inputBody  any
+		outputBody map[string]any
+		expectErr  string
+	}{
+		{
+			"string",
+			testMessage,
+			testParsed(),
+			"",
+		},
+		{
+			"syslog-header",
+			"<134>Feb 18 10:00:00 host " + testMessage,
+			func() map[string]any {
+				parsed := testParsed()
+				parsed["syslog_header"] = "<134>Feb 18 10:00:00 host"
+				return parsed
+			}(),
+			"",
+		},
+		{
+			"not-cef",
+			"name=stanza",
+			nil,
+			`message does not contain "CEF:"`,
+		},
+		{
+			"invalid-type",
+			[]byte(testMessage),
+			nil,
+			"type '[]uint8' cannot be parsed as CEF",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := Parser{}
+			x, err := parser.parse(tc.inputBody)
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.outputBody, x)
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package leef
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+)
+
+const testMessage = "LEEF:2.0|Lancope|StealthWatch|1.0|41|^|src=10.0.1.8^dst=10.0.0.5^sev=5^msg=Suspicious traffic"
+
+func testParsed() map[string]any {
+	return map[string]any{
+		"version":         "2.0",
+		"vendor":          "Lancope",
+		"product":         "StealthWatch",
+		"product_version": "1.0",
+		"event_id":        "41",
+		"attributes": map[string]any{
+			"src": "10.0.1.8",
+			"dst": "10.0.0.5",
+			"sev": "5",
+			"msg": "Suspicious traffic",
+		},
+	}
+}
+
+func TestInit(t *testing.T) {
+	builder, ok := operator.DefaultRegistry.Lookup("leef_parser")
+	require.True(t, ok, "expected leef_parser to be registered")
+	require.Equal(t, "leef_parser", builder().Type())
+}
+
+func TestProcess(t *testing.T) {
+	cases := []struct {
+		name   string
+		op     func() (operator.Operator, error)
+		input  *entry.Entry
+		expect *entry.Entry
+	}{
+		{
+			"default",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: testMessage,
+			},
+			&entry.Entry{
+				Attributes: testParsed(),
+				Body:       testMessage,
+			},
+		},
+		{
+			"parse-from-syslog-message",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseFrom = entry.NewAttributeField("message")
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Attributes: map[string]any{
+					"hostname": "firewall",
+					"message":  testMessage,
+				},
+			},
+			&entry.Entry{
+				Attributes: func() map[string]any {
+					m := testParsed()
+					m["hostname"] = "firewall"
+					m["message"] = testMessage
+					return m
+				}(),
+			},
+		},
+		{
+			"parse-to",
+			func() (operator.Operator, error) {
+				cfg := NewConfigWithID("test_id")
+				cfg.ParseTo = entry.RootableField{Field: entry.NewBodyField("leef")}
+				set := componenttest.NewNopTelemetrySettings()
+				return cfg.Build(set)
+			},
+			&entry.Entry{
+				Body: testMessage,
+			},
+			&entry.Entry{
+				Body: map[string]any{
+					"leef": testParsed(),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			op, err := tc.op()
+			require.NoError(t, err, "did not expect operator function to return an error, this is a bug with the test case")
+
+			err = op.Process(t.Context(), tc.input)
+			require.NoError(t, err)
+			require.Equal(t, tc.expect, tc.input)
+		})
+	}
+}
+
+func TestParserParse(t *testing.T) {
+	cases := []struct {
+		name       string
+		inputBody  any
+		outputBody map[string]any
+		expectErr  string
+	}{
+		{
+			"string",
+			testMessage,
+			testParsed(),
+			"",
+		},
+		{
+			"not-leef",
+			"CEF:0|Security|threatmanager|1.0|100|stopped|10|",
+			nil,
+			`message does not contain "LEEF:"`,
+		},
+		{
+			"invalid-type",
+			[]byte(testMessage),
+			nil,
+			"type '[]uint8' cannot be parsed as LEEF",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := Parser{}
+			x, err := parser.parse(tc.inputBody)
+			if tc.expectErr != "" {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.outputBody, x)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktracerecombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktracerecombine"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	operatorType       = "stacktrace_recombine"
+	defaultCombineWith = "\n"
+)
+
+func init() {
+	operator.Register(operatorType, func() operator.Builder { return NewConfig() })
+}
+
+// NewConfig creates a new stacktrace_recombine config with default values
+func NewConfig() *Config {
+	return NewConfigWithID(operatorType)
+}
+
+// NewConfigWithID creates a new stacktrace_recombine config with default values
+func NewConfigWithID(operatorID string) *Config {
+	return &Config{
+		TransformerConfig: helper.NewTransformerConfig(operatorID, operatorType),
+		Languages:         languageNames(),
+		CombineField:      entry.NewBodyField(),
+		CombineWith:       defaultCombineWith,
+		SourceIdentifier:  entry.NewAttributeField(attrs.LogFilePath),
+		MaxLines:          1000,
+		MaxSources:        1000,
+		ForceFlushTimeout: 5 * time.Second,
+	}
+}
+
+// Config is the configuration of a stacktrace_recombine operator
+type Config struct {
+	helper.TransformerConfig `mapstructure:",squash"`
+	Languages                []string        `mapstructure:"languages"`
+	CombineField             entry.Field     `mapstructure:"combine_field"`
+	CombineWith              string          `mapstructure:"combine_with"`
+	SourceIdentifier         entry.Field     `mapstructure:"source_identifier"`
+	MaxLines                 int             `mapstructure:"max_lines"`
+	MaxLogSize               helper.ByteSize `mapstructure:"max_log_size,omitempty"`
+	ForceFlushTimeout        time.Duration   `mapstructure:"force_flush_period"`
+	MaxSources               int             `mapstructure:"max_sources"`
+}
+
+// Build creates a new Transformer from a config
+func (c *Config) Build(set component.TelemetrySettings) (operator.Operator, error) {
+	transformer, err := c.TransformerConfig.Build(set)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transformer config: %w", err)
+	}
+
+	if len(c.Languages) == 0 {
+		return nil, errors.New("at least one language must be set")
+	}
+	enabled := make([]*language, 0, len(c.Languages))
+	for _, name := range c.Languages {
+		l := findLanguage(name)
+		if l == nil {
+			return nil, fmt.Errorf("invalid language '%s', must be one of %s", name, strings.Join(languageNames(), ", "))
+		}
+		enabled = append(enabled, l)
+	}
+
+	if c.CombineField.FieldInterface == nil {
+		return nil, errors.New("missing required argument 'combine_field'")
+	}
+	if c.MaxLines <= 0 {
+		return nil, errors.New("'max_lines' must be positive")
+	}
+	if c.MaxSources <= 0 {
+		return nil, errors.New("'max_sources' must be positive")
+	}
+	if c.ForceFlushTimeout <= 0 {
+		return nil, errors.New("'force_flush_period' must be positive")
+	}
+
+	return &Transformer{
+		TransformerOperator: transformer,
+		languages:           enabled,
+		combineField:        c.CombineField,
+		combineWith:         c.CombineWith,
+		sourceIdentifier:    c.SourceIdentifier,
+		maxLines:            c.MaxLines,
+		maxLogSize:          int64(c.MaxLogSize),
+		maxSources:          c.MaxSources,
+		forceFlushTimeout:   c.ForceFlushTimeout,
+		ticker:              time.NewTicker(c.ForceFlushTimeout),
+		chClose:             make(chan struct{}),
+		sources:             make(map[string]*source),
+	}, nil
+}
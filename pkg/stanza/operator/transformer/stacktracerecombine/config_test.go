@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktracerecombine
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/operatortest"
+)
+
+func TestUnmarshal(t *testing.T) {
+	operatortest.ConfigUnmarshalTests{
+		DefaultConfig: NewConfig(),
+		TestsFile:     filepath.Join(".", "testdata", "config.yaml"),
+		Tests: []operatortest.ConfigUnmarshalTest{
+			{
+				Name:   "default",
+				Expect: NewConfig(),
+			},
+			{
+				Name: "custom_id",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.OperatorID = "merge-stack-traces"
+					return cfg
+				}(),
+			},
+			{
+				Name: "languages",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.Languages = []string{LanguageJava, LanguagePython}
+					return cfg
+				}(),
+			},
+			{
+				Name: "limits",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.MaxLines = 50
+					cfg.MaxLogSize = helper.ByteSize(256000)
+					cfg.ForceFlushTimeout = time.Second
+					cfg.MaxSources = 10
+					return cfg
+				}(),
+			},
+			{
+				Name: "message_field",
+				Expect: func() *Config {
+					cfg := NewConfig()
+					cfg.CombineField = entry.NewBodyField("message")
+					cfg.CombineWith = "\r\n"
+					cfg.SourceIdentifier = entry.NewAttributeField("k8s.pod.uid")
+					return cfg
+				}(),
+			},
+		},
+	}.Run(t)
+}
+
+func TestBuild(t *testing.T) {
+	cases := []struct {
+		name        string
+		modify      func(*Config)
+		expectedErr string
+	}{
+		{
+			name:   "default",
+			modify: func(*Config) {},
+		},
+		{
+			name: "no_languages",
+			modify: func(cfg *Config) {
+				cfg.Languages = nil
+			},
+			expectedErr: "at least one language must be set",
+		},
+		{
+			name: "unknown_language",
+			modify: func(cfg *Config) {
+				cfg.Languages = []string{LanguageGo, "cobol"}
+			},
+			expectedErr: "invalid language 'cobol', must be one of java, python, go, nodejs, dotnet, ruby",
+		},
+		{
+			name: "max_lines",
+			modify: func(cfg *Config) {
+				cfg.MaxLines = 0
+			},
+			expectedErr: "'max_lines' must be positive",
+		},
+		{
+			name: "max_sources",
+			modify: func(cfg *Config) {
+				cfg.MaxSources = 0
+			},
+			expectedErr: "'max_sources' must be positive",
+		},
+		{
+			name: "force_flush_period",
+			modify: func(cfg *Config) {
+				cfg.ForceFlushTimeout = 0
+			},
+			expectedErr: "'force_flush_period' must be positive",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			tc.modify(cfg)
+			_, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+			if tc.expectedErr == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
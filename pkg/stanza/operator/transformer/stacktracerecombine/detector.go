@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktracerecombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktracerecombine"
+
+// lineKind classifies a line with respect to the stack traces of a source
+type lineKind int
+
+const (
+	// lineNoTrace is a line which is not part of a stack trace
+	lineNoTrace lineKind = iota
+	// lineTraceStart is the first line of a stack trace
+	lineTraceStart
+	// lineTraceContinue is part of the current stack trace, which may continue
+	lineTraceContinue
+	// lineTraceEnd is the last line of the current stack trace
+	lineTraceEnd
+)
+
+// cursor is the state of a stack trace of one language
+type cursor struct {
+	language *language
+	state    string
+}
+
+// detector follows the lines of a single source. The first line of a stack trace often matches several
+// languages, e.g. Java and .NET exceptions, so the state of every language matching so far is tracked
+// until the lines only match one of them, or none anymore.
+type detector struct {
+	languages []*language
+	cursors   []cursor
+}
+
+func newDetector(languages []*language) *detector {
+	return &detector{languages: languages}
+}
+
+// inTrace reports whether the detector is within a stack trace
+func (d *detector) inTrace() bool {
+	return len(d.cursors) > 0
+}
+
+func (d *detector) reset() {
+	d.cursors = nil
+}
+
+func (d *detector) detect(line string) lineKind {
+	if d.inTrace() {
+		var next []cursor
+		var complete bool
+		for _, c := range d.cursors {
+			state, ok := c.language.transition(c.state, line)
+			switch {
+			case !ok:
+			case state == endState:
+				complete = true
+			default:
+				next = append(next, cursor{language: c.language, state: state})
+			}
+		}
+		d.cursors = next
+		switch {
+		case len(next) > 0:
+			return lineTraceContinue
+		case complete:
+			return lineTraceEnd
+		}
+	}
+
+	for _, l := range d.languages {
+		if state, ok := l.transition(startState, line); ok {
+			d.cursors = append(d.cursors, cursor{language: l, state: state})
+		}
+	}
+	if d.inTrace() {
+		return lineTraceStart
+	}
+	return lineNoTrace
+}
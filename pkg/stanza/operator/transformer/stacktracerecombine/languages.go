@@ -0,0 +1,162 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktracerecombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktracerecombine"
+
+import (
+	"regexp"
+)
+
+const (
+	LanguageJava   = "java"
+	LanguagePython = "python"
+	LanguageGo     = "go"
+	LanguageNodeJS = "nodejs"
+	LanguageDotNet = "dotnet"
+	LanguageRuby   = "ruby"
+)
+
+const (
+	// startState is the state of a source outside of a stack trace
+	startState = "start"
+	// endState is reached by the last line of a stack trace, i.e. the trace is complete once it is reached
+	endState = "end"
+)
+
+// rule transitions from any of the states in from to state to, when a line matches the pattern
+type rule struct {
+	from    []string
+	pattern *regexp.Regexp
+	to      string
+}
+
+// language describes the stack traces of a language as a state machine over their lines
+type language struct {
+	name  string
+	rules []rule
+}
+
+func newRule(from []string, pattern, to string) rule {
+	return rule{from: from, pattern: regexp.MustCompile(pattern), to: to}
+}
+
+// transition returns the state reached from state with line, if any rule of the language applies
+func (l *language) transition(state, line string) (string, bool) {
+	for _, r := range l.rules {
+		for _, from := range r.from {
+			if from == state && r.pattern.MatchString(line) {
+				return r.to, true
+			}
+		}
+	}
+	return "", false
+}
+
+// languages are listed in the order in which they are enabled by default
+var languages = []*language{
+	{
+		// java.lang.IllegalStateException: message
+		// 	at com.example.App.run(App.java:42)
+		// 	... 3 more
+		// Caused by: java.io.IOException: message
+		name: LanguageJava,
+		rules: []rule{
+			newRule([]string{startState}, `(?:^|\s)(?:Exception in thread "[^"]*" )?(?:[a-zA-Z_$][\w$]*\.)+[\w$]*(?:Exception|Error|Throwable)(?::.*)?$`, "java_exception"),
+			newRule([]string{"java_exception", "java_frame"}, `^[\t ]+at \S`, "java_frame"),
+			newRule([]string{"java_exception", "java_frame"}, `^[\t ]*\.\.\. \d+ (?:more|common frames omitted)$`, "java_frame"),
+			newRule([]string{"java_exception", "java_frame"}, `^[\t ]*(?:Caused by|Suppressed): `, "java_exception"),
+		},
+	},
+	{
+		// Traceback (most recent call last):
+		//   File "app.py", line 3, in <module>
+		//     main()
+		// ValueError: message
+		name: LanguagePython,
+		rules: []rule{
+			newRule([]string{startState}, `^Traceback \(most recent call last\):$`, "python_traceback"),
+			newRule([]string{"python_traceback", "python_file", "python_code"}, `^[\t ]+File "[^"]*", line \d+`, "python_file"),
+			newRule([]string{"python_code"}, `^[\t ]+[~^]+[\t ]*$`, "python_code"),
+			newRule([]string{"python_file"}, `^[\t ]+\S`, "python_code"),
+			newRule([]string{"python_file", "python_code"}, `^(?:[A-Za-z_]\w*\.)*[A-Za-z_]\w*(?::.*)?$`, endState),
+		},
+	},
+	{
+		// panic: message
+		//
+		// goroutine 1 [running]:
+		// main.main()
+		// 	/app/main.go:5 +0x1d
+		//
+		// Goroutine dumps and panics recovered by net/http start with the goroutine header.
+		name: LanguageGo,
+		rules: []rule{
+			newRule([]string{startState}, `(?:^|\s)panic: `, "go_panic"),
+			newRule([]string{startState}, `^fatal error: `, "go_panic"),
+			newRule([]string{startState}, `http: panic serving `, "go_panic"),
+			newRule([]string{startState}, `^goroutine \d+ \[[^\]]+\]:$`, "go_function"),
+			newRule([]string{"go_panic"}, `^\t?panic: `, "go_panic"),
+			newRule([]string{"go_panic"}, `^\[signal `, "go_panic"),
+			newRule([]string{"go_panic", "go_function"}, `^$`, "go_goroutine"),
+			newRule([]string{"go_panic", "go_goroutine"}, `^goroutine \d+ \[[^\]]+\]:$`, "go_function"),
+			newRule([]string{"go_function"}, `^(?:\S+\(.*\)|created by \S+.*)$`, "go_location"),
+			newRule([]string{"go_function"}, `^\.\.\.\d* ?additional frames elided\.\.\.$`, "go_function"),
+			newRule([]string{"go_location"}, `^\t\S`, "go_function"),
+		},
+	},
+	{
+		// TypeError: message
+		//     at Object.<anonymous> (/app/index.js:1:7)
+		//     at node:internal/main/run_main_module:28:49
+		name: LanguageNodeJS,
+		rules: []rule{
+			newRule([]string{startState}, `(?:^|\s)(?:Uncaught )?[A-Z]?[\w$]*(?:Error|Exception)(?: \[[\w-]+\])?(?::.*)?$`, "nodejs_error"),
+			newRule([]string{"nodejs_error", "nodejs_frame"}, `^[\t ]+at \S`, "nodejs_frame"),
+			newRule([]string{"nodejs_frame"}, `^[\t ]+\.\.\. \d+ (?:more lines matching cause stack trace|lines matching cause stack trace)\.?$`, "nodejs_frame"),
+			newRule([]string{"nodejs_frame"}, `^[\t ]*\[cause\]: `, "nodejs_error"),
+		},
+	},
+	{
+		// System.InvalidOperationException: message
+		//  ---> System.Exception: inner message
+		//    --- End of inner exception stack trace ---
+		//    at App.Program.Main(String[] args) in /app/Program.cs:line 9
+		name: LanguageDotNet,
+		rules: []rule{
+			newRule([]string{startState}, `(?:^|\s)(?:[A-Za-z_]\w*\.)+[A-Za-z_]\w*Exception(?::.*)?$`, "dotnet_exception"),
+			newRule([]string{"dotnet_exception", "dotnet_frame"}, `^[\t ]+at \S`, "dotnet_frame"),
+			newRule([]string{"dotnet_exception", "dotnet_frame"}, `^[\t ]*---> `, "dotnet_exception"),
+			newRule([]string{"dotnet_exception", "dotnet_frame"}, `^[\t ]*--- End of (?:inner exception stack trace|stack trace from previous location(?: where exception was thrown)?) ---$`, "dotnet_frame"),
+		},
+	},
+	{
+		// app.rb:3:in `/': divided by 0 (ZeroDivisionError)
+		// 	from app.rb:3:in `divide'
+		// 	from app.rb:7:in `<main>'
+		name: LanguageRuby,
+		rules: []rule{
+			newRule([]string{startState}, "^\\S.*:\\d+:in [`'][^']*': .*$", "ruby_error"),
+			newRule([]string{startState}, `^[A-Z][\w:]*(?:Error|Exception) \(.*\):$`, "ruby_error"),
+			newRule([]string{"ruby_error", "ruby_frame"}, "^[\\t ]+from \\S.*:\\d+(?::in [`'].*)?$", "ruby_frame"),
+			newRule([]string{"ruby_error", "ruby_frame"}, "^[\\t ]*\\S+\\.rb:\\d+:in [`']", "ruby_frame"),
+			newRule([]string{"ruby_frame"}, `^[\t ]+\.\.\. \d+ levels\.\.\.$`, "ruby_frame"),
+		},
+	},
+}
+
+func languageNames() []string {
+	names := make([]string, 0, len(languages))
+	for _, l := range languages {
+		names = append(names, l.name)
+	}
+	return names
+}
+
+func findLanguage(name string) *language {
+	for _, l := range languages {
+		if l.name == name {
+			return l
+		}
+	}
+	return nil
+}
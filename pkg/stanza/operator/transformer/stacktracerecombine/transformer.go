@@ -0,0 +1,231 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktracerecombine // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/transformer/stacktracerecombine"
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const DefaultSourceIdentifier = "DefaultSourceIdentifier"
+
+// Transformer is an operator that combines the lines of stack traces, split across consecutive
+// log entries, into a single entry
+type Transformer struct {
+	helper.TransformerOperator
+	languages         []*language
+	combineField      entry.Field
+	combineWith       string
+	sourceIdentifier  entry.Field
+	maxLines          int
+	maxLogSize        int64
+	maxSources        int
+	forceFlushTimeout time.Duration
+	ticker            *time.Ticker
+	chClose           chan struct{}
+
+	sync.Mutex
+	sources map[string]*source
+}
+
+// source is the state of a source within a stack trace
+type source struct {
+	detector *detector
+	// baseEntry is the first entry of the stack trace, all other fields are taken from it
+	baseEntry  *entry.Entry
+	recombined bytes.Buffer
+	numLines   int
+	// held are empty lines of the stack trace which are only combined once the stack trace continues,
+	// so that stack traces do not end with empty lines
+	held       []*entry.Entry
+	lastUpdate time.Time
+}
+
+func (t *Transformer) Start(_ operator.Persister) error {
+	go t.flushLoop()
+	return nil
+}
+
+func (t *Transformer) flushLoop() {
+	for {
+		select {
+		case <-t.ticker.C:
+			t.Lock()
+			timeNow := time.Now()
+			for id, src := range t.sources {
+				if timeNow.Sub(src.lastUpdate) < t.forceFlushTimeout {
+					continue
+				}
+				if err := t.flushSource(context.Background(), id); err != nil {
+					t.Logger().Error("there was error flushing combined logs", zap.Error(err))
+				}
+			}
+			// check every 1/5 forceFlushTimeout
+			t.ticker.Reset(t.forceFlushTimeout / 5)
+			t.Unlock()
+		case <-t.chClose:
+			t.ticker.Stop()
+			return
+		}
+	}
+}
+
+func (t *Transformer) Stop() error {
+	t.Lock()
+	defer t.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	t.flushAllSources(ctx)
+
+	close(t.chClose)
+	return nil
+}
+
+func (t *Transformer) ProcessBatch(ctx context.Context, entries []*entry.Entry) error {
+	return t.ProcessBatchWith(ctx, entries, t.Process)
+}
+
+func (t *Transformer) Process(ctx context.Context, e *entry.Entry) error {
+	// Lock the operator because process can't run concurrently
+	t.Lock()
+	defer t.Unlock()
+
+	var id string
+	if err := e.Read(t.sourceIdentifier, &id); err != nil {
+		t.Logger().Warn("entry does not contain the source_identifier, so it may be pooled with other sources")
+	}
+	if id == "" {
+		id = DefaultSourceIdentifier
+	}
+
+	var line string
+	if err := e.Read(t.combineField, &line); err != nil {
+		// an entry without the combine_field cannot be part of a stack trace
+		if err := t.flushSource(ctx, id); err != nil {
+			return err
+		}
+		return t.Write(ctx, e)
+	}
+
+	src := t.sources[id]
+	if src == nil {
+		src = &source{detector: newDetector(t.languages)}
+	}
+	src.lastUpdate = time.Now()
+
+	switch src.detector.detect(line) {
+	case lineNoTrace:
+		if err := t.flushSource(ctx, id); err != nil {
+			return err
+		}
+		return t.Write(ctx, e)
+	case lineTraceStart:
+		if err := t.flushBatch(ctx, src); err != nil {
+			return err
+		}
+		t.addSource(ctx, id, src)
+		t.addToBatch(ctx, src, e, line)
+		return nil
+	case lineTraceEnd:
+		t.addToBatch(ctx, src, e, line)
+		return t.flushSource(ctx, id)
+	default:
+		t.addToBatch(ctx, src, e, line)
+		return nil
+	}
+}
+
+// addSource tracks the source, unless it is already tracked
+func (t *Transformer) addSource(ctx context.Context, id string, src *source) {
+	if _, ok := t.sources[id]; ok {
+		return
+	}
+	if len(t.sources) >= t.maxSources {
+		t.Logger().Error("Too many sources. Flushing all batched logs. Consider increasing max_sources parameter")
+		t.flushAllSources(ctx)
+	}
+	t.sources[id] = src
+}
+
+// addToBatch adds a line of the current stack trace to the batch of the source
+func (t *Transformer) addToBatch(ctx context.Context, src *source, e *entry.Entry, line string) {
+	if strings.TrimSpace(line) == "" {
+		src.held = append(src.held, e)
+		return
+	}
+
+	if src.baseEntry == nil {
+		// the stack trace continues after its batch was flushed because it reached a limit
+		src.baseEntry = e
+	} else {
+		for range src.held {
+			src.recombined.WriteString(t.combineWith)
+			src.numLines++
+		}
+		src.recombined.WriteString(t.combineWith)
+	}
+	src.held = nil
+	src.recombined.WriteString(line)
+	src.numLines++
+
+	if src.numLines >= t.maxLines || (t.maxLogSize > 0 && int64(src.recombined.Len()) > t.maxLogSize) {
+		if err := t.flushBatch(ctx, src); err != nil {
+			t.Logger().Error("there was error flushing combined logs", zap.Error(err))
+		}
+	}
+}
+
+// flushAllSources flushes all sources.
+func (t *Transformer) flushAllSources(ctx context.Context) {
+	var errs error
+	for id := range t.sources {
+		errs = multierr.Append(errs, t.flushSource(ctx, id))
+	}
+	if errs != nil {
+		t.Logger().Error("there was error flushing combined logs", zap.Error(errs))
+	}
+}
+
+// flushSource flushes the batch of the source and stops tracking it, i.e. ends its current stack trace
+func (t *Transformer) flushSource(ctx context.Context, id string) error {
+	src, ok := t.sources[id]
+	if !ok {
+		return nil
+	}
+	delete(t.sources, id)
+	src.detector.reset()
+	return t.flushBatch(ctx, src)
+}
+
+// flushBatch combines the lines of the batch into its first entry, then forwards it
+// along with any held empty lines to the next operator in the pipeline
+func (t *Transformer) flushBatch(ctx context.Context, src *source) error {
+	var errs error
+	if src.baseEntry != nil {
+		if err := src.baseEntry.Set(t.combineField, src.recombined.String()); err != nil {
+			errs = multierr.Append(errs, err)
+		} else {
+			errs = multierr.Append(errs, t.Write(ctx, src.baseEntry))
+		}
+	}
+	for _, e := range src.held {
+		errs = multierr.Append(errs, t.Write(ctx, e))
+	}
+	src.baseEntry = nil
+	src.recombined.Reset()
+	src.numLines = 0
+	src.held = nil
+	return errs
+}
@@ -0,0 +1,292 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package stacktracerecombine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/entry"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer/attrs"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/testutil"
+)
+
+const (
+	javaTrace = `Exception in thread "main" java.lang.IllegalStateException: outer
+	at com.example.App.run(App.java:42)
+	at com.example.App.main(App.java:10)
+Caused by: java.io.IOException: inner
+	at com.example.Store.load(Store.java:7)
+	... 2 more`
+
+	javaLoggedTrace = `2024-05-01 10:00:00 ERROR [main] c.e.App - request failed: java.lang.NullPointerException: value
+	at com.example.Handler.handle(Handler.java:15)
+	at java.base/java.lang.Thread.run(Thread.java:833)`
+
+	pythonTrace = `Traceback (most recent call last):
+  File "/app/main.py", line 10, in <module>
+    main()
+  File "/app/main.py", line 6, in main
+    return 1 / 0
+           ~~^~~
+ZeroDivisionError: division by zero`
+
+	goPanic = `panic: runtime error: index out of range [5] with length 3
+
+goroutine 1 [running]:
+main.lookup(...)
+	/app/main.go:12
+main.main()
+	/app/main.go:7 +0x1d
+exit status 2`
+
+	goDump = `goroutine 1 [running]:
+main.main()
+	/app/main.go:7 +0x1d
+
+goroutine 18 [chan receive, 2 minutes]:
+github.com/example/app/worker.(*Pool).run(0xc000120000)
+	/app/worker/pool.go:31 +0x45
+created by github.com/example/app/worker.New in goroutine 1
+	/app/worker/pool.go:20 +0x8e`
+
+	nodeTrace = `TypeError: Cannot read properties of undefined (reading 'id')
+    at getUser (/app/users.js:4:17)
+    at Layer.handle [as handle_request] (/app/node_modules/express/lib/router/layer.js:95:5)
+    at node:internal/process/task_queues:95:5`
+
+	dotnetTrace = `Unhandled exception. System.InvalidOperationException: Operation failed
+ ---> System.ArgumentNullException: Value cannot be null. (Parameter 'name')
+   at App.Service.Validate(String name) in /app/Service.cs:line 21
+   --- End of inner exception stack trace ---
+   at App.Service.Run() in /app/Service.cs:line 12
+   at App.Program.Main(String[] args) in /app/Program.cs:line 9`
+
+	rubyTrace = "app.rb:3:in `/': divided by 0 (ZeroDivisionError)\n" +
+		"\tfrom app.rb:3:in `divide'\n" +
+		"\tfrom app.rb:7:in `<main>'"
+)
+
+func TestTransformer(t *testing.T) {
+	cases := []struct {
+		name      string
+		languages []string
+		input     []string
+		expected  []string
+	}{
+		{
+			name:     "NoTrace",
+			input:    []string{"starting", "listening on :8080", ""},
+			expected: []string{"starting", "listening on :8080", ""},
+		},
+		{
+			name:     "Java",
+			input:    lines("before", javaTrace, "after"),
+			expected: []string{"before", javaTrace, "after"},
+		},
+		{
+			name:     "JavaWithLogPrefix",
+			input:    lines(javaLoggedTrace, "next"),
+			expected: []string{javaLoggedTrace, "next"},
+		},
+		{
+			name:     "Python",
+			input:    lines("before", pythonTrace, "after"),
+			expected: []string{"before", pythonTrace, "after"},
+		},
+		{
+			name:     "GoPanic",
+			input:    lines(goPanic),
+			expected: []string{strings.TrimSuffix(goPanic, "\nexit status 2"), "exit status 2"},
+		},
+		{
+			name:     "GoGoroutineDump",
+			input:    lines(goDump, "", "after"),
+			expected: []string{goDump, "", "after"},
+		},
+		{
+			name:     "NodeJS",
+			input:    lines("before", nodeTrace, "after"),
+			expected: []string{"before", nodeTrace, "after"},
+		},
+		{
+			name:     "DotNet",
+			input:    lines("before", dotnetTrace, "after"),
+			expected: []string{"before", dotnetTrace, "after"},
+		},
+		{
+			name:     "Ruby",
+			input:    lines("before", rubyTrace, "after"),
+			expected: []string{"before", rubyTrace, "after"},
+		},
+		{
+			name:     "ConsecutiveTraces",
+			input:    lines(javaTrace, pythonTrace, nodeTrace, rubyTrace),
+			expected: []string{javaTrace, pythonTrace, nodeTrace, rubyTrace},
+		},
+		{
+			name:     "SameTraceTwice",
+			input:    lines(pythonTrace, pythonTrace),
+			expected: []string{pythonTrace, pythonTrace},
+		},
+		{
+			name:     "ExceptionWithoutFrames",
+			input:    []string{"java.lang.RuntimeException: no frames", "next"},
+			expected: []string{"java.lang.RuntimeException: no frames", "next"},
+		},
+		{
+			name:      "DisabledLanguage",
+			languages: []string{LanguageJava},
+			input:     lines(pythonTrace),
+			expected:  lines(pythonTrace),
+		},
+		{
+			name:     "FramesWithoutException",
+			input:    []string{"\tat com.example.App.run(App.java:42)", "    at getUser (/app/users.js:4:17)"},
+			expected: []string{"\tat com.example.App.run(App.java:42)", "    at getUser (/app/users.js:4:17)"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := NewConfig()
+			if tc.languages != nil {
+				cfg.Languages = tc.languages
+			}
+			op, fake := newTestTransformer(t, cfg)
+
+			for _, line := range tc.input {
+				require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody(line, "file1")}))
+			}
+			require.NoError(t, op.Stop())
+
+			for _, expected := range tc.expected {
+				fake.ExpectBody(t, expected)
+			}
+			fake.ExpectNoEntry(t, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestTransformerSources(t *testing.T) {
+	op, fake := newTestTransformer(t, NewConfig())
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	// the lines of traces of different sources are interleaved
+	java := lines(javaTrace)
+	python := lines(pythonTrace)
+	for i := 0; i < max(len(java), len(python)); i++ {
+		if i < len(java) {
+			require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody(java[i], "file1")}))
+		}
+		if i < len(python) {
+			require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody(python[i], "file2")}))
+		}
+	}
+	fake.ExpectBody(t, pythonTrace)
+	require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody("after", "file1")}))
+	fake.ExpectBody(t, javaTrace)
+	fake.ExpectBody(t, "after")
+}
+
+func TestTransformerMaxLines(t *testing.T) {
+	cfg := NewConfig()
+	cfg.MaxLines = 4
+	op, fake := newTestTransformer(t, cfg)
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	for _, line := range lines(javaTrace, "after") {
+		require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody(line, "file1")}))
+	}
+
+	// the trace is split once it reaches the limit, but the remaining lines are still combined
+	java := lines(javaTrace)
+	fake.ExpectBody(t, strings.Join(java[:4], "\n"))
+	fake.ExpectBody(t, strings.Join(java[4:], "\n"))
+	fake.ExpectBody(t, "after")
+}
+
+func TestTransformerForceFlush(t *testing.T) {
+	cfg := NewConfig()
+	cfg.ForceFlushTimeout = 100 * time.Millisecond
+	op, fake := newTestTransformer(t, cfg)
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	for _, line := range lines(nodeTrace) {
+		require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody(line, "file1")}))
+	}
+	fake.ExpectNoEntry(t, 50*time.Millisecond)
+	fake.ExpectBody(t, nodeTrace)
+
+	// lines written after the flush do not continue the flushed trace
+	require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{entryWithBody("    at late (/app/late.js:1:1)", "file1")}))
+	fake.ExpectBody(t, "    at late (/app/late.js:1:1)")
+}
+
+func TestTransformerKeepsFirstEntry(t *testing.T) {
+	op, fake := newTestTransformer(t, NewConfig())
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	first := entryWithBody("java.lang.RuntimeException: boom", "file1")
+	first.Timestamp = time.Date(2024, time.May, 1, 10, 0, 0, 0, time.UTC)
+	first.AddAttribute("first", "true")
+	require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{
+		first,
+		entryWithBody("\tat com.example.App.run(App.java:42)", "file1"),
+		entryWithBody("after", "file1"),
+	}))
+
+	e := <-fake.Received
+	require.Equal(t, "java.lang.RuntimeException: boom\n\tat com.example.App.run(App.java:42)", e.Body)
+	require.Equal(t, first.Timestamp, e.Timestamp)
+	require.Equal(t, "true", e.Attributes["first"])
+	fake.ExpectBody(t, "after")
+}
+
+func TestTransformerWithoutCombineField(t *testing.T) {
+	op, fake := newTestTransformer(t, NewConfig())
+	defer func() { require.NoError(t, op.Stop()) }()
+
+	structured := entryWithBody("", "file1")
+	structured.Body = map[string]any{"message": "structured"}
+	require.NoError(t, op.ProcessBatch(t.Context(), []*entry.Entry{
+		entryWithBody("java.lang.RuntimeException: boom", "file1"),
+		entryWithBody("\tat com.example.App.run(App.java:42)", "file1"),
+		structured,
+	}))
+
+	fake.ExpectBody(t, "java.lang.RuntimeException: boom\n\tat com.example.App.run(App.java:42)")
+	fake.ExpectBody(t, map[string]any{"message": "structured"})
+}
+
+func newTestTransformer(t *testing.T, cfg *Config) (operator.Operator, *testutil.FakeOutput) {
+	cfg.OutputIDs = []string{"fake"}
+	op, err := cfg.Build(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NoError(t, op.Start(testutil.NewUnscopedMockPersister()))
+
+	fake := testutil.NewFakeOutput(t)
+	require.NoError(t, op.SetOutputs([]operator.Operator{fake}))
+	return op, fake
+}
+
+func entryWithBody(body, source string) *entry.Entry {
+	e := entry.New()
+	e.Body = body
+	e.AddAttribute(attrs.LogFilePath, source)
+	return e
+}
+
+func lines(texts ...string) []string {
+	var result []string
+	for _, text := range texts {
+		result = append(result, strings.Split(text, "\n")...)
+	}
+	return result
+}
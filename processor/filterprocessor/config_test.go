@@ -888,6 +888,33 @@ func TestLoadingConfigOTTL(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "logs_mix_config"),
 			errorMessage: "cannot use ottl conditions and include/exclude for logs at the same time",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:      "IsHealthCheck",
+						Params:    []string{"path"},
+						Condition: `IsMatch(path, "^/health")`,
+					},
+				},
+				Traces: TraceFilters{
+					SpanConditions: []string{
+						`IsHealthCheck(attributes["http.path"])`,
+					},
+				},
+				Logs: LogFilters{
+					LogConditions: []string{
+						`IsHealthCheck(attributes["http.path"])`,
+					},
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_function"),
+			errorMessage: "functions::0: converter \"IsHealthCheck\" must define either an expression or a condition",
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_syntax_span"),
 		},
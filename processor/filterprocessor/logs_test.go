@@ -769,6 +769,32 @@ func TestFilterLogProcessorWithOTTL(t *testing.T) {
 	}
 }
 
+func TestFilterLogProcessorWithFunctions(t *testing.T) {
+	cfg := &Config{
+		Functions: []ottl.FunctionDefinition{
+			{
+				Name:      "IsOperation",
+				Params:    []string{"name"},
+				Condition: `body == Concat(["operation", name], "")`,
+			},
+		},
+		Logs: LogFilters{LogConditions: []string{`IsOperation("A")`}},
+	}
+	processor, err := newFilterLogsProcessor(processortest.NewNopSettings(metadata.Type), cfg)
+	require.NoError(t, err)
+
+	got, err := processor.processLogs(t.Context(), constructLogs())
+	require.NoError(t, err)
+
+	want := constructLogs()
+	for i := 0; i < want.ResourceLogs().At(0).ScopeLogs().Len(); i++ {
+		want.ResourceLogs().At(0).ScopeLogs().At(i).LogRecords().RemoveIf(func(log plog.LogRecord) bool {
+			return log.Body().AsString() == "operationA"
+		})
+	}
+	assert.Equal(t, want, got)
+}
+
 func TestFilterLogProcessorTelemetry(t *testing.T) {
 	tel := componenttest.NewTelemetry()
 	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) }) //nolint:usetesting
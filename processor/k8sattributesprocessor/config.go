@@ -173,6 +173,63 @@ type ExtractConfig struct {
 	// OtelAnnotations extracts all pod annotations with the prefix "resource.opentelemetry.io" as resource attributes
 	// E.g. "resource.opentelemetry.io/foo" becomes "foo"
 	OtelAnnotations bool `mapstructure:"otel_annotations"`
+
+	// Owners allows resolving the owners of pods other than the built-in workloads, usually custom
+	// resources such as Argo Rollouts, Knative Services or Argo Workflows, and extracting their metadata.
+	// The owners are resolved by walking up the owner references of the pods, through ReplicaSets and
+	// the owners of the kinds listed here.
+	// It is a list of OwnerConfig type. See OwnerConfig documentation for more details.
+	Owners []OwnerConfig `mapstructure:"owners"`
+}
+
+// OwnerConfig allows specifying a kind of owner to resolve, and the metadata to extract from it.
+// The resources of this kind must be listed and watched, which requires the matching RBAC permissions.
+type OwnerConfig struct {
+	// Group is the API group of the owner, e.g. argoproj.io.
+	Group string `mapstructure:"group"`
+	// Version is the API version of the owner, e.g. v1alpha1.
+	Version string `mapstructure:"version"`
+	// Resource is the plural name of the resource, e.g. rollouts.
+	Resource string `mapstructure:"resource"`
+	// Kind is the kind of the owner, as found in the owner references, e.g. Rollout.
+	Kind string `mapstructure:"kind"`
+
+	// NameAttribute is the resource attribute the name of the owner is recorded as.
+	// It defaults to k8s.<lowercase kind>.name, e.g. k8s.rollout.name, and can be set to
+	// k8s.deployment.name to report an Argo Rollout as a deployment.
+	NameAttribute string `mapstructure:"name_attribute"`
+	// UIDAttribute is the resource attribute the UID of the owner is recorded as.
+	// It defaults to k8s.<lowercase kind>.uid, e.g. k8s.rollout.uid.
+	UIDAttribute string `mapstructure:"uid_attribute"`
+
+	// Labels allows extracting data from the labels of the owner.
+	// When tag_name is not specified, the attributes are named k8s.<lowercase kind>.label.<label key>.
+	// The from field is not used.
+	Labels []FieldExtractConfig `mapstructure:"labels"`
+	// Annotations allows extracting data from the annotations of the owner.
+	// When tag_name is not specified, the attributes are named k8s.<lowercase kind>.annotation.<annotation key>.
+	// The from field is not used.
+	Annotations []FieldExtractConfig `mapstructure:"annotations"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+func (cfg *OwnerConfig) Validate() error {
+	if cfg.Version == "" || cfg.Resource == "" || cfg.Kind == "" {
+		return fmt.Errorf("owners must have a version, a resource and a kind, currently version:%q, resource:%q and kind:%q", cfg.Version, cfg.Resource, cfg.Kind)
+	}
+	for _, f := range append(cfg.Labels, cfg.Annotations...) {
+		if f.Key != "" && f.KeyRegex != "" {
+			return fmt.Errorf("Out of Key or KeyRegex only one option is expected to be configured at a time, currently Key:%s and KeyRegex:%s", f.Key, f.KeyRegex)
+		}
+		if f.KeyRegex != "" {
+			if _, err := regexp.Compile("^(?:" + f.KeyRegex + ")$"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // FieldExtractConfig allows specifying an extraction rule to extract a resource attribute from pod (or namespace)
@@ -130,6 +130,39 @@ func TestLoadConfig(t *testing.T) {
 				WaitForMetadataTimeout: 10 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "owners"),
+			expected: &Config{
+				APIConfig: k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				Extract: ExtractConfig{
+					Metadata: enabledAttributes(),
+					Owners: []OwnerConfig{
+						{
+							Group:         "argoproj.io",
+							Version:       "v1alpha1",
+							Resource:      "rollouts",
+							Kind:          "Rollout",
+							NameAttribute: "k8s.deployment.name",
+							UIDAttribute:  "k8s.deployment.uid",
+							Labels:        []FieldExtractConfig{{Key: "team"}},
+						},
+						{
+							Group:    "argoproj.io",
+							Version:  "v1alpha1",
+							Resource: "workflows",
+							Kind:     "Workflow",
+						},
+					},
+				},
+				Exclude: ExcludeConfig{
+					Pods: []ExcludePodConfig{
+						{Name: "jaeger-agent"},
+						{Name: "jaeger-collector"},
+					},
+				},
+				WaitForMetadataTimeout: 10 * time.Second,
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "too_many_sources"),
 		},
@@ -151,6 +184,12 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_keyregex_annotations"),
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_owner"),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "bad_owner_keyregex"),
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "bad_filter_label_op"),
 		},
@@ -21,6 +21,7 @@ import (
 	apps_v1 "k8s.io/api/apps/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -57,12 +58,16 @@ type WatchClient struct {
 	deleteMut              sync.Mutex
 	logger                 *zap.Logger
 	kc                     kubernetes.Interface
+	apiCfg                 k8sconfig.APIConfig
+	shareInformers         bool
+	sharedInformers        []*k8sconfig.SharedInformer
 	informer               cache.SharedInformer
 	namespaceInformer      cache.SharedInformer
 	nodeInformer           cache.SharedInformer
 	deploymentInformer     cache.SharedInformer
 	statefulsetInformer    cache.SharedInformer
 	replicasetInformer     cache.SharedInformer
+	ownerInformers         []cache.SharedInformer
 	replicasetRegex        *regexp.Regexp
 	cronJobRegex           *regexp.Regexp
 	deleteQueue            []deleteRequest
@@ -98,6 +103,10 @@ type WatchClient struct {
 	// Key is replicaset uid
 	ReplicaSets map[string]*ReplicaSet
 
+	// A map containing the owners resolved by the owner resolution rules.
+	// Key is owner uid
+	Owners map[string]*Owner
+
 	telemetryBuilder *metadata.TelemetryBuilder
 }
 
@@ -115,6 +124,8 @@ type InformersFactoryList struct {
 	newInformer           InformerProvider
 	newNamespaceInformer  InformerProviderNamespace
 	newReplicaSetInformer InformerProviderWorkload
+	newOwnerInformer      InformerProviderOwner
+	newDynamicClient      APIDynamicClientProvider
 }
 
 // New initializes a new k8s Client.
@@ -155,6 +166,8 @@ func New(
 	c.ReplicaSets = map[string]*ReplicaSet{}
 	c.Deployments = map[string]*Deployment{}
 	c.StatefulSets = map[string]*StatefulSet{}
+	c.Owners = map[string]*Owner{}
+	c.shareInformers = newClientSet == nil
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -164,6 +177,7 @@ func New(
 		return nil, err
 	}
 	c.kc = kc
+	c.apiCfg = apiCfg
 
 	labelSelector, fieldSelector, err := selectorsFromFilters(c.Filters)
 	if err != nil {
@@ -174,11 +188,15 @@ func New(
 		zap.String("labelSelector", labelSelector.String()),
 		zap.String("fieldSelector", fieldSelector.String()),
 	)
-	if informersFactory.newInformer == nil {
+	// the informers created by the default providers are shared with the other components watching the same objects
+	sharedPods := informersFactory.newInformer == nil
+	if sharedPods {
 		informersFactory.newInformer = newSharedInformer
 	}
 
-	if informersFactory.newNamespaceInformer == nil {
+	sharedNamespaces := informersFactory.newNamespaceInformer == nil
+	var namespaceFieldSelector string
+	if sharedNamespaces {
 		switch {
 		case c.extractNamespaceLabelsAnnotations():
 			// if rules to extract metadata from namespace is configured use namespace shared informer containing
@@ -188,13 +206,25 @@ func New(
 			// use kube-system shared informer to only watch kube-system namespace
 			// reducing overhead of watching all the namespaces
 			informersFactory.newNamespaceInformer = newKubeSystemSharedInformer
+			namespaceFieldSelector = fields.OneTermEqualSelector("metadata.name", kubeSystemNamespace).String()
 		default:
 			informersFactory.newNamespaceInformer = NewNoOpInformer
+			sharedNamespaces = false
 		}
 	}
 
-	c.informer = informersFactory.newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
-	err = c.informer.SetTransform(
+	c.informer, err = c.newInformer(
+		sharedPods,
+		k8sconfig.InformerKey{
+			Resource:      api_v1.SchemeGroupVersion.WithResource("pods"),
+			Namespace:     c.Filters.Namespace,
+			LabelSelector: labelSelector.String(),
+			FieldSelector: fieldSelector.String(),
+			Transform:     podFieldsFor(c.Rules).transformName(),
+		},
+		func() cache.SharedInformer {
+			return informersFactory.newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
+		},
 		func(object any) (any, error) {
 			originalPod, success := object.(*api_v1.Pod)
 			if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
@@ -208,14 +238,38 @@ func New(
 		return nil, err
 	}
 
-	c.namespaceInformer = informersFactory.newNamespaceInformer(c.kc)
+	// namespaces are few and small, so they are cached as they are received, which allows sharing them with the
+	// components caching complete namespaces, such as the k8s_cluster receiver
+	c.namespaceInformer, err = c.newInformer(
+		sharedNamespaces,
+		k8sconfig.InformerKey{
+			Resource:      api_v1.SchemeGroupVersion.WithResource("namespaces"),
+			FieldSelector: namespaceFieldSelector,
+		},
+		func() cache.SharedInformer {
+			return informersFactory.newNamespaceInformer(c.kc)
+		},
+		nil,
+	)
+	if err != nil {
+		return nil, err
+	}
 
-	if rules.DeploymentName || rules.DeploymentUID {
-		if informersFactory.newReplicaSetInformer == nil {
+	if c.watchReplicaSets() {
+		sharedReplicaSets := informersFactory.newReplicaSetInformer == nil
+		if sharedReplicaSets {
 			informersFactory.newReplicaSetInformer = newReplicaSetSharedInformer
 		}
-		c.replicasetInformer = informersFactory.newReplicaSetInformer(c.kc, c.Filters.Namespace)
-		err = c.replicasetInformer.SetTransform(
+		c.replicasetInformer, err = c.newInformer(
+			sharedReplicaSets,
+			k8sconfig.InformerKey{
+				Resource:  apps_v1.SchemeGroupVersion.WithResource("replicasets"),
+				Namespace: c.Filters.Namespace,
+				Transform: "k8sattributes/replicaset",
+			},
+			func() cache.SharedInformer {
+				return informersFactory.newReplicaSetInformer(c.kc, c.Filters.Namespace)
+			},
 			func(object any) (any, error) {
 				originalReplicaset, success := object.(*apps_v1.ReplicaSet)
 				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
@@ -230,16 +284,90 @@ func New(
 		}
 	}
 
+	if len(rules.Owners) > 0 {
+		if err = c.newOwnerInformers(apiCfg, informersFactory); err != nil {
+			return nil, err
+		}
+	}
+
 	if c.extractNodeLabelsAnnotations() || c.extractNodeUID() {
-		c.nodeInformer = k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
+		var nodeFieldSelector string
+		if c.Filters.Node != "" {
+			nodeFieldSelector = fields.OneTermEqualSelector("metadata.name", c.Filters.Node).String()
+		}
+		withMetadata := c.extractNodeLabelsAnnotations()
+		c.nodeInformer, err = c.newInformer(
+			true,
+			k8sconfig.InformerKey{
+				Resource:      api_v1.SchemeGroupVersion.WithResource("nodes"),
+				FieldSelector: nodeFieldSelector,
+				Transform:     metadataTransformName("k8sattributes/node", withMetadata),
+			},
+			func() cache.SharedInformer {
+				return k8sconfig.NewNodeSharedInformer(c.kc, c.Filters.Node, 5*time.Minute)
+			},
+			func(object any) (any, error) {
+				originalNode, success := object.(*api_v1.Node)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
+
+				return removeUnnecessaryNodeData(originalNode, withMetadata), nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.extractDeploymentLabelsAnnotations() {
-		c.deploymentInformer = newDeploymentSharedInformer(c.kc, c.Filters.Namespace)
+		c.deploymentInformer, err = c.newInformer(
+			true,
+			k8sconfig.InformerKey{
+				Resource:  apps_v1.SchemeGroupVersion.WithResource("deployments"),
+				Namespace: c.Filters.Namespace,
+				Transform: "k8sattributes/deployment",
+			},
+			func() cache.SharedInformer {
+				return newDeploymentSharedInformer(c.kc, c.Filters.Namespace)
+			},
+			func(object any) (any, error) {
+				originalDeployment, success := object.(*apps_v1.Deployment)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
+
+				return removeUnnecessaryDeploymentData(originalDeployment), nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if c.extractStatefulSetLabelsAnnotations() {
-		c.statefulsetInformer = newStatefulSetSharedInformer(c.kc, c.Filters.Namespace)
+		c.statefulsetInformer, err = c.newInformer(
+			true,
+			k8sconfig.InformerKey{
+				Resource:  apps_v1.SchemeGroupVersion.WithResource("statefulsets"),
+				Namespace: c.Filters.Namespace,
+				Transform: "k8sattributes/statefulset",
+			},
+			func() cache.SharedInformer {
+				return newStatefulSetSharedInformer(c.kc, c.Filters.Namespace)
+			},
+			func(object any) (any, error) {
+				originalStatefulSet, success := object.(*apps_v1.StatefulSet)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
+
+				return removeUnnecessaryStatefulSetData(originalStatefulSet), nil
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return c, err
@@ -250,7 +378,7 @@ func (c *WatchClient) Start() error {
 	synced := make([]cache.InformerSynced, 0)
 	// start the replicaSet informer first, as the replica sets need to be
 	// present at the time the pods are handled, to correctly establish the connection between pods and deployments
-	if c.Rules.DeploymentName || c.Rules.DeploymentUID {
+	if c.watchReplicaSets() {
 		reg, err := c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 			AddFunc:    c.handleReplicaSetAdd,
 			UpdateFunc: c.handleReplicaSetUpdate,
@@ -263,6 +391,15 @@ func (c *WatchClient) Start() error {
 		go c.replicasetInformer.Run(c.stopCh)
 	}
 
+	for i, informer := range c.ownerInformers {
+		reg, err := informer.AddEventHandler(c.ownerEventHandler(&c.Rules.Owners[i]))
+		if err != nil {
+			return err
+		}
+		synced = append(synced, reg.HasSynced)
+		go informer.Run(c.stopCh)
+	}
+
 	reg, err := c.namespaceInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handleNamespaceAdd,
 		UpdateFunc: c.handleNamespaceUpdate,
@@ -313,17 +450,13 @@ func (c *WatchClient) Start() error {
 		go c.statefulsetInformer.Run(c.stopCh)
 	}
 
-	reg, err = c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+	// start the podInformer with the prerequisite of the other informers to be finished first
+	podSynced := make(chan cache.InformerSynced, 1)
+	go c.runInformerWithDependencies(c.informer, cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
 		UpdateFunc: c.handlePodUpdate,
 		DeleteFunc: c.handlePodDelete,
-	})
-	if err != nil {
-		return err
-	}
-
-	// start the podInformer with the prerequisite of the other informers to be finished first
-	go c.runInformerWithDependencies(c.informer, synced)
+	}, synced, podSynced)
 
 	if c.waitForMetadata {
 		timeoutCh := make(chan struct{})
@@ -334,7 +467,12 @@ func (c *WatchClient) Start() error {
 		// Wait for the Pod informer to be completed.
 		// The other informers will already be finished at this point, as the pod informer
 		// waits for them be finished before it can run
-		if !cache.WaitForCacheSync(timeoutCh, reg.HasSynced) {
+		var hasSynced cache.InformerSynced
+		select {
+		case hasSynced = <-podSynced:
+		case <-timeoutCh:
+		}
+		if hasSynced == nil || !cache.WaitForCacheSync(timeoutCh, hasSynced) {
 			return errors.New("failed to wait for caches to sync")
 		}
 	}
@@ -344,6 +482,7 @@ func (c *WatchClient) Start() error {
 // Stop signals the k8s watcher/informer to stop watching for new events.
 func (c *WatchClient) Stop() {
 	close(c.stopCh)
+	c.releaseInformers()
 }
 
 func (c *WatchClient) handlePodAdd(obj any) {
@@ -408,6 +547,7 @@ func (c *WatchClient) handleNamespaceDelete(obj any) {
 			// Thats why we dont need an implementation for deleteQueue and gracePeriod for namespaces.
 			delete(c.Namespaces, ns.Name)
 		}
+		c.telemetryBuilder.OtelsvcK8sNamespaceTableSize.Record(context.Background(), int64(len(c.Namespaces)))
 		c.m.Unlock()
 	} else {
 		c.logger.Error("object received was not of type api_v1.Namespace", zap.Any("received", obj))
@@ -439,6 +579,7 @@ func (c *WatchClient) handleNodeDelete(obj any) {
 		if n, ok := c.Nodes[node.Name]; ok {
 			delete(c.Nodes, n.Name)
 		}
+		c.telemetryBuilder.OtelsvcK8sNodeTableSize.Record(context.Background(), int64(len(c.Nodes)))
 		c.m.Unlock()
 	} else {
 		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
@@ -470,6 +611,7 @@ func (c *WatchClient) handleDeploymentDelete(obj any) {
 		if n, ok := c.Deployments[string(deployment.UID)]; ok {
 			delete(c.Deployments, n.UID)
 		}
+		c.telemetryBuilder.OtelsvcK8sDeploymentTableSize.Record(context.Background(), int64(len(c.Deployments)))
 		c.m.Unlock()
 	} else {
 		c.logger.Error("object received was not of type api_v1.Deployment", zap.Any("received", obj))
@@ -501,6 +643,7 @@ func (c *WatchClient) handleStatefulSetDelete(obj any) {
 		if n, ok := c.StatefulSets[string(statefulset.UID)]; ok {
 			delete(c.StatefulSets, n.UID)
 		}
+		c.telemetryBuilder.OtelsvcK8sStatefulsetTableSize.Record(context.Background(), int64(len(c.StatefulSets)))
 		c.m.Unlock()
 	} else {
 		c.logger.Error("object received was not of type api_v1.StatefulSet", zap.Any("received", obj))
@@ -727,6 +870,10 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		}
 	}
 
+	if len(c.Rules.Owners) > 0 {
+		c.resolveOwners(ownerReferencesFromAPI(pod.OwnerReferences), tags)
+	}
+
 	if c.Rules.Node {
 		tags[tagNodeName] = pod.Spec.NodeName
 	}
@@ -765,8 +912,45 @@ func copyLabel(pod *api_v1.Pod, tags map[string]string, labelKey string, key att
 	}
 }
 
+// podFields are the fields of the pods needed by the extraction rules, besides the ones always needed to identify
+// the pods. The pod informers transforming pods to the same fields can be shared.
+type podFields struct {
+	StartTime   bool
+	Node        bool
+	HostName    bool
+	Containers  bool
+	Images      bool
+	ImageIDs    bool
+	Labels      bool
+	Annotations bool
+	Owners      bool
+}
+
+func podFieldsFor(rules ExtractionRules) podFields {
+	return podFields{
+		StartTime:   rules.StartTime,
+		Node:        rules.Node,
+		HostName:    rules.PodHostName,
+		Containers:  needContainerAttributes(rules),
+		Images:      rules.ContainerImageName || rules.ContainerImageTag || rules.ServiceVersion,
+		ImageIDs:    rules.ContainerImageRepoDigests,
+		Labels:      len(rules.Labels) > 0 || rules.ServiceName || rules.ServiceVersion,
+		Annotations: len(rules.Annotations) > 0,
+		Owners:      rules.IncludesOwnerMetadata(),
+	}
+}
+
+// transformName names the transformation of the pods in the key of the shared pod informers.
+func (f podFields) transformName() string {
+	return fmt.Sprintf("k8sattributes/pod%+v", f)
+}
+
 // This function removes all data from the Pod except what is required by extraction rules and pod association
 func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Pod {
+	return podFieldsFor(rules).removeUnnecessaryData(pod)
+}
+
+func (f podFields) removeUnnecessaryData(pod *api_v1.Pod) *api_v1.Pod {
 	// name, namespace, uid, start time and ip are needed for identifying Pods
 	// there's room to optimize this further, it's kept this way for simplicity
 	transformedPod := api_v1.Pod{
@@ -784,30 +968,26 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 		},
 	}
 
-	if rules.StartTime {
+	if f.StartTime {
 		transformedPod.SetCreationTimestamp(pod.GetCreationTimestamp())
 	}
 
-	if rules.PodUID {
-		transformedPod.SetUID(pod.GetUID())
-	}
-
-	if rules.Node {
+	if f.Node {
 		transformedPod.Spec.NodeName = pod.Spec.NodeName
 	}
 
-	if rules.PodHostName {
+	if f.HostName {
 		transformedPod.Spec.Hostname = pod.Spec.Hostname
 	}
 
-	if needContainerAttributes(rules) {
+	if f.Containers {
 		removeUnnecessaryContainerStatus := func(c api_v1.ContainerStatus) api_v1.ContainerStatus {
 			transformedContainerStatus := api_v1.ContainerStatus{
 				Name:         c.Name,
 				ContainerID:  c.ContainerID,
 				RestartCount: c.RestartCount,
 			}
-			if rules.ContainerImageRepoDigests {
+			if f.ImageIDs {
 				transformedContainerStatus.ImageID = c.ImageID
 			}
 			return transformedContainerStatus
@@ -827,7 +1007,7 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 		removeUnnecessaryContainerData := func(c api_v1.Container) api_v1.Container {
 			transformedContainer := api_v1.Container{}
 			transformedContainer.Name = c.Name // we always need the name, it's used for identification
-			if rules.ContainerImageName || rules.ContainerImageTag || rules.ServiceVersion {
+			if f.Images {
 				transformedContainer.Image = c.Image
 			}
 			return transformedContainer
@@ -845,15 +1025,15 @@ func removeUnnecessaryPodData(pod *api_v1.Pod, rules ExtractionRules) *api_v1.Po
 		}
 	}
 
-	if len(rules.Labels) > 0 || rules.ServiceName || rules.ServiceVersion {
+	if f.Labels {
 		transformedPod.Labels = pod.Labels
 	}
 
-	if len(rules.Annotations) > 0 {
+	if f.Annotations {
 		transformedPod.Annotations = pod.Annotations
 	}
 
-	if rules.IncludesOwnerMetadata() {
+	if f.Owners {
 		transformedPod.SetOwnerReferences(pod.GetOwnerReferences())
 	}
 
@@ -1284,6 +1464,7 @@ func (c *WatchClient) addOrUpdateNamespace(namespace *api_v1.Namespace) {
 	if namespace.Name != "" {
 		c.Namespaces[namespace.Name] = newNamespace
 	}
+	c.telemetryBuilder.OtelsvcK8sNamespaceTableSize.Record(context.Background(), int64(len(c.Namespaces)))
 	c.m.Unlock()
 }
 
@@ -1351,6 +1532,56 @@ func (c *WatchClient) extractNodeLabelsAnnotations() bool {
 	return false
 }
 
+// watchReplicaSets determines whether the ReplicaSets need to be watched, either to find the deployment
+// owning pods, or to walk through them when resolving other owners.
+func (c *WatchClient) watchReplicaSets() bool {
+	return c.Rules.DeploymentName || c.Rules.DeploymentUID || len(c.Rules.Owners) > 0
+}
+
+// newOwnerInformers creates an informer for each owner resolution rule, all sharing the same dynamic client.
+func (c *WatchClient) newOwnerInformers(apiCfg k8sconfig.APIConfig, informersFactory InformersFactoryList) error {
+	if informersFactory.newDynamicClient == nil {
+		informersFactory.newDynamicClient = k8sconfig.MakeDynamicClient
+	}
+	defaultOwnerInformer := informersFactory.newOwnerInformer == nil
+	if defaultOwnerInformer {
+		informersFactory.newOwnerInformer = newOwnerSharedInformer
+	}
+
+	dc, err := informersFactory.newDynamicClient(apiCfg)
+	if err != nil {
+		return err
+	}
+	shared := defaultOwnerInformer
+	for i := range c.Rules.Owners {
+		rule := &c.Rules.Owners[i]
+		informer, err := c.newInformer(
+			shared,
+			k8sconfig.InformerKey{
+				Resource:  rule.Resource,
+				Namespace: c.Filters.Namespace,
+				Transform: rule.transformName(),
+			},
+			func() cache.SharedInformer {
+				return informersFactory.newOwnerInformer(dc, rule.Resource, c.Filters.Namespace)
+			},
+			func(object any) (any, error) {
+				originalOwner, success := object.(*unstructured.Unstructured)
+				if !success { // means this is a cache.DeletedFinalStateUnknown, in which case we do nothing
+					return object, nil
+				}
+
+				return removeUnnecessaryOwnerData(originalOwner, rule), nil
+			},
+		)
+		if err != nil {
+			return err
+		}
+		c.ownerInformers = append(c.ownerInformers, informer)
+	}
+	return nil
+}
+
 func (c *WatchClient) extractNodeUID() bool {
 	return c.Rules.NodeUID
 }
@@ -1366,6 +1597,7 @@ func (c *WatchClient) addOrUpdateNode(node *api_v1.Node) {
 	if node.Name != "" {
 		c.Nodes[node.Name] = newNode
 	}
+	c.telemetryBuilder.OtelsvcK8sNodeTableSize.Record(context.Background(), int64(len(c.Nodes)))
 	c.m.Unlock()
 }
 
@@ -1380,6 +1612,7 @@ func (c *WatchClient) addOrUpdateDeployment(deployment *apps_v1.Deployment) {
 	if deployment.UID != "" {
 		c.Deployments[string(deployment.UID)] = newDeployment
 	}
+	c.telemetryBuilder.OtelsvcK8sDeploymentTableSize.Record(context.Background(), int64(len(c.Deployments)))
 	c.m.Unlock()
 }
 
@@ -1394,6 +1627,7 @@ func (c *WatchClient) addOrUpdateStatefulSet(statefulset *apps_v1.StatefulSet) {
 	if statefulset.UID != "" {
 		c.StatefulSets[string(statefulset.UID)] = newStatefulSet
 	}
+	c.telemetryBuilder.OtelsvcK8sStatefulsetTableSize.Record(context.Background(), int64(len(c.StatefulSets)))
 	c.m.Unlock()
 }
 
@@ -1431,6 +1665,7 @@ func (c *WatchClient) handleReplicaSetDelete(obj any) {
 		c.m.Lock()
 		key := string(replicaset.UID)
 		delete(c.ReplicaSets, key)
+		c.telemetryBuilder.OtelsvcK8sReplicasetTableSize.Record(context.Background(), int64(len(c.ReplicaSets)))
 		c.m.Unlock()
 	} else {
 		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
@@ -1454,10 +1689,15 @@ func (c *WatchClient) addOrUpdateReplicaSet(replicaset *apps_v1.ReplicaSet) {
 		}
 	}
 
+	if len(c.Rules.Owners) > 0 {
+		newReplicaSet.Owners = ownerReferencesFromAPI(replicaset.OwnerReferences)
+	}
+
 	c.m.Lock()
 	if replicaset.UID != "" {
 		c.ReplicaSets[string(replicaset.UID)] = newReplicaSet
 	}
+	c.telemetryBuilder.OtelsvcK8sReplicasetTableSize.Record(context.Background(), int64(len(c.ReplicaSets)))
 	c.m.Unlock()
 }
 
@@ -1474,6 +1714,49 @@ func removeUnnecessaryReplicaSetData(replicaset *apps_v1.ReplicaSet) *apps_v1.Re
 	return &transformedReplicaset
 }
 
+// This function removes all data from the Node except what is required by extraction rules
+func removeUnnecessaryNodeData(node *api_v1.Node, withMetadata bool) *api_v1.Node {
+	transformedNode := api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name: node.GetName(),
+			UID:  node.GetUID(),
+		},
+	}
+	if withMetadata {
+		transformedNode.SetLabels(node.GetLabels())
+		transformedNode.SetAnnotations(node.GetAnnotations())
+	}
+	return &transformedNode
+}
+
+// This function removes all data from the Deployment except what is required by extraction rules
+func removeUnnecessaryDeploymentData(deployment *apps_v1.Deployment) *apps_v1.Deployment {
+	transformedDeployment := apps_v1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        deployment.GetName(),
+			Namespace:   deployment.GetNamespace(),
+			UID:         deployment.GetUID(),
+			Labels:      deployment.GetLabels(),
+			Annotations: deployment.GetAnnotations(),
+		},
+	}
+	return &transformedDeployment
+}
+
+// This function removes all data from the StatefulSet except what is required by extraction rules
+func removeUnnecessaryStatefulSetData(statefulset *apps_v1.StatefulSet) *apps_v1.StatefulSet {
+	transformedStatefulSet := apps_v1.StatefulSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:        statefulset.GetName(),
+			Namespace:   statefulset.GetNamespace(),
+			UID:         statefulset.GetUID(),
+			Labels:      statefulset.GetLabels(),
+			Annotations: statefulset.GetAnnotations(),
+		},
+	}
+	return &transformedStatefulSet
+}
+
 func (c *WatchClient) getReplicaSet(uid string) (*ReplicaSet, bool) {
 	c.m.RLock()
 	replicaset, ok := c.ReplicaSets[uid]
@@ -1494,10 +1777,17 @@ func (c *WatchClient) getStatefulSet(uid string) (*StatefulSet, bool) {
 	return nil, false
 }
 
-// runInformerWithDependencies starts the given informer. The second argument is a list of other informers that should complete
-// before the informer is started. This is necessary e.g. for the pod informer which requires the replica set informer
-// to be finished to correctly establish the connection to the replicaset/deployment it belongs to.
-func (c *WatchClient) runInformerWithDependencies(informer cache.SharedInformer, dependencies []cache.InformerSynced) {
+// runInformerWithDependencies starts the given informer. The third argument is a list of other informers that should complete
+// before the handler is added and the informer is started. This is necessary e.g. for the pod informer which requires the replica set informer
+// to be finished to correctly establish the connection to the replicaset/deployment it belongs to. As the informer may already be running,
+// when it is shared, the handler is only added once the dependencies completed. Its synced function is sent on the given channel, which is
+// closed without it if the handler can't be added.
+func (c *WatchClient) runInformerWithDependencies(
+	informer cache.SharedInformer,
+	handler cache.ResourceEventHandler,
+	dependencies []cache.InformerSynced,
+	synced chan<- cache.InformerSynced,
+) {
 	if len(dependencies) > 0 {
 		timeoutCh := make(chan struct{})
 		// TODO hard coding the timeout for now, check if we should make this configurable
@@ -1507,6 +1797,13 @@ func (c *WatchClient) runInformerWithDependencies(informer cache.SharedInformer,
 		defer t.Stop()
 		cache.WaitForCacheSync(timeoutCh, dependencies...)
 	}
+	reg, err := informer.AddEventHandler(handler)
+	if err != nil {
+		c.logger.Error("failed to add the event handler to the informer", zap.Error(err))
+		close(synced)
+		return
+	}
+	synced <- reg.HasSynced
 	informer.Run(c.stopCh)
 }
 
@@ -4,6 +4,7 @@
 package kube
 
 import (
+	"context"
 	"errors"
 	"regexp"
 	"testing"
@@ -12,6 +13,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -27,6 +30,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/metadatatest"
 )
 
 func newFakeAPIClientset(_ k8sconfig.APIConfig) (kubernetes.Interface, error) {
@@ -2689,3 +2693,54 @@ func TestGetIdentifiersFromAssoc(t *testing.T) {
 		})
 	}
 }
+
+func TestRemoveUnnecessaryObjectData(t *testing.T) {
+	objectMeta := meta_v1.ObjectMeta{
+		Name:              "object",
+		Namespace:         "ns1",
+		UID:               "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+		CreationTimestamp: meta_v1.Now(),
+		ResourceVersion:   "42",
+		Labels:            map[string]string{"label1": "lv1"},
+		Annotations:       map[string]string{"annotation1": "av1"},
+		ManagedFields:     []meta_v1.ManagedFieldsEntry{{Manager: "kubectl"}},
+	}
+
+	node := removeUnnecessaryNodeData(&api_v1.Node{ObjectMeta: objectMeta, Status: api_v1.NodeStatus{Images: []api_v1.ContainerImage{{SizeBytes: 1}}}}, false)
+	assert.Equal(t, &api_v1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: "object", UID: objectMeta.UID}}, node)
+	node = removeUnnecessaryNodeData(&api_v1.Node{ObjectMeta: objectMeta}, true)
+	assert.Equal(t, objectMeta.Labels, node.Labels)
+	assert.Equal(t, objectMeta.Annotations, node.Annotations)
+
+	expectedMeta := meta_v1.ObjectMeta{
+		Name: "object", Namespace: "ns1", UID: objectMeta.UID, Labels: objectMeta.Labels, Annotations: objectMeta.Annotations,
+	}
+	replicas := int32(3)
+	deployment := removeUnnecessaryDeploymentData(&apps_v1.Deployment{ObjectMeta: objectMeta, Spec: apps_v1.DeploymentSpec{Replicas: &replicas}})
+	assert.Equal(t, &apps_v1.Deployment{ObjectMeta: expectedMeta}, deployment)
+	statefulset := removeUnnecessaryStatefulSetData(&apps_v1.StatefulSet{ObjectMeta: objectMeta, Spec: apps_v1.StatefulSetSpec{Replicas: &replicas}})
+	assert.Equal(t, &apps_v1.StatefulSet{ObjectMeta: expectedMeta}, statefulset)
+}
+
+func TestTableSizeTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	factory := InformersFactoryList{
+		newInformer:           NewFakeInformer,
+		newNamespaceInformer:  NewFakeNamespaceInformer,
+		newReplicaSetInformer: NewFakeReplicaSetInformer,
+	}
+	client, err := New(tel.NewTelemetrySettings(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, factory, false, 10*time.Second)
+	require.NoError(t, err)
+	c := client.(*WatchClient)
+
+	c.handleNamespaceAdd(&api_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns1"}})
+	c.handleNamespaceAdd(&api_v1.Namespace{ObjectMeta: meta_v1.ObjectMeta{Name: "ns2"}})
+	c.handleNodeAdd(&api_v1.Node{ObjectMeta: meta_v1.ObjectMeta{Name: "node1"}})
+	c.handleReplicaSetAdd(&apps_v1.ReplicaSet{ObjectMeta: meta_v1.ObjectMeta{Name: "rs1", UID: "rs1-uid"}})
+	c.handleReplicaSetDelete(&apps_v1.ReplicaSet{ObjectMeta: meta_v1.ObjectMeta{Name: "rs1", UID: "rs1-uid"}})
+
+	metadatatest.AssertEqualOtelsvcK8sNamespaceTableSize(t, tel, []metricdata.DataPoint[int64]{{Value: 2}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOtelsvcK8sNodeTableSize(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualOtelsvcK8sReplicasetTableSize(t, tel, []metricdata.DataPoint[int64]{{Value: 0}}, metricdatatest.IgnoreTimestamp())
+}
@@ -9,10 +9,13 @@ import (
 	apps_v1 "k8s.io/api/apps/v1"
 	api_v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
 )
@@ -48,6 +51,15 @@ type InformerProviderWorkload func(
 	namespace string,
 ) cache.SharedInformer
 
+// InformerProviderOwner defines a function type that returns a new SharedInformer. It is used to
+// allow passing custom shared informers to the watch client for fetching arbitrary owners of pods,
+// usually custom resources.
+type InformerProviderOwner func(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer
+
 func newSharedInformer(
 	client kubernetes.Interface,
 	namespace string,
@@ -208,3 +220,23 @@ func statefulsetWatchFuncWithSelectors(client kubernetes.Interface, namespace st
 		return client.AppsV1().StatefulSets(namespace).Watch(context.Background(), opts)
 	}
 }
+
+func newOwnerSharedInformer(
+	client dynamic.Interface,
+	resource schema.GroupVersionResource,
+	namespace string,
+) cache.SharedInformer {
+	informer := cache.NewSharedInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts metav1.ListOptions) (runtime.Object, error) {
+				return client.Resource(resource).Namespace(namespace).List(context.Background(), opts)
+			},
+			WatchFunc: func(opts metav1.ListOptions) (watch.Interface, error) {
+				return client.Resource(resource).Namespace(namespace).Watch(context.Background(), opts)
+			},
+		},
+		&unstructured.Unstructured{},
+		watchSyncPeriod,
+	)
+	return informer
+}
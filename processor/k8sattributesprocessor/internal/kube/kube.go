@@ -11,6 +11,7 @@ import (
 	"go.opentelemetry.io/collector/component"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -108,6 +109,10 @@ type ClientProvider func(component.TelemetrySettings, k8sconfig.APIConfig, Extra
 // Clientset object.
 type APIClientsetProvider func(config k8sconfig.APIConfig) (kubernetes.Interface, error)
 
+// APIDynamicClientProvider defines a func type that initializes and return a new kubernetes
+// dynamic client, used to watch the custom resources owning pods.
+type APIDynamicClientProvider func(config k8sconfig.APIConfig) (dynamic.Interface, error)
+
 // Pod represents a kubernetes pod.
 type Pod struct {
 	Name           string
@@ -255,6 +260,9 @@ type ExtractionRules struct {
 
 	Annotations []FieldExtractionRule
 	Labels      []FieldExtractionRule
+
+	// Owners are the rules to resolve the owners of pods of other kinds than the built-in workloads.
+	Owners []OwnerResolutionRule
 }
 
 // IncludesOwnerMetadata determines whether the ExtractionRules include metadata about Pod Owners
@@ -277,7 +285,7 @@ func (rules *ExtractionRules) IncludesOwnerMetadata() bool {
 			return true
 		}
 	}
-	return rules.ServiceName
+	return rules.ServiceName || len(rules.Owners) > 0
 }
 
 // FieldExtractionRule is used to specify which fields to extract from pod fields
@@ -406,6 +414,8 @@ type ReplicaSet struct {
 	Namespace  string
 	UID        string
 	Deployment Deployment
+	// Owners are only kept when owners are resolved, to walk through the ReplicaSets.
+	Owners []OwnerReference
 }
 
 // StatefulSet represents a kubernetes statefulset.
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	"go.uber.org/zap"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/tools/cache"
+)
+
+// maxOwnerChainLength bounds the number of owners followed from a pod, which protects against
+// owner references forming a cycle.
+const maxOwnerChainLength = 10
+
+// OwnerResolutionRule describes how to resolve the owners of pods of a given kind, which
+// are usually custom resources such as Argo Rollouts, Knative Services or Argo Workflows.
+type OwnerResolutionRule struct {
+	// Resource identifies the resource watched to resolve the owners.
+	Resource schema.GroupVersionResource
+	// Kind is the kind of the owners, as found in the owner references.
+	Kind string
+	// NameAttribute is the attribute the name of the owner is recorded as, if any.
+	NameAttribute string
+	// UIDAttribute is the attribute the UID of the owner is recorded as, if any.
+	UIDAttribute string
+
+	Labels      []FieldExtractionRule
+	Annotations []FieldExtractionRule
+}
+
+func (r *OwnerResolutionRule) matches(ref OwnerReference) bool {
+	if ref.Kind != r.Kind {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	return err == nil && gv.Group == r.Resource.Group
+}
+
+func (r *OwnerResolutionRule) labelsFormat() string {
+	return "k8s." + strings.ToLower(r.Kind) + ".label.%s"
+}
+
+func (r *OwnerResolutionRule) annotationsFormat() string {
+	return "k8s." + strings.ToLower(r.Kind) + ".annotation.%s"
+}
+
+// OwnerReference is a reference from an object to its owner.
+type OwnerReference struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	UID        string
+	Controller bool
+}
+
+// Owner represents a workload resolved by one of the owner resolution rules.
+type Owner struct {
+	Name       string
+	UID        string
+	Attributes map[string]string
+	// Owners are the owners of this workload, which are followed to resolve the whole chain.
+	Owners []OwnerReference
+}
+
+func ownerReferencesFromAPI(refs []meta_v1.OwnerReference) []OwnerReference {
+	if len(refs) == 0 {
+		return nil
+	}
+	owners := make([]OwnerReference, 0, len(refs))
+	for _, ref := range refs {
+		owners = append(owners, OwnerReference{
+			APIVersion: ref.APIVersion,
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			UID:        string(ref.UID),
+			Controller: ref.Controller != nil && *ref.Controller,
+		})
+	}
+	return owners
+}
+
+// controllerOf returns the owner managing an object, which is the one flagged as its controller,
+// or its only owner when none is flagged.
+func controllerOf(refs []OwnerReference) (OwnerReference, bool) {
+	for _, ref := range refs {
+		if ref.Controller {
+			return ref, true
+		}
+	}
+	if len(refs) == 1 {
+		return refs[0], true
+	}
+	return OwnerReference{}, false
+}
+
+// resolveOwners walks up the owners of a pod, adding the attributes of the owners matching one of
+// the owner resolution rules. ReplicaSets are walked through, so that the owners of ReplicaSets
+// other than Deployments, like Argo Rollouts, are resolved as well.
+func (c *WatchClient) resolveOwners(refs []OwnerReference, tags map[string]string) {
+	visited := map[string]bool{}
+	for range maxOwnerChainLength {
+		ref, ok := controllerOf(refs)
+		if !ok || visited[ref.UID] {
+			return
+		}
+		visited[ref.UID] = true
+
+		if rule, ok := c.ownerRuleFor(ref); ok {
+			if rule.NameAttribute != "" {
+				tags[rule.NameAttribute] = ref.Name
+			}
+			if rule.UIDAttribute != "" {
+				tags[rule.UIDAttribute] = ref.UID
+			}
+			owner, found := c.getOwner(ref.UID)
+			if !found {
+				return
+			}
+			maps.Copy(tags, owner.Attributes)
+			refs = owner.Owners
+			continue
+		}
+
+		if ref.Kind != "ReplicaSet" {
+			return
+		}
+		replicaset, found := c.getReplicaSet(ref.UID)
+		if !found {
+			return
+		}
+		refs = replicaset.Owners
+	}
+}
+
+func (c *WatchClient) ownerRuleFor(ref OwnerReference) (*OwnerResolutionRule, bool) {
+	for i := range c.Rules.Owners {
+		if c.Rules.Owners[i].matches(ref) {
+			return &c.Rules.Owners[i], true
+		}
+	}
+	return nil, false
+}
+
+func (c *WatchClient) getOwner(uid string) (*Owner, bool) {
+	c.m.RLock()
+	owner, ok := c.Owners[uid]
+	c.m.RUnlock()
+	return owner, ok
+}
+
+func (c *WatchClient) ownerEventHandler(rule *OwnerResolutionRule) cache.ResourceEventHandlerFuncs {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj any) {
+			c.handleOwnerAddOrUpdate(rule, obj)
+		},
+		UpdateFunc: func(_, newObj any) {
+			c.handleOwnerAddOrUpdate(rule, newObj)
+		},
+		DeleteFunc: func(obj any) {
+			c.handleOwnerDelete(rule, obj)
+		},
+	}
+}
+
+func (c *WatchClient) handleOwnerAddOrUpdate(rule *OwnerResolutionRule, obj any) {
+	if owner, ok := obj.(*unstructured.Unstructured); ok {
+		c.addOrUpdateOwner(rule, owner)
+	} else {
+		c.logger.Error(fmt.Sprintf("object received was not of type %s", rule.Kind), zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleOwnerDelete(rule *OwnerResolutionRule, obj any) {
+	if owner, ok := ignoreDeletedFinalStateUnknown(obj).(*unstructured.Unstructured); ok {
+		c.m.Lock()
+		delete(c.Owners, string(owner.GetUID()))
+		c.telemetryBuilder.OtelsvcK8sOwnerTableSize.Record(context.Background(), int64(len(c.Owners)))
+		c.m.Unlock()
+	} else {
+		c.logger.Error(fmt.Sprintf("object received was not of type %s", rule.Kind), zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) addOrUpdateOwner(rule *OwnerResolutionRule, obj *unstructured.Unstructured) {
+	newOwner := &Owner{
+		Name:       obj.GetName(),
+		UID:        string(obj.GetUID()),
+		Attributes: map[string]string{},
+		Owners:     ownerReferencesFromAPI(obj.GetOwnerReferences()),
+	}
+	for _, r := range rule.Labels {
+		r.extractFromMetadata(obj.GetLabels(), newOwner.Attributes, rule.labelsFormat())
+	}
+	for _, r := range rule.Annotations {
+		r.extractFromMetadata(obj.GetAnnotations(), newOwner.Attributes, rule.annotationsFormat())
+	}
+
+	c.m.Lock()
+	if newOwner.UID != "" {
+		c.Owners[newOwner.UID] = newOwner
+	}
+	c.telemetryBuilder.OtelsvcK8sOwnerTableSize.Record(context.Background(), int64(len(c.Owners)))
+	c.m.Unlock()
+}
+
+// transformName names the transformation of the owners in the key of the shared owner informers.
+func (r *OwnerResolutionRule) transformName() string {
+	return fmt.Sprintf("k8sattributes/owner{Labels:%t Annotations:%t}", len(r.Labels) > 0, len(r.Annotations) > 0)
+}
+
+// removeUnnecessaryOwnerData removes all data from the owner except what is required to walk the
+// owner references and by the extraction rules.
+func removeUnnecessaryOwnerData(obj *unstructured.Unstructured, rule *OwnerResolutionRule) *unstructured.Unstructured {
+	transformed := &unstructured.Unstructured{Object: map[string]any{}}
+	transformed.SetAPIVersion(obj.GetAPIVersion())
+	transformed.SetKind(obj.GetKind())
+	transformed.SetName(obj.GetName())
+	transformed.SetNamespace(obj.GetNamespace())
+	transformed.SetUID(obj.GetUID())
+	transformed.SetResourceVersion(obj.GetResourceVersion())
+	transformed.SetOwnerReferences(obj.GetOwnerReferences())
+	if len(rule.Labels) > 0 {
+		transformed.SetLabels(obj.GetLabels())
+	}
+	if len(rule.Annotations) > 0 {
+		transformed.SetAnnotations(obj.GetAnnotations())
+	}
+	return transformed
+}
@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	apps_v1 "k8s.io/api/apps/v1"
+	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+var (
+	rolloutRule = OwnerResolutionRule{
+		Resource:      schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"},
+		Kind:          "Rollout",
+		NameAttribute: "k8s.deployment.name",
+		UIDAttribute:  "k8s.rollout.uid",
+		Labels: []FieldExtractionRule{
+			{Name: "team", Key: "team"},
+		},
+		Annotations: []FieldExtractionRule{
+			{KeyRegex: regexp.MustCompile(`^rollout\.argoproj\.io/(.+)$`)},
+		},
+	}
+	knativeRevisionRule = OwnerResolutionRule{
+		Resource:      schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "revisions"},
+		Kind:          "Revision",
+		NameAttribute: "k8s.revision.name",
+	}
+	knativeConfigurationRule = OwnerResolutionRule{
+		Resource: schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "configurations"},
+		Kind:     "Configuration",
+	}
+	knativeServiceRule = OwnerResolutionRule{
+		Resource:      schema.GroupVersionResource{Group: "serving.knative.dev", Version: "v1", Resource: "services"},
+		Kind:          "Service",
+		NameAttribute: "k8s.knative.service.name",
+		Labels: []FieldExtractionRule{
+			{Name: "service.version", Key: "app.kubernetes.io/version"},
+		},
+	}
+	deploymentRule = OwnerResolutionRule{
+		Resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		Kind:     "Deployment",
+	}
+	workflowRule = OwnerResolutionRule{
+		Resource:      schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "workflows"},
+		Kind:          "Workflow",
+		NameAttribute: "k8s.workflow.name",
+		UIDAttribute:  "k8s.workflow.uid",
+	}
+)
+
+func newOwner(apiVersion, kind, name, uid string, owners ...meta_v1.OwnerReference) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(apiVersion)
+	obj.SetKind(kind)
+	obj.SetName(name)
+	obj.SetNamespace("ns1")
+	obj.SetUID(types.UID(uid))
+	obj.SetOwnerReferences(owners)
+	return obj
+}
+
+func controllerRef(apiVersion, kind, name, uid string) meta_v1.OwnerReference {
+	isController := true
+	return meta_v1.OwnerReference{APIVersion: apiVersion, Kind: kind, Name: name, UID: types.UID(uid), Controller: &isController}
+}
+
+func TestOwnerResolution(t *testing.T) {
+	rollout := newOwner("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid")
+	rollout.SetLabels(map[string]string{"team": "payments", "other": "ignored"})
+	rollout.SetAnnotations(map[string]string{"rollout.argoproj.io/revision": "3"})
+	knativeService := newOwner("serving.knative.dev/v1", "Service", "hello", "service-uid")
+	knativeService.SetLabels(map[string]string{"app.kubernetes.io/version": "1.2.0"})
+
+	testCases := []struct {
+		name        string
+		rules       []OwnerResolutionRule
+		replicasets []*apps_v1.ReplicaSet
+		owners      map[*OwnerResolutionRule][]*unstructured.Unstructured
+		podOwners   []meta_v1.OwnerReference
+		attributes  map[string]string
+	}{
+		{
+			name:  "argo rollout",
+			rules: []OwnerResolutionRule{rolloutRule},
+			replicasets: []*apps_v1.ReplicaSet{{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name: "checkout-5d8f7", UID: "rs-uid", Namespace: "ns1",
+					OwnerReferences: []meta_v1.OwnerReference{controllerRef("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid")},
+				},
+			}},
+			owners:    map[*OwnerResolutionRule][]*unstructured.Unstructured{&rolloutRule: {rollout}},
+			podOwners: []meta_v1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "checkout-5d8f7", "rs-uid")},
+			attributes: map[string]string{
+				"k8s.deployment.name": "checkout",
+				"k8s.rollout.uid":     "rollout-uid",
+				"team":                "payments",
+				"k8s.rollout.annotation.rollout.argoproj.io/revision": "3",
+			},
+		},
+		{
+			name:  "argo rollout not in the cache",
+			rules: []OwnerResolutionRule{rolloutRule},
+			replicasets: []*apps_v1.ReplicaSet{{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name: "checkout-5d8f7", UID: "rs-uid", Namespace: "ns1",
+					OwnerReferences: []meta_v1.OwnerReference{controllerRef("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid")},
+				},
+			}},
+			podOwners: []meta_v1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "checkout-5d8f7", "rs-uid")},
+			attributes: map[string]string{
+				"k8s.deployment.name": "checkout",
+				"k8s.rollout.uid":     "rollout-uid",
+			},
+		},
+		{
+			name:  "knative service",
+			rules: []OwnerResolutionRule{deploymentRule, knativeRevisionRule, knativeConfigurationRule, knativeServiceRule},
+			replicasets: []*apps_v1.ReplicaSet{{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name: "hello-00001-deployment-7f9c", UID: "rs-uid", Namespace: "ns1",
+					OwnerReferences: []meta_v1.OwnerReference{controllerRef("apps/v1", "Deployment", "hello-00001-deployment", "deployment-uid")},
+				},
+			}},
+			owners: map[*OwnerResolutionRule][]*unstructured.Unstructured{
+				&deploymentRule: {newOwner("apps/v1", "Deployment", "hello-00001-deployment", "deployment-uid",
+					controllerRef("serving.knative.dev/v1", "Revision", "hello-00001", "revision-uid"))},
+				&knativeRevisionRule: {newOwner("serving.knative.dev/v1", "Revision", "hello-00001", "revision-uid",
+					controllerRef("serving.knative.dev/v1", "Configuration", "hello", "configuration-uid"))},
+				&knativeConfigurationRule: {newOwner("serving.knative.dev/v1", "Configuration", "hello", "configuration-uid",
+					controllerRef("serving.knative.dev/v1", "Service", "hello", "service-uid"))},
+				&knativeServiceRule: {knativeService},
+			},
+			podOwners: []meta_v1.OwnerReference{controllerRef("apps/v1", "ReplicaSet", "hello-00001-deployment-7f9c", "rs-uid")},
+			attributes: map[string]string{
+				"k8s.revision.name":        "hello-00001",
+				"k8s.knative.service.name": "hello",
+				"service.version":          "1.2.0",
+			},
+		},
+		{
+			name:      "argo workflow",
+			rules:     []OwnerResolutionRule{workflowRule},
+			podOwners: []meta_v1.OwnerReference{controllerRef("argoproj.io/v1alpha1", "Workflow", "nightly-build", "workflow-uid")},
+			attributes: map[string]string{
+				"k8s.workflow.name": "nightly-build",
+				"k8s.workflow.uid":  "workflow-uid",
+			},
+		},
+		{
+			name:      "different group",
+			rules:     []OwnerResolutionRule{workflowRule},
+			podOwners: []meta_v1.OwnerReference{controllerRef("example.com/v1", "Workflow", "nightly-build", "workflow-uid")},
+		},
+		{
+			name:  "owner cycle",
+			rules: []OwnerResolutionRule{rolloutRule},
+			owners: map[*OwnerResolutionRule][]*unstructured.Unstructured{
+				&rolloutRule: {newOwner("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid",
+					controllerRef("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid"))},
+			},
+			podOwners: []meta_v1.OwnerReference{controllerRef("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid")},
+			attributes: map[string]string{
+				"k8s.deployment.name": "checkout",
+				"k8s.rollout.uid":     "rollout-uid",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, _ := newTestClient(t)
+			// Disable saving ip into k8s.pod.ip
+			c.Associations[0].Sources[0].Name = ""
+			c.Rules = ExtractionRules{Owners: tc.rules}
+
+			for _, rs := range tc.replicasets {
+				c.handleReplicaSetAdd(removeUnnecessaryReplicaSetData(rs))
+			}
+			for rule, owners := range tc.owners {
+				handler := c.ownerEventHandler(rule)
+				for _, owner := range owners {
+					handler.OnAdd(removeUnnecessaryOwnerData(owner, rule), false)
+				}
+			}
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name:            "pod",
+					UID:             "pod-uid",
+					Namespace:       "ns1",
+					OwnerReferences: tc.podOwners,
+				},
+				Status: api_v1.PodStatus{PodIP: "1.1.1.1"},
+			}
+			c.handlePodAdd(removeUnnecessaryPodData(pod, c.Rules))
+
+			p, ok := c.GetPod(newPodIdentifier("connection", "", "1.1.1.1"))
+			require.True(t, ok)
+			if tc.attributes == nil {
+				tc.attributes = map[string]string{}
+			}
+			assert.Equal(t, tc.attributes, p.Attributes)
+		})
+	}
+}
+
+func TestOwnerHandlers(t *testing.T) {
+	c, logs := newTestClient(t)
+	c.Rules = ExtractionRules{Owners: []OwnerResolutionRule{rolloutRule}}
+	handler := c.ownerEventHandler(&c.Rules.Owners[0])
+
+	rollout := newOwner("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid")
+	rollout.SetLabels(map[string]string{"team": "payments"})
+	handler.OnAdd(rollout, false)
+	require.Contains(t, c.Owners, "rollout-uid")
+	assert.Equal(t, map[string]string{"team": "payments"}, c.Owners["rollout-uid"].Attributes)
+
+	updated := rollout.DeepCopy()
+	updated.SetLabels(map[string]string{"team": "checkout"})
+	handler.OnUpdate(rollout, updated)
+	assert.Equal(t, map[string]string{"team": "checkout"}, c.Owners["rollout-uid"].Attributes)
+
+	handler.OnDelete(cache.DeletedFinalStateUnknown{Obj: updated})
+	assert.Empty(t, c.Owners)
+
+	handler.OnAdd(&api_v1.Pod{}, false)
+	assert.Equal(t, 1, logs.FilterMessage("object received was not of type Rollout").Len())
+}
+
+func TestRemoveUnnecessaryOwnerData(t *testing.T) {
+	owner := newOwner("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid",
+		controllerRef("argoproj.io/v1alpha1", "Other", "other", "other-uid"))
+	owner.SetLabels(map[string]string{"team": "payments"})
+	owner.SetAnnotations(map[string]string{"note": "large"})
+	require.NoError(t, unstructured.SetNestedField(owner.Object, int64(3), "spec", "replicas"))
+
+	transformed := removeUnnecessaryOwnerData(owner, &OwnerResolutionRule{
+		Labels: []FieldExtractionRule{{Name: "team", Key: "team"}},
+	})
+
+	assert.Equal(t, "checkout", transformed.GetName())
+	assert.Equal(t, "ns1", transformed.GetNamespace())
+	assert.Equal(t, types.UID("rollout-uid"), transformed.GetUID())
+	assert.Equal(t, owner.GetOwnerReferences(), transformed.GetOwnerReferences())
+	assert.Equal(t, map[string]string{"team": "payments"}, transformed.GetLabels())
+	assert.Empty(t, transformed.GetAnnotations())
+	assert.NotContains(t, transformed.Object, "spec")
+}
+
+func TestNewOwnerInformers(t *testing.T) {
+	set := componenttest.NewNopTelemetrySettings()
+	scheme := runtime.NewScheme()
+	dc := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+		rolloutRule.Resource: "RolloutList",
+	}, newOwner("argoproj.io/v1alpha1", "Rollout", "checkout", "rollout-uid"))
+
+	var watched []schema.GroupVersionResource
+	factory := InformersFactoryList{
+		newInformer:           NewFakeInformer,
+		newNamespaceInformer:  NewFakeNamespaceInformer,
+		newReplicaSetInformer: NewFakeReplicaSetInformer,
+		newDynamicClient: func(k8sconfig.APIConfig) (dynamic.Interface, error) {
+			return dc, nil
+		},
+		newOwnerInformer: func(client dynamic.Interface, resource schema.GroupVersionResource, namespace string) cache.SharedInformer {
+			watched = append(watched, resource)
+			return newOwnerSharedInformer(client, resource, namespace)
+		},
+	}
+	rules := ExtractionRules{Owners: []OwnerResolutionRule{rolloutRule}}
+	c, err := New(set, k8sconfig.APIConfig{}, rules, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, factory, false, 10*time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, []schema.GroupVersionResource{rolloutRule.Resource}, watched)
+
+	wc := c.(*WatchClient)
+	require.NoError(t, c.Start())
+	defer c.Stop()
+	assert.EventuallyWithT(t, func(collect *assert.CollectT) {
+		owner, ok := wc.getOwner("rollout-uid")
+		assert.True(collect, ok)
+		assert.Equal(collect, "checkout", owner.Name)
+	}, 5*time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor/internal/kube"
+
+import (
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+// newInformer returns the informer described by the key, with the transformation applied to the objects before
+// they are cached. The informers are shared, through the k8sconfig package, with the other processors and
+// components acquiring an informer with the same key, e.g. the k8sattributes processors of the pipelines of
+// other signals, so that the kubernetes objects are only watched and cached once. Every client keeps its own
+// event handlers, tables of extracted metadata and telemetry.
+//
+// The informers are only shared when both the clientset and the informer are created by the default providers,
+// the key only describes the informers created by them.
+func (c *WatchClient) newInformer(
+	shared bool,
+	key k8sconfig.InformerKey,
+	newInformer func() cache.SharedInformer,
+	transform cache.TransformFunc,
+) (cache.SharedInformer, error) {
+	create := func() (cache.SharedInformer, error) {
+		informer := newInformer()
+		if transform == nil {
+			return informer, nil
+		}
+		if err := informer.SetTransform(transform); err != nil {
+			return nil, err
+		}
+		return informer, nil
+	}
+	if !shared || !c.shareInformers {
+		return create()
+	}
+
+	key.APIConfig = c.apiCfg
+	informer, err := k8sconfig.AcquireSharedInformer(key, create)
+	if err != nil {
+		return nil, err
+	}
+	c.sharedInformers = append(c.sharedInformers, informer)
+	return informer, nil
+}
+
+// releaseInformers releases the shared informers, which are stopped once no other component uses them.
+func (c *WatchClient) releaseInformers() {
+	for _, informer := range c.sharedInformers {
+		informer.Release()
+	}
+	c.sharedInformers = nil
+}
+
+// metadataTransformName names the transformation of objects of which the labels and annotations are only kept
+// when withMetadata is set.
+func metadataTransformName(name string, withMetadata bool) string {
+	if withMetadata {
+		return name + "/metadata"
+	}
+	return name
+}
@@ -0,0 +1,89 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package kube
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	api_v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
+)
+
+func newSharingTestClient(share bool) *WatchClient {
+	return &WatchClient{
+		kc:             fake.NewClientset(),
+		apiCfg:         k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeNone},
+		shareInformers: share,
+	}
+}
+
+func newSharingTestInformer(t *testing.T, c *WatchClient, rules ExtractionRules, created *int) cache.SharedInformer {
+	informer, err := c.newInformer(
+		true,
+		k8sconfig.InformerKey{
+			Resource:  api_v1.SchemeGroupVersion.WithResource("pods"),
+			Transform: podFieldsFor(rules).transformName(),
+		},
+		func() cache.SharedInformer {
+			*created++
+			return NewFakeInformer(c.kc, "", nil, nil)
+		},
+		nil,
+	)
+	require.NoError(t, err)
+	return informer
+}
+
+func TestSharedInformers(t *testing.T) {
+	labels := []FieldExtractionRule{{Name: "l1", KeyRegex: regexp.MustCompile("^l.*$")}}
+	traces := newSharingTestClient(true)
+	metrics := newSharingTestClient(true)
+	logs := newSharingTestClient(true)
+	created := 0
+
+	// rules extracting different attributes from the same pod fields share the informer
+	tracesInformer := newSharingTestInformer(t, traces, ExtractionRules{PodName: true, Labels: labels}, &created)
+	metricsInformer := newSharingTestInformer(t, metrics, ExtractionRules{Namespace: true, Labels: []FieldExtractionRule{{Name: "l2", Key: "l2"}}}, &created)
+	assert.Equal(t, 1, created)
+	assert.Same(t, tracesInformer.(*k8sconfig.SharedInformer).SharedInformer, metricsInformer.(*k8sconfig.SharedInformer).SharedInformer)
+
+	// rules needing other pod fields don't
+	logsInformer := newSharingTestInformer(t, logs, ExtractionRules{PodName: true, Labels: labels, Node: true}, &created)
+	assert.Equal(t, 2, created)
+	assert.NotSame(t, tracesInformer.(*k8sconfig.SharedInformer).SharedInformer, logsInformer.(*k8sconfig.SharedInformer).SharedInformer)
+
+	// the informer is created again once all the clients using it released it
+	traces.releaseInformers()
+	metrics.releaseInformers()
+	logs.releaseInformers()
+	assert.Empty(t, traces.sharedInformers)
+	newSharingTestInformer(t, traces, ExtractionRules{PodName: true, Labels: labels}, &created)
+	assert.Equal(t, 3, created)
+	traces.releaseInformers()
+}
+
+func TestSharedInformersNotShared(t *testing.T) {
+	c := newSharingTestClient(false)
+	created := 0
+
+	first := newSharingTestInformer(t, c, ExtractionRules{}, &created)
+	second := newSharingTestInformer(t, c, ExtractionRules{}, &created)
+	assert.Equal(t, 2, created)
+	assert.IsType(t, &FakeInformer{}, first)
+	assert.NotSame(t, first, second)
+	assert.Empty(t, c.sharedInformers)
+}
+
+func TestPodFieldsFor(t *testing.T) {
+	assert.Equal(t, podFields{}, podFieldsFor(ExtractionRules{PodName: true, Namespace: true, PodUID: true}))
+	assert.Equal(t, podFields{Containers: true, Images: true, Labels: true}, podFieldsFor(ExtractionRules{ServiceVersion: true}))
+	assert.Equal(t, podFields{Containers: true, ImageIDs: true}, podFieldsFor(ExtractionRules{ContainerImageRepoDigests: true}))
+	assert.NotEqual(t, podFieldsFor(ExtractionRules{}).transformName(), podFieldsFor(ExtractionRules{StartTime: true}).transformName())
+}
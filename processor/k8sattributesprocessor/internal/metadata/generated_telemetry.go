@@ -23,29 +23,35 @@ func Tracer(settings component.TelemetrySettings) trace.Tracer {
 // TelemetryBuilder provides an interface for components to report telemetry
 // as defined in metadata and user config.
 type TelemetryBuilder struct {
-	meter                        metric.Meter
-	mu                           sync.Mutex
-	registrations                []metric.Registration
-	OtelsvcK8sDeploymentAdded    metric.Int64Counter
-	OtelsvcK8sDeploymentDeleted  metric.Int64Counter
-	OtelsvcK8sDeploymentUpdated  metric.Int64Counter
-	OtelsvcK8sIPLookupMiss       metric.Int64Counter
-	OtelsvcK8sNamespaceAdded     metric.Int64Counter
-	OtelsvcK8sNamespaceDeleted   metric.Int64Counter
-	OtelsvcK8sNamespaceUpdated   metric.Int64Counter
-	OtelsvcK8sNodeAdded          metric.Int64Counter
-	OtelsvcK8sNodeDeleted        metric.Int64Counter
-	OtelsvcK8sNodeUpdated        metric.Int64Counter
-	OtelsvcK8sPodAdded           metric.Int64Counter
-	OtelsvcK8sPodDeleted         metric.Int64Counter
-	OtelsvcK8sPodTableSize       metric.Int64Gauge
-	OtelsvcK8sPodUpdated         metric.Int64Counter
-	OtelsvcK8sReplicasetAdded    metric.Int64Counter
-	OtelsvcK8sReplicasetDeleted  metric.Int64Counter
-	OtelsvcK8sReplicasetUpdated  metric.Int64Counter
-	OtelsvcK8sStatefulsetAdded   metric.Int64Counter
-	OtelsvcK8sStatefulsetDeleted metric.Int64Counter
-	OtelsvcK8sStatefulsetUpdated metric.Int64Counter
+	meter                          metric.Meter
+	mu                             sync.Mutex
+	registrations                  []metric.Registration
+	OtelsvcK8sDeploymentAdded      metric.Int64Counter
+	OtelsvcK8sDeploymentDeleted    metric.Int64Counter
+	OtelsvcK8sDeploymentTableSize  metric.Int64Gauge
+	OtelsvcK8sDeploymentUpdated    metric.Int64Counter
+	OtelsvcK8sIPLookupMiss         metric.Int64Counter
+	OtelsvcK8sNamespaceAdded       metric.Int64Counter
+	OtelsvcK8sNamespaceDeleted     metric.Int64Counter
+	OtelsvcK8sNamespaceTableSize   metric.Int64Gauge
+	OtelsvcK8sNamespaceUpdated     metric.Int64Counter
+	OtelsvcK8sNodeAdded            metric.Int64Counter
+	OtelsvcK8sNodeDeleted          metric.Int64Counter
+	OtelsvcK8sNodeTableSize        metric.Int64Gauge
+	OtelsvcK8sNodeUpdated          metric.Int64Counter
+	OtelsvcK8sOwnerTableSize       metric.Int64Gauge
+	OtelsvcK8sPodAdded             metric.Int64Counter
+	OtelsvcK8sPodDeleted           metric.Int64Counter
+	OtelsvcK8sPodTableSize         metric.Int64Gauge
+	OtelsvcK8sPodUpdated           metric.Int64Counter
+	OtelsvcK8sReplicasetAdded      metric.Int64Counter
+	OtelsvcK8sReplicasetDeleted    metric.Int64Counter
+	OtelsvcK8sReplicasetTableSize  metric.Int64Gauge
+	OtelsvcK8sReplicasetUpdated    metric.Int64Counter
+	OtelsvcK8sStatefulsetAdded     metric.Int64Counter
+	OtelsvcK8sStatefulsetDeleted   metric.Int64Counter
+	OtelsvcK8sStatefulsetTableSize metric.Int64Gauge
+	OtelsvcK8sStatefulsetUpdated   metric.Int64Counter
 }
 
 // TelemetryBuilderOption applies changes to default builder.
@@ -89,6 +95,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sDeploymentTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_otelsvc_k8s_deployment_table_size",
+		metric.WithDescription("Size of table containing deployment info"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sDeploymentUpdated, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_deployment_updated",
 		metric.WithDescription("Number of deployment update events received"),
@@ -113,6 +125,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sNamespaceTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_otelsvc_k8s_namespace_table_size",
+		metric.WithDescription("Size of table containing namespace info"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sNamespaceUpdated, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_namespace_updated",
 		metric.WithDescription("Number of namespace update events received"),
@@ -131,12 +149,24 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sNodeTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_otelsvc_k8s_node_table_size",
+		metric.WithDescription("Size of table containing node info"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sNodeUpdated, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_node_updated",
 		metric.WithDescription("Number of node update events received"),
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sOwnerTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_otelsvc_k8s_owner_table_size",
+		metric.WithDescription("Size of table containing the info of the owners resolved by the owner resolution rules"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sPodAdded, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_pod_added",
 		metric.WithDescription("Number of pod add events received"),
@@ -173,6 +203,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sReplicasetTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_otelsvc_k8s_replicaset_table_size",
+		metric.WithDescription("Size of table containing ReplicaSet info"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sReplicasetUpdated, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_replicaset_updated",
 		metric.WithDescription("Number of ReplicaSet update events received"),
@@ -191,6 +227,12 @@ func NewTelemetryBuilder(settings component.TelemetrySettings, options ...Teleme
 		metric.WithUnit("1"),
 	)
 	errs = errors.Join(errs, err)
+	builder.OtelsvcK8sStatefulsetTableSize, err = builder.meter.Int64Gauge(
+		"otelcol_otelsvc_k8s_statefulset_table_size",
+		metric.WithDescription("Size of table containing statefulset info"),
+		metric.WithUnit("1"),
+	)
+	errs = errors.Join(errs, err)
 	builder.OtelsvcK8sStatefulsetUpdated, err = builder.meter.Int64Counter(
 		"otelcol_otelsvc_k8s_statefulset_updated",
 		metric.WithDescription("Number of statefulset update events received"),
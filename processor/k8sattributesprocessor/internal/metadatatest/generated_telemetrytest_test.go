@@ -22,23 +22,29 @@ func TestSetupTelemetry(t *testing.T) {
 	defer tb.Shutdown()
 	tb.OtelsvcK8sDeploymentAdded.Add(context.Background(), 1)
 	tb.OtelsvcK8sDeploymentDeleted.Add(context.Background(), 1)
+	tb.OtelsvcK8sDeploymentTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sDeploymentUpdated.Add(context.Background(), 1)
 	tb.OtelsvcK8sIPLookupMiss.Add(context.Background(), 1)
 	tb.OtelsvcK8sNamespaceAdded.Add(context.Background(), 1)
 	tb.OtelsvcK8sNamespaceDeleted.Add(context.Background(), 1)
+	tb.OtelsvcK8sNamespaceTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sNamespaceUpdated.Add(context.Background(), 1)
 	tb.OtelsvcK8sNodeAdded.Add(context.Background(), 1)
 	tb.OtelsvcK8sNodeDeleted.Add(context.Background(), 1)
+	tb.OtelsvcK8sNodeTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sNodeUpdated.Add(context.Background(), 1)
+	tb.OtelsvcK8sOwnerTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sPodAdded.Add(context.Background(), 1)
 	tb.OtelsvcK8sPodDeleted.Add(context.Background(), 1)
 	tb.OtelsvcK8sPodTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sPodUpdated.Add(context.Background(), 1)
 	tb.OtelsvcK8sReplicasetAdded.Add(context.Background(), 1)
 	tb.OtelsvcK8sReplicasetDeleted.Add(context.Background(), 1)
+	tb.OtelsvcK8sReplicasetTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sReplicasetUpdated.Add(context.Background(), 1)
 	tb.OtelsvcK8sStatefulsetAdded.Add(context.Background(), 1)
 	tb.OtelsvcK8sStatefulsetDeleted.Add(context.Background(), 1)
+	tb.OtelsvcK8sStatefulsetTableSize.Record(context.Background(), 1)
 	tb.OtelsvcK8sStatefulsetUpdated.Add(context.Background(), 1)
 	AssertEqualOtelsvcK8sDeploymentAdded(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
@@ -46,6 +52,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualOtelsvcK8sDeploymentDeleted(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualOtelsvcK8sDeploymentTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualOtelsvcK8sDeploymentUpdated(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -58,6 +67,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualOtelsvcK8sNamespaceDeleted(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualOtelsvcK8sNamespaceTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualOtelsvcK8sNamespaceUpdated(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -67,9 +79,15 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualOtelsvcK8sNodeDeleted(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualOtelsvcK8sNodeTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualOtelsvcK8sNodeUpdated(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualOtelsvcK8sOwnerTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualOtelsvcK8sPodAdded(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -88,6 +106,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualOtelsvcK8sReplicasetDeleted(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualOtelsvcK8sReplicasetTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualOtelsvcK8sReplicasetUpdated(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -97,6 +118,9 @@ func TestSetupTelemetry(t *testing.T) {
 	AssertEqualOtelsvcK8sStatefulsetDeleted(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
+	AssertEqualOtelsvcK8sStatefulsetTableSize(t, testTel,
+		[]metricdata.DataPoint[int64]{{Value: 1}},
+		metricdatatest.IgnoreTimestamp())
 	AssertEqualOtelsvcK8sStatefulsetUpdated(t, testTel,
 		[]metricdata.DataPoint[int64]{{Value: 1}},
 		metricdatatest.IgnoreTimestamp())
@@ -7,9 +7,11 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"time"
 
 	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -286,6 +288,53 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 	return rules, nil
 }
 
+// withExtractOwners allows specifying the owners of pods to resolve, other than the built-in workloads.
+func withExtractOwners(owners ...OwnerConfig) option {
+	return func(p *kubernetesprocessor) error {
+		var rules []kube.OwnerResolutionRule
+		for _, o := range owners {
+			kind := strings.ToLower(o.Kind)
+			rule := kube.OwnerResolutionRule{
+				Resource:      schema.GroupVersionResource{Group: o.Group, Version: o.Version, Resource: o.Resource},
+				Kind:          o.Kind,
+				NameAttribute: o.NameAttribute,
+				UIDAttribute:  o.UIDAttribute,
+			}
+			if rule.NameAttribute == "" {
+				rule.NameAttribute = fmt.Sprintf("k8s.%s.name", kind)
+			}
+			if rule.UIDAttribute == "" {
+				rule.UIDAttribute = fmt.Sprintf("k8s.%s.uid", kind)
+			}
+
+			var err error
+			if rule.Labels, err = extractFieldRules("labels", ownerFieldExtractConfigs(kind, "label", o.Labels)...); err != nil {
+				return err
+			}
+			if rule.Annotations, err = extractFieldRules("annotations", ownerFieldExtractConfigs(kind, "annotation", o.Annotations)...); err != nil {
+				return err
+			}
+			rules = append(rules, rule)
+		}
+		p.rules.Owners = rules
+		return nil
+	}
+}
+
+// ownerFieldExtractConfigs names the attributes extracted from owners following the semantic conventions,
+// e.g. k8s.rollout.label.<key>, as they are not extracted from a fixed set of sources.
+func ownerFieldExtractConfigs(kind, fieldType string, fields []FieldExtractConfig) []FieldExtractConfig {
+	named := make([]FieldExtractConfig, 0, len(fields))
+	for _, f := range fields {
+		if f.TagName == "" && f.Key != "" {
+			f.TagName = fmt.Sprintf("k8s.%s.%s.%s", kind, fieldType, f.Key)
+		}
+		f.From = kind
+		named = append(named, f)
+	}
+	return named
+}
+
 // withFilterNode allows specifying options to control filtering pods by a node/host.
 func withFilterNode(node, nodeFromEnvVar string) option {
 	return func(p *kubernetesprocessor) error {
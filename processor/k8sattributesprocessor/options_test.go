@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/selection"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
@@ -779,3 +780,89 @@ func TestOtelAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestWithExtractOwners(t *testing.T) {
+	tests := []struct {
+		name    string
+		owners  []OwnerConfig
+		want    []kube.OwnerResolutionRule
+		wantErr bool
+	}{
+		{
+			name: "no owners",
+		},
+		{
+			name: "defaults",
+			owners: []OwnerConfig{{
+				Group:    "argoproj.io",
+				Version:  "v1alpha1",
+				Resource: "rollouts",
+				Kind:     "Rollout",
+				Labels: []FieldExtractConfig{
+					{Key: "team"},
+					{TagName: "$1", KeyRegex: "app.kubernetes.io/(.*)"},
+				},
+				Annotations: []FieldExtractConfig{
+					{TagName: "revision", Key: "rollout.argoproj.io/revision"},
+				},
+			}},
+			want: []kube.OwnerResolutionRule{{
+				Resource:      schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"},
+				Kind:          "Rollout",
+				NameAttribute: "k8s.rollout.name",
+				UIDAttribute:  "k8s.rollout.uid",
+				Labels: []kube.FieldExtractionRule{
+					{Name: "k8s.rollout.label.team", Key: "team", From: "rollout"},
+					{
+						Name:                 "$1",
+						KeyRegex:             regexp.MustCompile("^(?:app.kubernetes.io/(.*))$"),
+						HasKeyRegexReference: true,
+						From:                 "rollout",
+					},
+				},
+				Annotations: []kube.FieldExtractionRule{
+					{Name: "revision", Key: "rollout.argoproj.io/revision", From: "rollout"},
+				},
+			}},
+		},
+		{
+			name: "custom attributes",
+			owners: []OwnerConfig{{
+				Group:         "argoproj.io",
+				Version:       "v1alpha1",
+				Resource:      "rollouts",
+				Kind:          "Rollout",
+				NameAttribute: "k8s.deployment.name",
+				UIDAttribute:  "k8s.deployment.uid",
+			}},
+			want: []kube.OwnerResolutionRule{{
+				Resource:      schema.GroupVersionResource{Group: "argoproj.io", Version: "v1alpha1", Resource: "rollouts"},
+				Kind:          "Rollout",
+				NameAttribute: "k8s.deployment.name",
+				UIDAttribute:  "k8s.deployment.uid",
+			}},
+		},
+		{
+			name: "bad regex",
+			owners: []OwnerConfig{{
+				Version:  "v1",
+				Resource: "services",
+				Kind:     "Service",
+				Labels:   []FieldExtractConfig{{KeyRegex: "["}},
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := kubernetesprocessor{}
+			err := withExtractOwners(tt.owners...)(&p)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, p.rules.Owners)
+		})
+	}
+}
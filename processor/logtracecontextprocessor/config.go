@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtracecontextprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// formatW3C matches W3C trace context `traceparent` values.
+	formatW3C = "w3c"
+	// formatB3 matches the single `b3` header and the `X-B3-TraceId`/`X-B3-SpanId` headers.
+	formatB3 = "b3"
+	// formatXRay matches AWS X-Ray trace IDs.
+	formatXRay = "xray"
+	// formatDatadog matches the `dd.trace_id` and `dd.span_id` fields injected by the Datadog tracers.
+	formatDatadog = "datadog"
+
+	// encodingHex is used for IDs written as hexadecimal strings.
+	encodingHex = "hex"
+	// encodingDecimal is used for 64-bit IDs written as unsigned decimal integers.
+	encodingDecimal = "decimal"
+	// encodingXRay is used for trace IDs written in the AWS X-Ray format.
+	encodingXRay = "xray"
+
+	traceIDGroup = "trace_id"
+	spanIDGroup  = "span_id"
+)
+
+var (
+	supportedFormats   = []string{formatW3C, formatB3, formatXRay, formatDatadog}
+	supportedEncodings = []string{encodingHex, encodingDecimal, encodingXRay}
+
+	errNothingToSearch = errors.New("from_body must be enabled or from_attributes must be set")
+	errNoFormats       = errors.New("at least one format or pattern must be configured")
+)
+
+// Config defines the configuration for the log trace context processor.
+type Config struct {
+	// Formats are the well-known trace context formats searched in the log records.
+	Formats []string `mapstructure:"formats"`
+	// Patterns are custom regular expressions searched in the log records before the formats.
+	Patterns []PatternConfig `mapstructure:"patterns"`
+	// FromBody enables searching the body of the log records.
+	FromBody bool `mapstructure:"from_body"`
+	// FromAttributes are the log record attributes to search, in addition to the body.
+	FromAttributes []string `mapstructure:"from_attributes"`
+	// Override replaces the trace context of log records which already have a trace ID.
+	Override bool `mapstructure:"override"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+// PatternConfig defines a custom regular expression extracting the trace context.
+type PatternConfig struct {
+	// Regex must define a `trace_id` named capture group, and can define a `span_id` one.
+	Regex string `mapstructure:"regex"`
+	// Encoding of the captured IDs: hex, decimal or xray. Defaults to hex.
+	Encoding string `mapstructure:"encoding"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
+var _ component.Config = (*Config)(nil)
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		Formats:  slices.Clone(supportedFormats),
+		FromBody: true,
+	}
+}
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	var errs []error
+	if !cfg.FromBody && len(cfg.FromAttributes) == 0 {
+		errs = append(errs, errNothingToSearch)
+	}
+	if len(cfg.Formats) == 0 && len(cfg.Patterns) == 0 {
+		errs = append(errs, errNoFormats)
+	}
+	for _, format := range cfg.Formats {
+		if !slices.Contains(supportedFormats, format) {
+			errs = append(errs, fmt.Errorf("unsupported format %q, must be one of %v", format, supportedFormats))
+		}
+	}
+	for i, pattern := range cfg.Patterns {
+		if err := pattern.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("patterns[%d]: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (cfg PatternConfig) validate() error {
+	if cfg.Encoding != "" && !slices.Contains(supportedEncodings, cfg.Encoding) {
+		return fmt.Errorf("unsupported encoding %q, must be one of %v", cfg.Encoding, supportedEncodings)
+	}
+	re, err := regexp.Compile(cfg.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid regex: %w", err)
+	}
+	if re.SubexpIndex(traceIDGroup) < 0 {
+		return fmt.Errorf("regex %q must define a %q named capture group", cfg.Regex, traceIDGroup)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtracecontextprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor/internal/metadata"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.Config
+		errContains string
+	}{
+		{
+			id:       component.NewIDWithName(metadata.Type, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "custom"),
+			expected: &Config{
+				Formats:        []string{formatW3C, formatDatadog},
+				FromBody:       true,
+				FromAttributes: []string{"trace", "log.iostream"},
+				Override:       true,
+				Patterns: []PatternConfig{
+					{Regex: `request_trace=(?P<trace_id>[0-9a-f]{32}) request_span=(?P<span_id>[0-9a-f]{16})`},
+					{Regex: `xray_id=(?P<trace_id>1-[0-9a-f]{8}-[0-9a-f]{24})`, Encoding: encodingXRay},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "attributes_only"),
+			expected: &Config{
+				Formats:        []string{formatW3C},
+				FromAttributes: []string{"traceparent"},
+			},
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "nothing_to_search"),
+			errContains: errNothingToSearch.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "no_formats"),
+			errContains: errNoFormats.Error(),
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_format"),
+			errContains: `unsupported format "jaeger"`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "missing_trace_id_group"),
+			errContains: `patterns[0]: regex "trace=([0-9a-f]{32})" must define a "trace_id" named capture group`,
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_regex"),
+			errContains: "patterns[0]: invalid regex",
+		},
+		{
+			id:          component.NewIDWithName(metadata.Type, "invalid_encoding"),
+			errContains: `patterns[0]: unsupported encoding "base64"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if tt.errContains != "" {
+				assert.ErrorContains(t, xconfmap.Validate(cfg), tt.errContains)
+				return
+			}
+			assert.NoError(t, xconfmap.Validate(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
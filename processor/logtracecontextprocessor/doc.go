@@ -0,0 +1,8 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+//go:generate mdatagen metadata.yaml
+
+// Package logtracecontextprocessor extracts the trace context written in the body or the
+// attributes of log records, and sets it as the trace ID and span ID of the records.
+package logtracecontextprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor"
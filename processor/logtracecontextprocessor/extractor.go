@@ -0,0 +1,260 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtracecontextprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor"
+
+import (
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/core/xidutils"
+)
+
+// keyValueSeparator matches what separates a key from its value in the most common log formats,
+// e.g. `key=value`, `key: value` and `"key":"value"`.
+const keyValueSeparator = `[\s"':=]+`
+
+// traceContext is the trace context found in a log record.
+type traceContext struct {
+	traceID pcommon.TraceID
+	spanID  pcommon.SpanID
+	// hasSampled is set when the format carries the sampling decision.
+	hasSampled bool
+	sampled    bool
+}
+
+// extractor finds the trace context in the text of a log record.
+type extractor interface {
+	extract(text string) (traceContext, bool)
+}
+
+type (
+	traceIDDecoder func(string) (pcommon.TraceID, bool)
+	spanIDDecoder  func(string) (pcommon.SpanID, bool)
+	sampledDecoder func(string) (sampled, ok bool)
+)
+
+// regexExtractor extracts the trace context from the capture groups of a single regular expression.
+type regexExtractor struct {
+	re           *regexp.Regexp
+	traceIDIndex int
+	spanIDIndex  int
+	sampledIndex int
+	decodeTrace  traceIDDecoder
+	decodeSpan   spanIDDecoder
+	decodeSample sampledDecoder
+	// validate rejects matches which do not conform to the format.
+	validate func(match []string) bool
+}
+
+func (e *regexExtractor) extract(text string) (traceContext, bool) {
+	for _, match := range e.re.FindAllStringSubmatch(text, -1) {
+		if e.validate != nil && !e.validate(match) {
+			continue
+		}
+		traceID, ok := e.decodeTrace(match[e.traceIDIndex])
+		if !ok {
+			continue
+		}
+		tc := traceContext{traceID: traceID}
+		if e.spanIDIndex > 0 && match[e.spanIDIndex] != "" {
+			tc.spanID, _ = e.decodeSpan(match[e.spanIDIndex])
+		}
+		if e.sampledIndex > 0 && match[e.sampledIndex] != "" {
+			tc.sampled, tc.hasSampled = e.decodeSample(match[e.sampledIndex])
+		}
+		return tc, true
+	}
+	return traceContext{}, false
+}
+
+// fieldsExtractor extracts the trace ID and the span ID from two separate fields, which can appear in any order.
+type fieldsExtractor struct {
+	traceIDRe   *regexp.Regexp
+	spanIDRe    *regexp.Regexp
+	decodeTrace traceIDDecoder
+	decodeSpan  spanIDDecoder
+}
+
+func (e *fieldsExtractor) extract(text string) (traceContext, bool) {
+	match := e.traceIDRe.FindStringSubmatch(text)
+	if match == nil {
+		return traceContext{}, false
+	}
+	traceID, ok := e.decodeTrace(match[1])
+	if !ok {
+		return traceContext{}, false
+	}
+	tc := traceContext{traceID: traceID}
+	if match = e.spanIDRe.FindStringSubmatch(text); match != nil {
+		tc.spanID, _ = e.decodeSpan(match[1])
+	}
+	return tc, true
+}
+
+// newPatternExtractor creates an extractor for a custom pattern.
+func newPatternExtractor(cfg PatternConfig) (extractor, error) {
+	re, err := regexp.Compile(cfg.Regex)
+	if err != nil {
+		return nil, fmt.Errorf("invalid regex: %w", err)
+	}
+	e := &regexExtractor{
+		re:           re,
+		traceIDIndex: re.SubexpIndex(traceIDGroup),
+		spanIDIndex:  re.SubexpIndex(spanIDGroup),
+		decodeSpan:   decodeHexSpanID,
+	}
+	switch cfg.Encoding {
+	case encodingDecimal:
+		e.decodeTrace = decodeDecimalTraceID
+		e.decodeSpan = decodeDecimalSpanID
+	case encodingXRay:
+		e.decodeTrace = decodeXRayTraceID
+	default:
+		e.decodeTrace = decodeHexTraceID
+	}
+	return e, nil
+}
+
+// newFormatExtractors creates the extractors for a well-known format.
+func newFormatExtractors(format string) []extractor {
+	switch format {
+	case formatW3C:
+		return []extractor{&regexExtractor{
+			// version-trace_id-parent_id-trace_flags
+			re:           regexp.MustCompile(`\b([0-9a-f]{2})-([0-9a-f]{32})-([0-9a-f]{16})-([0-9a-f]{2})\b`),
+			traceIDIndex: 2,
+			spanIDIndex:  3,
+			sampledIndex: 4,
+			decodeTrace:  decodeHexTraceID,
+			decodeSpan:   decodeHexSpanID,
+			decodeSample: decodeW3CSampled,
+			// version ff is forbidden by the specification
+			validate: func(match []string) bool { return match[1] != "ff" },
+		}}
+	case formatB3:
+		return []extractor{
+			&regexExtractor{
+				// b3: trace_id-span_id[-sampling_state[-parent_span_id]]
+				re:           regexp.MustCompile(`(?i)\bb3` + keyValueSeparator + `([0-9a-f]{32}|[0-9a-f]{16})-([0-9a-f]{16})(?:-([01d]))?\b`),
+				traceIDIndex: 1,
+				spanIDIndex:  2,
+				sampledIndex: 3,
+				decodeTrace:  decodeHexTraceID,
+				decodeSpan:   decodeHexSpanID,
+				decodeSample: decodeB3Sampled,
+			},
+			&fieldsExtractor{
+				traceIDRe:   regexp.MustCompile(`(?i)\bx-b3-traceid` + keyValueSeparator + `([0-9a-f]{32}|[0-9a-f]{16})\b`),
+				spanIDRe:    regexp.MustCompile(`(?i)\bx-b3-spanid` + keyValueSeparator + `([0-9a-f]{16})\b`),
+				decodeTrace: decodeHexTraceID,
+				decodeSpan:  decodeHexSpanID,
+			},
+		}
+	case formatXRay:
+		return []extractor{&regexExtractor{
+			// 1-<epoch>-<unique id>, followed by the parent segment ID in the tracing header or in the SDKs logs
+			re:           regexp.MustCompile(`\b(1-[0-9a-f]{8}-[0-9a-f]{24})\b(?:(?:;\s*Parent=|@)([0-9a-f]{16})\b)?`),
+			traceIDIndex: 1,
+			spanIDIndex:  2,
+			decodeTrace:  decodeXRayTraceID,
+			decodeSpan:   decodeHexSpanID,
+		}}
+	case formatDatadog:
+		return []extractor{&fieldsExtractor{
+			// the tracers inject 64-bit decimal IDs, or 128-bit hexadecimal trace IDs when enabled
+			traceIDRe:   regexp.MustCompile(`\bdd[._]trace_id` + keyValueSeparator + `([0-9a-f]{32}|[0-9]{1,20})\b`),
+			spanIDRe:    regexp.MustCompile(`\bdd[._]span_id` + keyValueSeparator + `([0-9]{1,20})\b`),
+			decodeTrace: decodeDatadogTraceID,
+			decodeSpan:  decodeDecimalSpanID,
+		}}
+	}
+	return nil
+}
+
+// decodeHexTraceID decodes a hexadecimal trace ID, 64-bit IDs are left padded with zeros.
+func decodeHexTraceID(s string) (pcommon.TraceID, bool) {
+	var traceID pcommon.TraceID
+	if len(s) > 2*len(traceID) {
+		return traceID, false
+	}
+	if _, err := hex.Decode(traceID[:], []byte(strings.Repeat("0", 2*len(traceID)-len(s))+s)); err != nil {
+		return pcommon.NewTraceIDEmpty(), false
+	}
+	return traceID, !traceID.IsEmpty()
+}
+
+// decodeDecimalTraceID decodes a 64-bit decimal trace ID as the lower half of the trace ID.
+func decodeDecimalTraceID(s string) (pcommon.TraceID, bool) {
+	low, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return pcommon.NewTraceIDEmpty(), false
+	}
+	traceID := xidutils.UInt64ToTraceID(0, low)
+	return traceID, !traceID.IsEmpty()
+}
+
+// decodeDatadogTraceID decodes the 128-bit hexadecimal and the 64-bit decimal Datadog trace IDs.
+func decodeDatadogTraceID(s string) (pcommon.TraceID, bool) {
+	if len(s) == 32 {
+		return decodeHexTraceID(s)
+	}
+	return decodeDecimalTraceID(s)
+}
+
+// decodeXRayTraceID decodes an AWS X-Ray trace ID such as 1-5f84c7a1-e7d1852db8c4fd35d88bf49a:
+// the epoch and the unique identifier are concatenated to build the 128-bit trace ID.
+func decodeXRayTraceID(s string) (pcommon.TraceID, bool) {
+	parts := strings.Split(s, "-")
+	if len(parts) != 3 || parts[0] != "1" || len(parts[1]) != 8 || len(parts[2]) != 24 {
+		return pcommon.NewTraceIDEmpty(), false
+	}
+	return decodeHexTraceID(parts[1] + parts[2])
+}
+
+// decodeHexSpanID decodes a hexadecimal span ID, shorter IDs are left padded with zeros.
+func decodeHexSpanID(s string) (pcommon.SpanID, bool) {
+	var spanID pcommon.SpanID
+	if len(s) > 2*len(spanID) {
+		return spanID, false
+	}
+	if _, err := hex.Decode(spanID[:], []byte(strings.Repeat("0", 2*len(spanID)-len(s))+s)); err != nil {
+		return pcommon.NewSpanIDEmpty(), false
+	}
+	return spanID, !spanID.IsEmpty()
+}
+
+// decodeDecimalSpanID decodes a 64-bit decimal span ID.
+func decodeDecimalSpanID(s string) (pcommon.SpanID, bool) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return pcommon.NewSpanIDEmpty(), false
+	}
+	spanID := xidutils.UInt64ToSpanID(v)
+	return spanID, !spanID.IsEmpty()
+}
+
+// decodeW3CSampled reads the sampled flag of the W3C trace flags.
+func decodeW3CSampled(s string) (sampled, ok bool) {
+	flags, err := strconv.ParseUint(s, 16, 8)
+	if err != nil {
+		return false, false
+	}
+	return flags&1 == 1, true
+}
+
+// decodeB3Sampled reads the B3 sampling state, where `d` means debug and implies sampled.
+func decodeB3Sampled(s string) (sampled, ok bool) {
+	switch strings.ToLower(s) {
+	case "1", "d":
+		return true, true
+	case "0":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,230 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtracecontextprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func traceIDFromHex(t *testing.T, s string) pcommon.TraceID {
+	traceID, ok := decodeHexTraceID(s)
+	require.True(t, ok)
+	return traceID
+}
+
+func spanIDFromHex(t *testing.T, s string) pcommon.SpanID {
+	spanID, ok := decodeHexSpanID(s)
+	require.True(t, ok)
+	return spanID
+}
+
+func TestFormatExtractors(t *testing.T) {
+	tests := []struct {
+		name        string
+		format      string
+		text        string
+		expected    traceContext
+		notExpected bool
+	}{
+		{
+			name:   "w3c traceparent",
+			format: formatW3C,
+			text:   "GET /api/orders traceparent=00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01 status=200",
+			expected: traceContext{
+				traceID:    traceIDFromHex(t, "4bf92f3577b34da6a3ce929d0e0e4736"),
+				spanID:     spanIDFromHex(t, "00f067aa0ba902b7"),
+				hasSampled: true,
+				sampled:    true,
+			},
+		},
+		{
+			name:   "w3c not sampled",
+			format: formatW3C,
+			text:   `{"traceparent":"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00"}`,
+			expected: traceContext{
+				traceID:    traceIDFromHex(t, "4bf92f3577b34da6a3ce929d0e0e4736"),
+				spanID:     spanIDFromHex(t, "00f067aa0ba902b7"),
+				hasSampled: true,
+			},
+		},
+		{
+			name:        "w3c forbidden version",
+			format:      formatW3C,
+			text:        "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			notExpected: true,
+		},
+		{
+			name:        "w3c zero trace ID",
+			format:      formatW3C,
+			text:        "00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+			notExpected: true,
+		},
+		{
+			name:   "b3 single header",
+			format: formatB3,
+			text:   "b3: 80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1-05e3ac9a4f6e3b90",
+			expected: traceContext{
+				traceID:    traceIDFromHex(t, "80f198ee56343ba864fe8b2a57d3eff7"),
+				spanID:     spanIDFromHex(t, "e457b5a2e4d86bd1"),
+				hasSampled: true,
+				sampled:    true,
+			},
+		},
+		{
+			name:   "b3 single header with 64-bit trace ID",
+			format: formatB3,
+			text:   "b3=a3ce929d0e0e4736-00f067aa0ba902b7",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "0000000000000000a3ce929d0e0e4736"),
+				spanID:  spanIDFromHex(t, "00f067aa0ba902b7"),
+			},
+		},
+		{
+			name:   "b3 multiple headers",
+			format: formatB3,
+			text:   "X-B3-SpanId: e457b5a2e4d86bd1, X-B3-TraceId: 80f198ee56343ba864fe8b2a57d3eff7",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "80f198ee56343ba864fe8b2a57d3eff7"),
+				spanID:  spanIDFromHex(t, "e457b5a2e4d86bd1"),
+			},
+		},
+		{
+			name:   "xray tracing header",
+			format: formatXRay,
+			text:   "X-Amzn-Trace-Id: Root=1-5759e988-bd862e3fe1be46a994272793;Parent=53995c3f42cd8ad8;Sampled=1",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "5759e988bd862e3fe1be46a994272793"),
+				spanID:  spanIDFromHex(t, "53995c3f42cd8ad8"),
+			},
+		},
+		{
+			name:   "xray sdk log",
+			format: formatXRay,
+			text:   "[INFO] processing order AWS-XRAY-TRACE-ID: 1-5759e988-bd862e3fe1be46a994272793@53995c3f42cd8ad8",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "5759e988bd862e3fe1be46a994272793"),
+				spanID:  spanIDFromHex(t, "53995c3f42cd8ad8"),
+			},
+		},
+		{
+			name:   "xray without parent",
+			format: formatXRay,
+			text:   "trace 1-5759e988-bd862e3fe1be46a994272793 started",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "5759e988bd862e3fe1be46a994272793"),
+			},
+		},
+		{
+			name:   "datadog 64-bit IDs",
+			format: formatDatadog,
+			text:   "2024-05-02 12:00:00 INFO [dd.service=checkout dd.trace_id=1370206187262016291 dd.span_id=4957462130123452817] order placed",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "00000000000000001303f34484cb7b23"),
+				spanID:  spanIDFromHex(t, "44cc718aa575b191"),
+			},
+		},
+		{
+			name:   "datadog 128-bit trace ID in JSON",
+			format: formatDatadog,
+			text:   `{"dd.trace_id":"6634f63c00000000a3ce929d0e0e4736","dd.span_id":"4957462130123452817"}`,
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "6634f63c00000000a3ce929d0e0e4736"),
+				spanID:  spanIDFromHex(t, "44cc718aa575b191"),
+			},
+		},
+		{
+			name:        "datadog out of range trace ID",
+			format:      formatDatadog,
+			text:        "dd.trace_id=99999999999999999999",
+			notExpected: true,
+		},
+		{
+			name:        "no trace context",
+			format:      formatW3C,
+			text:        "user logged in",
+			notExpected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extractors := newFormatExtractors(tt.format)
+			require.NotEmpty(t, extractors)
+			var tc traceContext
+			var ok bool
+			for _, e := range extractors {
+				if tc, ok = e.extract(tt.text); ok {
+					break
+				}
+			}
+			if tt.notExpected {
+				assert.False(t, ok)
+				return
+			}
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, tc)
+		})
+	}
+}
+
+func TestPatternExtractor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      PatternConfig
+		text     string
+		expected traceContext
+	}{
+		{
+			name: "hex",
+			cfg:  PatternConfig{Regex: `rid=(?P<trace_id>[0-9A-F]+)/(?P<span_id>[0-9A-F]+)`},
+			text: "rid=4BF92F3577B34DA6A3CE929D0E0E4736/F067AA0BA902B7",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "4bf92f3577b34da6a3ce929d0e0e4736"),
+				spanID:  spanIDFromHex(t, "00f067aa0ba902b7"),
+			},
+		},
+		{
+			name: "decimal",
+			cfg:  PatternConfig{Regex: `trace=(?P<trace_id>\d+) span=(?P<span_id>\d+)`, Encoding: encodingDecimal},
+			text: "trace=1370206187262016291 span=4957462130123452817",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "1303f34484cb7b23"),
+				spanID:  spanIDFromHex(t, "44cc718aa575b191"),
+			},
+		},
+		{
+			name: "xray without span ID",
+			cfg:  PatternConfig{Regex: `xray=(?P<trace_id>\S+)`, Encoding: encodingXRay},
+			text: "xray=1-5759e988-bd862e3fe1be46a994272793",
+			expected: traceContext{
+				traceID: traceIDFromHex(t, "5759e988bd862e3fe1be46a994272793"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := newPatternExtractor(tt.cfg)
+			require.NoError(t, err)
+			tc, ok := e.extract(tt.text)
+			require.True(t, ok)
+			assert.Equal(t, tt.expected, tc)
+		})
+	}
+}
+
+func TestDecodeXRayTraceID(t *testing.T) {
+	traceID, ok := decodeXRayTraceID("1-5f84c7a1-e7d1852db8c4fd35d88bf49a")
+	require.True(t, ok)
+	assert.Equal(t, "5f84c7a1e7d1852db8c4fd35d88bf49a", traceID.String())
+
+	for _, invalid := range []string{"2-5f84c7a1-e7d1852db8c4fd35d88bf49a", "1-5f84c7a1e7d1852db8c4fd35d88bf49a", "1-5f84c7a1-e7d1852db8c4fd35d88bf4", "1-5f84c7a1-e7d1852db8c4fd35d88bf4zz"} {
+		_, ok = decodeXRayTraceID(invalid)
+		assert.False(t, ok, invalid)
+	}
+}
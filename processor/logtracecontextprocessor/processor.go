@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtracecontextprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor/internal/metadata"
+)
+
+type logTraceContextProcessor struct {
+	extractors       []extractor
+	fromBody         bool
+	fromAttributes   []string
+	override         bool
+	telemetryBuilder *metadata.TelemetryBuilder
+}
+
+func newProcessor(cfg *Config, settings processor.Settings) (*logTraceContextProcessor, error) {
+	var extractors []extractor
+	// custom patterns are more specific than the well-known formats, so they are tried first
+	for i, pattern := range cfg.Patterns {
+		e, err := newPatternExtractor(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("patterns[%d]: %w", i, err)
+		}
+		extractors = append(extractors, e)
+	}
+	for _, format := range cfg.Formats {
+		extractors = append(extractors, newFormatExtractors(format)...)
+	}
+
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(settings.TelemetrySettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create telemetry builder: %w", err)
+	}
+
+	return &logTraceContextProcessor{
+		extractors:       extractors,
+		fromBody:         cfg.FromBody,
+		fromAttributes:   cfg.FromAttributes,
+		override:         cfg.Override,
+		telemetryBuilder: telemetryBuilder,
+	}, nil
+}
+
+func (p *logTraceContextProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var updated int64
+	var sb strings.Builder
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				if !p.override && !lr.TraceID().IsEmpty() {
+					continue
+				}
+				sb.Reset()
+				p.writeSearchText(&sb, lr)
+				tc, ok := p.extract(sb.String())
+				if !ok {
+					continue
+				}
+				lr.SetTraceID(tc.traceID)
+				lr.SetSpanID(tc.spanID)
+				if tc.hasSampled {
+					lr.SetFlags(lr.Flags().WithIsSampled(tc.sampled))
+				}
+				updated++
+			}
+		}
+	}
+	if updated > 0 {
+		p.telemetryBuilder.ProcessorLogtracecontextLogRecordsUpdated.Add(ctx, updated)
+	}
+	return ld, nil
+}
+
+func (p *logTraceContextProcessor) extract(text string) (traceContext, bool) {
+	if text == "" {
+		return traceContext{}, false
+	}
+	for _, e := range p.extractors {
+		if tc, ok := e.extract(text); ok {
+			return tc, true
+		}
+	}
+	return traceContext{}, false
+}
+
+func (p *logTraceContextProcessor) shutdown(context.Context) error {
+	p.telemetryBuilder.Shutdown()
+	return nil
+}
+
+// writeSearchText renders the configured attributes as `key=value` pairs, followed by the body.
+// Maps are flattened with their keys joined by dots, so that nested fields such as
+// {"dd": {"trace_id": "..."}} can be matched as `dd.trace_id=...`.
+func (p *logTraceContextProcessor) writeSearchText(sb *strings.Builder, lr plog.LogRecord) {
+	for _, key := range p.fromAttributes {
+		if v, ok := lr.Attributes().Get(key); ok {
+			writeValue(sb, key, v)
+		}
+	}
+	if p.fromBody {
+		writeValue(sb, "", lr.Body())
+	}
+}
+
+func writeValue(sb *strings.Builder, key string, v pcommon.Value) {
+	if v.Type() == pcommon.ValueTypeMap {
+		for k, child := range v.Map().All() {
+			if key != "" {
+				k = key + "." + k
+			}
+			writeValue(sb, k, child)
+		}
+		return
+	}
+	if v.Type() == pcommon.ValueTypeEmpty {
+		return
+	}
+	if sb.Len() > 0 {
+		sb.WriteByte(' ')
+	}
+	if key != "" {
+		sb.WriteString(key)
+		sb.WriteByte('=')
+	}
+	sb.WriteString(v.AsString())
+}
@@ -0,0 +1,189 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logtracecontextprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/logtracecontextprocessor/internal/metadatatest"
+)
+
+const (
+	testTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	testSpanID  = "00f067aa0ba902b7"
+)
+
+func TestProcessLogs(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             func(*Config)
+		record          func(plog.LogRecord)
+		expectedTraceID string
+		expectedSpanID  string
+		expectedSampled bool
+	}{
+		{
+			name: "string body",
+			record: func(lr plog.LogRecord) {
+				lr.Body().SetStr("handled request traceparent=00-" + testTraceID + "-" + testSpanID + "-01")
+			},
+			expectedTraceID: testTraceID,
+			expectedSpanID:  testSpanID,
+			expectedSampled: true,
+		},
+		{
+			name: "nested map body",
+			record: func(lr plog.LogRecord) {
+				body := lr.Body().SetEmptyMap()
+				body.PutStr("message", "order placed")
+				dd := body.PutEmptyMap("dd")
+				dd.PutStr("trace_id", "1370206187262016291")
+				dd.PutStr("span_id", "4957462130123452817")
+			},
+			expectedTraceID: "00000000000000001303f34484cb7b23",
+			expectedSpanID:  "44cc718aa575b191",
+		},
+		{
+			name: "attributes",
+			cfg: func(cfg *Config) {
+				cfg.FromAttributes = []string{"dd.trace_id", "dd.span_id"}
+			},
+			record: func(lr plog.LogRecord) {
+				lr.Body().SetStr("order placed")
+				lr.Attributes().PutInt("dd.trace_id", 1370206187262016291)
+				lr.Attributes().PutStr("dd.span_id", "4957462130123452817")
+			},
+			expectedTraceID: "00000000000000001303f34484cb7b23",
+			expectedSpanID:  "44cc718aa575b191",
+		},
+		{
+			name: "attributes not configured",
+			record: func(lr plog.LogRecord) {
+				lr.Body().SetStr("order placed")
+				lr.Attributes().PutStr("traceparent", "00-"+testTraceID+"-"+testSpanID+"-01")
+			},
+		},
+		{
+			name: "body disabled",
+			cfg: func(cfg *Config) {
+				cfg.FromBody = false
+				cfg.FromAttributes = []string{"http.request.header.traceparent"}
+			},
+			record: func(lr plog.LogRecord) {
+				lr.Body().SetStr("traceparent=00-80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-01")
+				lr.Attributes().PutStr("http.request.header.traceparent", "00-"+testTraceID+"-"+testSpanID+"-00")
+			},
+			expectedTraceID: testTraceID,
+			expectedSpanID:  testSpanID,
+		},
+		{
+			name: "custom pattern before formats",
+			cfg: func(cfg *Config) {
+				cfg.Patterns = []PatternConfig{{Regex: `correlation=(?P<trace_id>[0-9a-f]{32})`}}
+			},
+			record: func(lr plog.LogRecord) {
+				lr.Body().SetStr("traceparent=00-80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-01 correlation=" + testTraceID)
+			},
+			expectedTraceID: testTraceID,
+		},
+		{
+			name: "existing trace context is kept",
+			record: func(lr plog.LogRecord) {
+				lr.SetTraceID(traceIDFromHex(t, "80f198ee56343ba864fe8b2a57d3eff7"))
+				lr.SetSpanID(spanIDFromHex(t, "e457b5a2e4d86bd1"))
+				lr.Body().SetStr("traceparent=00-" + testTraceID + "-" + testSpanID + "-01")
+			},
+			expectedTraceID: "80f198ee56343ba864fe8b2a57d3eff7",
+			expectedSpanID:  "e457b5a2e4d86bd1",
+		},
+		{
+			name: "existing trace context is overridden",
+			cfg: func(cfg *Config) {
+				cfg.Override = true
+			},
+			record: func(lr plog.LogRecord) {
+				lr.SetTraceID(traceIDFromHex(t, "80f198ee56343ba864fe8b2a57d3eff7"))
+				lr.SetSpanID(spanIDFromHex(t, "e457b5a2e4d86bd1"))
+				lr.Body().SetStr("X-Amzn-Trace-Id: Root=1-5759e988-bd862e3fe1be46a994272793")
+			},
+			expectedTraceID: "5759e988bd862e3fe1be46a994272793",
+		},
+		{
+			name: "format disabled",
+			cfg: func(cfg *Config) {
+				cfg.Formats = []string{formatB3}
+			},
+			record: func(lr plog.LogRecord) {
+				lr.Body().SetStr("traceparent=00-" + testTraceID + "-" + testSpanID + "-01")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			if tt.cfg != nil {
+				tt.cfg(cfg)
+			}
+			require.NoError(t, cfg.Validate())
+
+			sink := &consumertest.LogsSink{}
+			p, err := NewFactory().CreateLogs(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+			t.Cleanup(func() { require.NoError(t, p.Shutdown(context.Background())) })
+
+			ld := plog.NewLogs()
+			tt.record(ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty())
+			require.NoError(t, p.ConsumeLogs(t.Context(), ld))
+
+			require.Len(t, sink.AllLogs(), 1)
+			lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+			expectedTraceID := pcommon.NewTraceIDEmpty()
+			if tt.expectedTraceID != "" {
+				expectedTraceID = traceIDFromHex(t, tt.expectedTraceID)
+			}
+			expectedSpanID := pcommon.NewSpanIDEmpty()
+			if tt.expectedSpanID != "" {
+				expectedSpanID = spanIDFromHex(t, tt.expectedSpanID)
+			}
+			assert.Equal(t, expectedTraceID, lr.TraceID())
+			assert.Equal(t, expectedSpanID, lr.SpanID())
+			assert.Equal(t, tt.expectedSampled, lr.Flags().IsSampled())
+		})
+	}
+}
+
+func TestProcessLogsTelemetry(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+
+	p, err := newProcessor(createDefaultConfig().(*Config), metadatatest.NewSettings(tel))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, p.shutdown(context.Background())) })
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("b3=" + testTraceID + "-" + testSpanID)
+	records.AppendEmpty().Body().SetStr("no trace context")
+	records.AppendEmpty().Body().SetStr("dd.trace_id=1370206187262016291")
+	_, err = p.processLogs(t.Context(), ld)
+	require.NoError(t, err)
+
+	metadatatest.AssertEqualProcessorLogtracecontextLogRecordsUpdated(t, tel,
+		[]metricdata.DataPoint[int64]{{Value: 2}},
+		metricdatatest.IgnoreTimestamp())
+}
@@ -4,6 +4,9 @@
 package resourcedetectionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
@@ -15,13 +18,16 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/azure/aks"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/consul"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/dmi"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/docker"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/gcp"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/heroku"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/k8snode"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/kubeadm"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openshift"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/system"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/vmware"
 )
 
 // Config defines configuration for Resource processor.
@@ -41,6 +47,18 @@ type Config struct {
 	// If a supplied attribute is not a valid attribute of a supplied detector it will be ignored.
 	// Deprecated: Please use detector's resource_attributes config instead
 	Attributes []string `mapstructure:"attributes"`
+	// RefreshInterval is the interval at which the resource is detected again in the background,
+	// so that changes like new cloud tags or node labels are added to the telemetry without
+	// restarting the collector. The resource is only detected at startup when not set.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval < 0 {
+		return errors.New("refresh_interval must not be negative")
+	}
+	return nil
 }
 
 // DetectorConfig contains user-specified configurations unique to all individual detectors
@@ -89,6 +107,15 @@ type DetectorConfig struct {
 
 	// Kubeadm contains user-specified configurations for the Kubeadm detector
 	KubeadmConfig kubeadm.Config `mapstructure:"kubeadm"`
+
+	// DMIConfig contains user-specified configurations for the DMI detector
+	DMIConfig dmi.Config `mapstructure:"dmi"`
+
+	// OpenStackConfig contains user-specified configurations for the OpenStack detector
+	OpenStackConfig openstack.Config `mapstructure:"openstack"`
+
+	// VMwareConfig contains user-specified configurations for the VMware detector
+	VMwareConfig vmware.Config `mapstructure:"vmware"`
 }
 
 func detectorCreateDefaultConfig() DetectorConfig {
@@ -108,6 +135,9 @@ func detectorCreateDefaultConfig() DetectorConfig {
 		OpenShiftConfig:        openshift.CreateDefaultConfig(),
 		K8SNodeConfig:          k8snode.CreateDefaultConfig(),
 		KubeadmConfig:          kubeadm.CreateDefaultConfig(),
+		DMIConfig:              dmi.CreateDefaultConfig(),
+		OpenStackConfig:        openstack.CreateDefaultConfig(),
+		VMwareConfig:           vmware.CreateDefaultConfig(),
 	}
 }
 
@@ -143,6 +173,12 @@ func (d *DetectorConfig) GetConfigFromType(detectorType internal.DetectorType) i
 		return d.K8SNodeConfig
 	case kubeadm.TypeStr:
 		return d.KubeadmConfig
+	case dmi.TypeStr:
+		return d.DMIConfig
+	case openstack.TypeStr:
+		return d.OpenStackConfig
+	case vmware.TypeStr:
+		return d.VMwareConfig
 	default:
 		return nil
 	}
@@ -64,6 +64,12 @@ func TestLoadConfig(t *testing.T) {
 	resourceAttributesConfig.EC2Config = ec2ResourceAttributesConfig
 	resourceAttributesConfig.SystemConfig = systemResourceAttributesConfig
 
+	onPremConfig := detectorCreateDefaultConfig()
+	onPremConfig.DMIConfig.Directory = "/hostfs/sys/class/dmi/id"
+	onPremConfig.OpenStackConfig.Meta = []string{"^team$"}
+	onPremConfig.VMwareConfig.GuestInfo = []string{"datacenter", "cluster"}
+	onPremConfig.VMwareConfig.RPCToolPath = "/usr/bin/vmware-rpctool"
+
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -137,6 +143,29 @@ func TestLoadConfig(t *testing.T) {
 			id:           component.NewIDWithName(metadata.Type, "invalid"),
 			errorMessage: "hostname_sources contains invalid value: \"invalid_source\"",
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "refresh"),
+			expected: &Config{
+				Detectors:       []string{"env", "ec2"},
+				ClientConfig:    cfg,
+				Override:        false,
+				DetectorConfig:  detectorCreateDefaultConfig(),
+				RefreshInterval: 5 * time.Minute,
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "onprem"),
+			expected: &Config{
+				Detectors:      []string{"env", "vmware", "openstack", "dmi"},
+				ClientConfig:   cfg,
+				Override:       false,
+				DetectorConfig: onPremConfig,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "invalid_refresh_interval"),
+			errorMessage: "refresh_interval must not be negative",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
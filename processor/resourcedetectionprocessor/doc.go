@@ -18,6 +18,9 @@
 //go:generate mdatagen internal/k8snode/metadata.yaml
 //go:generate mdatagen internal/kubeadm/metadata.yaml
 //go:generate mdatagen internal/dynatrace/metadata.yaml
+//go:generate mdatagen internal/dmi/metadata.yaml
+//go:generate mdatagen internal/openstack/metadata.yaml
+//go:generate mdatagen internal/vmware/metadata.yaml
 
 // package resourcedetectionprocessor implements a processor
 // which can be used to detect resource information from the host,
@@ -0,0 +1,24 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/dmi"
+
+import (
+	dmiprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/dmi/internal/metadata"
+)
+
+// Config defines user-specified configurations unique to the DMI detector
+type Config struct {
+	// Directory is the directory the DMI information is read from. It defaults to /sys/class/dmi/id,
+	// and can be set to the same directory of the host file system when running in a container.
+	Directory          string                            `mapstructure:"directory"`
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		Directory:          dmiprovider.DefaultDirectory,
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dmi provides a detector that loads the hardware information of the host from the
+// DMI/SMBIOS tables exposed by the Linux kernel.
+package dmi // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/dmi"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	dmiprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/dmi/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr = "dmi"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a DMI/SMBIOS metadata detector
+type Detector struct {
+	provider dmiprovider.Provider
+	logger   *zap.Logger
+	rb       *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new DMI/SMBIOS metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	return &Detector{
+		provider: dmiprovider.NewProvider(cfg.Directory),
+		logger:   p.Logger,
+		rb:       metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects the DMI/SMBIOS metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.provider.Metadata(ctx)
+	if err != nil {
+		if errors.Is(err, dmiprovider.ErrNotAvailable) {
+			d.logger.Debug("DMI information is not available on this host")
+			return pcommon.NewResource(), "", nil
+		}
+		return pcommon.NewResource(), "", err
+	}
+
+	setIfNotEmpty(d.rb.SetHostType, meta.ProductName)
+	setIfNotEmpty(d.rb.SetHostID, meta.ProductUUID)
+	setIfNotEmpty(d.rb.SetDmiSystemVendor, meta.SysVendor)
+	setIfNotEmpty(d.rb.SetDmiSystemSerialNumber, meta.ProductSerial)
+	setIfNotEmpty(d.rb.SetDmiChassisType, meta.ChassisType)
+	setIfNotEmpty(d.rb.SetDmiChassisVendor, meta.ChassisVendor)
+	setIfNotEmpty(d.rb.SetDmiBiosVendor, meta.BIOSVendor)
+	setIfNotEmpty(d.rb.SetDmiBiosVersion, meta.BIOSVersion)
+
+	return d.rb.Emit(), conventions.SchemaURL, nil
+}
+
+func setIfNotEmpty(setter func(string), value string) {
+	if value != "" {
+		setter(value)
+	}
+}
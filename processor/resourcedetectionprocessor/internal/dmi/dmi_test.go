@@ -0,0 +1,142 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dmi
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	dmiprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/dmi/internal/metadata"
+)
+
+type mockMetadata struct {
+	mock.Mock
+}
+
+func (m *mockMetadata) Metadata(context.Context) (*dmiprovider.Metadata, error) {
+	args := m.MethodCalled("Metadata")
+	meta, _ := args.Get(0).(*dmiprovider.Metadata)
+	return meta, args.Error(1)
+}
+
+func allEnabledConfig() metadata.ResourceAttributesConfig {
+	cfg := metadata.DefaultResourceAttributesConfig()
+	cfg.HostID.Enabled = true
+	cfg.DmiSystemVendor.Enabled = true
+	cfg.DmiSystemSerialNumber.Enabled = true
+	cfg.DmiChassisType.Enabled = true
+	cfg.DmiChassisVendor.Enabled = true
+	cfg.DmiBiosVendor.Enabled = true
+	cfg.DmiBiosVersion.Enabled = true
+	return cfg
+}
+
+func TestNewDetector(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sys_vendor"), []byte("LENOVO\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "product_name"), []byte("ThinkPad T14\n"), 0o600))
+	cfg := CreateDefaultConfig()
+	cfg.Directory = dir
+
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+	require.NoError(t, err)
+	res, schemaURL, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{"host.type": "ThinkPad T14"}, res.Attributes().AsRaw())
+}
+
+func TestDetect(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(&dmiprovider.Metadata{
+		SysVendor:     "Dell Inc.",
+		ProductName:   "PowerEdge R640",
+		ProductSerial: "ABC1234",
+		ProductUUID:   "4c4c4544-0042-3510-8052-b4c04f4a4e32",
+		ChassisVendor: "Dell Inc.",
+		ChassisType:   "Rack Mount Chassis",
+		BIOSVendor:    "Dell Inc.",
+		BIOSVersion:   "2.19.1",
+	}, nil)
+
+	tests := []struct {
+		name     string
+		cfg      metadata.ResourceAttributesConfig
+		expected map[string]any
+	}{
+		{
+			name: "default attributes",
+			cfg:  metadata.DefaultResourceAttributesConfig(),
+			expected: map[string]any{
+				"host.type": "PowerEdge R640",
+			},
+		},
+		{
+			name: "all attributes",
+			cfg:  allEnabledConfig(),
+			expected: map[string]any{
+				"host.type":                "PowerEdge R640",
+				"host.id":                  "4c4c4544-0042-3510-8052-b4c04f4a4e32",
+				"dmi.system.vendor":        "Dell Inc.",
+				"dmi.system.serial_number": "ABC1234",
+				"dmi.chassis.type":         "Rack Mount Chassis",
+				"dmi.chassis.vendor":       "Dell Inc.",
+				"dmi.bios.vendor":          "Dell Inc.",
+				"dmi.bios.version":         "2.19.1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &Detector{provider: md, logger: zap.NewNop(), rb: metadata.NewResourceBuilder(tt.cfg)}
+			res, schemaURL, err := d.Detect(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, conventions.SchemaURL, schemaURL)
+			assert.Equal(t, tt.expected, res.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestDetectUnreadableFields(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(&dmiprovider.Metadata{SysVendor: "QEMU", ProductName: "Standard PC (Q35 + ICH9, 2009)"}, nil)
+
+	d := &Detector{provider: md, logger: zap.NewNop(), rb: metadata.NewResourceBuilder(allEnabledConfig())}
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"dmi.system.vendor": "QEMU",
+		"host.type":         "Standard PC (Q35 + ICH9, 2009)",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectNotAvailable(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(nil, dmiprovider.ErrNotAvailable)
+
+	d := &Detector{provider: md, logger: zap.NewNop(), rb: metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig())}
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+}
+
+func TestDetectError(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(nil, errors.New("permission denied"))
+
+	d := &Detector{provider: md, logger: zap.NewNop(), rb: metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig())}
+	_, _, err := d.Detect(t.Context())
+	assert.EqualError(t, err, "permission denied")
+}
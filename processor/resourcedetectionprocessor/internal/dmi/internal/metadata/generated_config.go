@@ -0,0 +1,67 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// ResourceAttributeConfig provides common config for a particular resource attribute.
+type ResourceAttributeConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledSetByUser bool
+}
+
+func (rac *ResourceAttributeConfig) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(rac)
+	if err != nil {
+		return err
+	}
+	rac.enabledSetByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// ResourceAttributesConfig provides config for resourcedetectionprocessor/dmi resource attributes.
+type ResourceAttributesConfig struct {
+	DmiBiosVendor         ResourceAttributeConfig `mapstructure:"dmi.bios.vendor"`
+	DmiBiosVersion        ResourceAttributeConfig `mapstructure:"dmi.bios.version"`
+	DmiChassisType        ResourceAttributeConfig `mapstructure:"dmi.chassis.type"`
+	DmiChassisVendor      ResourceAttributeConfig `mapstructure:"dmi.chassis.vendor"`
+	DmiSystemSerialNumber ResourceAttributeConfig `mapstructure:"dmi.system.serial_number"`
+	DmiSystemVendor       ResourceAttributeConfig `mapstructure:"dmi.system.vendor"`
+	HostID                ResourceAttributeConfig `mapstructure:"host.id"`
+	HostType              ResourceAttributeConfig `mapstructure:"host.type"`
+}
+
+func DefaultResourceAttributesConfig() ResourceAttributesConfig {
+	return ResourceAttributesConfig{
+		DmiBiosVendor: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		DmiBiosVersion: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		DmiChassisType: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		DmiChassisVendor: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		DmiSystemSerialNumber: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		DmiSystemVendor: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		HostID: ResourceAttributeConfig{
+			Enabled: false,
+		},
+		HostType: ResourceAttributeConfig{
+			Enabled: true,
+		},
+	}
+}
@@ -0,0 +1,85 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+// ResourceBuilder is a helper struct to build resources predefined in metadata.yaml.
+// The ResourceBuilder is not thread-safe and must not to be used in multiple goroutines.
+type ResourceBuilder struct {
+	config ResourceAttributesConfig
+	res    pcommon.Resource
+}
+
+// NewResourceBuilder creates a new ResourceBuilder. This method should be called on the start of the application.
+func NewResourceBuilder(rac ResourceAttributesConfig) *ResourceBuilder {
+	return &ResourceBuilder{
+		config: rac,
+		res:    pcommon.NewResource(),
+	}
+}
+
+// SetDmiBiosVendor sets provided value as "dmi.bios.vendor" attribute.
+func (rb *ResourceBuilder) SetDmiBiosVendor(val string) {
+	if rb.config.DmiBiosVendor.Enabled {
+		rb.res.Attributes().PutStr("dmi.bios.vendor", val)
+	}
+}
+
+// SetDmiBiosVersion sets provided value as "dmi.bios.version" attribute.
+func (rb *ResourceBuilder) SetDmiBiosVersion(val string) {
+	if rb.config.DmiBiosVersion.Enabled {
+		rb.res.Attributes().PutStr("dmi.bios.version", val)
+	}
+}
+
+// SetDmiChassisType sets provided value as "dmi.chassis.type" attribute.
+func (rb *ResourceBuilder) SetDmiChassisType(val string) {
+	if rb.config.DmiChassisType.Enabled {
+		rb.res.Attributes().PutStr("dmi.chassis.type", val)
+	}
+}
+
+// SetDmiChassisVendor sets provided value as "dmi.chassis.vendor" attribute.
+func (rb *ResourceBuilder) SetDmiChassisVendor(val string) {
+	if rb.config.DmiChassisVendor.Enabled {
+		rb.res.Attributes().PutStr("dmi.chassis.vendor", val)
+	}
+}
+
+// SetDmiSystemSerialNumber sets provided value as "dmi.system.serial_number" attribute.
+func (rb *ResourceBuilder) SetDmiSystemSerialNumber(val string) {
+	if rb.config.DmiSystemSerialNumber.Enabled {
+		rb.res.Attributes().PutStr("dmi.system.serial_number", val)
+	}
+}
+
+// SetDmiSystemVendor sets provided value as "dmi.system.vendor" attribute.
+func (rb *ResourceBuilder) SetDmiSystemVendor(val string) {
+	if rb.config.DmiSystemVendor.Enabled {
+		rb.res.Attributes().PutStr("dmi.system.vendor", val)
+	}
+}
+
+// SetHostID sets provided value as "host.id" attribute.
+func (rb *ResourceBuilder) SetHostID(val string) {
+	if rb.config.HostID.Enabled {
+		rb.res.Attributes().PutStr("host.id", val)
+	}
+}
+
+// SetHostType sets provided value as "host.type" attribute.
+func (rb *ResourceBuilder) SetHostType(val string) {
+	if rb.config.HostType.Enabled {
+		rb.res.Attributes().PutStr("host.type", val)
+	}
+}
+
+// Emit returns the built resource and resets the internal builder state.
+func (rb *ResourceBuilder) Emit() pcommon.Resource {
+	r := rb.res
+	rb.res = pcommon.NewResource()
+	return r
+}
@@ -0,0 +1,82 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceBuilder(t *testing.T) {
+	for _, tt := range []string{"default", "all_set", "none_set"} {
+		t.Run(tt, func(t *testing.T) {
+			cfg := loadResourceAttributesConfig(t, tt)
+			rb := NewResourceBuilder(cfg)
+			rb.SetDmiBiosVendor("dmi.bios.vendor-val")
+			rb.SetDmiBiosVersion("dmi.bios.version-val")
+			rb.SetDmiChassisType("dmi.chassis.type-val")
+			rb.SetDmiChassisVendor("dmi.chassis.vendor-val")
+			rb.SetDmiSystemSerialNumber("dmi.system.serial_number-val")
+			rb.SetDmiSystemVendor("dmi.system.vendor-val")
+			rb.SetHostID("host.id-val")
+			rb.SetHostType("host.type-val")
+
+			res := rb.Emit()
+			assert.Equal(t, 0, rb.Emit().Attributes().Len()) // Second call should return empty Resource
+
+			switch tt {
+			case "default":
+				assert.Equal(t, 1, res.Attributes().Len())
+			case "all_set":
+				assert.Equal(t, 8, res.Attributes().Len())
+			case "none_set":
+				assert.Equal(t, 0, res.Attributes().Len())
+				return
+			default:
+				assert.Failf(t, "unexpected test case: %s", tt)
+			}
+
+			val, ok := res.Attributes().Get("dmi.bios.vendor")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "dmi.bios.vendor-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("dmi.bios.version")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "dmi.bios.version-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("dmi.chassis.type")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "dmi.chassis.type-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("dmi.chassis.vendor")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "dmi.chassis.vendor-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("dmi.system.serial_number")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "dmi.system.serial_number-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("dmi.system.vendor")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "dmi.system.vendor-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("host.id")
+			assert.Equal(t, tt == "all_set", ok)
+			if ok {
+				assert.Equal(t, "host.id-val", val.Str())
+			}
+			val, ok = res.Attributes().Get("host.type")
+			assert.True(t, ok)
+			if ok {
+				assert.Equal(t, "host.type-val", val.Str())
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
+
+import (
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack/internal/metadata"
+)
+
+// Config defines user-specified configurations unique to the OpenStack detector
+type Config struct {
+	// Meta is a list of regex's to match the keys of the metadata of the instance that
+	// users want to add as resource attributes to processed data
+	Meta               []string                          `mapstructure:"meta"`
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		Meta:               []string{},
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
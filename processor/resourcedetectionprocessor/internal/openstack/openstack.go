@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack"
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr    = "openstack"
+	metaPrefix = "openstack.meta."
+
+	// OpenStack is not part of the cloud providers of the semantic conventions yet
+	cloudProviderOpenStack = "openstack"
+	cloudPlatformNova      = "openstack_nova"
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is an OpenStack metadata detector
+type Detector struct {
+	newProvider    func(*http.Client) openstack.Provider
+	metaKeyRegexes []*regexp.Regexp
+	logger         *zap.Logger
+	rb             *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new OpenStack metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	metaKeyRegexes, err := compileRegexes(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Detector{
+		newProvider:    openstack.NewProvider,
+		metaKeyRegexes: metaKeyRegexes,
+		logger:         p.Logger,
+		rb:             metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects OpenStack metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	provider := d.newProvider(getClientConfig(ctx, d.logger))
+	meta, err := provider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("OpenStack detector metadata retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderOpenStack)
+	d.rb.SetCloudPlatform(cloudPlatformNova)
+	d.rb.SetHostID(meta.UUID)
+	if meta.Name != "" {
+		d.rb.SetHostName(meta.Name)
+	}
+	if meta.ProjectID != "" {
+		d.rb.SetCloudAccountID(meta.ProjectID)
+	}
+	if meta.AvailabilityZone != "" {
+		d.rb.SetCloudAvailabilityZone(meta.AvailabilityZone)
+	}
+
+	// the flavor is only exposed by the EC2 compatible metadata, which may be disabled
+	if instanceType, err := provider.InstanceType(ctx); err != nil {
+		d.logger.Debug("OpenStack detector failed to retrieve the flavor of the instance", zap.Error(err))
+	} else if instanceType != "" {
+		d.rb.SetHostType(instanceType)
+	}
+	res := d.rb.Emit()
+
+	if len(d.metaKeyRegexes) != 0 {
+		for key, val := range meta.Meta {
+			if regexArrayMatch(d.metaKeyRegexes, key) {
+				res.Attributes().PutStr(metaPrefix+key, val)
+			}
+		}
+	}
+
+	return res, conventions.SchemaURL, nil
+}
+
+// getClientConfig returns the client built from the HTTP client settings of the processor
+func getClientConfig(ctx context.Context, logger *zap.Logger) *http.Client {
+	client, err := internal.ClientFromContext(ctx)
+	if err != nil {
+		client = http.DefaultClient
+		logger.Debug("Error retrieving client from context thus creating default", zap.Error(err))
+	}
+	return client
+}
+
+func compileRegexes(cfg Config) ([]*regexp.Regexp, error) {
+	metaRegexes := make([]*regexp.Regexp, len(cfg.Meta))
+	for i, elem := range cfg.Meta {
+		regex, err := regexp.Compile(elem)
+		if err != nil {
+			return nil, err
+		}
+		metaRegexes[i] = regex
+	}
+	return metaRegexes, nil
+}
+
+func regexArrayMatch(arr []*regexp.Regexp, val string) bool {
+	for _, elem := range arr {
+		matched := elem.MatchString(val)
+		if matched {
+			return true
+		}
+	}
+	return false
+}
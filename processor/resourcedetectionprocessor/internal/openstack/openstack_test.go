@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package openstack
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/openstack"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/openstack/internal/metadata"
+)
+
+type mockMetadata struct {
+	mock.Mock
+}
+
+func (m *mockMetadata) Metadata(context.Context) (*openstack.Metadata, error) {
+	args := m.MethodCalled("Metadata")
+	meta, _ := args.Get(0).(*openstack.Metadata)
+	return meta, args.Error(1)
+}
+
+func (m *mockMetadata) InstanceType(context.Context) (string, error) {
+	args := m.MethodCalled("InstanceType")
+	return args.String(0), args.Error(1)
+}
+
+func mockProvider(md *mockMetadata) func(*http.Client) openstack.Provider {
+	return func(*http.Client) openstack.Provider {
+		return md
+	}
+}
+
+var testMetadata = &openstack.Metadata{
+	UUID:             "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+	Name:             "web-1",
+	Hostname:         "web-1.novalocal",
+	AvailabilityZone: "nova",
+	ProjectID:        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+	Meta:             map[string]string{"team": "payments", "env": "prod", "ssh_key": "admin"},
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestNewDetectorInvalidMeta(t *testing.T) {
+	cfg := CreateDefaultConfig()
+	cfg.Meta = []string{"*"}
+	_, err := NewDetector(processortest.NewNopSettings(processortest.NopType), cfg)
+	assert.Error(t, err)
+}
+
+func TestDetect(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(testMetadata, nil)
+	md.On("InstanceType").Return("m1.small", nil)
+
+	d := &Detector{
+		newProvider:    mockProvider(md),
+		metaKeyRegexes: []*regexp.Regexp{regexp.MustCompile("^team$"), regexp.MustCompile("^env$")},
+		logger:         zap.NewNop(),
+		rb:             metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	res, schemaURL, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "openstack",
+		"cloud.platform":          "openstack_nova",
+		"cloud.account.id":        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		"cloud.availability_zone": "nova",
+		"host.id":                 "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		"host.name":               "web-1",
+		"host.type":               "m1.small",
+		"openstack.meta.team":     "payments",
+		"openstack.meta.env":      "prod",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectUsesProcessorClient(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(nil, errors.New("not found"))
+	client := &http.Client{}
+	var used *http.Client
+
+	d := &Detector{
+		newProvider: func(c *http.Client) openstack.Provider {
+			used = c
+			return md
+		},
+		logger: zap.NewNop(),
+		rb:     metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+	_, _, err := d.Detect(internal.ContextWithClient(t.Context(), client))
+	require.NoError(t, err)
+	assert.Same(t, client, used)
+}
+
+func TestDetectWithoutFlavor(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(testMetadata, nil)
+	md.On("InstanceType").Return("", errors.New("not found"))
+
+	d := &Detector{newProvider: mockProvider(md), logger: zap.NewNop(), rb: metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig())}
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":          "openstack",
+		"cloud.platform":          "openstack_nova",
+		"cloud.account.id":        "f7ac731cc11f40efbc03a9f9e1d1d21f",
+		"cloud.availability_zone": "nova",
+		"host.id":                 "d8e02d56-2648-49a3-bf97-6be8f1204f38",
+		"host.name":               "web-1",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectNotOpenStack(t *testing.T) {
+	md := &mockMetadata{}
+	md.On("Metadata").Return(nil, errors.New("OpenStack metadata service replied with status code: 404 Not Found"))
+
+	d := &Detector{newProvider: mockProvider(md), logger: zap.NewNop(), rb: metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig())}
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, 0, res.Attributes().Len())
+	md.AssertNotCalled(t, "InstanceType")
+}
@@ -10,7 +10,9 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	backoff "github.com/cenkalti/backoff/v5"
@@ -18,6 +20,8 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/metadata"
 )
 
 var allowErrorPropagationFeatureGate = featuregate.GlobalRegistry().MustRegister(
@@ -70,7 +74,12 @@ func (f *ResourceProviderFactory) CreateResourceProvider(
 		}
 	}
 
-	provider := NewResourceProvider(params.Logger, timeout, attributesToKeep, detectors...)
+	telemetryBuilder, err := metadata.NewTelemetryBuilder(params.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := NewResourceProvider(params.Logger, telemetryBuilder, timeout, attributesToKeep, detectors...)
 	return provider, nil
 }
 
@@ -95,11 +104,19 @@ func (f *ResourceProviderFactory) getDetectors(params processor.Settings, detect
 
 type ResourceProvider struct {
 	logger           *zap.Logger
+	telemetryBuilder *metadata.TelemetryBuilder
 	timeout          time.Duration
 	detectors        []Detector
-	detectedResource *resourceResult
+	detectedResource atomic.Pointer[resourceResult]
 	once             sync.Once
 	attributesToKeep map[string]struct{}
+
+	// refreshMu protects the state of the background refresh, shared by the processors
+	// of all the signals using this provider.
+	refreshMu   sync.Mutex
+	refreshRefs int
+	stopRefresh context.CancelFunc
+	refreshDone chan struct{}
 }
 
 type resourceResult struct {
@@ -108,33 +125,154 @@ type resourceResult struct {
 	err       error
 }
 
-func NewResourceProvider(logger *zap.Logger, timeout time.Duration, attributesToKeep map[string]struct{}, detectors ...Detector) *ResourceProvider {
+func NewResourceProvider(logger *zap.Logger, telemetryBuilder *metadata.TelemetryBuilder, timeout time.Duration, attributesToKeep map[string]struct{}, detectors ...Detector) *ResourceProvider {
 	return &ResourceProvider{
 		logger:           logger,
+		telemetryBuilder: telemetryBuilder,
 		timeout:          timeout,
 		detectors:        detectors,
 		attributesToKeep: attributesToKeep,
 	}
 }
 
+// Get returns the detected resource, detecting it on the first call. Once refreshed, the latest
+// resource detected is returned.
 func (p *ResourceProvider) Get(ctx context.Context, client *http.Client) (resource pcommon.Resource, schemaURL string, err error) {
 	p.once.Do(func() {
 		var cancel context.CancelFunc
 		ctx, cancel = context.WithTimeout(ctx, client.Timeout)
 		defer cancel()
-		p.detectResource(ctx, client.Timeout)
+		result, detectErr := p.detectResource(ctx, client.Timeout, p.logger.Info)
+		if allowErrorPropagationFeatureGate.IsEnabled() {
+			result.err = detectErr
+		}
+		p.detectedResource.Store(result)
 	})
 
-	return p.detectedResource.resource, p.detectedResource.schemaURL, p.detectedResource.err
+	detected := p.detectedResource.Load()
+	return detected.resource, detected.schemaURL, detected.err
+}
+
+// Resource returns the latest resource detected. It must only be called once Get returned.
+func (p *ResourceProvider) Resource() (resource pcommon.Resource, schemaURL string) {
+	detected := p.detectedResource.Load()
+	return detected.resource, detected.schemaURL
+}
+
+// StartRefreshing starts detecting the resource again every refreshInterval in the background.
+// The refresh is shared by all the callers, and runs until all of them called StopRefreshing.
+func (p *ResourceProvider) StartRefreshing(refreshInterval time.Duration, client *http.Client) {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+
+	p.refreshRefs++
+	if p.refreshRefs > 1 {
+		return
+	}
+
+	var ctx context.Context
+	ctx, p.stopRefresh = context.WithCancel(ContextWithClient(context.Background(), client))
+	p.refreshDone = make(chan struct{})
+	go p.refreshLoop(ctx, refreshInterval, client.Timeout, p.refreshDone)
+}
+
+// StopRefreshing stops refreshing the resource once all the callers of StartRefreshing called it.
+func (p *ResourceProvider) StopRefreshing() {
+	p.refreshMu.Lock()
+	defer p.refreshMu.Unlock()
+
+	if p.refreshRefs == 0 {
+		return
+	}
+	p.refreshRefs--
+	if p.refreshRefs > 0 {
+		return
+	}
+	p.stopRefresh()
+	<-p.refreshDone
+}
+
+func (p *ResourceProvider) refreshLoop(ctx context.Context, refreshInterval, timeout time.Duration, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.refresh(ctx, timeout)
+		}
+	}
 }
 
-func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Duration) {
-	p.detectedResource = &resourceResult{}
+// refresh detects the resource again, and replaces the detected resource if all the detectors
+// succeeded, so that a transient failure doesn't remove attributes from the telemetry.
+func (p *ResourceProvider) refresh(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
+	// the detections are logged at debug level after the first one, as they happen on every refresh
+	result, err := p.detectResource(ctx, timeout, p.logger.Debug)
+	if err != nil {
+		if errors.Is(context.Cause(ctx), context.Canceled) {
+			// the refresh was stopped
+			return
+		}
+		p.logger.Warn("failed to refresh resource information, keeping the previously detected resource", zap.Error(err))
+		p.telemetryBuilder.ProcessorResourcedetectionRefreshFailures.Add(ctx, 1)
+		return
+	}
+
+	previous := p.detectedResource.Load()
+	if previous.schemaURL == result.schemaURL && previous.resource.Attributes().Equal(result.resource.Attributes()) {
+		return
+	}
+	// the error of the first detection, if any, is kept, as Start already failed with it
+	result.err = previous.err
+	p.detectedResource.Store(result)
+
+	added, changed, removed := diffAttributes(previous.resource.Attributes(), result.resource.Attributes())
+	p.logger.Info("detected resource information changed",
+		zap.Any("resource", result.resource.Attributes().AsRaw()),
+		zap.Strings("added", added),
+		zap.Strings("changed", changed),
+		zap.Strings("removed", removed))
+	p.telemetryBuilder.ProcessorResourcedetectionResourceChanges.Add(ctx, 1)
+}
+
+// diffAttributes returns the keys of the attributes added, changed and removed from previous to current.
+func diffAttributes(previous, current pcommon.Map) (added, changed, removed []string) {
+	for k, v := range current.All() {
+		pv, ok := previous.Get(k)
+		switch {
+		case !ok:
+			added = append(added, k)
+		case !pv.Equal(v):
+			changed = append(changed, k)
+		}
+	}
+	for k := range previous.All() {
+		if _, ok := current.Get(k); !ok {
+			removed = append(removed, k)
+		}
+	}
+	slices.Sort(added)
+	slices.Sort(changed)
+	slices.Sort(removed)
+	return added, changed, removed
+}
+
+// detectResource runs all the detectors and merges the resources they detected. The returned
+// error joins the errors of the detectors which failed to detect a resource before ctx is done.
+// The progress of the detection is logged with log.
+func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Duration, log func(string, ...zap.Field)) (*resourceResult, error) {
 	res := pcommon.NewResource()
 	mergedSchemaURL := ""
+	var detectErr error
 
-	p.logger.Info("began detecting resource information")
+	log("began detecting resource information")
 
 	resultsChan := make([]chan resourceResult, len(p.detectors))
 	for i, detector := range p.detectors {
@@ -174,9 +312,7 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 	for _, ch := range resultsChan {
 		result := <-ch
 		if result.err != nil {
-			if allowErrorPropagationFeatureGate.IsEnabled() {
-				p.detectedResource.err = errors.Join(p.detectedResource.err, result.err)
-			}
+			detectErr = errors.Join(detectErr, result.err)
 		} else {
 			mergedSchemaURL = MergeSchemaURL(mergedSchemaURL, result.schemaURL)
 			MergeResource(res, result.resource, false)
@@ -185,13 +321,12 @@ func (p *ResourceProvider) detectResource(ctx context.Context, timeout time.Dura
 
 	droppedAttributes := filterAttributes(res.Attributes(), p.attributesToKeep)
 
-	p.logger.Info("detected resource information", zap.Any("resource", res.Attributes().AsRaw()))
+	log("detected resource information", zap.Any("resource", res.Attributes().AsRaw()))
 	if len(droppedAttributes) > 0 {
-		p.logger.Info("dropped resource information", zap.Strings("resource keys", droppedAttributes))
+		log("dropped resource information", zap.Strings("resource keys", droppedAttributes))
 	}
 
-	p.detectedResource.resource = res
-	p.detectedResource.schemaURL = mergedSchemaURL
+	return &resourceResult{resource: res, schemaURL: mergedSchemaURL}, detectErr
 }
 
 func MergeSchemaURL(currentSchemaURL, newSchemaURL string) string {
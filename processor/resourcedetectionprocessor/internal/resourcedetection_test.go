@@ -15,13 +15,18 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/featuregate"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/metadatatest"
 )
 
 type mockDetector struct {
@@ -146,7 +151,7 @@ func TestDetectResource_Error_ContextDeadline_WithErrPropagation(t *testing.T) {
 	md2 := &mockDetector{}
 	md2.On("Detect").Return(pcommon.NewResource(), errors.New("err2"))
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), newNopTelemetryBuilder(t), time.Second, nil, md1, md2)
 
 	var cancel context.CancelFunc
 	ctx, cancel := context.WithTimeout(t.Context(), 3*time.Second)
@@ -165,7 +170,7 @@ func TestDetectResource_Error_ContextDeadline_WithoutErrPropagation(t *testing.T
 	md2 := &mockDetector{}
 	md2.On("Detect").Return(pcommon.NewResource(), errors.New("err2"))
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), newNopTelemetryBuilder(t), time.Second, nil, md1, md2)
 
 	var cancel context.CancelFunc
 	ctx, cancel := context.WithTimeout(t.Context(), 3*time.Second)
@@ -240,7 +245,7 @@ func TestDetectResource_Parallel(t *testing.T) {
 
 	expectedResourceAttrs := map[string]any{"a": "1", "b": "2", "c": "3"}
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), newNopTelemetryBuilder(t), time.Second, nil, md1, md2)
 
 	// call p.Get multiple times
 	wg := &sync.WaitGroup{}
@@ -282,7 +287,7 @@ func TestDetectResource_Reconnect(t *testing.T) {
 
 	expectedResourceAttrs := map[string]any{"a": "1", "b": "2", "c": "3"}
 
-	p := NewResourceProvider(zap.NewNop(), time.Second, nil, md1, md2)
+	p := NewResourceProvider(zap.NewNop(), newNopTelemetryBuilder(t), time.Second, nil, md1, md2)
 
 	detected, _, err := p.Get(t.Context(), &http.Client{Timeout: 15 * time.Second})
 	assert.NoError(t, err)
@@ -292,6 +297,107 @@ func TestDetectResource_Reconnect(t *testing.T) {
 	md2.AssertNumberOfCalls(t, "Detect", 2) // 1 error + 1 success
 }
 
+func newNopTelemetryBuilder(t *testing.T) *metadata.TelemetryBuilder {
+	tb, err := metadata.NewTelemetryBuilder(componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	return tb
+}
+
+func newResource(t *testing.T, attrs map[string]any) pcommon.Resource {
+	res := pcommon.NewResource()
+	require.NoError(t, res.Attributes().FromRaw(attrs))
+	return res
+}
+
+func TestRefreshResource(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { require.NoError(t, tel.Shutdown(context.Background())) })
+	tb, err := metadata.NewTelemetryBuilder(tel.NewTelemetrySettings())
+	require.NoError(t, err)
+	core, logs := observer.New(zap.InfoLevel)
+
+	md := &mockDetector{}
+	md.On("Detect").Return(newResource(t, map[string]any{"a": "1", "b": "2", "c": "3"}), nil).Twice()
+	md.On("Detect").Return(newResource(t, map[string]any{"a": "1", "b": "22", "d": "4"}), nil).Once()
+	md.On("Detect").Return(pcommon.NewResource(), errors.New("metadata unavailable"))
+
+	p := NewResourceProvider(zap.New(core), tb, time.Second, nil, md)
+	_, _, err = p.Get(t.Context(), &http.Client{Timeout: 10 * time.Second})
+	require.NoError(t, err)
+
+	// the same resource is detected
+	p.refresh(t.Context(), time.Second)
+	res, _ := p.Resource()
+	assert.Equal(t, map[string]any{"a": "1", "b": "2", "c": "3"}, res.Attributes().AsRaw())
+	assert.Zero(t, logs.FilterMessage("detected resource information changed").Len())
+	// only the first detection is logged at info level
+	assert.Equal(t, 1, logs.FilterMessage("began detecting resource information").Len())
+	assert.Equal(t, 1, logs.FilterMessage("detected resource information").Len())
+
+	// the resource changed
+	p.refresh(t.Context(), time.Second)
+	res, _ = p.Resource()
+	assert.Equal(t, map[string]any{"a": "1", "b": "22", "d": "4"}, res.Attributes().AsRaw())
+	changes := logs.FilterMessage("detected resource information changed").All()
+	require.Len(t, changes, 1)
+	fields := changes[0].ContextMap()
+	assert.Equal(t, []any{"d"}, fields["added"])
+	assert.Equal(t, []any{"b"}, fields["changed"])
+	assert.Equal(t, []any{"c"}, fields["removed"])
+
+	// the detector fails, the previous resource is kept
+	p.refresh(t.Context(), 10*time.Millisecond)
+	res, _ = p.Resource()
+	assert.Equal(t, map[string]any{"a": "1", "b": "22", "d": "4"}, res.Attributes().AsRaw())
+
+	metadatatest.AssertEqualProcessorResourcedetectionResourceChanges(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+	metadatatest.AssertEqualProcessorResourcedetectionRefreshFailures(t, tel, []metricdata.DataPoint[int64]{{Value: 1}}, metricdatatest.IgnoreTimestamp())
+}
+
+func TestRefreshResourceInBackground(t *testing.T) {
+	md := &mockDetector{}
+	md.On("Detect").Return(newResource(t, map[string]any{"a": "1"}), nil).Once()
+	md.On("Detect").Return(newResource(t, map[string]any{"a": "2"}), nil)
+
+	p := NewResourceProvider(zap.NewNop(), newNopTelemetryBuilder(t), time.Second, nil, md)
+	client := &http.Client{Timeout: 10 * time.Second}
+	res, _, err := p.Get(t.Context(), client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1"}, res.Attributes().AsRaw())
+
+	// the refresh is shared by the processors of all the signals
+	p.StartRefreshing(time.Millisecond, client)
+	p.StartRefreshing(time.Millisecond, client)
+	assert.Eventually(t, func() bool {
+		res, _ := p.Resource()
+		return res.Attributes().AsRaw()["a"] == "2"
+	}, 5*time.Second, time.Millisecond)
+
+	p.StopRefreshing()
+	assert.Equal(t, 1, p.refreshRefs)
+	p.StopRefreshing()
+	assert.Equal(t, 0, p.refreshRefs)
+	select {
+	case <-p.refreshDone:
+	default:
+		assert.Fail(t, "the refresh should be stopped")
+	}
+	// stopping again is a no-op
+	p.StopRefreshing()
+}
+
+func TestDiffAttributes(t *testing.T) {
+	previous := pcommon.NewMap()
+	require.NoError(t, previous.FromRaw(map[string]any{"a": "1", "b": "2", "c": int64(3), "d": "4"}))
+	current := pcommon.NewMap()
+	require.NoError(t, current.FromRaw(map[string]any{"a": "1", "b": "22", "c": "3", "e": "5", "f": "6"}))
+
+	added, changed, removed := diffAttributes(previous, current)
+	assert.Equal(t, []string{"e", "f"}, added)
+	assert.Equal(t, []string{"b", "c"}, changed)
+	assert.Equal(t, []string{"d"}, removed)
+}
+
 func TestFilterAttributes_Match(t *testing.T) {
 	m := map[string]struct{}{
 		"host.name": {},
@@ -0,0 +1,32 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vmware // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/vmware"
+
+import (
+	dmiprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+	vmwareprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/vmware"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/vmware/internal/metadata"
+)
+
+// Config defines user-specified configurations unique to the VMware detector
+type Config struct {
+	// GuestInfo is a list of guestinfo variables to add as resource attributes to processed data,
+	// e.g. "datacenter" to read the guestinfo.datacenter variable.
+	GuestInfo []string `mapstructure:"guestinfo"`
+	// RPCToolPath is the path of the vmware-rpctool command of VMware Tools or open-vm-tools,
+	// used to read the guestinfo variables. It is looked up in the PATH by default.
+	RPCToolPath string `mapstructure:"rpctool_path"`
+	// DMIDirectory is the directory the DMI information identifying the virtual machine is read from.
+	DMIDirectory       string                            `mapstructure:"dmi_directory"`
+	ResourceAttributes metadata.ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+func CreateDefaultConfig() Config {
+	return Config{
+		GuestInfo:          []string{},
+		RPCToolPath:        vmwareprovider.DefaultRPCToolPath,
+		DMIDirectory:       dmiprovider.DefaultDirectory,
+		ResourceAttributes: metadata.DefaultResourceAttributesConfig(),
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package vmware provides a detector that identifies VMware virtual machines from their DMI
+// information, and loads the guestinfo variables set on them.
+package vmware // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/vmware"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	dmiprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+	vmwareprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/vmware"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/vmware/internal/metadata"
+)
+
+const (
+	// TypeStr is type of detector.
+	TypeStr         = "vmware"
+	guestInfoPrefix = "vmware.guestinfo."
+
+	// VMware is not part of the cloud providers of the semantic conventions yet
+	cloudProviderVMware  = "vmware"
+	cloudPlatformVSphere = "vmware_vsphere"
+
+	// vmwareVendor is the system vendor of VMware virtual machines
+	vmwareVendor = "VMware, Inc."
+)
+
+var _ internal.Detector = (*Detector)(nil)
+
+// Detector is a VMware metadata detector
+type Detector struct {
+	dmiProvider   dmiprovider.Provider
+	provider      vmwareprovider.Provider
+	guestInfoKeys []string
+	logger        *zap.Logger
+	rb            *metadata.ResourceBuilder
+}
+
+// NewDetector creates a new VMware metadata detector
+func NewDetector(p processor.Settings, dcfg internal.DetectorConfig) (internal.Detector, error) {
+	cfg := dcfg.(Config)
+
+	return &Detector{
+		dmiProvider:   dmiprovider.NewProvider(cfg.DMIDirectory),
+		provider:      vmwareprovider.NewProvider(cfg.RPCToolPath),
+		guestInfoKeys: cfg.GuestInfo,
+		logger:        p.Logger,
+		rb:            metadata.NewResourceBuilder(cfg.ResourceAttributes),
+	}, nil
+}
+
+// Detect detects VMware metadata and returns a resource with the available ones
+func (d *Detector) Detect(ctx context.Context) (resource pcommon.Resource, schemaURL string, err error) {
+	meta, err := d.dmiProvider.Metadata(ctx)
+	if err != nil {
+		d.logger.Debug("VMware detector DMI information retrieval failed", zap.Error(err))
+		// return an empty Resource and no error
+		return pcommon.NewResource(), "", nil
+	}
+	if meta.SysVendor != vmwareVendor {
+		d.logger.Debug("Not running on a VMware virtual machine", zap.String("vendor", meta.SysVendor))
+		return pcommon.NewResource(), "", nil
+	}
+
+	d.rb.SetCloudProvider(cloudProviderVMware)
+	d.rb.SetCloudPlatform(cloudPlatformVSphere)
+	if meta.ProductUUID != "" {
+		d.rb.SetHostID(meta.ProductUUID)
+	}
+	res := d.rb.Emit()
+
+	for _, key := range d.guestInfoKeys {
+		value, err := d.provider.GuestInfo(ctx, key)
+		if errors.Is(err, vmwareprovider.ErrGuestInfoNotFound) {
+			d.logger.Debug("guestinfo variable is not set", zap.String("key", key))
+			continue
+		}
+		if err != nil {
+			// the RPC tool is likely missing, the other variables can't be read either
+			d.logger.Warn("failed reading guestinfo variables", zap.Error(err))
+			break
+		}
+		res.Attributes().PutStr(guestInfoPrefix+strings.TrimPrefix(key, "guestinfo."), value)
+	}
+
+	return res, conventions.SchemaURL, nil
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package vmware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/processor/processortest"
+	conventions "go.opentelemetry.io/otel/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	dmiprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/dmi"
+	vmwareprovider "github.com/open-telemetry/opentelemetry-collector-contrib/internal/metadataproviders/vmware"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor/internal/vmware/internal/metadata"
+)
+
+type mockDMI struct {
+	mock.Mock
+}
+
+func (m *mockDMI) Metadata(context.Context) (*dmiprovider.Metadata, error) {
+	args := m.MethodCalled("Metadata")
+	meta, _ := args.Get(0).(*dmiprovider.Metadata)
+	return meta, args.Error(1)
+}
+
+type mockGuestInfo struct {
+	mock.Mock
+}
+
+func (m *mockGuestInfo) GuestInfo(_ context.Context, key string) (string, error) {
+	args := m.MethodCalled("GuestInfo", key)
+	return args.String(0), args.Error(1)
+}
+
+var vmwareDMI = &dmiprovider.Metadata{
+	SysVendor:   "VMware, Inc.",
+	ProductName: "VMware7,1",
+	ProductUUID: "421a2b3c-4d5e-6f70-8192-a3b4c5d6e7f8",
+}
+
+func newTestDetector(dmi dmiprovider.Provider, guestInfo vmwareprovider.Provider, keys ...string) *Detector {
+	return &Detector{
+		dmiProvider:   dmi,
+		provider:      guestInfo,
+		guestInfoKeys: keys,
+		logger:        zap.NewNop(),
+		rb:            metadata.NewResourceBuilder(metadata.DefaultResourceAttributesConfig()),
+	}
+}
+
+func TestNewDetector(t *testing.T) {
+	d, err := NewDetector(processortest.NewNopSettings(processortest.NopType), CreateDefaultConfig())
+	require.NoError(t, err)
+	assert.NotNil(t, d)
+}
+
+func TestDetect(t *testing.T) {
+	dmi := &mockDMI{}
+	dmi.On("Metadata").Return(vmwareDMI, nil)
+	guestInfo := &mockGuestInfo{}
+	guestInfo.On("GuestInfo", "datacenter").Return("dc-1", nil)
+	guestInfo.On("GuestInfo", "guestinfo.cluster").Return("cluster-1", nil)
+	guestInfo.On("GuestInfo", "owner").Return("", vmwareprovider.ErrGuestInfoNotFound)
+
+	d := newTestDetector(dmi, guestInfo, "datacenter", "guestinfo.cluster", "owner")
+	res, schemaURL, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, conventions.SchemaURL, schemaURL)
+	assert.Equal(t, map[string]any{
+		"cloud.provider":              "vmware",
+		"cloud.platform":              "vmware_vsphere",
+		"host.id":                     "421a2b3c-4d5e-6f70-8192-a3b4c5d6e7f8",
+		"vmware.guestinfo.datacenter": "dc-1",
+		"vmware.guestinfo.cluster":    "cluster-1",
+	}, res.Attributes().AsRaw())
+}
+
+func TestDetectRPCToolFailure(t *testing.T) {
+	dmi := &mockDMI{}
+	dmi.On("Metadata").Return(&dmiprovider.Metadata{SysVendor: "VMware, Inc."}, nil)
+	guestInfo := &mockGuestInfo{}
+	guestInfo.On("GuestInfo", "datacenter").Return("", errors.New("executable file not found in $PATH"))
+
+	d := newTestDetector(dmi, guestInfo, "datacenter", "cluster")
+	res, _, err := d.Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"cloud.provider": "vmware",
+		"cloud.platform": "vmware_vsphere",
+	}, res.Attributes().AsRaw())
+	guestInfo.AssertNotCalled(t, "GuestInfo", "cluster")
+}
+
+func TestDetectNotVMware(t *testing.T) {
+	tests := []struct {
+		name string
+		meta *dmiprovider.Metadata
+		err  error
+	}{
+		{
+			name: "other vendor",
+			meta: &dmiprovider.Metadata{SysVendor: "QEMU", ProductName: "Standard PC (i440FX + PIIX, 1996)"},
+		},
+		{
+			name: "no DMI information",
+			err:  dmiprovider.ErrNotAvailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmi := &mockDMI{}
+			dmi.On("Metadata").Return(tt.meta, tt.err)
+			guestInfo := &mockGuestInfo{}
+
+			d := newTestDetector(dmi, guestInfo, "datacenter")
+			res, _, err := d.Detect(t.Context())
+			require.NoError(t, err)
+			assert.Equal(t, 0, res.Attributes().Len())
+			guestInfo.AssertNotCalled(t, "GuestInfo", mock.Anything)
+		})
+	}
+}
@@ -297,6 +297,55 @@ func TestResourceProcessor(t *testing.T) {
 	}
 }
 
+func TestResourceProcessorRefresh(t *testing.T) {
+	factory := &factory{providers: map[component.ID]*internal.ResourceProvider{}}
+
+	md1 := &mockDetector{}
+	res1 := pcommon.NewResource()
+	require.NoError(t, res1.Attributes().FromRaw(map[string]any{"k8s.node.label.pool": "spot"}))
+	res2 := pcommon.NewResource()
+	require.NoError(t, res2.Attributes().FromRaw(map[string]any{"k8s.node.label.pool": "on-demand"}))
+	md1.On("Detect").Return(res1, nil).Once()
+	md1.On("Detect").Return(res2, nil)
+	factory.resourceProviderFactory = internal.NewProviderFactory(
+		map[internal.DetectorType]internal.DetectorFactory{"mock": func(processor.Settings, internal.DetectorConfig) (internal.Detector, error) {
+			return md1, nil
+		}})
+
+	cfg := &Config{
+		Override:        true,
+		Detectors:       []string{"mock"},
+		ClientConfig:    confighttp.ClientConfig{Timeout: time.Second},
+		RefreshInterval: time.Millisecond,
+	}
+
+	ttn := new(consumertest.TracesSink)
+	rtp, err := factory.createTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, ttn)
+	require.NoError(t, err)
+	tln := new(consumertest.LogsSink)
+	rlp, err := factory.createLogsProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), cfg, tln)
+	require.NoError(t, err)
+
+	require.NoError(t, rtp.Start(t.Context(), componenttest.NewNopHost()))
+	require.NoError(t, rlp.Start(t.Context(), componenttest.NewNopHost()))
+
+	assert.EventuallyWithT(t, func(tt *assert.CollectT) {
+		td := ptrace.NewTraces()
+		td.ResourceSpans().AppendEmpty()
+		assert.NoError(tt, rtp.ConsumeTraces(t.Context(), td))
+		assert.Equal(tt, map[string]any{"k8s.node.label.pool": "on-demand"}, td.ResourceSpans().At(0).Resource().Attributes().AsRaw())
+
+		ld := plog.NewLogs()
+		ld.ResourceLogs().AppendEmpty()
+		assert.NoError(tt, rlp.ConsumeLogs(t.Context(), ld))
+		assert.Equal(tt, map[string]any{"k8s.node.label.pool": "on-demand"}, ld.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	}, 5*time.Second, time.Millisecond)
+
+	// the refresh is stopped once both processors are shut down
+	assert.NoError(t, rtp.Shutdown(t.Context()))
+	assert.NoError(t, rlp.Shutdown(t.Context()))
+}
+
 func benchmarkConsumeTraces(b *testing.B, cfg *Config) {
 	factory := NewFactory()
 	sink := new(consumertest.TracesSink)
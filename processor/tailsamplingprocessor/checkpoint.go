@@ -0,0 +1,372 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	pendingIndexKey          = "pending_traces"
+	pendingTraceKeyPrefix    = "pending_trace."
+	sampledDecisionsKey      = "decisions.sampled"
+	nonSampledDecisionsKey   = "decisions.non_sampled"
+	traceIDLen               = len(pcommon.TraceID{})
+	arrivalTimeEncodedLength = 8
+)
+
+var (
+	traceMarshaler   = &ptrace.ProtoMarshaler{}
+	traceUnmarshaler = &ptrace.ProtoUnmarshaler{}
+)
+
+// getStorageClient returns a storage client for the given storage extension,
+// or nil if no storage extension was configured.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, name string) (storage.Client, error) {
+	if storageID == nil {
+		return nil, nil
+	}
+
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindProcessor, componentID, name)
+}
+
+// checkpointer keeps track of the in-flight traces and sampling decisions that
+// changed since the last checkpoint and writes them to a storage.Client, so
+// they can be restored after a restart.
+//
+// Pending traces are stored one key per trace, with an index key listing all
+// of them. Decisions are stored as an append-only log of segments, one per
+// checkpoint, which is trimmed to the size of the corresponding decision cache.
+type checkpointer struct {
+	client storage.Client
+	logger *zap.Logger
+
+	mu        sync.Mutex
+	dirty     map[pcommon.TraceID]struct{}
+	removed   map[pcommon.TraceID]struct{}
+	persisted map[pcommon.TraceID]struct{}
+
+	sampled    *decisionLog
+	nonSampled *decisionLog
+}
+
+// decisionLog holds the decisions made since the last checkpoint, as well as
+// the index of the segments already written to the storage.
+type decisionLog struct {
+	key    string
+	maxIDs int
+	ids    []pcommon.TraceID
+	index  decisionLogIndex
+}
+
+type decisionLogIndex struct {
+	Next     uint64            `json:"next"`
+	Segments []decisionSegment `json:"segments"`
+}
+
+type decisionSegment struct {
+	Seq   uint64 `json:"seq"`
+	Count int    `json:"count"`
+}
+
+func newCheckpointer(client storage.Client, logger *zap.Logger, cfg DecisionCacheConfig) *checkpointer {
+	return &checkpointer{
+		client:     client,
+		logger:     logger,
+		dirty:      make(map[pcommon.TraceID]struct{}),
+		removed:    make(map[pcommon.TraceID]struct{}),
+		persisted:  make(map[pcommon.TraceID]struct{}),
+		sampled:    &decisionLog{key: sampledDecisionsKey, maxIDs: cfg.SampledCacheSize},
+		nonSampled: &decisionLog{key: nonSampledDecisionsKey, maxIDs: cfg.NonSampledCacheSize},
+	}
+}
+
+// markPending records that new spans were added to the given pending trace.
+func (c *checkpointer) markPending(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.removed, id)
+	c.dirty[id] = struct{}{}
+}
+
+// markRemoved records that the given trace is no longer held in memory.
+func (c *checkpointer) markRemoved(id pcommon.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.dirty, id)
+	c.removed[id] = struct{}{}
+}
+
+// recordDecision records the final decision made for the given trace.
+func (c *checkpointer) recordDecision(id pcommon.TraceID, decision sampling.Decision) {
+	dl := c.nonSampled
+	if decision == sampling.Sampled {
+		dl = c.sampled
+	}
+	if dl.maxIDs <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dl.ids = append(dl.ids, id)
+}
+
+// checkpoint writes every change recorded since the previous checkpoint to the
+// storage. The lookup function is used to fetch the current state of pending traces.
+func (c *checkpointer) checkpoint(ctx context.Context, lookup func(pcommon.TraceID) (*sampling.TraceData, bool)) error {
+	c.mu.Lock()
+	dirty, removed := c.dirty, c.removed
+	c.dirty = make(map[pcommon.TraceID]struct{})
+	c.removed = make(map[pcommon.TraceID]struct{})
+	sampledIDs, nonSampledIDs := c.sampled.ids, c.nonSampled.ids
+	c.sampled.ids, c.nonSampled.ids = nil, nil
+	c.mu.Unlock()
+
+	var ops []*storage.Operation
+	persisted := make(map[pcommon.TraceID]struct{}, len(c.persisted)+len(dirty))
+	for id := range c.persisted {
+		persisted[id] = struct{}{}
+	}
+
+	for id := range dirty {
+		value, ok := encodePendingTrace(lookup(id))
+		if !ok {
+			removed[id] = struct{}{}
+			continue
+		}
+		ops = append(ops, storage.SetOperation(pendingTraceKey(id), value))
+		persisted[id] = struct{}{}
+	}
+	for id := range removed {
+		if _, ok := persisted[id]; !ok {
+			continue
+		}
+		ops = append(ops, storage.DeleteOperation(pendingTraceKey(id)))
+		delete(persisted, id)
+	}
+	if len(dirty) > 0 || len(removed) > 0 {
+		ops = append(ops, storage.SetOperation(pendingIndexKey, encodeTraceIDs(persisted)))
+	}
+
+	sampledIndex, sampledOps, err := c.sampled.appendSegment(sampledIDs)
+	if err != nil {
+		return err
+	}
+	nonSampledIndex, nonSampledOps, err := c.nonSampled.appendSegment(nonSampledIDs)
+	if err != nil {
+		return err
+	}
+	ops = append(ops, sampledOps...)
+	ops = append(ops, nonSampledOps...)
+
+	if len(ops) == 0 {
+		return nil
+	}
+
+	if err := c.client.Batch(ctx, ops...); err != nil {
+		// Put the changes back, so they are retried on the next checkpoint.
+		c.mu.Lock()
+		for id := range dirty {
+			if _, ok := c.removed[id]; !ok {
+				c.dirty[id] = struct{}{}
+			}
+		}
+		for id := range removed {
+			if _, ok := c.dirty[id]; !ok {
+				c.removed[id] = struct{}{}
+			}
+		}
+		c.sampled.ids = append(sampledIDs, c.sampled.ids...)
+		c.nonSampled.ids = append(nonSampledIDs, c.nonSampled.ids...)
+		c.mu.Unlock()
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+
+	c.persisted = persisted
+	c.sampled.index = sampledIndex
+	c.nonSampled.index = nonSampledIndex
+	return nil
+}
+
+// appendSegment returns the operations to write the given IDs as a new segment
+// of the decision log, along with the resulting index. Segments that are no
+// longer needed to fill the decision cache are deleted.
+func (l *decisionLog) appendSegment(ids []pcommon.TraceID) (decisionLogIndex, []*storage.Operation, error) {
+	if len(ids) == 0 {
+		return l.index, nil, nil
+	}
+
+	index := decisionLogIndex{
+		Next:     l.index.Next + 1,
+		Segments: append([]decisionSegment{}, l.index.Segments...),
+	}
+	segment := decisionSegment{Seq: l.index.Next, Count: len(ids)}
+	index.Segments = append(index.Segments, segment)
+
+	value := make([]byte, 0, len(ids)*traceIDLen)
+	for _, id := range ids {
+		value = append(value, id[:]...)
+	}
+	ops := []*storage.Operation{storage.SetOperation(l.segmentKey(segment.Seq), value)}
+
+	total := 0
+	for _, s := range index.Segments {
+		total += s.Count
+	}
+	for len(index.Segments) > 1 && total-index.Segments[0].Count >= l.maxIDs {
+		total -= index.Segments[0].Count
+		ops = append(ops, storage.DeleteOperation(l.segmentKey(index.Segments[0].Seq)))
+		index.Segments = index.Segments[1:]
+	}
+
+	encodedIndex, err := json.Marshal(index)
+	if err != nil {
+		return l.index, nil, fmt.Errorf("failed to marshal decision index: %w", err)
+	}
+	ops = append(ops, storage.SetOperation(l.key, encodedIndex))
+	return index, ops, nil
+}
+
+func (l *decisionLog) segmentKey(seq uint64) string {
+	return l.key + "." + strconv.FormatUint(seq, 10)
+}
+
+// restoreDecisions reads the decision log from the storage and calls the given
+// function with every trace ID in it, from the oldest to the most recent one.
+func (c *checkpointer) restoreDecisions(ctx context.Context, l *decisionLog, put func(pcommon.TraceID)) error {
+	data, err := c.client.Get(ctx, l.key)
+	if err != nil {
+		return fmt.Errorf("failed to read decision index %q: %w", l.key, err)
+	}
+	if len(data) == 0 {
+		return nil
+	}
+
+	var index decisionLogIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return fmt.Errorf("failed to unmarshal decision index %q: %w", l.key, err)
+	}
+	l.index = index
+
+	for _, s := range index.Segments {
+		segment, err := c.client.Get(ctx, l.segmentKey(s.Seq))
+		if err != nil {
+			return fmt.Errorf("failed to read decision segment %q: %w", l.segmentKey(s.Seq), err)
+		}
+		for _, id := range decodeTraceIDs(segment) {
+			put(id)
+		}
+	}
+	return nil
+}
+
+// restorePendingTraces reads the pending traces from the storage and calls
+// the given function for each one of them.
+func (c *checkpointer) restorePendingTraces(ctx context.Context, restore func(pcommon.TraceID, time.Time, ptrace.Traces)) error {
+	data, err := c.client.Get(ctx, pendingIndexKey)
+	if err != nil {
+		return fmt.Errorf("failed to read pending traces index: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range decodeTraceIDs(data) {
+		value, err := c.client.Get(ctx, pendingTraceKey(id))
+		if err != nil {
+			return fmt.Errorf("failed to read pending trace %s: %w", id, err)
+		}
+		arrivalTime, td, err := decodePendingTrace(value)
+		if err != nil {
+			// A single corrupted trace shouldn't prevent restoring the others.
+			c.logger.Warn("Discarding pending trace that could not be restored", zap.Stringer("id", id), zap.Error(err))
+			c.persisted[id] = struct{}{}
+			c.removed[id] = struct{}{}
+			continue
+		}
+		c.persisted[id] = struct{}{}
+		restore(id, arrivalTime, td)
+	}
+	return nil
+}
+
+func pendingTraceKey(id pcommon.TraceID) string {
+	return pendingTraceKeyPrefix + id.String()
+}
+
+// encodePendingTrace encodes the arrival time and spans of a trace still
+// waiting for a decision. It returns false if the trace was already decided.
+func encodePendingTrace(trace *sampling.TraceData, ok bool) ([]byte, bool) {
+	if !ok {
+		return nil, false
+	}
+
+	trace.Lock()
+	defer trace.Unlock()
+	if trace.FinalDecision != sampling.Unspecified {
+		return nil, false
+	}
+
+	spans, err := traceMarshaler.MarshalTraces(trace.ReceivedBatches)
+	if err != nil {
+		return nil, false
+	}
+	value := make([]byte, 0, arrivalTimeEncodedLength+len(spans))
+	value = binary.BigEndian.AppendUint64(value, uint64(trace.ArrivalTime.UnixNano()))
+	return append(value, spans...), true
+}
+
+func decodePendingTrace(value []byte) (time.Time, ptrace.Traces, error) {
+	if len(value) < arrivalTimeEncodedLength {
+		return time.Time{}, ptrace.Traces{}, errors.New("pending trace is too short")
+	}
+	arrivalTime := time.Unix(0, int64(binary.BigEndian.Uint64(value[:arrivalTimeEncodedLength])))
+	td, err := traceUnmarshaler.UnmarshalTraces(value[arrivalTimeEncodedLength:])
+	if err != nil {
+		return time.Time{}, ptrace.Traces{}, err
+	}
+	return arrivalTime, td, nil
+}
+
+func encodeTraceIDs(ids map[pcommon.TraceID]struct{}) []byte {
+	value := make([]byte, 0, len(ids)*traceIDLen)
+	for id := range ids {
+		value = append(value, id[:]...)
+	}
+	return value
+}
+
+func decodeTraceIDs(value []byte) []pcommon.TraceID {
+	ids := make([]pcommon.TraceID, 0, len(value)/traceIDLen)
+	for len(value) >= traceIDLen {
+		ids = append(ids, pcommon.TraceID(value[:traceIDLen]))
+		value = value[traceIDLen:]
+	}
+	return ids
+}
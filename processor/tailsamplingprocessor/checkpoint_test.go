@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+func newCheckpointTestProcessor(t *testing.T, storageID component.ID, mpe *mockPolicyEvaluator, sink *consumertest.TracesSink) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{
+			SampledCacheSize:    100,
+			NonSampledCacheSize: 100,
+		},
+		StorageID: &storageID,
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}),
+		},
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	return p.(*tailSamplingSpanProcessor)
+}
+
+func TestCheckpointRestoresPendingTraces(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink := new(consumertest.TracesSink)
+	tsp := newCheckpointTestProcessor(t, storageID, mpe, sink)
+	require.NoError(t, tsp.Start(t.Context(), host))
+
+	traceIDs, batches := generateIDsAndBatches(10)
+	for _, batch := range batches {
+		require.NoError(t, tsp.ConsumeTraces(t.Context(), batch))
+	}
+
+	// The first tick closes the batch without making decisions, but checkpoints the traces.
+	tsp.policyTicker.OnTick()
+	require.Equal(t, 0, mpe.EvaluationCount)
+	require.NoError(t, tsp.Shutdown(t.Context()))
+	require.Empty(t, sink.AllTraces())
+
+	// A new processor picks up the pending traces and makes decisions on them.
+	tsp = newCheckpointTestProcessor(t, storageID, mpe, sink)
+	require.NoError(t, tsp.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(t.Context()))
+	}()
+
+	for i, id := range traceIDs {
+		d, ok := tsp.idToTrace.Load(id)
+		require.True(t, ok, "Missing expected traceId")
+		assert.Equal(t, int64(i+1), d.(*sampling.TraceData).SpanCount.Load())
+	}
+
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	assert.Equal(t, len(traceIDs), mpe.EvaluationCount)
+	assert.Equal(t, 55, sink.SpanCount())
+	assert.Equal(t, uint64(0), tsp.numTracesOnMap.Load())
+}
+
+func TestCheckpointRestoresDecisions(t *testing.T) {
+	host := storagetest.NewStorageHost().WithFileBackedStorageExtension("test", t.TempDir())
+	storageID := storagetest.NewStorageID("test")
+
+	sampledID := uInt64ToTraceID(1)
+	notSampledID := uInt64ToTraceID(2)
+
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink := new(consumertest.TracesSink)
+	tsp := newCheckpointTestProcessor(t, storageID, mpe, sink)
+	require.NoError(t, tsp.Start(t.Context(), host))
+
+	require.NoError(t, tsp.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+
+	mpe.NextDecision = sampling.NotSampled
+	require.NoError(t, tsp.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledID)))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	require.NoError(t, tsp.Shutdown(t.Context()))
+	require.Equal(t, 1, sink.SpanCount())
+	require.Equal(t, 2, mpe.EvaluationCount)
+
+	tsp = newCheckpointTestProcessor(t, storageID, mpe, sink)
+	require.NoError(t, tsp.Start(t.Context(), host))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(t.Context()))
+	}()
+
+	_, ok := tsp.sampledIDCache.Get(sampledID)
+	assert.True(t, ok)
+	_, ok = tsp.nonSampledIDCache.Get(notSampledID)
+	assert.True(t, ok)
+
+	// Late spans are released based on the restored decisions, without evaluating policies.
+	require.NoError(t, tsp.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	require.NoError(t, tsp.ConsumeTraces(t.Context(), simpleTracesWithID(notSampledID)))
+	assert.Equal(t, 2, sink.SpanCount())
+	assert.Equal(t, 2, mpe.EvaluationCount)
+	assert.Equal(t, uint64(0), tsp.numTracesOnMap.Load())
+}
+
+func TestCheckpointDecisionLogIsTrimmed(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+	c := newCheckpointer(client, zap.NewNop(), DecisionCacheConfig{SampledCacheSize: 3})
+	lookup := func(pcommon.TraceID) (*sampling.TraceData, bool) { return nil, false }
+
+	for i := uint64(1); i <= 5; i++ {
+		c.recordDecision(uInt64ToTraceID(i), sampling.Sampled)
+		require.NoError(t, c.checkpoint(t.Context(), lookup))
+	}
+	// The non-sampled cache is disabled, so its decisions are not recorded.
+	c.recordDecision(uInt64ToTraceID(6), sampling.NotSampled)
+	require.NoError(t, c.checkpoint(t.Context(), lookup))
+
+	var restored []pcommon.TraceID
+	c = newCheckpointer(client, zap.NewNop(), DecisionCacheConfig{SampledCacheSize: 3})
+	require.NoError(t, c.restoreDecisions(t.Context(), c.sampled, func(id pcommon.TraceID) {
+		restored = append(restored, id)
+	}))
+	assert.Equal(t, []pcommon.TraceID{uInt64ToTraceID(3), uInt64ToTraceID(4), uInt64ToTraceID(5)}, restored)
+
+	require.NoError(t, c.restoreDecisions(t.Context(), c.nonSampled, func(pcommon.TraceID) {
+		assert.Fail(t, "no non-sampled decisions expected")
+	}))
+
+	// Trimmed segments are removed from the storage.
+	data, err := client.Get(t.Context(), c.sampled.segmentKey(0))
+	require.NoError(t, err)
+	assert.Nil(t, data)
+}
+
+func TestCheckpointStorageNotFound(t *testing.T) {
+	storageID := storagetest.NewStorageID("missing")
+	tsp := newCheckpointTestProcessor(t, storageID, &mockPolicyEvaluator{}, new(consumertest.TracesSink))
+	require.ErrorContains(t, tsp.Start(t.Context(), componenttest.NewNopHost()), "storage extension 'test_storage/missing' not found")
+	require.NoError(t, tsp.Shutdown(t.Context()))
+}
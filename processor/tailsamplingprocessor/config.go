@@ -4,8 +4,11 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
+	"go.opentelemetry.io/collector/component"
+
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 )
 
@@ -45,6 +48,9 @@ const (
 	// OTTLCondition sample traces which match user provided OpenTelemetry Transformation Language
 	// conditions.
 	OTTLCondition PolicyType = "ottl_condition"
+	// Outlier sample traces containing spans whose latency or errors are outliers against a rolling
+	// baseline of their service and span name.
+	Outlier PolicyType = "outlier"
 )
 
 // sharedPolicyCfg holds the common configuration to all policies that are used in derivative policy configurations
@@ -74,6 +80,8 @@ type sharedPolicyCfg struct {
 	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
 	// Configs for OTTL condition filter sampling policy evaluator
 	OTTLConditionCfg OTTLConditionCfg `mapstructure:"ottl_condition"`
+	// Configs for outlier sampling policy evaluator.
+	OutlierCfg OutlierCfg `mapstructure:"outlier"`
 }
 
 // CompositeSubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -233,6 +241,30 @@ type OTTLConditionCfg struct {
 	SpanEventConditions []string       `mapstructure:"spanevent"`
 }
 
+// OutlierCfg holds the configurable settings to create an outlier sampling policy evaluator.
+// A baseline of the latency and error ratio is kept for every service name and span name,
+// and traces with at least one span that is an outlier against its baseline are sampled.
+type OutlierCfg struct {
+	// HalfLife is the time after which an observation weighs half as much in the baselines.
+	// Defaults to 5m.
+	HalfLife time.Duration `mapstructure:"half_life"`
+	// MinSamples is the number of spans a baseline needs before spans can be outliers against it.
+	// Defaults to 100.
+	MinSamples int64 `mapstructure:"min_samples"`
+	// LatencyDeviation is the number of standard deviations of the log latency above the baseline
+	// mean at which a span is a latency outlier. Defaults to 2.33, i.e. about the p99 of the baseline.
+	LatencyDeviation float64 `mapstructure:"latency_deviation"`
+	// ErrorRatioThreshold is the baseline error ratio below which an error span is an outlier.
+	// Defaults to 0.05.
+	ErrorRatioThreshold float64 `mapstructure:"error_ratio_threshold"`
+	// MaxTracesPerSecond is the maximum number of outlier traces sampled per second.
+	// If left as default 0, all outlier traces are sampled.
+	MaxTracesPerSecond int64 `mapstructure:"max_traces_per_second"`
+	// MaxBaselines is the maximum number of baselines kept, the least recently used ones being
+	// evicted first. Defaults to 10000.
+	MaxBaselines int `mapstructure:"max_baselines"`
+}
+
 type DecisionCacheConfig struct {
 	// SampledCacheSize specifies the size of the cache that holds the sampled trace IDs.
 	// This value will be the maximum amount of trace IDs that the cache can hold before overwriting previous IDs.
@@ -244,6 +276,15 @@ type DecisionCacheConfig struct {
 	// For effective use, this value should be at least an order of magnitude greater than Config.NumTraces.
 	// If left as default 0, a no-op DecisionCache will be used.
 	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
+	// SharedStorageID is the ID of a storage extension used to share the sampling decisions with
+	// other instances of the processor, such as replicas behind the loadbalancing exporter.
+	// Decisions found in the shared storage take precedence over evaluating the policies. They are looked
+	// up when the traces are due for a decision, never when spans are received.
+	// If not set, decisions are only cached locally.
+	SharedStorageID *component.ID `mapstructure:"shared_storage"`
+	// SharedStorageTimeout is the maximum time to wait for the batched lookup, and for the batched write,
+	// of the decisions made at each evaluation tick.
+	SharedStorageTimeout time.Duration `mapstructure:"shared_storage_timeout"`
 }
 
 // Config holds the configuration for tail-based sampling.
@@ -266,4 +307,16 @@ type Config struct {
 	Options []Option `mapstructure:"-"`
 	// Make decision as soon as a policy matches
 	SampleOnFirstMatch bool `mapstructure:"sample_on_first_match"`
+	// StorageID is the ID of a storage extension used to checkpoint the traces
+	// waiting for a decision and the decision caches, so they survive restarts.
+	// If not set, all the state is kept in memory only.
+	StorageID *component.ID `mapstructure:"storage"`
+}
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.DecisionCache.SharedStorageTimeout <= 0 {
+		return errors.New("decision_cache::shared_storage_timeout must be positive")
+	}
+	return nil
 }
@@ -35,7 +35,7 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
-			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000},
+			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1_000, NonSampledCacheSize: 10_000, SharedStorageTimeout: 100 * time.Millisecond},
 			PolicyCfgs: []PolicyCfg{
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
@@ -117,6 +117,19 @@ func TestLoadConfig(t *testing.T) {
 						},
 					},
 				},
+				{
+					sharedPolicyCfg: sharedPolicyCfg{
+						Name: "test-policy-12",
+						Type: Outlier,
+						OutlierCfg: OutlierCfg{
+							HalfLife:            10 * time.Minute,
+							MinSamples:          200,
+							LatencyDeviation:    3,
+							ErrorRatioThreshold: 0.01,
+							MaxTracesPerSecond:  50,
+						},
+					},
+				},
 				{
 					sharedPolicyCfg: sharedPolicyCfg{
 						Name: "and-policy-1",
@@ -186,3 +199,13 @@ func TestLoadConfig(t *testing.T) {
 			},
 		}, cfg)
 }
+
+func TestValidateSharedStorageTimeout(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	require.NoError(t, cfg.Validate())
+
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		cfg.DecisionCache.SharedStorageTimeout = timeout
+		assert.EqualError(t, cfg.Validate(), "decision_cache::shared_storage_timeout must be positive")
+	}
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/telemetry"
 )
 
+const defaultSharedStorageTimeout = 100 * time.Millisecond
+
 // NewFactory returns a new factory for the Tail Sampling processor.
 func NewFactory() processor.Factory {
 	return processor.NewFactory(
@@ -30,6 +32,9 @@ func createDefaultConfig() component.Config {
 		DecisionWait:       30 * time.Second,
 		NumTraces:          50000,
 		SampleOnFirstMatch: false,
+		DecisionCache: DecisionCacheConfig{
+			SharedStorageTimeout: defaultSharedStorageTimeout,
+		},
 	}
 }
 
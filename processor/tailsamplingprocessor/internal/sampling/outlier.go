@@ -0,0 +1,222 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"context"
+	"math"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultOutlierHalfLife            = 5 * time.Minute
+	defaultOutlierMinSamples          = 100
+	defaultOutlierLatencyDeviation    = 2.33
+	defaultOutlierErrorRatioThreshold = 0.05
+	defaultOutlierMaxBaselines        = 10000
+
+	// minLogLatencyStdDev avoids flagging tiny variations as outliers when the
+	// latency of an operation is almost constant. It is roughly a 5% deviation.
+	minLogLatencyStdDev = 0.05
+	serviceNameAttr     = "service.name"
+)
+
+// baselineKey identifies the operation a baseline is tracked for.
+type baselineKey struct {
+	service string
+	span    string
+}
+
+// baseline holds exponentially decaying statistics of the latency and errors
+// of an operation. Latencies are tracked in log space, as they are usually
+// close to log-normally distributed.
+type baseline struct {
+	count      int64
+	weight     float64
+	sum        float64
+	sumSquares float64
+	errors     float64
+	lastUpdate pcommon.Timestamp
+}
+
+// isOutlier tells whether a span is an outlier against the baseline,
+// before the span itself is added to it.
+func (b *baseline) isOutlier(logLatency float64, isError bool, minSamples int64, latencyDeviation, errorRatioThreshold float64) bool {
+	if b.count < minSamples {
+		return false
+	}
+	if isError && b.errors/b.weight < errorRatioThreshold {
+		return true
+	}
+
+	mean := b.sum / b.weight
+	stdDev := math.Sqrt(math.Max(0, b.sumSquares/b.weight-mean*mean))
+	return (logLatency-mean)/math.Max(stdDev, minLogLatencyStdDev) > latencyDeviation
+}
+
+// add decays the previous observations according to the time elapsed since the
+// last update, then adds the given one.
+func (b *baseline) add(logLatency float64, isError bool, ts pcommon.Timestamp, halfLife time.Duration) {
+	if b.count > 0 && ts > b.lastUpdate {
+		decay := math.Exp2(-float64(ts-b.lastUpdate) / float64(halfLife))
+		b.weight *= decay
+		b.sum *= decay
+		b.sumSquares *= decay
+		b.errors *= decay
+	}
+	if ts > b.lastUpdate {
+		b.lastUpdate = ts
+	}
+
+	b.count++
+	b.weight++
+	b.sum += logLatency
+	b.sumSquares += logLatency * logLatency
+	if isError {
+		b.errors++
+	}
+}
+
+type outlier struct {
+	logger              *zap.Logger
+	baselines           *lru.Cache[baselineKey, *baseline]
+	halfLife            time.Duration
+	minSamples          int64
+	latencyDeviation    float64
+	errorRatioThreshold float64
+
+	maxTracesPerSecond    int64
+	currentSecond         int64
+	tracesInCurrentSecond int64
+	timeProvider          TimeProvider
+}
+
+var _ PolicyEvaluator = (*outlier)(nil)
+
+// NewOutlier creates a policy evaluator that keeps a rolling baseline of the latency and error ratio of every
+// operation, identified by its service name and span name, and samples traces containing spans that are outliers
+// against the baseline of their operation. Zero values are replaced by defaults. At most maxTracesPerSecond traces
+// are sampled per second, unless it is zero.
+func NewOutlier(
+	settings component.TelemetrySettings,
+	halfLife time.Duration,
+	minSamples int64,
+	latencyDeviation, errorRatioThreshold float64,
+	maxTracesPerSecond int64,
+	maxBaselines int,
+	timeProvider TimeProvider,
+) (PolicyEvaluator, error) {
+	if halfLife <= 0 {
+		halfLife = defaultOutlierHalfLife
+	}
+	if minSamples <= 0 {
+		minSamples = defaultOutlierMinSamples
+	}
+	if latencyDeviation <= 0 {
+		latencyDeviation = defaultOutlierLatencyDeviation
+	}
+	if errorRatioThreshold <= 0 {
+		errorRatioThreshold = defaultOutlierErrorRatioThreshold
+	}
+	if maxBaselines <= 0 {
+		maxBaselines = defaultOutlierMaxBaselines
+	}
+
+	baselines, err := lru.New[baselineKey, *baseline](maxBaselines)
+	if err != nil {
+		return nil, err
+	}
+
+	return &outlier{
+		logger:              settings.Logger,
+		baselines:           baselines,
+		halfLife:            halfLife,
+		minSamples:          minSamples,
+		latencyDeviation:    latencyDeviation,
+		errorRatioThreshold: errorRatioThreshold,
+		maxTracesPerSecond:  maxTracesPerSecond,
+		timeProvider:        timeProvider,
+	}, nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+// Every span of the trace is added to the baselines, whatever the decision.
+func (o *outlier) Evaluate(_ context.Context, _ pcommon.TraceID, trace *TraceData) (Decision, error) {
+	o.logger.Debug("Evaluating spans in outlier filter")
+
+	trace.Lock()
+	defer trace.Unlock()
+
+	hasOutlier := false
+	rss := trace.ReceivedBatches.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		service := ""
+		if v, ok := rs.Resource().Attributes().Get(serviceNameAttr); ok {
+			service = v.AsString()
+		}
+
+		ilss := rs.ScopeSpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if o.observe(service, spans.At(k)) {
+					hasOutlier = true
+				}
+			}
+		}
+	}
+
+	if !hasOutlier {
+		return NotSampled, nil
+	}
+	if !o.withinBudget() {
+		o.logger.Debug("Outlier trace not sampled, the budget for the current second is exhausted")
+		return NotSampled, nil
+	}
+	return Sampled, nil
+}
+
+// observe checks whether the span is an outlier, then adds it to the baseline of its operation.
+func (o *outlier) observe(service string, span ptrace.Span) bool {
+	key := baselineKey{service: service, span: span.Name()}
+	b, ok := o.baselines.Get(key)
+	if !ok {
+		b = &baseline{}
+		o.baselines.Add(key, b)
+	}
+
+	duration := span.EndTimestamp().AsTime().Sub(span.StartTimestamp().AsTime())
+	// Durations are floored to 1µs so the logarithm is always defined.
+	logLatency := math.Log(math.Max(float64(duration)/float64(time.Microsecond), 1))
+	isError := span.Status().Code() == ptrace.StatusCodeError
+
+	isOutlier := b.isOutlier(logLatency, isError, o.minSamples, o.latencyDeviation, o.errorRatioThreshold)
+	b.add(logLatency, isError, span.EndTimestamp(), o.halfLife)
+	return isOutlier
+}
+
+// withinBudget reports whether another trace can be sampled in the current second.
+func (o *outlier) withinBudget() bool {
+	if o.maxTracesPerSecond <= 0 {
+		return true
+	}
+
+	currSecond := o.timeProvider.getCurSecond()
+	if o.currentSecond != currSecond {
+		o.currentSecond = currSecond
+		o.tracesInCurrentSecond = 0
+	}
+	if o.tracesInCurrentSecond >= o.maxTracesPerSecond {
+		return false
+	}
+	o.tracesInCurrentSecond++
+	return true
+}
@@ -0,0 +1,173 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package sampling
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+type outlierSpan struct {
+	service  string
+	name     string
+	duration time.Duration
+	isError  bool
+}
+
+func newOutlierTrace(end time.Time, spans ...outlierSpan) *TraceData {
+	traces := ptrace.NewTraces()
+	for _, s := range spans {
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", s.service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetName(s.name)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(end.Add(-s.duration)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(end))
+		if s.isError {
+			span.Status().SetCode(ptrace.StatusCodeError)
+		}
+	}
+	spanCount := &atomic.Int64{}
+	spanCount.Store(int64(len(spans)))
+	return &TraceData{
+		ReceivedBatches: traces,
+		SpanCount:       spanCount,
+	}
+}
+
+// warmUp feeds the policy with traces whose latency varies slightly around the given duration.
+func warmUp(t *testing.T, filter PolicyEvaluator, start time.Time, n int, s outlierSpan) time.Time {
+	base := s.duration
+	for i := 0; i < n; i++ {
+		s.duration = base + time.Duration(i%5)*base/10
+		start = start.Add(100 * time.Millisecond)
+		_, err := filter.Evaluate(t.Context(), traceID, newOutlierTrace(start, s))
+		require.NoError(t, err)
+	}
+	return start
+}
+
+func TestOutlierLatency(t *testing.T) {
+	filter, err := NewOutlier(componenttest.NewNopTelemetrySettings(), time.Minute, 50, 3, 0.05, 0, 0, FakeTimeProvider{})
+	require.NoError(t, err)
+
+	now := warmUp(t, filter, time.Unix(1000, 0), 50, outlierSpan{service: "checkout", name: "GET /cart", duration: 100 * time.Millisecond})
+	now = warmUp(t, filter, now, 50, outlierSpan{service: "search", name: "GET /search", duration: 2 * time.Second})
+
+	cases := []struct {
+		desc     string
+		span     outlierSpan
+		decision Decision
+	}{
+		{
+			desc:     "latency within the baseline",
+			span:     outlierSpan{service: "checkout", name: "GET /cart", duration: 120 * time.Millisecond},
+			decision: NotSampled,
+		},
+		{
+			desc:     "latency far above the baseline",
+			span:     outlierSpan{service: "checkout", name: "GET /cart", duration: time.Second},
+			decision: Sampled,
+		},
+		{
+			desc:     "same latency is normal for another service",
+			span:     outlierSpan{service: "search", name: "GET /search", duration: 2 * time.Second},
+			decision: NotSampled,
+		},
+		{
+			desc:     "operation without enough samples",
+			span:     outlierSpan{service: "checkout", name: "POST /pay", duration: time.Minute},
+			decision: NotSampled,
+		},
+		{
+			desc:     "error on an operation that rarely fails",
+			span:     outlierSpan{service: "search", name: "GET /search", duration: 2 * time.Second, isError: true},
+			decision: Sampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			now = now.Add(time.Millisecond)
+			decision, err := filter.Evaluate(t.Context(), traceID, newOutlierTrace(now, c.span))
+			assert.NoError(t, err)
+			assert.Equal(t, c.decision, decision)
+		})
+	}
+}
+
+func TestOutlierErrorRatio(t *testing.T) {
+	filter, err := NewOutlier(componenttest.NewNopTelemetrySettings(), time.Minute, 10, 0, 0.2, 0, 0, FakeTimeProvider{})
+	require.NoError(t, err)
+
+	// Half of the requests of this operation fail, so errors are not outliers.
+	now := time.Unix(1000, 0)
+	for i := 0; i < 20; i++ {
+		now = now.Add(time.Second)
+		span := outlierSpan{service: "legacy", name: "GET /flaky", duration: 10 * time.Millisecond, isError: i%2 == 0}
+		_, err := filter.Evaluate(t.Context(), traceID, newOutlierTrace(now, span))
+		require.NoError(t, err)
+	}
+
+	now = now.Add(time.Second)
+	span := outlierSpan{service: "legacy", name: "GET /flaky", duration: 10 * time.Millisecond, isError: true}
+	decision, err := filter.Evaluate(t.Context(), traceID, newOutlierTrace(now, span))
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestOutlierBaselineDecays(t *testing.T) {
+	filter, err := NewOutlier(componenttest.NewNopTelemetrySettings(), time.Second, 10, 3, 0, 0, 0, FakeTimeProvider{})
+	require.NoError(t, err)
+
+	now := warmUp(t, filter, time.Unix(1000, 0), 20, outlierSpan{service: "checkout", name: "GET /cart", duration: 100 * time.Millisecond})
+
+	// After a long time at a new latency, the old baseline has no weight left.
+	now = now.Add(time.Minute)
+	now = warmUp(t, filter, now, 1, outlierSpan{service: "checkout", name: "GET /cart", duration: time.Second})
+	now = now.Add(time.Minute)
+	now = warmUp(t, filter, now, 1, outlierSpan{service: "checkout", name: "GET /cart", duration: time.Second})
+
+	decision, err := filter.Evaluate(t.Context(), traceID, newOutlierTrace(now.Add(time.Millisecond), outlierSpan{service: "checkout", name: "GET /cart", duration: time.Second}))
+	require.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestOutlierBudget(t *testing.T) {
+	timeProvider := &manualTimeProvider{second: 1}
+	filter, err := NewOutlier(componenttest.NewNopTelemetrySettings(), time.Minute, 1, 0, 0.5, 2, 0, timeProvider)
+	require.NoError(t, err)
+
+	now := warmUp(t, filter, time.Unix(1000, 0), 10, outlierSpan{service: "checkout", name: "GET /cart", duration: 100 * time.Millisecond})
+	timeProvider.second++
+
+	evaluate := func() Decision {
+		now = now.Add(time.Millisecond)
+		decision, err := filter.Evaluate(t.Context(), traceID, newOutlierTrace(now, outlierSpan{service: "checkout", name: "GET /cart", duration: 100 * time.Millisecond, isError: true}))
+		require.NoError(t, err)
+		return decision
+	}
+
+	assert.Equal(t, Sampled, evaluate())
+	assert.Equal(t, Sampled, evaluate())
+	assert.Equal(t, NotSampled, evaluate())
+
+	timeProvider.second++
+	assert.Equal(t, Sampled, evaluate())
+}
+
+type manualTimeProvider struct {
+	second int64
+}
+
+func (m *manualTimeProvider) getCurSecond() int64 {
+	return m.second
+}
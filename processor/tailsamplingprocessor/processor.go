@@ -16,6 +16,7 @@ import (
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor"
@@ -51,21 +52,28 @@ type tailSamplingSpanProcessor struct {
 	telemetry *metadata.TelemetryBuilder
 	logger    *zap.Logger
 
-	nextConsumer       consumer.Traces
-	maxNumTraces       uint64
-	policies           []*policy
-	idToTrace          sync.Map
-	policyTicker       timeutils.TTicker
-	tickerFrequency    time.Duration
-	decisionBatcher    idbatcher.Batcher
-	sampledIDCache     cache.Cache[bool]
-	nonSampledIDCache  cache.Cache[bool]
-	deleteChan         chan pcommon.TraceID
-	numTracesOnMap     *atomic.Uint64
-	recordPolicy       bool
-	setPolicyMux       sync.Mutex
-	pendingPolicy      []PolicyCfg
-	sampleOnFirstMatch bool
+	nextConsumer      consumer.Traces
+	maxNumTraces      uint64
+	policies          []*policy
+	idToTrace         sync.Map
+	policyTicker      timeutils.TTicker
+	tickerFrequency   time.Duration
+	decisionBatcher   idbatcher.Batcher
+	sampledIDCache    cache.Cache[bool]
+	nonSampledIDCache cache.Cache[bool]
+	// sharedDecisions holds the decisions shared with other processor instances.
+	// It is consulted once per decision tick, never on the ingest path.
+	sharedDecisions     *sharedDecisions
+	sharedStorageClient storage.Client
+	deleteChan          chan pcommon.TraceID
+	numTracesOnMap      *atomic.Uint64
+	recordPolicy        bool
+	setPolicyMux        sync.Mutex
+	pendingPolicy       []PolicyCfg
+	sampleOnFirstMatch  bool
+	storageID           *component.ID
+	decisionCacheCfg    DecisionCacheConfig
+	checkpointer        *checkpointer
 }
 
 // spanAndScope a structure for holding information about span and its instrumentation scope.
@@ -130,6 +138,8 @@ func newTracesProcessor(ctx context.Context, set processor.Settings, nextConsume
 		numTracesOnMap:     &atomic.Uint64{},
 		deleteChan:         make(chan pcommon.TraceID, cfg.NumTraces),
 		sampleOnFirstMatch: cfg.SampleOnFirstMatch,
+		storageID:          cfg.StorageID,
+		decisionCacheCfg:   cfg.DecisionCache,
 	}
 	tsp.policyTicker = &timeutils.PolicyTicker{OnTickFunc: tsp.samplingPolicyOnTick}
 
@@ -197,6 +207,12 @@ func WithNonSampledDecisionCache(c cache.Cache[bool]) Option {
 	}
 }
 
+func withSharedDecisions(client storage.Client) Option {
+	return func(tsp *tailSamplingSpanProcessor) {
+		tsp.sharedDecisions = newSharedDecisions(client, tsp.decisionCacheCfg.SharedStorageTimeout, tsp.logger)
+	}
+}
+
 func withRecordPolicy() Option {
 	return func(tsp *tailSamplingSpanProcessor) {
 		tsp.recordPolicy = true
@@ -259,6 +275,10 @@ func getSharedPolicyEvaluator(settings component.TelemetrySettings, cfg *sharedP
 	case OTTLCondition:
 		ottlfCfg := cfg.OTTLConditionCfg
 		return sampling.NewOTTLConditionFilter(settings, ottlfCfg.SpanConditions, ottlfCfg.SpanEventConditions, ottlfCfg.ErrorMode)
+	case Outlier:
+		oCfg := cfg.OutlierCfg
+		return sampling.NewOutlier(settings, oCfg.HalfLife, oCfg.MinSamples, oCfg.LatencyDeviation, oCfg.ErrorRatioThreshold,
+			oCfg.MaxTracesPerSecond, oCfg.MaxBaselines, sampling.MonotonicClock{})
 
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
@@ -363,6 +383,12 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	batch, _ := tsp.decisionBatcher.CloseCurrentAndTakeFirstBatch()
 	batchLen := len(batch)
 
+	var shared, newDecisions map[pcommon.TraceID]sampling.Decision
+	if tsp.sharedDecisions != nil {
+		shared = tsp.sharedDecisions.getAll(batch)
+		newDecisions = make(map[pcommon.TraceID]sampling.Decision, len(batch))
+	}
+
 	for _, id := range batch {
 		d, ok := tsp.idToTrace.Load(id)
 		if !ok {
@@ -372,7 +398,15 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace := d.(*sampling.TraceData)
 		trace.DecisionTime = time.Now()
 
-		decision := tsp.makeDecision(id, trace, &metrics)
+		// Another instance may have already decided on this trace, in which case
+		// its decision is applied to keep the trace consistent.
+		decision, ok := shared[id]
+		if !ok {
+			decision = tsp.makeDecision(id, trace, &metrics)
+			if newDecisions != nil {
+				newDecisions[id] = decision
+			}
+		}
 
 		tsp.telemetry.ProcessorTailSamplingGlobalCountTracesSampled.Add(tsp.ctx, 1, decisionToAttributes[decision])
 
@@ -383,6 +417,10 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		trace.ReceivedBatches = ptrace.NewTraces()
 		trace.Unlock()
 
+		if tsp.checkpointer != nil {
+			tsp.checkpointer.recordDecision(id, decision)
+		}
+
 		if decision == sampling.Sampled {
 			tsp.releaseSampledTrace(ctx, id, allSpans)
 		} else {
@@ -390,6 +428,11 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 		}
 	}
 
+	if tsp.sharedDecisions != nil {
+		tsp.sharedDecisions.setAll(newDecisions)
+	}
+	tsp.checkpoint(ctx)
+
 	tsp.telemetry.ProcessorTailSamplingSamplingDecisionTimerLatency.Record(tsp.ctx, int64(time.Since(startTime)/time.Millisecond))
 	tsp.telemetry.ProcessorTailSamplingSamplingTracesOnMemory.Record(tsp.ctx, int64(tsp.numTracesOnMap.Load()))
 	tsp.telemetry.ProcessorTailSamplingSamplingTraceDroppedTooEarly.Add(tsp.ctx, metrics.idNotFoundOnMapCount)
@@ -555,18 +598,7 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 
 			if d, loaded = tsp.idToTrace.LoadOrStore(id, td); !loaded {
 				newTraceIDs++
-				tsp.decisionBatcher.AddToCurrentBatch(id)
-				tsp.numTracesOnMap.Add(1)
-				postDeletion := false
-				for !postDeletion {
-					select {
-					case tsp.deleteChan <- id:
-						postDeletion = true
-					default:
-						traceKeyToDrop := <-tsp.deleteChan
-						tsp.dropTrace(traceKeyToDrop, currTime)
-					}
-				}
+				tsp.trackNewTrace(id, currTime)
 			}
 		}
 
@@ -582,6 +614,9 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 			// If the final decision hasn't been made, add the new spans under the lock.
 			appendToTraces(actualData.ReceivedBatches, resourceSpans, spans)
 			actualData.Unlock()
+			if tsp.checkpointer != nil {
+				tsp.checkpointer.markPending(id)
+			}
 			continue
 		}
 
@@ -606,23 +641,128 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans ptrace.Resourc
 	tsp.telemetry.ProcessorTailSamplingNewTraceIDReceived.Add(tsp.ctx, newTraceIDs)
 }
 
+// trackNewTrace schedules a trace that was just added to idToTrace for a
+// decision, dropping the oldest traces if the maximum number of traces is reached.
+func (tsp *tailSamplingSpanProcessor) trackNewTrace(id pcommon.TraceID, currTime time.Time) {
+	tsp.decisionBatcher.AddToCurrentBatch(id)
+	tsp.numTracesOnMap.Add(1)
+	postDeletion := false
+	for !postDeletion {
+		select {
+		case tsp.deleteChan <- id:
+			postDeletion = true
+		default:
+			traceKeyToDrop := <-tsp.deleteChan
+			tsp.dropTrace(traceKeyToDrop, currTime)
+		}
+	}
+}
+
 func (*tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, host component.Host) error {
+	sharedClient, err := getStorageClient(ctx, host, tsp.decisionCacheCfg.SharedStorageID, tsp.set.ID, sharedDecisionsClientName)
+	if err != nil {
+		return err
+	}
+	if sharedClient != nil {
+		tsp.sharedStorageClient = sharedClient
+		tsp.sharedDecisions = newSharedDecisions(sharedClient, tsp.decisionCacheCfg.SharedStorageTimeout, tsp.logger)
+	}
+
+	client, err := getStorageClient(ctx, host, tsp.storageID, tsp.set.ID, "")
+	if err != nil {
+		return errors.Join(err, tsp.closeSharedStorage(ctx))
+	}
+	if client != nil {
+		tsp.checkpointer = newCheckpointer(client, tsp.logger, tsp.decisionCacheCfg)
+		if err := tsp.restoreCheckpoint(ctx); err != nil {
+			return errors.Join(err, client.Close(ctx), tsp.closeSharedStorage(ctx))
+		}
+	}
+
 	tsp.policyTicker.Start(tsp.tickerFrequency)
 	return nil
 }
 
 // Shutdown is invoked during service shutdown.
-func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
+func (tsp *tailSamplingSpanProcessor) Shutdown(ctx context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.Stop()
+
+	var errs error
+	if tsp.checkpointer != nil {
+		tsp.checkpoint(ctx)
+		errs = tsp.checkpointer.client.Close(ctx)
+	}
+	return errors.Join(errs, tsp.closeSharedStorage(ctx))
+}
+
+func (tsp *tailSamplingSpanProcessor) closeSharedStorage(ctx context.Context) error {
+	if tsp.sharedStorageClient == nil {
+		return nil
+	}
+	return tsp.sharedStorageClient.Close(ctx)
+}
+
+// restoreCheckpoint loads the decisions and pending traces stored in the
+// previous run. Restored traces are scheduled for a decision as if they had
+// just arrived, so they get the full decision wait to receive the rest of
+// their spans.
+func (tsp *tailSamplingSpanProcessor) restoreCheckpoint(ctx context.Context) error {
+	putSampled := func(id pcommon.TraceID) { tsp.sampledIDCache.Put(id, true) }
+	if err := tsp.checkpointer.restoreDecisions(ctx, tsp.checkpointer.sampled, putSampled); err != nil {
+		return err
+	}
+	putNonSampled := func(id pcommon.TraceID) { tsp.nonSampledIDCache.Put(id, true) }
+	if err := tsp.checkpointer.restoreDecisions(ctx, tsp.checkpointer.nonSampled, putNonSampled); err != nil {
+		return err
+	}
+
+	currTime := time.Now()
+	var restored int64
+	err := tsp.checkpointer.restorePendingTraces(ctx, func(id pcommon.TraceID, arrivalTime time.Time, td ptrace.Traces) {
+		spanCount := &atomic.Int64{}
+		spanCount.Store(int64(td.SpanCount()))
+		trace := &sampling.TraceData{
+			ArrivalTime:     arrivalTime,
+			SpanCount:       spanCount,
+			ReceivedBatches: td,
+		}
+		if _, loaded := tsp.idToTrace.LoadOrStore(id, trace); !loaded {
+			restored++
+			tsp.trackNewTrace(id, currTime)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	tsp.logger.Debug("Restored tail sampling checkpoint", zap.Int64("traces", restored))
 	return nil
 }
 
+// checkpoint writes the changes made since the last checkpoint to the storage,
+// if one is configured. Failures are logged and retried on the next checkpoint.
+func (tsp *tailSamplingSpanProcessor) checkpoint(ctx context.Context) {
+	if tsp.checkpointer == nil {
+		return
+	}
+	err := tsp.checkpointer.checkpoint(ctx, func(id pcommon.TraceID) (*sampling.TraceData, bool) {
+		d, ok := tsp.idToTrace.Load(id)
+		if !ok {
+			return nil, false
+		}
+		return d.(*sampling.TraceData), true
+	})
+	if err != nil {
+		tsp.logger.Warn("Failed to checkpoint tail sampling state", zap.Error(err))
+	}
+}
+
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletionTime time.Time) {
 	var trace *sampling.TraceData
 	if d, ok := tsp.idToTrace.Load(traceID); ok {
@@ -630,6 +770,9 @@ func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pcommon.TraceID, deletio
 		tsp.idToTrace.Delete(traceID)
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		tsp.numTracesOnMap.Add(^uint64(0))
+		if tsp.checkpointer != nil {
+			tsp.checkpointer.markRemoved(traceID)
+		}
 	}
 	if trace == nil {
 		tsp.logger.Debug("Attempt to delete trace ID not on table", zap.Stringer("id", traceID))
@@ -4,17 +4,22 @@
 package tailsamplingprocessor
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/processor/processortest"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/metric"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/metadata"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
@@ -693,3 +698,108 @@ func TestSampleOnFirstMatch(t *testing.T) {
 	// The final decision SHOULD be Sampled.
 	require.Equal(t, 1, nextConsumer.SpanCount())
 }
+
+func TestSharedDecisionCaches(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindProcessor, component.MustNewID("tail_sampling"), "")
+
+	newReplica := func(mpe *mockPolicyEvaluator, sink *consumertest.TracesSink) *tailSamplingSpanProcessor {
+		cfg := Config{
+			DecisionWait:  defaultTestDecisionWait,
+			NumTraces:     defaultNumTraces,
+			DecisionCache: DecisionCacheConfig{SampledCacheSize: 10, NonSampledCacheSize: 10, SharedStorageTimeout: defaultSharedStorageTimeout},
+			Options: []Option{
+				withDecisionBatcher(newSyncIDBatcher()),
+				withPolicies([]*policy{
+					{name: "mock-policy", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+				}),
+				withSharedDecisions(client),
+			},
+		}
+		p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+		require.NoError(t, err)
+		require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+		t.Cleanup(func() {
+			require.NoError(t, p.Shutdown(context.Background()))
+		})
+		return p.(*tailSamplingSpanProcessor)
+	}
+
+	mpe1 := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink1 := new(consumertest.TracesSink)
+	replica1 := newReplica(mpe1, sink1)
+
+	mpe2 := &mockPolicyEvaluator{NextDecision: sampling.NotSampled}
+	sink2 := new(consumertest.TracesSink)
+	replica2 := newReplica(mpe2, sink2)
+
+	sampledID := uInt64ToTraceID(1)
+	splitID := uInt64ToTraceID(2)
+
+	// Replica 1 receives and samples the first trace, and the first half of the second one.
+	require.NoError(t, replica1.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	require.NoError(t, replica1.ConsumeTraces(t.Context(), simpleTracesWithID(splitID)))
+	// Replica 2 receives the second half of the second trace before replica 1 decides.
+	require.NoError(t, replica2.ConsumeTraces(t.Context(), simpleTracesWithID(splitID)))
+
+	replica1.policyTicker.OnTick()
+	replica1.policyTicker.OnTick()
+	require.Equal(t, 2, mpe1.EvaluationCount)
+	require.Equal(t, 2, sink1.SpanCount())
+
+	// Both traces are stored under a single key each.
+	stored, err := client.Get(t.Context(), sharedDecisionKey(sampledID))
+	require.NoError(t, err)
+	assert.Equal(t, sharedSampledValue, stored)
+
+	// Late spans of the first trace arrive at replica 2 after a rebalance.
+	// The shared storage is not consulted on ingest, the spans wait for the next decision.
+	require.NoError(t, replica2.ConsumeTraces(t.Context(), simpleTracesWithID(sampledID)))
+	assert.Equal(t, 0, sink2.SpanCount())
+
+	// Replica 2 applies the decisions of replica 1 instead of evaluating its own policies.
+	replica2.policyTicker.OnTick()
+	replica2.policyTicker.OnTick()
+	assert.Equal(t, 0, mpe2.EvaluationCount)
+	assert.Equal(t, 2, sink2.SpanCount())
+	_, ok := replica2.sampledIDCache.Get(sampledID)
+	assert.True(t, ok)
+}
+
+type failingBatchClient struct {
+	storage.Client
+}
+
+func (failingBatchClient) Batch(context.Context, ...*storage.Operation) error {
+	return errors.New("unavailable")
+}
+
+func TestSharedDecisionsStorageError(t *testing.T) {
+	mpe := &mockPolicyEvaluator{NextDecision: sampling.Sampled}
+	sink := new(consumertest.TracesSink)
+	cfg := Config{
+		DecisionWait:  defaultTestDecisionWait,
+		NumTraces:     defaultNumTraces,
+		DecisionCache: DecisionCacheConfig{SharedStorageTimeout: defaultSharedStorageTimeout},
+		Options: []Option{
+			withDecisionBatcher(newSyncIDBatcher()),
+			withPolicies([]*policy{
+				{name: "mock-policy", evaluator: mpe, attribute: metric.WithAttributes(attribute.String("policy", "mock-policy"))},
+			}),
+			withSharedDecisions(failingBatchClient{}),
+		},
+	}
+	p, err := newTracesProcessor(t.Context(), processortest.NewNopSettings(metadata.Type), sink, cfg)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(t.Context(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, p.Shutdown(context.Background()))
+	}()
+	tsp := p.(*tailSamplingSpanProcessor)
+
+	// The processor falls back to its own policies when the shared storage is unavailable.
+	require.NoError(t, tsp.ConsumeTraces(t.Context(), simpleTracesWithID(uInt64ToTraceID(1))))
+	tsp.policyTicker.OnTick()
+	tsp.policyTicker.OnTick()
+	assert.Equal(t, 1, mpe.EvaluationCount)
+	assert.Equal(t, 1, sink.SpanCount())
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+)
+
+const (
+	// sharedDecisionsClientName is the name of the storage client used for the shared
+	// decisions, to keep their keys apart from the checkpoint ones.
+	sharedDecisionsClientName = "decision_cache"
+	sharedDecisionKeyPrefix   = "decision."
+)
+
+var (
+	sharedSampledValue    = []byte{1}
+	sharedNotSampledValue = []byte{0}
+)
+
+// sharedDecisions stores the sampling decisions in a storage shared by several
+// processor instances, such as replicas behind the loadbalancing exporter.
+// Each decision is stored under a single key per trace, and all the lookups and
+// writes of a decision tick are sent to the storage as one batch.
+// Entries are never deleted, the storage is expected to expire them.
+type sharedDecisions struct {
+	client  storage.Client
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+func newSharedDecisions(client storage.Client, timeout time.Duration, logger *zap.Logger) *sharedDecisions {
+	return &sharedDecisions{
+		client:  client,
+		timeout: timeout,
+		logger:  logger,
+	}
+}
+
+// getAll returns the decisions made by any of the processor instances for the given traces.
+// Traces without a shared decision are absent from the result. A failed lookup is logged
+// and treated as if no decision had been shared.
+func (s *sharedDecisions) getAll(ids []pcommon.TraceID) map[pcommon.TraceID]sampling.Decision {
+	if len(ids) == 0 {
+		return nil
+	}
+	ops := make([]*storage.Operation, len(ids))
+	for i, id := range ids {
+		ops[i] = storage.GetOperation(sharedDecisionKey(id))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.logger.Debug("Failed to get decisions from the shared storage", zap.Int("traces", len(ids)), zap.Error(err))
+		return nil
+	}
+
+	decisions := make(map[pcommon.TraceID]sampling.Decision)
+	for i, op := range ops {
+		if len(op.Value) != 1 {
+			continue
+		}
+		if op.Value[0] == sharedSampledValue[0] {
+			decisions[ids[i]] = sampling.Sampled
+		} else {
+			decisions[ids[i]] = sampling.NotSampled
+		}
+	}
+	return decisions
+}
+
+// setAll makes the given decisions visible to the other processor instances.
+func (s *sharedDecisions) setAll(decisions map[pcommon.TraceID]sampling.Decision) {
+	if len(decisions) == 0 {
+		return
+	}
+	ops := make([]*storage.Operation, 0, len(decisions))
+	for id, decision := range decisions {
+		value := sharedNotSampledValue
+		if decision == sampling.Sampled {
+			value = sharedSampledValue
+		}
+		ops = append(ops, storage.SetOperation(sharedDecisionKey(id), value))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	if err := s.client.Batch(ctx, ops...); err != nil {
+		s.logger.Warn("Failed to store decisions in the shared storage", zap.Int("traces", len(decisions)), zap.Error(err))
+	}
+}
+
+func sharedDecisionKey(id pcommon.TraceID) string {
+	return sharedDecisionKeyPrefix + id.String()
+}
@@ -47,6 +47,10 @@ type Config struct {
 	LogStatements     []common.ContextStatements `mapstructure:"log_statements"`
 	ProfileStatements []common.ContextStatements `mapstructure:"profile_statements"`
 
+	// Functions declares OTTL functions composed of other OTTL statements, expressions or
+	// conditions, which can be called from the statements of all the contexts.
+	Functions []ottl.FunctionDefinition `mapstructure:"functions"`
+
 	FlattenData bool `mapstructure:"flatten_data"`
 	logger      *zap.Logger
 
@@ -142,10 +146,17 @@ func (c *Config) Unmarshal(conf *confmap.Conf) error {
 var _ component.Config = (*Config)(nil)
 
 func (c *Config) Validate() error {
+	// The function definitions validate themselves, the statements cannot be parsed until they are valid.
+	for i := range c.Functions {
+		if c.Functions[i].Validate() != nil {
+			return nil
+		}
+	}
+
 	var errors error
 
 	if len(c.TraceStatements) > 0 {
-		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithSpanParser(c.spanFunctions), common.WithSpanEventParser(c.spanEventFunctions))
+		pc, err := common.NewTraceParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.Functions, common.WithSpanParser(c.spanFunctions, c.Functions), common.WithSpanEventParser(c.spanEventFunctions, c.Functions))
 		if err != nil {
 			return err
 		}
@@ -158,7 +169,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.MetricStatements) > 0 {
-		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithMetricParser(c.metricFunctions), common.WithDataPointParser(c.dataPointFunctions))
+		pc, err := common.NewMetricParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.Functions, common.WithMetricParser(c.metricFunctions, c.Functions), common.WithDataPointParser(c.dataPointFunctions, c.Functions))
 		if err != nil {
 			return err
 		}
@@ -171,7 +182,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.LogStatements) > 0 {
-		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithLogParser(c.logFunctions))
+		pc, err := common.NewLogParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.Functions, common.WithLogParser(c.logFunctions, c.Functions))
 		if err != nil {
 			return err
 		}
@@ -184,7 +195,7 @@ func (c *Config) Validate() error {
 	}
 
 	if len(c.ProfileStatements) > 0 {
-		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, common.WithProfileParser(c.profileFunctions))
+		pc, err := common.NewProfileParserCollection(component.TelemetrySettings{Logger: zap.NewNop()}, c.Functions, common.WithProfileParser(c.profileFunctions, c.Functions))
 		if err != nil {
 			return err
 		}
@@ -288,6 +288,38 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "with_functions"),
+			expected: &Config{
+				ErrorMode: ottl.PropagateError,
+				Functions: []ottl.FunctionDefinition{
+					{
+						Name:       "redact",
+						Params:     []string{"target"},
+						Statements: []string{`replace_pattern(target, "[0-9]{3}$", "***")`},
+					},
+					{
+						Name:      "IsHealthCheck",
+						Params:    []string{"path"},
+						Condition: `IsMatch(path, "^/health")`,
+					},
+				},
+				TraceStatements:  []common.ContextStatements{},
+				MetricStatements: []common.ContextStatements{},
+				LogStatements: []common.ContextStatements{
+					{
+						Statements: []string{`redact(log.attributes["card"]) where not IsHealthCheck(log.attributes["http.path"])`},
+					},
+				},
+				ProfileStatements: []common.ContextStatements{},
+			},
+		},
+		{
+			id: component.NewIDWithName(metadata.Type, "invalid_function"),
+			errors: []error{
+				errors.New(`converter "IsHealthCheck" must define either an expression or a condition`),
+			},
+		},
 		{
 			id: component.NewIDWithName(metadata.Type, "context_statements_error_mode"),
 			expected: &Config{
@@ -57,9 +57,9 @@ type LogParserCollection ottl.ParserCollection[LogsConsumer]
 
 type LogParserCollectionOption ottl.ParserCollectionOption[LogsConsumer]
 
-func WithLogParser(functions map[string]ottl.Factory[ottllog.TransformContext]) LogParserCollectionOption {
+func WithLogParser(functions map[string]ottl.Factory[ottllog.TransformContext], definitions []ottl.FunctionDefinition) LogParserCollectionOption {
 	return func(pc *ottl.ParserCollection[LogsConsumer]) error {
-		logParser, err := ottllog.NewParser(functions, pc.Settings, ottllog.EnablePathContextNames())
+		logParser, err := ottllog.NewParser(functions, pc.Settings, ottllog.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottllog.TransformContext](definitions))
 		if err != nil {
 			return err
 		}
@@ -71,9 +71,9 @@ func WithLogErrorMode(errorMode ottl.ErrorMode) LogParserCollectionOption {
 	return LogParserCollectionOption(ottl.WithParserCollectionErrorMode[LogsConsumer](errorMode))
 }
 
-func NewLogParserCollection(settings component.TelemetrySettings, options ...LogParserCollectionOption) (*LogParserCollection, error) {
+func NewLogParserCollection(settings component.TelemetrySettings, definitions []ottl.FunctionDefinition, options ...LogParserCollectionOption) (*LogParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[LogsConsumer]{
-		withCommonContextParsers[LogsConsumer](),
+		withCommonContextParsers[LogsConsumer](definitions),
 		ottl.EnableParserCollectionModifiedPathsLogging[LogsConsumer](true),
 	}
 
@@ -192,13 +192,13 @@ type baseContext interface {
 	ProfilesConsumer
 }
 
-func withCommonContextParsers[R any]() ottl.ParserCollectionOption[R] {
+func withCommonContextParsers[R any](definitions []ottl.FunctionDefinition) ottl.ParserCollectionOption[R] {
 	return func(pc *ottl.ParserCollection[R]) error {
-		rp, err := ottlresource.NewParser(ResourceFunctions(), pc.Settings, ottlresource.EnablePathContextNames())
+		rp, err := ottlresource.NewParser(ResourceFunctions(), pc.Settings, ottlresource.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottlresource.TransformContext](definitions))
 		if err != nil {
 			return err
 		}
-		sp, err := ottlscope.NewParser(ScopeFunctions(), pc.Settings, ottlscope.EnablePathContextNames())
+		sp, err := ottlscope.NewParser(ScopeFunctions(), pc.Settings, ottlscope.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottlscope.TransformContext](definitions))
 		if err != nil {
 			return err
 		}
@@ -55,9 +55,9 @@ type ProfileParserCollection ottl.ParserCollection[ProfilesConsumer]
 
 type ProfileParserCollectionOption ottl.ParserCollectionOption[ProfilesConsumer]
 
-func WithProfileParser(functions map[string]ottl.Factory[ottlprofile.TransformContext]) ProfileParserCollectionOption {
+func WithProfileParser(functions map[string]ottl.Factory[ottlprofile.TransformContext], definitions []ottl.FunctionDefinition) ProfileParserCollectionOption {
 	return func(pc *ottl.ParserCollection[ProfilesConsumer]) error {
-		profileParser, err := ottlprofile.NewParser(functions, pc.Settings, ottlprofile.EnablePathContextNames())
+		profileParser, err := ottlprofile.NewParser(functions, pc.Settings, ottlprofile.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottlprofile.TransformContext](definitions))
 		if err != nil {
 			return err
 		}
@@ -69,9 +69,9 @@ func WithProfileErrorMode(errorMode ottl.ErrorMode) ProfileParserCollectionOptio
 	return ProfileParserCollectionOption(ottl.WithParserCollectionErrorMode[ProfilesConsumer](errorMode))
 }
 
-func NewProfileParserCollection(settings component.TelemetrySettings, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
+func NewProfileParserCollection(settings component.TelemetrySettings, definitions []ottl.FunctionDefinition, options ...ProfileParserCollectionOption) (*ProfileParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[ProfilesConsumer]{
-		withCommonContextParsers[ProfilesConsumer](),
+		withCommonContextParsers[ProfilesConsumer](definitions),
 		ottl.EnableParserCollectionModifiedPathsLogging[ProfilesConsumer](true),
 	}
 
@@ -95,9 +95,9 @@ type TraceParserCollection ottl.ParserCollection[TracesConsumer]
 
 type TraceParserCollectionOption ottl.ParserCollectionOption[TracesConsumer]
 
-func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]) TraceParserCollectionOption {
+func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext], definitions []ottl.FunctionDefinition) TraceParserCollectionOption {
 	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
-		parser, err := ottlspan.NewParser(functions, pc.Settings, ottlspan.EnablePathContextNames())
+		parser, err := ottlspan.NewParser(functions, pc.Settings, ottlspan.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottlspan.TransformContext](definitions))
 		if err != nil {
 			return err
 		}
@@ -105,9 +105,9 @@ func WithSpanParser(functions map[string]ottl.Factory[ottlspan.TransformContext]
 	}
 }
 
-func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.TransformContext]) TraceParserCollectionOption {
+func WithSpanEventParser(functions map[string]ottl.Factory[ottlspanevent.TransformContext], definitions []ottl.FunctionDefinition) TraceParserCollectionOption {
 	return func(pc *ottl.ParserCollection[TracesConsumer]) error {
-		parser, err := ottlspanevent.NewParser(functions, pc.Settings, ottlspanevent.EnablePathContextNames())
+		parser, err := ottlspanevent.NewParser(functions, pc.Settings, ottlspanevent.EnablePathContextNames(), ottl.WithFunctionDefinitions[ottlspanevent.TransformContext](definitions))
 		if err != nil {
 			return err
 		}
@@ -119,9 +119,9 @@ func WithTraceErrorMode(errorMode ottl.ErrorMode) TraceParserCollectionOption {
 	return TraceParserCollectionOption(ottl.WithParserCollectionErrorMode[TracesConsumer](errorMode))
 }
 
-func NewTraceParserCollection(settings component.TelemetrySettings, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
+func NewTraceParserCollection(settings component.TelemetrySettings, definitions []ottl.FunctionDefinition, options ...TraceParserCollectionOption) (*TraceParserCollection, error) {
 	pcOptions := []ottl.ParserCollectionOption[TracesConsumer]{
-		withCommonContextParsers[TracesConsumer](),
+		withCommonContextParsers[TracesConsumer](definitions),
 		ottl.EnableParserCollectionModifiedPathsLogging[TracesConsumer](true),
 	}
 
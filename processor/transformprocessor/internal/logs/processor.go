@@ -23,8 +23,8 @@ type Processor struct {
 	flatMode bool
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, flatMode bool, settings component.TelemetrySettings, logFunctions map[string]ottl.Factory[ottllog.TransformContext]) (*Processor, error) {
-	pc, err := common.NewLogParserCollection(settings, common.WithLogParser(logFunctions), common.WithLogErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, flatMode bool, settings component.TelemetrySettings, logFunctions map[string]ottl.Factory[ottllog.TransformContext], definitions []ottl.FunctionDefinition) (*Processor, error) {
+	pc, err := common.NewLogParserCollection(settings, definitions, common.WithLogParser(logFunctions, definitions), common.WithLogErrorMode(errorMode))
 	if err != nil {
 		return nil, err
 	}
@@ -21,8 +21,8 @@ type Processor struct {
 	logger   *zap.Logger
 }
 
-func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings, profileFunctions map[string]ottl.Factory[ottlprofile.TransformContext]) (*Processor, error) {
-	pc, err := common.NewProfileParserCollection(settings, common.WithProfileParser(profileFunctions), common.WithProfileErrorMode(errorMode))
+func NewProcessor(contextStatements []common.ContextStatements, errorMode ottl.ErrorMode, settings component.TelemetrySettings, profileFunctions map[string]ottl.Factory[ottlprofile.TransformContext], definitions []ottl.FunctionDefinition) (*Processor, error) {
+	pc, err := common.NewProfileParserCollection(settings, definitions, common.WithProfileParser(profileFunctions, definitions), common.WithProfileErrorMode(errorMode))
 	if err != nil {
 		return nil, err
 	}
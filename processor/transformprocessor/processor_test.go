@@ -10,9 +10,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/processor/processortest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/golden"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/pdatatest/plogtest"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/common"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor/internal/metadata"
@@ -145,3 +147,51 @@ func BenchmarkLogsWithFlatten(b *testing.B) {
 		assert.NoError(b, p.ConsumeLogs(b.Context(), input))
 	}
 }
+
+func TestProcessLogsWithFunctions(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Functions = []ottl.FunctionDefinition{
+		{
+			Name:       "redact",
+			Params:     []string{"target"},
+			Statements: []string{`replace_pattern(target, "[0-9]{3}$", "***")`},
+		},
+		{
+			Name:      "IsHealthCheck",
+			Params:    []string{"path"},
+			Condition: `IsMatch(path, "^/health")`,
+		},
+	}
+	oCfg.LogStatements = []common.ContextStatements{
+		{
+			Statements: []string{
+				`redact(log.attributes["card"]) where not IsHealthCheck(log.attributes["http.path"])`,
+			},
+		},
+	}
+	require.NoError(t, oCfg.Validate())
+	sink := new(consumertest.LogsSink)
+	p, err := factory.CreateLogs(t.Context(), processortest.NewNopSettings(metadata.Type), oCfg, sink)
+	require.NoError(t, err)
+
+	input := plog.NewLogs()
+	records := input.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	checkout := records.AppendEmpty()
+	checkout.Attributes().PutStr("http.path", "/checkout")
+	checkout.Attributes().PutStr("card", "123456789")
+	health := records.AppendEmpty()
+	health.Attributes().PutStr("http.path", "/health")
+	health.Attributes().PutStr("card", "123456789")
+
+	assert.NoError(t, p.ConsumeLogs(t.Context(), input))
+
+	actual := sink.AllLogs()
+	require.Len(t, actual, 1)
+	actualRecords := actual[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	card, _ := actualRecords.At(0).Attributes().Get("card")
+	assert.Equal(t, "123456***", card.Str())
+	card, _ = actualRecords.At(1).Attributes().Get("card")
+	assert.Equal(t, "123456789", card.Str())
+}
@@ -42,6 +42,22 @@ type SQSConfig struct {
 	MaxNumberOfMessages int64 `mapstructure:"max_number_of_messages"`
 }
 
+// PollingConfig configures the continuous ingestion of the objects of the bucket, which is listed periodically.
+type PollingConfig struct {
+	// Interval is the time between two listings of the bucket. Default is 1 minute.
+	Interval time.Duration `mapstructure:"interval"`
+	// StartAfter is the key after which the objects are listed, when no object was ingested yet. The objects whose
+	// key is lexicographically smaller or equal are not ingested.
+	StartAfter string `mapstructure:"start_after"`
+	// MaxTrackedObjects is the maximum number of the most recent objects tracked, by key, to detect the objects
+	// which are added or modified behind the most recent one. The objects before are not listed anymore.
+	// Default is 10000.
+	MaxTrackedObjects int `mapstructure:"max_tracked_objects"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
+}
+
 // Notifications groups optional notification sources.
 type Notifications struct {
 	OpAMP *component.ID `mapstructure:"opampextension"`
@@ -68,6 +84,11 @@ type Config struct {
 	Notifications Notifications      `mapstructure:"notifications"`
 	// SQS configures receiving S3 object change notifications via an SQS queue.
 	SQS *SQSConfig `mapstructure:"sqs"`
+	// Polling configures the continuous ingestion of the objects of the bucket.
+	Polling *PollingConfig `mapstructure:"polling"`
+	// StorageID is the storage extension used to keep track of the objects ingested by polling, so they are not
+	// ingested again after a restart.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 const (
@@ -98,9 +119,10 @@ func (c Config) Validate() error {
 	hasStartTime := c.StartTime != ""
 	hasEndTime := c.EndTime != ""
 	hasSQS := c.SQS != nil
+	hasPolling := c.Polling != nil
 
-	if !hasStartTime && !hasEndTime && !hasSQS {
-		errs = multierr.Append(errs, errors.New("either starttime/endtime or sqs configuration must be provided"))
+	if !hasStartTime && !hasEndTime && !hasSQS && !hasPolling {
+		errs = multierr.Append(errs, errors.New("either starttime/endtime, sqs or polling configuration must be provided"))
 	}
 
 	// If one of StartTime/EndTime is specified, the other must also be specified
@@ -115,6 +137,12 @@ func (c Config) Validate() error {
 	if hasStartTime && hasSQS {
 		errs = multierr.Append(errs, errors.New("starttime/endtime and sqs configuration cannot be used together"))
 	}
+	if hasPolling && (hasStartTime || hasSQS) {
+		errs = multierr.Append(errs, errors.New("polling configuration cannot be used together with starttime/endtime or sqs configuration"))
+	}
+	if c.StorageID != nil && !hasPolling {
+		errs = multierr.Append(errs, errors.New("storage is only supported with polling configuration"))
+	}
 
 	// Validate StartTime format if specified
 	if hasStartTime {
@@ -146,6 +174,13 @@ func (c Config) Validate() error {
 			errs = multierr.Append(errs, errors.New("sqs.max_number_of_messages must be between 1 and 10"))
 		}
 	}
+
+	if c.Polling != nil && c.Polling.Interval < 0 {
+		errs = multierr.Append(errs, errors.New("polling.interval must not be negative"))
+	}
+	if c.Polling != nil && c.Polling.MaxTrackedObjects < 0 {
+		errs = multierr.Append(errs, errors.New("polling.max_tracked_objects must not be negative"))
+	}
 	return errs
 }
 
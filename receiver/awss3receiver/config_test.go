@@ -6,6 +6,7 @@ package awss3receiver
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -61,12 +62,43 @@ func TestConfig_Validate_Valid(t *testing.T) {
 		}
 		assert.NoError(t, cfg.Validate())
 	})
+
+	// Valid config with polling
+	t.Run("with polling", func(t *testing.T) {
+		storageID := component.MustNewID("file_storage")
+		cfg := Config{
+			S3Downloader: S3DownloaderConfig{
+				S3Bucket:    "abucket",
+				S3Partition: "minute",
+			},
+			Polling:   &PollingConfig{},
+			StorageID: &storageID,
+		}
+		assert.NoError(t, cfg.Validate())
+	})
+}
+
+func TestConfig_Validate_Storage(t *testing.T) {
+	storageID := component.MustNewID("file_storage")
+	cfg := Config{
+		S3Downloader: S3DownloaderConfig{
+			S3Bucket:    "abucket",
+			S3Partition: "minute",
+		},
+		SQS: &SQSConfig{
+			QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/test-queue",
+			Region:   "us-east-1",
+		},
+		StorageID: &storageID,
+	}
+	assert.EqualError(t, cfg.Validate(), "storage is only supported with polling configuration")
 }
 
 func TestLoadConfig(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
 	require.NoError(t, err)
 	opampExtension := component.NewIDWithName(component.MustNewType("opamp"), "bar")
+	fileStorage := component.MustNewID("file_storage")
 	tests := []struct {
 		id           component.ID
 		expected     component.Config
@@ -74,7 +106,7 @@ func TestLoadConfig(t *testing.T) {
 	}{
 		{
 			id:           component.NewIDWithName(metadata.Type, ""),
-			errorMessage: "bucket is required; either starttime/endtime or sqs configuration must be provided",
+			errorMessage: "bucket is required; either starttime/endtime, sqs or polling configuration must be provided",
 		},
 		{
 			id:           component.NewIDWithName(metadata.Type, "1"),
@@ -148,6 +180,34 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(metadata.Type, "6"),
+			expected: &Config{
+				S3Downloader: S3DownloaderConfig{
+					Region:              "us-east-1",
+					S3Bucket:            "abucket",
+					S3Prefix:            "AWSLogs/123456789012/CloudTrail",
+					S3Partition:         "minute",
+					EndpointPartitionID: "aws",
+				},
+				Encodings: []Encoding{
+					{
+						Extension: component.NewIDWithName(component.MustNewType("awslogs_encoding"), "cloudtrail"),
+						Suffix:    ".json.gz",
+					},
+				},
+				Polling: &PollingConfig{
+					Interval:          5 * time.Minute,
+					StartAfter:        "AWSLogs/123456789012/CloudTrail/us-east-1/2025/01/01/",
+					MaxTrackedObjects: 50000,
+				},
+				StorageID: &fileStorage,
+			},
+		},
+		{
+			id:           component.NewIDWithName(metadata.Type, "7"),
+			errorMessage: "polling configuration cannot be used together with starttime/endtime or sqs configuration; polling.interval must not be negative; polling.max_tracked_objects must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
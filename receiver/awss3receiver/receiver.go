@@ -11,9 +11,12 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -34,9 +37,13 @@ type receiverProcessor interface {
 }
 
 type awss3Receiver struct {
+	id              component.ID
 	reader          s3Reader
 	logger          *zap.Logger
 	cancel          context.CancelFunc
+	wg              sync.WaitGroup
+	storageID       *component.ID
+	storageClient   storage.Client
 	obsrecv         *receiverhelper.ObsReport
 	encodingsConfig []Encoding
 	telemetryType   string
@@ -62,8 +69,13 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 		if err != nil {
 			return nil, err
 		}
+	case cfg.Polling != nil:
+		reader, err = newS3PollingReader(ctx, settings.Logger, cfg)
+		if err != nil {
+			return nil, err
+		}
 	default:
-		return nil, errors.New("invalid configuration: either time-based (StartTime/EndTime) or SQS-based configuration must be provided")
+		return nil, errors.New("invalid configuration: either time-based (StartTime/EndTime), SQS-based or polling configuration must be provided")
 	}
 
 	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{
@@ -76,6 +88,7 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 	}
 
 	return &awss3Receiver{
+		id:              settings.ID,
 		reader:          reader,
 		telemetryType:   telemetryType,
 		logger:          settings.Logger,
@@ -84,6 +97,7 @@ func newAWSS3Receiver(ctx context.Context, cfg *Config, telemetryType string, se
 		dataProcessor:   processor,
 		encodingsConfig: cfg.Encodings,
 		notifier:        notifier,
+		storageID:       cfg.StorageID,
 	}, nil
 }
 
@@ -99,10 +113,20 @@ func (r *awss3Receiver) Start(ctx context.Context, host component.Host) error {
 	if err != nil {
 		return err
 	}
+	if reader, ok := r.reader.(s3CheckpointingReader); ok && r.storageID != nil {
+		// The receivers of the different signals with the same ID ingest the objects independently
+		r.storageClient, err = getStorageClient(ctx, host, *r.storageID, r.id, r.telemetryType)
+		if err != nil {
+			return err
+		}
+		reader.setStorageClient(r.storageClient)
+	}
 
 	var cancelCtx context.Context
 	cancelCtx, r.cancel = context.WithCancel(context.Background())
+	r.wg.Add(1)
 	go func() {
+		defer r.wg.Done()
 		_ = r.reader.readAll(cancelCtx, r.telemetryType, r.receiveBytes)
 	}()
 	return nil
@@ -117,6 +141,10 @@ func (r *awss3Receiver) Shutdown(ctx context.Context) error {
 	if r.cancel != nil {
 		r.cancel()
 	}
+	r.wg.Wait()
+	if r.storageClient != nil {
+		return r.storageClient.Close(ctx)
+	}
 	return nil
 }
 
@@ -124,15 +152,18 @@ func (r *awss3Receiver) receiveBytes(ctx context.Context, key string, data []byt
 	if data == nil {
 		return nil
 	}
-	if strings.HasSuffix(key, ".gz") {
+	// The objects are decompressed, unless an encoding extension is configured for the compressed objects, such as
+	// the AWS logs, which decompresses them itself.
+	_, suffix := r.extensions.findExtension(key)
+	if strings.HasSuffix(key, ".gz") && !strings.HasSuffix(suffix, ".gz") {
 		reader, err := gzip.NewReader(bytes.NewReader(data))
 		if err != nil {
-			return err
+			return consumererror.NewPermanent(err)
 		}
 		key = strings.TrimSuffix(key, ".gz")
 		data, err = io.ReadAll(reader)
 		if err != nil {
-			return err
+			return consumererror.NewPermanent(err)
 		}
 	}
 	return r.dataProcessor.processReceivedData(ctx, r, key, data)
@@ -172,7 +203,7 @@ func (r *traceReceiver) processReceivedData(ctx context.Context, rcvr *awss3Rece
 	rcvr.logger.Debug("Processing trace file", zap.String("key", key), zap.String("format", format))
 	traces, err := unmarshaler.UnmarshalTraces(data)
 	if err != nil {
-		return err
+		return consumererror.NewPermanent(err)
 	}
 	obsCtx := rcvr.obsrecv.StartTracesOp(ctx)
 	err = r.consumer.ConsumeTraces(ctx, traces)
@@ -214,7 +245,7 @@ func (r *metricsReceiver) processReceivedData(ctx context.Context, rcvr *awss3Re
 	rcvr.logger.Debug("Processing metric file", zap.String("key", key), zap.String("format", format))
 	metrics, err := unmarshaler.UnmarshalMetrics(data)
 	if err != nil {
-		return err
+		return consumererror.NewPermanent(err)
 	}
 	obsCtx := rcvr.obsrecv.StartMetricsOp(ctx)
 	err = r.consumer.ConsumeMetrics(ctx, metrics)
@@ -256,7 +287,7 @@ func (r *logsReceiver) processReceivedData(ctx context.Context, rcvr *awss3Recei
 	rcvr.logger.Debug("Processing log file", zap.String("key", key), zap.String("format", format))
 	logs, err := unmarshaler.UnmarshalLogs(data)
 	if err != nil {
-		return err
+		return consumererror.NewPermanent(err)
 	}
 	obsCtx := rcvr.obsrecv.StartLogsOp(ctx)
 	err = r.consumer.ConsumeLogs(ctx, logs)
@@ -285,3 +316,15 @@ func (encodings encodingExtensions) findExtension(key string) (component.Compone
 	}
 	return nil, ""
 }
+
+func getStorageClient(ctx context.Context, host component.Host, storageID, componentID component.ID, name string) (storage.Client, error) {
+	extension, ok := host.GetExtensions()[storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension %q not found", storageID)
+	}
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("extension %q is not a storage extension", storageID)
+	}
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, name)
+}
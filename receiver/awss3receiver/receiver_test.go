@@ -14,6 +14,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
@@ -511,6 +513,74 @@ func Test_receiveBytes_logs(t *testing.T) {
 	}
 }
 
+// bodyExtension unmarshals the data into a log record with the data as body.
+type bodyExtension struct {
+	nonEncodingExtension
+}
+
+func (bodyExtension) UnmarshalLogs(data []byte) (plog.Logs, error) {
+	logs := plog.NewLogs()
+	logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(string(data))
+	return logs, nil
+}
+
+func Test_receiveBytes_compressedEncodingExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		suffix   string
+		expected []byte
+	}{
+		{
+			name:     "extension for the compressed objects",
+			suffix:   ".log.gz",
+			expected: gzipCompress([]byte("test")),
+		},
+		{
+			name:     "extension for the decompressed objects",
+			suffix:   ".log",
+			expected: []byte("test"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logsConsumer := new(consumertest.LogsSink)
+			obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{ReceiverCreateSettings: receivertest.NewNopSettings(metadata.Type)})
+			require.NoError(t, err)
+			r := &awss3Receiver{
+				logger:  zap.NewNop(),
+				obsrecv: obsrecv,
+				extensions: encodingExtensions{
+					{
+						extension: &bodyExtension{},
+						suffix:    tt.suffix,
+					},
+				},
+				dataProcessor: &logsReceiver{
+					consumer: logsConsumer,
+				},
+			}
+			require.NoError(t, r.receiveBytes(t.Context(), "test.log.gz", gzipCompress([]byte("test"))))
+			require.Len(t, logsConsumer.AllLogs(), 1)
+			body := logsConsumer.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str()
+			assert.Equal(t, string(tt.expected), body)
+		})
+	}
+}
+
+func Test_receiveBytes_permanentErrors(t *testing.T) {
+	obsrecv, err := receiverhelper.NewObsReport(receiverhelper.ObsReportSettings{ReceiverCreateSettings: receivertest.NewNopSettings(metadata.Type)})
+	require.NoError(t, err)
+	r := &awss3Receiver{
+		logger:        zap.NewNop(),
+		obsrecv:       obsrecv,
+		dataProcessor: &logsReceiver{consumer: consumertest.NewNop()},
+	}
+	err = r.receiveBytes(t.Context(), "test.json.gz", []byte("invalid gzip"))
+	assert.True(t, consumererror.IsPermanent(err))
+	err = r.receiveBytes(t.Context(), "test.json", []byte("invalid json"))
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
 func Test_newEncodingExtensions(t *testing.T) {
 	expectedExtension := &unmarshalExtension{}
 	host := hostWithExtensions{
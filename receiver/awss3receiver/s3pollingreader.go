@@ -0,0 +1,242 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollingInterval   = time.Minute
+	defaultMaxTrackedObjects = 10000
+
+	// startAfterStorageKey is the storage key of the key after which the objects are listed.
+	startAfterStorageKey = "start_after"
+	// objectStorageKeyPrefix prefixes the storage keys of the ETags of the tracked objects.
+	objectStorageKeyPrefix = "object/"
+)
+
+// s3PollingReader lists the objects of the bucket periodically, and ingests the ones which weren't ingested yet or
+// which changed since. The objects are listed after a high-water mark, the largest key of the objects which are not
+// tracked anymore, and the most recent objects after it are tracked by their ETag, kept in memory and in the
+// storage. The high-water mark is kept in the storage as well.
+type s3PollingReader struct {
+	logger *zap.Logger
+
+	listObjectsClient ListObjectsAPI
+	getObjectClient   GetObjectAPI
+	s3Bucket          string
+	s3Prefix          string
+	interval          time.Duration
+	maxTracked        int
+	storageClient     storage.Client
+	// startAfter is the key after which the objects are listed, loaded from the storage by the first poll.
+	startAfter       string
+	startAfterLoaded bool
+	// ingested holds the ETag of the tracked objects, by key. The ETag is empty for the objects which failed to be
+	// ingested and are retried.
+	ingested map[string]string
+}
+
+func newS3PollingReader(ctx context.Context, logger *zap.Logger, cfg *Config) (*s3PollingReader, error) {
+	if cfg.Polling == nil {
+		return nil, errors.New("polling configuration is required")
+	}
+	listObjectsClient, getObjectClient, err := newS3Client(ctx, cfg.S3Downloader)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := cfg.Polling.Interval
+	if interval == 0 {
+		interval = defaultPollingInterval
+	}
+	maxTracked := cfg.Polling.MaxTrackedObjects
+	if maxTracked == 0 {
+		maxTracked = defaultMaxTrackedObjects
+	}
+
+	return &s3PollingReader{
+		logger:            logger,
+		listObjectsClient: listObjectsClient,
+		getObjectClient:   getObjectClient,
+		s3Bucket:          cfg.S3Downloader.S3Bucket,
+		s3Prefix:          cfg.S3Downloader.S3Prefix,
+		interval:          interval,
+		maxTracked:        maxTracked,
+		storageClient:     storage.NewNopClient(),
+		startAfter:        cfg.Polling.StartAfter,
+		ingested:          make(map[string]string),
+	}, nil
+}
+
+// setStorageClient implements the s3CheckpointingReader interface
+func (r *s3PollingReader) setStorageClient(client storage.Client) {
+	r.storageClient = client
+}
+
+// readAll implements the s3Reader interface
+func (r *s3PollingReader) readAll(ctx context.Context, _ string, callback s3ObjectCallback) error {
+	r.logger.Info("Start polling objects",
+		zap.String("s3Bucket", r.s3Bucket),
+		zap.String("s3Prefix", r.s3Prefix),
+		zap.Duration("interval", r.interval))
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		if err := r.poll(ctx, callback); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			r.logger.Warn("Error polling objects", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			r.logger.Info("Context canceled, stopping polling objects")
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll lists the objects of the bucket after the high-water mark and ingests the ones which weren't ingested yet.
+func (r *s3PollingReader) poll(ctx context.Context, callback s3ObjectCallback) error {
+	if !r.startAfterLoaded {
+		checkpoint, err := r.storageClient.Get(ctx, startAfterStorageKey)
+		if err != nil {
+			return err
+		}
+		if checkpoint != nil {
+			r.startAfter = string(checkpoint)
+		}
+		r.startAfterLoaded = true
+	}
+
+	params := &s3.ListObjectsV2Input{
+		Bucket: &r.s3Bucket,
+	}
+	if r.s3Prefix != "" {
+		params.Prefix = &r.s3Prefix
+	}
+	if r.startAfter != "" {
+		params.StartAfter = &r.startAfter
+	}
+	r.logger.Debug("Listing objects", zap.String("prefix", r.s3Prefix), zap.String("startAfter", r.startAfter))
+
+	listed := make(map[string]struct{}, len(r.ingested))
+	p := r.listObjectsClient.NewListObjectsV2Paginator(params)
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return err
+		}
+		for _, obj := range page.Contents {
+			key := aws.ToString(obj.Key)
+			// Skip the placeholders of the folders
+			if strings.HasSuffix(key, "/") {
+				continue
+			}
+			listed[key] = struct{}{}
+			if err := r.ingest(ctx, key, aws.ToString(obj.ETag), callback); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The checkpoints of the deleted objects are not needed anymore
+	for key := range r.ingested {
+		if _, ok := listed[key]; ok {
+			continue
+		}
+		if err := r.untrack(ctx, key); err != nil {
+			return err
+		}
+	}
+	return r.advance(ctx)
+}
+
+// advance moves the high-water mark forward, so that only the most recent objects remain tracked.
+func (r *s3PollingReader) advance(ctx context.Context) error {
+	if len(r.ingested) <= r.maxTracked {
+		return nil
+	}
+	keys := slices.Sorted(maps.Keys(r.ingested))
+	for _, key := range keys[:len(keys)-r.maxTracked] {
+		if r.ingested[key] == "" {
+			r.logger.Warn("S3 object failed to be ingested before the high-water mark moved past it, dropping it", zap.String("key", key))
+		}
+		if err := r.untrack(ctx, key); err != nil {
+			return err
+		}
+		r.startAfter = key
+	}
+	return r.storageClient.Set(ctx, startAfterStorageKey, []byte(r.startAfter))
+}
+
+// untrack removes an object from the tracked objects.
+func (r *s3PollingReader) untrack(ctx context.Context, key string) error {
+	if err := r.storageClient.Delete(ctx, objectStorageKeyPrefix+key); err != nil {
+		return err
+	}
+	delete(r.ingested, key)
+	return nil
+}
+
+// ingest ingests an object, unless it was already ingested with the same ETag. An object which failed to be
+// retrieved or consumed is ingested again by the next poll, unless the failure is permanent.
+func (r *s3PollingReader) ingest(ctx context.Context, key, etag string, callback s3ObjectCallback) error {
+	ingestedETag, ok := r.ingested[key]
+	if !ok {
+		checkpoint, err := r.storageClient.Get(ctx, objectStorageKeyPrefix+key)
+		if err != nil {
+			return err
+		}
+		ingestedETag = string(checkpoint)
+	}
+	if ingestedETag != "" && ingestedETag == etag {
+		r.ingested[key] = etag
+		return nil
+	}
+
+	r.logger.Debug("Processing S3 object", zap.String("key", key))
+	content, err := retrieveS3Object(ctx, r.getObjectClient, r.s3Bucket, key)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		r.logger.Error("Failed to get S3 object", zap.String("key", key), zap.Error(err))
+		r.ingested[key] = ""
+		return nil
+	}
+	if err = callback(ctx, key, content); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !consumererror.IsPermanent(err) {
+			r.logger.Error("Failed to process S3 object content, it will be retried", zap.String("key", key), zap.Error(err))
+			r.ingested[key] = ""
+			return nil
+		}
+		r.logger.Error("Failed to process S3 object content, dropping it", zap.String("key", key), zap.Error(err))
+	}
+
+	if err = r.storageClient.Set(ctx, objectStorageKeyPrefix+key, []byte(etag)); err != nil {
+		return err
+	}
+	r.ingested[key] = etag
+	return nil
+}
@@ -0,0 +1,234 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package awss3receiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/awss3receiver"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+// mockBucket holds the objects of a bucket, as ETag by key.
+type mockBucket struct {
+	objects     map[string]string
+	gets        []string
+	startAfters []string
+}
+
+func (b *mockBucket) newPollingReader(t *testing.T, client storage.Client) *s3PollingReader {
+	return &s3PollingReader{
+		logger: zap.NewNop(),
+		listObjectsClient: mockListObjectsAPI(func(params *s3.ListObjectsV2Input) ListObjectsV2Pager {
+			t.Helper()
+			require.Equal(t, "bucket", *params.Bucket)
+			require.Equal(t, "AWSLogs/", *params.Prefix)
+			startAfter := aws.ToString(params.StartAfter)
+			b.startAfters = append(b.startAfters, startAfter)
+			page := &s3.ListObjectsV2Output{}
+			for _, key := range slices.Sorted(maps.Keys(b.objects)) {
+				if key > startAfter {
+					page.Contents = append(page.Contents, types.Object{Key: aws.String(key), ETag: aws.String(b.objects[key])})
+				}
+			}
+			return &mockListObjectsV2Pager{Pages: []*s3.ListObjectsV2Output{page}}
+		}),
+		getObjectClient: mockGetObjectAPI(func(_ context.Context, params *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+			b.gets = append(b.gets, *params.Key)
+			return &s3.GetObjectOutput{
+				Body: io.NopCloser(bytes.NewReader([]byte(b.objects[*params.Key]))),
+			}, nil
+		}),
+		s3Bucket:      "bucket",
+		s3Prefix:      "AWSLogs/",
+		interval:      time.Minute,
+		maxTracked:    defaultMaxTrackedObjects,
+		storageClient: client,
+		ingested:      make(map[string]string),
+	}
+}
+
+func TestS3PollingReader_poll(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("awss3"), "logs")
+	bucket := &mockBucket{objects: map[string]string{
+		"AWSLogs/a.log.gz": "etag-a",
+		"AWSLogs/b.log.gz": "etag-b",
+		"AWSLogs/folder/":  "etag-folder",
+	}}
+	reader := bucket.newPollingReader(t, client)
+
+	var ingested []string
+	callback := func(_ context.Context, key string, content []byte) error {
+		assert.Equal(t, bucket.objects[key], string(content))
+		ingested = append(ingested, key)
+		return nil
+	}
+
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.ElementsMatch(t, []string{"AWSLogs/a.log.gz", "AWSLogs/b.log.gz"}, ingested)
+
+	// The objects are only ingested once
+	ingested = nil
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Empty(t, ingested)
+
+	// Objects are ingested again when they change, and the checkpoints of the deleted objects are deleted
+	bucket.objects["AWSLogs/a.log.gz"] = "etag-a2"
+	delete(bucket.objects, "AWSLogs/b.log.gz")
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Equal(t, []string{"AWSLogs/a.log.gz"}, ingested)
+	checkpoint, err := client.Get(t.Context(), objectStorageKeyPrefix+"AWSLogs/b.log.gz")
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	// The checkpoints are kept across restarts
+	ingested = nil
+	bucket.objects["AWSLogs/c.log.gz"] = "etag-c"
+	reader = bucket.newPollingReader(t, client)
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Equal(t, []string{"AWSLogs/c.log.gz"}, ingested)
+}
+
+func TestS3PollingReader_pollHighWaterMark(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("awss3"), "logs")
+	bucket := &mockBucket{objects: map[string]string{
+		"AWSLogs/2025/01/01.log": "etag-01",
+		"AWSLogs/2025/01/02.log": "etag-02",
+		"AWSLogs/2025/01/03.log": "etag-03",
+		"AWSLogs/2025/01/04.log": "etag-04",
+	}}
+	reader := bucket.newPollingReader(t, client)
+	reader.startAfter = "AWSLogs/2025/01/01.log"
+	reader.maxTracked = 2
+
+	var ingested []string
+	callback := func(_ context.Context, key string, _ []byte) error {
+		ingested = append(ingested, key)
+		return nil
+	}
+
+	// The objects up to start_after are skipped, and only the most recent objects are tracked
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Equal(t, []string{"AWSLogs/2025/01/02.log", "AWSLogs/2025/01/03.log", "AWSLogs/2025/01/04.log"}, ingested)
+	assert.Equal(t, map[string]string{"AWSLogs/2025/01/03.log": "etag-03", "AWSLogs/2025/01/04.log": "etag-04"}, reader.ingested)
+	checkpoint, err := client.Get(t.Context(), objectStorageKeyPrefix+"AWSLogs/2025/01/02.log")
+	require.NoError(t, err)
+	assert.Nil(t, checkpoint)
+
+	// The objects are listed after the high-water mark, the objects tracked behind the most recent one are ingested
+	ingested = nil
+	bucket.objects["AWSLogs/2025/01/03.log"] = "etag-03b"
+	bucket.objects["AWSLogs/2025/01/05.log"] = "etag-05"
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Equal(t, "AWSLogs/2025/01/02.log", bucket.startAfters[len(bucket.startAfters)-1])
+	assert.Equal(t, []string{"AWSLogs/2025/01/03.log", "AWSLogs/2025/01/05.log"}, ingested)
+
+	// The high-water mark is kept across restarts, and takes precedence over start_after
+	ingested = nil
+	reader = bucket.newPollingReader(t, client)
+	reader.startAfter = "AWSLogs/2025/01/01.log"
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Equal(t, "AWSLogs/2025/01/03.log", bucket.startAfters[len(bucket.startAfters)-1])
+	assert.Empty(t, ingested)
+}
+
+func TestS3PollingReader_pollCallbackErrors(t *testing.T) {
+	client := storagetest.NewInMemoryClient(component.KindReceiver, component.MustNewID("awss3"), "logs")
+	bucket := &mockBucket{objects: map[string]string{
+		"AWSLogs/retryable.log": "etag-retryable",
+		"AWSLogs/permanent.log": "etag-permanent",
+	}}
+	reader := bucket.newPollingReader(t, client)
+
+	callback := func(_ context.Context, key string, _ []byte) error {
+		if key == "AWSLogs/permanent.log" {
+			return consumererror.NewPermanent(errors.New("invalid content"))
+		}
+		return errors.New("consumer unavailable")
+	}
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.ElementsMatch(t, []string{"AWSLogs/retryable.log", "AWSLogs/permanent.log"}, bucket.gets)
+
+	// Only the objects which failed with a non-permanent error are retried
+	bucket.gets = nil
+	require.NoError(t, reader.poll(t.Context(), callback))
+	assert.Equal(t, []string{"AWSLogs/retryable.log"}, bucket.gets)
+}
+
+func TestS3PollingReader_readAll(t *testing.T) {
+	bucket := &mockBucket{objects: map[string]string{"AWSLogs/a.log": "etag-a"}}
+	reader := bucket.newPollingReader(t, storage.NewNopClient())
+	reader.interval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(t.Context())
+	done := make(chan error)
+	go func() {
+		done <- reader.readAll(ctx, "logs", func(context.Context, string, []byte) error {
+			return nil
+		})
+	}()
+
+	// Without storage, the objects are only ingested once by the reader
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	assert.ErrorIs(t, <-done, context.Canceled)
+	assert.Equal(t, []string{"AWSLogs/a.log"}, bucket.gets)
+}
+
+// mockCheckpointingReader is a s3CheckpointingReader which only keeps its storage client.
+type mockCheckpointingReader struct {
+	client storage.Client
+}
+
+func (*mockCheckpointingReader) readAll(ctx context.Context, _ string, _ s3ObjectCallback) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (r *mockCheckpointingReader) setStorageClient(client storage.Client) {
+	r.client = client
+}
+
+func TestAWSS3Receiver_storage(t *testing.T) {
+	newReceiver := func(storageID component.ID) (*awss3Receiver, *mockCheckpointingReader) {
+		reader := &mockCheckpointingReader{}
+		return &awss3Receiver{
+			id:            component.MustNewID("awss3"),
+			logger:        zap.NewNop(),
+			telemetryType: "logs",
+			reader:        reader,
+			storageID:     &storageID,
+		}, reader
+	}
+
+	r, reader := newReceiver(component.MustNewIDWithName("test_storage", "test"))
+	require.NoError(t, r.Start(t.Context(), storagetest.NewStorageHost().WithInMemoryStorageExtension("test")))
+	require.NotNil(t, reader.client)
+	require.NoError(t, reader.client.Set(t.Context(), "key", []byte("etag")))
+	require.NoError(t, r.Shutdown(t.Context()))
+
+	r, _ = newReceiver(component.MustNewIDWithName("test_storage", "missing"))
+	err := r.Start(t.Context(), storagetest.NewStorageHost())
+	assert.EqualError(t, err, `storage extension "test_storage/missing" not found`)
+
+	r, _ = newReceiver(component.MustNewIDWithName("non_storage", "test"))
+	err = r.Start(t.Context(), storagetest.NewStorageHost().WithNonStorageExtension("test"))
+	assert.EqualError(t, err, `extension "non_storage/test" is not a storage extension`)
+}
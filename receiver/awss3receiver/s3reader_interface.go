@@ -5,6 +5,8 @@ package awss3receiver // import "github.com/open-telemetry/opentelemetry-collect
 
 import (
 	"context"
+
+	"go.opentelemetry.io/collector/extension/xextension/storage"
 )
 
 // s3ObjectCallback is a function that processes a single S3 object content
@@ -15,3 +17,10 @@ type s3Reader interface {
 	// readAll processes all S3 objects matching specified criteria and passes content to callback
 	readAll(ctx context.Context, telemetryType string, callback s3ObjectCallback) error
 }
+
+// s3CheckpointingReader is a s3Reader keeping track of the ingested objects in a storage
+type s3CheckpointingReader interface {
+	s3Reader
+	// setStorageClient sets the storage client used to keep track of the ingested objects, before reading
+	setStorageClient(client storage.Client)
+}
@@ -89,6 +89,7 @@ func newTestReceiver(t *testing.T, cfg *Config) *kubernetesReceiver {
 	require.NotNil(t, r)
 	rcvr, ok := r.(*kubernetesReceiver)
 	require.True(t, ok)
+	rcvr.resourceWatcher.shareInformers = false
 	rcvr.resourceWatcher.makeClient = func(_ k8sconfig.APIConfig) (kubernetes.Interface, error) {
 		return fake.NewSimpleClientset(), nil
 	}
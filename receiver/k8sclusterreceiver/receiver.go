@@ -62,24 +62,19 @@ func (kr *kubernetesReceiver) startReceiver(ctx context.Context, host component.
 
 	go func() {
 		kr.settings.Logger.Info("Starting shared informers and wait for initial cache sync.")
-		for _, informer := range kr.resourceWatcher.informerFactories {
-			if informer == nil {
-				continue
-			}
-			timedContextForInitialSync := kr.resourceWatcher.startWatchingResources(ctx, informer)
-
-			// Wait till either the initial cache sync times out or until the cancel method
-			// corresponding to this context is called.
-			<-timedContextForInitialSync.Done()
-
-			// If the context times out, set initialSyncTimedOut and report a fatal error. Currently
-			// this timeout is 10 minutes, which appears to be long enough.
-			if errors.Is(timedContextForInitialSync.Err(), context.DeadlineExceeded) {
-				kr.resourceWatcher.initialSyncTimedOut.Store(true)
-				kr.settings.Logger.Error("Timed out waiting for initial cache sync.")
-				componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errors.New("failed to start receiver")))
-				return
-			}
+		timedContextForInitialSync := kr.resourceWatcher.startWatchingResources(ctx)
+
+		// Wait till either the initial cache sync times out or until the cancel method
+		// corresponding to this context is called.
+		<-timedContextForInitialSync.Done()
+
+		// If the context times out, set initialSyncTimedOut and report a fatal error. Currently
+		// this timeout is 10 minutes, which appears to be long enough.
+		if errors.Is(timedContextForInitialSync.Err(), context.DeadlineExceeded) {
+			kr.resourceWatcher.initialSyncTimedOut.Store(true)
+			kr.settings.Logger.Error("Timed out waiting for initial cache sync.")
+			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(errors.New("failed to start receiver")))
+			return
 		}
 
 		kr.settings.Logger.Info("Completed syncing shared informer caches.")
@@ -145,6 +140,7 @@ func (kr *kubernetesReceiver) stopReceiver() {
 	if kr.cancel != nil {
 		kr.cancel()
 	}
+	kr.resourceWatcher.releaseInformers()
 }
 
 func (kr *kubernetesReceiver) Shutdown(context.Context) error {
@@ -6,7 +6,6 @@ package k8sclusterreceiver // import "github.com/open-telemetry/opentelemetry-co
 import (
 	"context"
 	"fmt"
-	"reflect"
 	"sync/atomic"
 	"time"
 
@@ -45,15 +44,50 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/k8sclusterreceiver/internal/utils"
 )
 
-type sharedInformer interface {
-	Start(<-chan struct{})
-	WaitForCacheSync(<-chan struct{}) map[reflect.Type]bool
+// informerList runs the informers of the watched kinds, which are shared with other components when possible.
+type informerList struct {
+	informers []cache.SharedInformer
+	shared    []*k8sconfig.SharedInformer
+}
+
+func (l *informerList) add(informer cache.SharedInformer) {
+	l.informers = append(l.informers, informer)
+}
+
+// Start runs the informers until stopCh is closed.
+func (l *informerList) Start(stopCh <-chan struct{}) {
+	for _, informer := range l.informers {
+		go informer.Run(stopCh)
+	}
+}
+
+// WaitForCacheSync waits until the caches of all the informers are synced, or stopCh is closed. The event
+// handlers are not waited for, as they wait for the initial sync themselves.
+func (l *informerList) WaitForCacheSync(stopCh <-chan struct{}) bool {
+	synced := make([]cache.InformerSynced, 0, len(l.informers))
+	for _, informer := range l.informers {
+		synced = append(synced, informer.HasSynced)
+	}
+	return cache.WaitForCacheSync(stopCh, synced...)
+}
+
+// transformedKinds are the kinds of which the objects are transformed by transformObject before being cached.
+var transformedKinds = map[schema.GroupVersionKind]bool{
+	gvk.Pod:         true,
+	gvk.Node:        true,
+	gvk.ReplicaSet:  true,
+	gvk.Job:         true,
+	gvk.Deployment:  true,
+	gvk.DaemonSet:   true,
+	gvk.StatefulSet: true,
+	gvk.Service:     true,
 }
 
 type resourceWatcher struct {
 	client              kubernetes.Interface
 	osQuotaClient       quotaclientset.Interface
-	informerFactories   []sharedInformer
+	informers           *informerList
+	shareInformers      bool
 	metadataStore       *metadata.Store
 	logger              *zap.Logger
 	metadataConsumers   []metadataConsumer
@@ -79,6 +113,7 @@ func newResourceWatcher(set receiver.Settings, cfg *Config, metadataStore *metad
 		initialSyncTimedOut:      &atomic.Bool{},
 		initialTimeout:           defaultInitialSyncTimeout,
 		config:                   cfg,
+		shareInformers:           true,
 		makeClient:               k8sconfig.MakeClient,
 		makeOpenShiftQuotaClient: k8sconfig.MakeOpenShiftQuotaClient,
 	}
@@ -108,6 +143,7 @@ func (rw *resourceWatcher) initialize() error {
 
 func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 	factory := rw.getInformerFactory()
+	rw.informers = &informerList{}
 
 	// Map of supported group version kinds by name of a kind.
 	// If none of the group versions are supported by k8s server for a specific kind,
@@ -149,10 +185,12 @@ func (rw *resourceWatcher) prepareSharedInformerFactory() error {
 
 	if rw.osQuotaClient != nil {
 		quotaFactory := quotainformersv1.NewSharedInformerFactory(rw.osQuotaClient, 0)
-		rw.setupInformer(gvk.ClusterResourceQuota, quotaFactory.Quota().V1().ClusterResourceQuotas().Informer())
-		rw.informerFactories = append(rw.informerFactories, quotaFactory)
+		informer := quotaFactory.Quota().V1().ClusterResourceQuotas().Informer()
+		if err := informer.SetTransform(transformObject); err != nil {
+			rw.logger.Error("error setting informer transform function", zap.Error(err))
+		}
+		rw.setupInformer(gvk.ClusterResourceQuota, informer)
 	}
-	rw.informerFactories = append(rw.informerFactories, factory)
 
 	return nil
 }
@@ -196,48 +234,98 @@ func (rw *resourceWatcher) isKindSupported(gvk schema.GroupVersionKind) (bool, e
 func (rw *resourceWatcher) setupInformerForKind(kind schema.GroupVersionKind, factory informers.SharedInformerFactory) {
 	switch kind {
 	case gvk.Pod:
-		rw.setupInformer(kind, factory.Core().V1().Pods().Informer())
+		rw.setupSharedInformer(kind, "pods", factory.Core().V1().Pods().Informer)
 	case gvk.Node:
 		if rw.config.Namespace == "" {
-			rw.setupInformer(kind, factory.Core().V1().Nodes().Informer())
+			rw.setupSharedInformer(kind, "nodes", factory.Core().V1().Nodes().Informer)
 		}
 	case gvk.Namespace:
 		if rw.config.Namespace == "" {
-			rw.setupInformer(kind, factory.Core().V1().Namespaces().Informer())
+			rw.setupSharedInformer(kind, "namespaces", factory.Core().V1().Namespaces().Informer)
 		}
 	case gvk.ReplicationController:
-		rw.setupInformer(kind, factory.Core().V1().ReplicationControllers().Informer())
+		rw.setupSharedInformer(kind, "replicationcontrollers", factory.Core().V1().ReplicationControllers().Informer)
 	case gvk.ResourceQuota:
-		rw.setupInformer(kind, factory.Core().V1().ResourceQuotas().Informer())
+		rw.setupSharedInformer(kind, "resourcequotas", factory.Core().V1().ResourceQuotas().Informer)
 	case gvk.Service:
-		rw.setupInformer(kind, factory.Core().V1().Services().Informer())
+		rw.setupSharedInformer(kind, "services", factory.Core().V1().Services().Informer)
 	case gvk.DaemonSet:
-		rw.setupInformer(kind, factory.Apps().V1().DaemonSets().Informer())
+		rw.setupSharedInformer(kind, "daemonsets", factory.Apps().V1().DaemonSets().Informer)
 	case gvk.Deployment:
-		rw.setupInformer(kind, factory.Apps().V1().Deployments().Informer())
+		rw.setupSharedInformer(kind, "deployments", factory.Apps().V1().Deployments().Informer)
 	case gvk.ReplicaSet:
-		rw.setupInformer(kind, factory.Apps().V1().ReplicaSets().Informer())
+		rw.setupSharedInformer(kind, "replicasets", factory.Apps().V1().ReplicaSets().Informer)
 	case gvk.StatefulSet:
-		rw.setupInformer(kind, factory.Apps().V1().StatefulSets().Informer())
+		rw.setupSharedInformer(kind, "statefulsets", factory.Apps().V1().StatefulSets().Informer)
 	case gvk.Job:
-		rw.setupInformer(kind, factory.Batch().V1().Jobs().Informer())
+		rw.setupSharedInformer(kind, "jobs", factory.Batch().V1().Jobs().Informer)
 	case gvk.CronJob:
-		rw.setupInformer(kind, factory.Batch().V1().CronJobs().Informer())
+		rw.setupSharedInformer(kind, "cronjobs", factory.Batch().V1().CronJobs().Informer)
 	case gvk.HorizontalPodAutoscaler:
-		rw.setupInformer(kind, factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer())
+		rw.setupSharedInformer(kind, "horizontalpodautoscalers", factory.Autoscaling().V2().HorizontalPodAutoscalers().Informer)
 	default:
 		rw.logger.Error("Could not setup an informer for provided group version kind",
 			zap.String("group version kind", kind.String()))
 	}
 }
 
+// setupSharedInformer sets up the informer of the kind, which is shared through the k8sconfig package with the
+// other components watching the same resource with the same settings, e.g. the k8sattributes processor watching
+// the namespaces. The factory is only used to create the informer when no other component shares it yet, and is
+// never started: the informers are run by the informer list.
+func (rw *resourceWatcher) setupSharedInformer(kind schema.GroupVersionKind, resource string, newInformer func() cache.SharedIndexInformer) {
+	create := func() (cache.SharedInformer, error) {
+		informer := newInformer()
+		if err := informer.SetTransform(transformObject); err != nil {
+			return nil, err
+		}
+		return informer, nil
+	}
+	if !rw.shareInformers {
+		informer, err := create()
+		if err != nil {
+			rw.logger.Error("error setting informer transform function", zap.Error(err))
+			return
+		}
+		rw.setupInformer(kind, informer)
+		return
+	}
+
+	key := k8sconfig.InformerKey{
+		APIConfig: rw.config.APIConfig,
+		Resource:  kind.GroupVersion().WithResource(resource),
+		Namespace: rw.config.Namespace,
+	}
+	if transformedKinds[kind] {
+		key.Transform = metadata.Type.String()
+	}
+	informer, err := k8sconfig.AcquireSharedInformer(key, create)
+	if err != nil {
+		rw.logger.Error("error setting informer transform function", zap.Error(err))
+		return
+	}
+	rw.informers.shared = append(rw.informers.shared, informer)
+	rw.setupInformer(kind, informer)
+}
+
+// releaseInformers releases the shared informers, which are stopped once no other component uses them.
+func (rw *resourceWatcher) releaseInformers() {
+	if rw.informers == nil {
+		return
+	}
+	for _, informer := range rw.informers.shared {
+		informer.Release()
+	}
+	rw.informers.shared = nil
+}
+
 // startWatchingResources starts up all informers.
-func (rw *resourceWatcher) startWatchingResources(ctx context.Context, inf sharedInformer) context.Context {
+func (rw *resourceWatcher) startWatchingResources(ctx context.Context) context.Context {
 	var cancel context.CancelFunc
 	timedContextForInitialSync, cancel := context.WithTimeout(ctx, rw.initialTimeout)
 
-	// Start off individual informers in the factory.
-	inf.Start(ctx.Done())
+	// Start off individual informers.
+	rw.informers.Start(ctx.Done())
 
 	// Ensure cache is synced with initial state, once informers are started up.
 	// Note that the event handler can start receiving events as soon as the informers
@@ -245,25 +333,22 @@ func (rw *resourceWatcher) startWatchingResources(ctx context.Context, inf share
 	// collecting data before the cache sync since all data may not be available.
 	// This method will block either till the timeout set on the context, until
 	// the initial sync is complete or the parent context is cancelled.
-	inf.WaitForCacheSync(timedContextForInitialSync.Done())
+	rw.informers.WaitForCacheSync(timedContextForInitialSync.Done())
 	defer cancel()
 	return timedContextForInitialSync
 }
 
 // setupInformer adds event handlers to informers and setups a metadataStore.
-func (rw *resourceWatcher) setupInformer(gvk schema.GroupVersionKind, informer cache.SharedIndexInformer) {
-	err := informer.SetTransform(transformObject)
-	if err != nil {
-		rw.logger.Error("error setting informer transform function", zap.Error(err))
-	}
-	_, err = informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+func (rw *resourceWatcher) setupInformer(gvk schema.GroupVersionKind, informer cache.SharedInformer) {
+	_, err := informer.AddEventHandlerWithResyncPeriod(cache.ResourceEventHandlerFuncs{
 		AddFunc:    rw.onAdd,
 		UpdateFunc: rw.onUpdate,
 		DeleteFunc: rw.onDelete,
-	})
+	}, rw.config.MetadataCollectionInterval)
 	if err != nil {
 		rw.logger.Error("error adding event handler to informer", zap.Error(err))
 	}
+	rw.informers.add(informer)
 	rw.metadataStore.Setup(gvk, informer.GetStore())
 }
 
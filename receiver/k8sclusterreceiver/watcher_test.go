@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/maps"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/experimentalmetricmetadata"
@@ -205,6 +206,43 @@ func TestPrepareSharedInformerFactory(t *testing.T) {
 	}
 }
 
+func TestPrepareSharedInformerFactorySharesInformers(t *testing.T) {
+	newWatcher := func(namespace string) *resourceWatcher {
+		return &resourceWatcher{
+			client:         newFakeClientWithAllResources(),
+			logger:         zap.NewNop(),
+			metadataStore:  metadata.NewStore(),
+			config:         &Config{Namespace: namespace},
+			shareInformers: true,
+		}
+	}
+	rw1 := newWatcher("")
+	rw2 := newWatcher("")
+	namespaced := newWatcher("ns1")
+	require.NoError(t, rw1.prepareSharedInformerFactory())
+	require.NoError(t, rw2.prepareSharedInformerFactory())
+	require.NoError(t, namespaced.prepareSharedInformerFactory())
+
+	// the watchers with the same settings share all their informers
+	require.NotEmpty(t, rw1.informers.shared)
+	require.Len(t, rw2.informers.shared, len(rw1.informers.shared))
+	informers := map[cache.SharedInformer]bool{}
+	for _, informer := range rw1.informers.shared {
+		informers[informer.SharedInformer] = true
+	}
+	for _, informer := range rw2.informers.shared {
+		assert.True(t, informers[informer.SharedInformer])
+	}
+	for _, informer := range namespaced.informers.shared {
+		assert.False(t, informers[informer.SharedInformer])
+	}
+
+	rw1.releaseInformers()
+	rw2.releaseInformers()
+	namespaced.releaseInformers()
+	assert.Empty(t, rw1.informers.shared)
+}
+
 func TestSetupInformerForKind(t *testing.T) {
 	obs, logs := observer.New(zap.WarnLevel)
 	obsLogger := zap.New(obs)
@@ -4,6 +4,9 @@
 package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
 )
@@ -11,11 +14,40 @@ import (
 // Config holds common fields and embedded protocol-specific configurations
 type Config struct {
 	confighttp.ServerConfig `mapstructure:",squash"`
+
+	// Reassembly configures the reassembly of classic histograms and summaries, which are sent as several series
+	// that can be split across remote-write requests.
+	Reassembly ReassemblyConfig `mapstructure:"reassembly"`
+}
+
+// ReassemblyConfig holds the settings of the reassembly of classic histograms and summaries.
+type ReassemblyConfig struct {
+	// Enabled reassembles the series of classic histograms and summaries into histogram and summary data points.
+	// When disabled, the series of classic histograms and summaries are dropped.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxWait is the maximum time to wait for the missing series of a classic histogram or summary, after which
+	// the data point is built from the series received.
+	MaxWait time.Duration `mapstructure:"max_wait"`
+	// MaxPending is the maximum number of classic histogram and summary data points waiting for their missing
+	// series. When it is reached, the data points waiting for the longest time are built from the series received.
+	MaxPending int `mapstructure:"max_pending"`
+
+	// prevent unkeyed literal initialization
+	_ struct{}
 }
 
 var _ component.Config = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
-func (*Config) Validate() error {
+func (cfg *Config) Validate() error {
+	if !cfg.Reassembly.Enabled {
+		return nil
+	}
+	if cfg.Reassembly.MaxWait <= 0 {
+		return errors.New("reassembly::max_wait must be positive")
+	}
+	if cfg.Reassembly.MaxPending <= 0 {
+		return errors.New("reassembly::max_pending must be positive")
+	}
 	return nil
 }
@@ -5,6 +5,7 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -15,3 +16,18 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.NotNil(t, cfg, "failed to create default config")
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Reassembly.MaxWait = 0
+	assert.EqualError(t, cfg.Validate(), "reassembly::max_wait must be positive")
+
+	cfg.Reassembly.MaxWait = time.Second
+	cfg.Reassembly.MaxPending = 0
+	assert.EqualError(t, cfg.Validate(), "reassembly::max_pending must be positive")
+
+	cfg.Reassembly.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
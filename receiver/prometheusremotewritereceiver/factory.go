@@ -5,6 +5,7 @@ package prometheusremotewritereceiver // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -27,6 +28,11 @@ func createDefaultConfig() component.Config {
 		ServerConfig: confighttp.ServerConfig{
 			Endpoint: "localhost:9090",
 		},
+		Reassembly: ReassemblyConfig{
+			Enabled:    true,
+			MaxWait:    10 * time.Second,
+			MaxPending: 10000,
+		},
 	}
 }
 
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"container/list"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// layoutsCacheSize is the number of classic histograms and summaries whose number of buckets or quantiles is kept.
+const layoutsCacheSize = 100000
+
+// classicPart is the part of a classic histogram or summary held by a series.
+type classicPart int
+
+const (
+	classicBucket classicPart = iota
+	classicQuantile
+	classicSum
+	classicCount
+)
+
+// classicSeries is a series of a classic histogram or summary.
+type classicSeries struct {
+	part classicPart
+	// bound is the upper bound of a bucket, or the quantile of a quantile.
+	bound float64
+	// labels are the labels of the histogram or summary: the metric name is the name of the metric family, and
+	// the le or quantile label is removed.
+	labels labels.Labels
+}
+
+// parseClassicSeries returns the part of a classic histogram or summary held by the series.
+func parseClassicSeries(ls labels.Labels, metricType writev2.Metadata_MetricType) (classicSeries, error) {
+	name := ls.Get(labels.MetricName)
+	b := labels.NewBuilder(ls)
+	var s classicSeries
+
+	switch {
+	case metricType == writev2.Metadata_METRIC_TYPE_HISTOGRAM && strings.HasSuffix(name, "_bucket"):
+		le := ls.Get(labels.BucketLabel)
+		bound, err := strconv.ParseFloat(le, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid %q label %q for histogram bucket %q", labels.BucketLabel, le, name)
+		}
+		s.part, s.bound = classicBucket, bound
+		b.Del(labels.BucketLabel)
+		b.Set(labels.MetricName, strings.TrimSuffix(name, "_bucket"))
+	case metricType == writev2.Metadata_METRIC_TYPE_SUMMARY && ls.Has(model.QuantileLabel):
+		quantile := ls.Get(model.QuantileLabel)
+		bound, err := strconv.ParseFloat(quantile, 64)
+		if err != nil {
+			return s, fmt.Errorf("invalid %q label %q for summary %q", model.QuantileLabel, quantile, name)
+		}
+		s.part, s.bound = classicQuantile, bound
+		b.Del(model.QuantileLabel)
+	case strings.HasSuffix(name, "_sum"):
+		s.part = classicSum
+		b.Set(labels.MetricName, strings.TrimSuffix(name, "_sum"))
+	case strings.HasSuffix(name, "_count"):
+		s.part = classicCount
+		b.Set(labels.MetricName, strings.TrimSuffix(name, "_count"))
+	default:
+		return s, fmt.Errorf("series %q is not part of a classic histogram or summary", name)
+	}
+
+	s.labels = b.Labels()
+	return s, nil
+}
+
+// classicGroupKey identifies a data point of a classic histogram or summary.
+type classicGroupKey struct {
+	series    uint64
+	timestamp int64
+}
+
+// classicGroup holds the series of a classic histogram or summary data point received so far.
+type classicGroup struct {
+	key              classicGroupKey
+	metricType       writev2.Metadata_MetricType
+	labels           labels.Labels
+	unit             string
+	description      string
+	createdTimestamp int64
+
+	// values holds the cumulative counts of the buckets by upper bound, or the values by quantile.
+	values   map[float64]float64
+	sum      float64
+	hasSum   bool
+	count    float64
+	hasCount bool
+
+	received time.Time
+}
+
+func (g *classicGroup) add(s classicSeries, v float64) {
+	switch s.part {
+	case classicBucket, classicQuantile:
+		g.values[s.bound] = v
+	case classicSum:
+		g.sum, g.hasSum = v, true
+	case classicCount:
+		g.count, g.hasCount = v, true
+	}
+}
+
+// reassembler groups the series of classic histograms and summaries into data points. As the series of a data
+// point can be split across requests, the data points are kept until they are complete or until max wait.
+type reassembler struct {
+	maxWait    time.Duration
+	maxPending int
+
+	mu      sync.Mutex
+	pending map[classicGroupKey]*list.Element
+	// order holds the pending data points by time of reception.
+	order *list.List
+	// layouts holds the number of buckets or quantiles of the last data point of each histogram or summary,
+	// so that the next data points are known to be complete once they have as many.
+	layouts *lru.Cache[uint64, int]
+}
+
+func newReassembler(cfg ReassemblyConfig) (*reassembler, error) {
+	layouts, err := lru.New[uint64, int](layoutsCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+	return &reassembler{
+		maxWait:    cfg.MaxWait,
+		maxPending: cfg.MaxPending,
+		pending:    make(map[classicGroupKey]*list.Element),
+		order:      list.New(),
+		layouts:    layouts,
+	}, nil
+}
+
+// add adds a sample of a series to its data point, and returns the key of the data point.
+func (r *reassembler) add(s classicSeries, metricType writev2.Metadata_MetricType, unit, description string, createdTimestamp int64, sample writev2.Sample, now time.Time) classicGroupKey {
+	key := classicGroupKey{series: s.labels.Hash(), timestamp: sample.Timestamp}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var g *classicGroup
+	if e, ok := r.pending[key]; ok {
+		g = e.Value.(*classicGroup)
+	} else {
+		g = &classicGroup{
+			key:        key,
+			metricType: metricType,
+			labels:     s.labels,
+			values:     make(map[float64]float64),
+			received:   now,
+		}
+		r.pending[key] = r.order.PushBack(g)
+	}
+	if len(g.description) < len(description) {
+		g.description = description
+	}
+	if unit != "" {
+		g.unit = unit
+	}
+	if createdTimestamp != 0 {
+		g.createdTimestamp = createdTimestamp
+	}
+	g.add(s, sample.Value)
+	return key
+}
+
+// complete removes and returns the data points of the given keys which are complete, as well as the data points
+// waiting for the longest time when there are more than max pending.
+func (r *reassembler) complete(keys []classicGroupKey) []*classicGroup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var groups []*classicGroup
+	for _, key := range keys {
+		e, ok := r.pending[key]
+		if !ok || !r.isComplete(e.Value.(*classicGroup)) {
+			continue
+		}
+		groups = append(groups, r.remove(e))
+	}
+	for r.order.Len() > r.maxPending {
+		groups = append(groups, r.remove(r.order.Front()))
+	}
+	return groups
+}
+
+// expire removes and returns the data points waiting for more than max wait.
+func (r *reassembler) expire(now time.Time) []*classicGroup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var groups []*classicGroup
+	for e := r.order.Front(); e != nil && now.Sub(e.Value.(*classicGroup).received) >= r.maxWait; e = r.order.Front() {
+		groups = append(groups, r.remove(e))
+	}
+	return groups
+}
+
+// flush removes and returns all the pending data points.
+func (r *reassembler) flush() []*classicGroup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	groups := make([]*classicGroup, 0, r.order.Len())
+	for e := r.order.Front(); e != nil; e = r.order.Front() {
+		groups = append(groups, r.remove(e))
+	}
+	return groups
+}
+
+// isComplete reports whether the data point has its count, its sum and as many buckets or quantiles as the
+// previous data point of the histogram or summary. The first data point of a histogram or summary is never
+// complete, as its number of buckets or quantiles is not known yet.
+//
+// Must be called holding lock.
+func (r *reassembler) isComplete(g *classicGroup) bool {
+	if !g.hasCount || !g.hasSum {
+		return false
+	}
+	if _, ok := g.values[math.Inf(1)]; g.metricType == writev2.Metadata_METRIC_TYPE_HISTOGRAM && !ok {
+		return false
+	}
+	layout, ok := r.layouts.Get(g.key.series)
+	return ok && len(g.values) >= layout
+}
+
+// remove removes the data point from the pending ones and records its number of buckets or quantiles.
+//
+// Must be called holding lock.
+func (r *reassembler) remove(e *list.Element) *classicGroup {
+	g := r.order.Remove(e).(*classicGroup)
+	delete(r.pending, g.key)
+	r.layouts.Add(g.key.series, len(g.values))
+	return g
+}
+
+// addClassicHistogramDatapoint adds the data point of a classic histogram, and reports whether it could be built
+// from the series received.
+func addClassicHistogramDatapoint(datapoints pmetric.HistogramDataPointSlice, g *classicGroup) bool {
+	count, hasCount := g.count, g.hasCount
+	if !hasCount {
+		// The +Inf bucket holds the count of the histogram
+		count, hasCount = g.values[math.Inf(1)]
+	}
+	if !hasCount {
+		return false
+	}
+
+	dp := datapoints.AppendEmpty()
+	setClassicTimestamps(dp, g)
+	extractAttributes(g.labels).CopyTo(dp.Attributes())
+	if value.IsStaleNaN(count) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return true
+	}
+
+	// The buckets of classic histograms are cumulative, while the ones of OTLP histograms are not.
+	var previous float64
+	for _, bound := range slices.Sorted(maps.Keys(g.values)) {
+		if math.IsInf(bound, 1) {
+			continue
+		}
+		cumulative := g.values[bound]
+		dp.ExplicitBounds().Append(bound)
+		dp.BucketCounts().Append(uint64(max(cumulative-previous, 0)))
+		previous = max(previous, cumulative)
+	}
+	dp.BucketCounts().Append(uint64(max(count-previous, 0)))
+	dp.SetCount(uint64(count))
+	if g.hasSum {
+		dp.SetSum(g.sum)
+	}
+	return true
+}
+
+// addClassicSummaryDatapoint adds the data point of a summary, and reports whether it could be built from the
+// series received.
+func addClassicSummaryDatapoint(datapoints pmetric.SummaryDataPointSlice, g *classicGroup) bool {
+	if !g.hasCount {
+		return false
+	}
+
+	dp := datapoints.AppendEmpty()
+	setClassicTimestamps(dp, g)
+	extractAttributes(g.labels).CopyTo(dp.Attributes())
+	if value.IsStaleNaN(g.count) {
+		dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return true
+	}
+
+	for _, quantile := range slices.Sorted(maps.Keys(g.values)) {
+		qv := dp.QuantileValues().AppendEmpty()
+		qv.SetQuantile(quantile)
+		qv.SetValue(g.values[quantile])
+	}
+	dp.SetCount(uint64(g.count))
+	dp.SetSum(g.sum)
+	return true
+}
+
+type timestampsSetter interface {
+	SetStartTimestamp(pcommon.Timestamp)
+	SetTimestamp(pcommon.Timestamp)
+}
+
+func setClassicTimestamps(dp timestampsSetter, g *classicGroup) {
+	dp.SetStartTimestamp(pcommon.Timestamp(g.createdTimestamp * int64(time.Millisecond)))
+	dp.SetTimestamp(pcommon.Timestamp(g.key.timestamp * int64(time.Millisecond)))
+}
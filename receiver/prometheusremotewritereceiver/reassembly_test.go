@@ -0,0 +1,269 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/value"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestParseClassicSeries(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		labels      labels.Labels
+		metricType  writev2.Metadata_MetricType
+		expected    classicSeries
+		expectError string
+	}{
+		{
+			name:       "histogram bucket",
+			labels:     labels.FromStrings(labels.MetricName, "latency_bucket", "le", "0.5", "foo", "bar"),
+			metricType: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+			expected:   classicSeries{part: classicBucket, bound: 0.5, labels: labels.FromStrings(labels.MetricName, "latency", "foo", "bar")},
+		},
+		{
+			name:       "histogram +Inf bucket",
+			labels:     labels.FromStrings(labels.MetricName, "latency_bucket", "le", "+Inf"),
+			metricType: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+			expected:   classicSeries{part: classicBucket, bound: math.Inf(1), labels: labels.FromStrings(labels.MetricName, "latency")},
+		},
+		{
+			name:        "histogram bucket with invalid le",
+			labels:      labels.FromStrings(labels.MetricName, "latency_bucket", "le", "foo"),
+			metricType:  writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+			expectError: `invalid "le" label "foo" for histogram bucket "latency_bucket"`,
+		},
+		{
+			name:       "histogram sum",
+			labels:     labels.FromStrings(labels.MetricName, "latency_sum", "foo", "bar"),
+			metricType: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+			expected:   classicSeries{part: classicSum, labels: labels.FromStrings(labels.MetricName, "latency", "foo", "bar")},
+		},
+		{
+			name:       "histogram count",
+			labels:     labels.FromStrings(labels.MetricName, "latency_count"),
+			metricType: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
+			expected:   classicSeries{part: classicCount, labels: labels.FromStrings(labels.MetricName, "latency")},
+		},
+		{
+			name:       "summary quantile",
+			labels:     labels.FromStrings(labels.MetricName, "latency", "quantile", "0.99"),
+			metricType: writev2.Metadata_METRIC_TYPE_SUMMARY,
+			expected:   classicSeries{part: classicQuantile, bound: 0.99, labels: labels.FromStrings(labels.MetricName, "latency")},
+		},
+		{
+			name:       "summary count",
+			labels:     labels.FromStrings(labels.MetricName, "latency_count"),
+			metricType: writev2.Metadata_METRIC_TYPE_SUMMARY,
+			expected:   classicSeries{part: classicCount, labels: labels.FromStrings(labels.MetricName, "latency")},
+		},
+		{
+			name:        "summary bucket",
+			labels:      labels.FromStrings(labels.MetricName, "latency_bucket", "le", "0.5"),
+			metricType:  writev2.Metadata_METRIC_TYPE_SUMMARY,
+			expectError: `series "latency_bucket" is not part of a classic histogram or summary`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := parseClassicSeries(tc.labels, tc.metricType)
+			if tc.expectError != "" {
+				assert.EqualError(t, err, tc.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, s)
+		})
+	}
+}
+
+func newTestReassembler(t *testing.T, maxPending int) *reassembler {
+	r, err := newReassembler(ReassemblyConfig{MaxWait: time.Minute, MaxPending: maxPending})
+	require.NoError(t, err)
+	return r
+}
+
+// histogramSeries holds the series of a classic histogram data point with buckets 1 and +Inf.
+var histogramSeries = map[string]struct {
+	labels labels.Labels
+	value  float64
+}{
+	"bucket_1":   {labels.FromStrings(labels.MetricName, "latency_bucket", "le", "1"), 1},
+	"bucket_inf": {labels.FromStrings(labels.MetricName, "latency_bucket", "le", "+Inf"), 3},
+	"sum":        {labels.FromStrings(labels.MetricName, "latency_sum"), 5},
+	"count":      {labels.FromStrings(labels.MetricName, "latency_count"), 3},
+}
+
+func addHistogramSeries(t *testing.T, r *reassembler, timestamp int64, now time.Time, series ...string) []classicGroupKey {
+	var keys []classicGroupKey
+	for _, name := range series {
+		s, err := parseClassicSeries(histogramSeries[name].labels, writev2.Metadata_METRIC_TYPE_HISTOGRAM)
+		require.NoError(t, err)
+		sample := writev2.Sample{Value: histogramSeries[name].value, Timestamp: timestamp}
+		keys = append(keys, r.add(s, writev2.Metadata_METRIC_TYPE_HISTOGRAM, "", "", 0, sample, now))
+	}
+	return keys
+}
+
+func TestReassemblerComplete(t *testing.T) {
+	r := newTestReassembler(t, 100)
+	now := time.Now()
+	all := []string{"bucket_1", "bucket_inf", "sum", "count"}
+
+	// The layout of the first data point is unknown, so it waits for max wait.
+	keys := addHistogramSeries(t, r, 1, now, all...)
+	assert.Empty(t, r.complete(keys))
+	groups := r.expire(now.Add(time.Minute))
+	require.Len(t, groups, 1)
+	assert.Len(t, groups[0].values, 2)
+
+	// The next data points are complete once they have as many buckets, even when split across requests.
+	keys = addHistogramSeries(t, r, 2, now, "bucket_1", "sum")
+	assert.Empty(t, r.complete(keys))
+	keys = addHistogramSeries(t, r, 2, now, "bucket_inf", "count")
+	groups = r.complete(keys)
+	require.Len(t, groups, 1)
+	assert.Equal(t, int64(2), groups[0].key.timestamp)
+	assert.Empty(t, r.flush())
+}
+
+func TestReassemblerMaxPending(t *testing.T) {
+	r := newTestReassembler(t, 1)
+	now := time.Now()
+
+	addHistogramSeries(t, r, 1, now, "sum")
+	keys := addHistogramSeries(t, r, 2, now, "sum")
+	groups := r.complete(keys)
+	require.Len(t, groups, 1)
+	assert.Equal(t, int64(1), groups[0].key.timestamp)
+
+	assert.Empty(t, r.expire(now.Add(time.Second)))
+	groups = r.flush()
+	require.Len(t, groups, 1)
+	assert.Equal(t, int64(2), groups[0].key.timestamp)
+}
+
+func TestAddClassicHistogramDatapoint(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		group    *classicGroup
+		expected func(dp pmetric.HistogramDataPoint)
+	}{
+		{
+			name: "complete",
+			group: &classicGroup{
+				values: map[float64]float64{0.5: 1, 1: 3, math.Inf(1): 4},
+				sum:    2.5, hasSum: true,
+				count: 4, hasCount: true,
+			},
+			expected: func(dp pmetric.HistogramDataPoint) {
+				dp.ExplicitBounds().FromRaw([]float64{0.5, 1})
+				dp.BucketCounts().FromRaw([]uint64{1, 2, 1})
+				dp.SetCount(4)
+				dp.SetSum(2.5)
+			},
+		},
+		{
+			name: "count from +Inf bucket without sum",
+			group: &classicGroup{
+				values: map[float64]float64{1: 2, math.Inf(1): 3},
+			},
+			expected: func(dp pmetric.HistogramDataPoint) {
+				dp.ExplicitBounds().FromRaw([]float64{1})
+				dp.BucketCounts().FromRaw([]uint64{2, 1})
+				dp.SetCount(3)
+			},
+		},
+		{
+			name: "non cumulative buckets are clamped",
+			group: &classicGroup{
+				values: map[float64]float64{1: 3, 2: 2},
+				count:  4, hasCount: true,
+			},
+			expected: func(dp pmetric.HistogramDataPoint) {
+				dp.ExplicitBounds().FromRaw([]float64{1, 2})
+				dp.BucketCounts().FromRaw([]uint64{3, 0, 1})
+				dp.SetCount(4)
+			},
+		},
+		{
+			name: "stale",
+			group: &classicGroup{
+				values: map[float64]float64{},
+				count:  math.Float64frombits(value.StaleNaN), hasCount: true,
+			},
+			expected: func(dp pmetric.HistogramDataPoint) {
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+			},
+		},
+		{
+			name: "without count",
+			group: &classicGroup{
+				values: map[float64]float64{1: 2},
+				sum:    1, hasSum: true,
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.group.labels = labels.FromStrings(labels.MetricName, "latency", "foo", "bar")
+			tc.group.key.timestamp = 2
+			tc.group.createdTimestamp = 1
+
+			dps := pmetric.NewHistogramDataPointSlice()
+			added := addClassicHistogramDatapoint(dps, tc.group)
+			if tc.expected == nil {
+				assert.False(t, added)
+				assert.Equal(t, 0, dps.Len())
+				return
+			}
+			require.True(t, added)
+
+			expected := pmetric.NewHistogramDataPointSlice()
+			dp := expected.AppendEmpty()
+			dp.SetStartTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+			dp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+			dp.Attributes().PutStr("foo", "bar")
+			tc.expected(dp)
+			assert.Equal(t, expected, dps)
+		})
+	}
+}
+
+func TestAddClassicSummaryDatapoint(t *testing.T) {
+	g := &classicGroup{
+		key:    classicGroupKey{timestamp: 2},
+		labels: labels.FromStrings(labels.MetricName, "latency", "foo", "bar"),
+		values: map[float64]float64{0.99: 3, 0.5: 1},
+		sum:    10, hasSum: true,
+		count: 5, hasCount: true,
+	}
+
+	dps := pmetric.NewSummaryDataPointSlice()
+	require.True(t, addClassicSummaryDatapoint(dps, g))
+
+	expected := pmetric.NewSummaryDataPointSlice()
+	dp := expected.AppendEmpty()
+	dp.SetTimestamp(pcommon.Timestamp(2 * int64(time.Millisecond)))
+	dp.Attributes().PutStr("foo", "bar")
+	dp.SetCount(5)
+	dp.SetSum(10)
+	qv := dp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(1)
+	qv = dp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.99)
+	qv.SetValue(3)
+	assert.Equal(t, expected, dps)
+
+	g.hasCount = false
+	assert.False(t, addClassicSummaryDatapoint(pmetric.NewSummaryDataPointSlice(), g))
+}
@@ -18,6 +18,7 @@ import (
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	promremote "github.com/prometheus/prometheus/storage/remote"
 	"go.opentelemetry.io/collector/component"
@@ -38,6 +39,18 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
 	}
 
+	metadataCache, err := lru.New[string, prompb.MetricMetadata](metadataCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create LRU cache: %w", err)
+	}
+
+	var r *reassembler
+	if cfg.Reassembly.Enabled {
+		if r, err = newReassembler(cfg.Reassembly); err != nil {
+			return nil, err
+		}
+	}
+
 	return &prometheusRemoteWriteReceiver{
 		settings:     settings,
 		nextConsumer: nextConsumer,
@@ -45,7 +58,9 @@ func newRemoteWriteReceiver(settings receiver.Settings, cfg *Config, nextConsume
 		server: &http.Server{
 			ReadTimeout: 60 * time.Second,
 		},
-		rmCache: cache,
+		rmCache:       cache,
+		metadataCache: metadataCache,
+		reassembler:   r,
 	}, nil
 }
 
@@ -58,7 +73,13 @@ type prometheusRemoteWriteReceiver struct {
 	wg     sync.WaitGroup
 
 	rmCache *lru.Cache[uint64, pmetric.ResourceMetrics]
-	obsrecv *receiverhelper.ObsReport
+	// metadataCache holds the metadata of the metric families sent in v1 requests, by metric family name.
+	metadataCache *lru.Cache[string, prompb.MetricMetadata]
+	obsrecv       *receiverhelper.ObsReport
+
+	// reassembler groups the series of classic histograms and summaries, nil when reassembly is disabled.
+	reassembler    *reassembler
+	stopReassembly chan struct{}
 }
 
 // metricIdentity contains all the components that uniquely identify a metric
@@ -130,10 +151,20 @@ func (prw *prometheusRemoteWriteReceiver) Start(ctx context.Context, host compon
 			componentstatus.ReportStatus(host, componentstatus.NewFatalErrorEvent(fmt.Errorf("error starting prometheus remote-write receiver: %w", err)))
 		}
 	}()
+
+	if prw.reassembler != nil {
+		prw.stopReassembly = make(chan struct{})
+		prw.wg.Add(1)
+		go prw.reassemblyLoop(prw.stopReassembly)
+	}
 	return nil
 }
 
 func (prw *prometheusRemoteWriteReceiver) Shutdown(ctx context.Context) error {
+	if prw.stopReassembly != nil {
+		close(prw.stopReassembly)
+		prw.stopReassembly = nil
+	}
 	if prw.server == nil {
 		return nil
 	}
@@ -142,10 +173,31 @@ func (prw *prometheusRemoteWriteReceiver) Shutdown(ctx context.Context) error {
 		// Only wait if Shutdown returns successfully,
 		// otherwise we may block indefinitely.
 		prw.wg.Wait()
+		if prw.reassembler != nil {
+			// Send the classic histograms and summaries still waiting for their missing series
+			prw.consumeClassicGroups(ctx, prw.reassembler.flush())
+		}
 	}
 	return err
 }
 
+// reassemblyLoop periodically sends the classic histograms and summaries which waited for their missing
+// series for longer than max wait.
+func (prw *prometheusRemoteWriteReceiver) reassemblyLoop(stop <-chan struct{}) {
+	defer prw.wg.Done()
+
+	ticker := time.NewTicker(min(prw.config.Reassembly.MaxWait, time.Second))
+	defer ticker.Stop()
+	for {
+		select {
+		case now := <-ticker.C:
+			prw.consumeClassicGroups(context.Background(), prw.reassembler.expire(now))
+		case <-stop:
+			return
+		}
+	}
+}
+
 func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *http.Request) {
 	contentType := req.Header.Get("Content-Type")
 	if contentType == "" {
@@ -160,12 +212,6 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
 		return
 	}
-	if msgType != promconfig.RemoteWriteProtoMsgV2 {
-		prw.settings.Logger.Warn("message received with unsupported proto version, rejecting")
-		http.Error(w, "Unsupported proto version", http.StatusUnsupportedMediaType)
-		return
-	}
-
 	// After parsing the content-type header, the next step would be to handle content-encoding.
 	// Luckly confighttp's Server has middleware that already decompress the request body for us.
 
@@ -176,15 +222,30 @@ func (prw *prometheusRemoteWriteReceiver) handlePRW(w http.ResponseWriter, req *
 		return
 	}
 
-	var prw2Req writev2.Request
-	if err = proto.Unmarshal(body, &prw2Req); err != nil {
-		prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
+	var m pmetric.Metrics
+	switch msgType {
+	case promconfig.RemoteWriteProtoMsgV1:
+		var prw1Req prompb.WriteRequest
+		if err = proto.Unmarshal(body, &prw1Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Remote-write v1 doesn't define the written response headers.
+		m, err = prw.translateV1(req.Context(), &prw1Req)
+	default:
+		var prw2Req writev2.Request
+		if err = proto.Unmarshal(body, &prw2Req); err != nil {
+			prw.settings.Logger.Warn("Error decoding remote write request", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 
-	m, stats, err := prw.translateV2(req.Context(), &prw2Req)
-	stats.SetHeaders(w)
+		var stats promremote.WriteResponseStats
+		m, stats, err = prw.translateV2(req.Context(), &prw2Req)
+		stats.SetHeaders(w)
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusBadRequest) // Following instructions at https://prometheus.io/docs/specs/remote_write_spec_2_0/#invalid-samples
 		return
@@ -244,6 +305,8 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		}
 		// The key is composed by: resource_hash:scope_name:scope_version:metric_name:unit:type
 		metricCache = make(map[uint64]pmetric.Metric)
+		// classicKeys holds the keys of the classic histogram and summary data points with series in the request.
+		classicKeys []classicGroupKey
 	)
 
 	for _, ts := range req.Timeseries {
@@ -296,23 +359,21 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		unit := req.Symbols[ts.Metadata.UnitRef]
 		description := req.Symbols[ts.Metadata.HelpRef]
 
+		// Handle the series of classic histograms and summaries separately, as they are reassembled into data points
+		if ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_SUMMARY ||
+			ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_HISTOGRAM && len(ts.Samples) != 0 {
+			classicKeys = prw.addClassicSeries(classicKeys, ls, ts, unit, description, &stats)
+			continue
+		}
+
 		// Handle histograms separately due to their complex mixed-schema processing
 		if ts.Metadata.Type == writev2.Metadata_METRIC_TYPE_HISTOGRAM {
 			prw.processHistogramTimeSeries(otelMetrics, ls, ts, scopeName, scopeVersion, metricName, unit, description, metricCache, &stats)
 			continue
 		}
 
-		// Handle regular metrics (gauge, counter, summary)
-		hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
-		existingRM, ok := prw.rmCache.Get(hashedLabels)
-		var rm pmetric.ResourceMetrics
-		if ok {
-			rm = existingRM
-		} else {
-			rm = otelMetrics.ResourceMetrics().AppendEmpty()
-			parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
-			prw.rmCache.Add(hashedLabels, rm)
-		}
+		// Handle regular metrics (gauge, counter)
+		rm := prw.getOrCreateResource(otelMetrics, ls)
 
 		resourceID := identity.OfResource(rm.Resource())
 		metricIdentity := createMetricIdentity(
@@ -325,23 +386,7 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 		)
 
 		metricKey := metricIdentity.Hash()
-
-		// Find or create scope
-		var scope pmetric.ScopeMetrics
-		var foundScope bool
-		for i := 0; i < rm.ScopeMetrics().Len(); i++ {
-			s := rm.ScopeMetrics().At(i)
-			if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
-				scope = s
-				foundScope = true
-				break
-			}
-		}
-		if !foundScope {
-			scope = rm.ScopeMetrics().AppendEmpty()
-			scope.Scope().SetName(scopeName)
-			scope.Scope().SetVersion(scopeVersion)
-		}
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
 
 		// Get or create metric
 		metric, exists := metricCache[metricKey]
@@ -355,9 +400,6 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 				sum := metric.SetEmptySum()
 				sum.SetIsMonotonic(true)
 				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
-			case writev2.Metadata_METRIC_TYPE_SUMMARY:
-				// Drop summary series as we will not handle them.
-				continue
 			default:
 				badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
 				continue
@@ -374,17 +416,154 @@ func (prw *prometheusRemoteWriteReceiver) translateV2(_ context.Context, req *wr
 			addNumberDatapoints(metric.Gauge().DataPoints(), ls, ts, &stats)
 		case writev2.Metadata_METRIC_TYPE_COUNTER:
 			addNumberDatapoints(metric.Sum().DataPoints(), ls, ts, &stats)
-		case writev2.Metadata_METRIC_TYPE_SUMMARY:
-			// Drop summary series as we will not handle them.
-			continue
 		default:
 			badRequestErrors = errors.Join(badRequestErrors, fmt.Errorf("unsupported metric type %q for metric %q", ts.Metadata.Type, metricName))
 		}
 	}
 
+	if len(classicKeys) != 0 {
+		prw.appendClassicGroups(prw.reassembler.complete(classicKeys), func(ls labels.Labels) pmetric.ResourceMetrics {
+			return prw.getOrCreateResource(otelMetrics, ls)
+		}, metricCache)
+	}
+
 	return otelMetrics, stats, badRequestErrors
 }
 
+// getOrCreateResource returns the resource metrics of the job and instance of the labels.
+func (prw *prometheusRemoteWriteReceiver) getOrCreateResource(otelMetrics pmetric.Metrics, ls labels.Labels) pmetric.ResourceMetrics {
+	hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
+	if existingRM, ok := prw.rmCache.Get(hashedLabels); ok {
+		return existingRM
+	}
+	rm := otelMetrics.ResourceMetrics().AppendEmpty()
+	parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
+	prw.rmCache.Add(hashedLabels, rm)
+	return rm
+}
+
+// getOrCreateScope returns the scope metrics of the resource metrics with the given name and version.
+func getOrCreateScope(rm pmetric.ResourceMetrics, scopeName, scopeVersion string) pmetric.ScopeMetrics {
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		s := rm.ScopeMetrics().At(i)
+		if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
+			return s
+		}
+	}
+	scope := rm.ScopeMetrics().AppendEmpty()
+	scope.Scope().SetName(scopeName)
+	scope.Scope().SetVersion(scopeVersion)
+	return scope
+}
+
+// addClassicSeries adds the samples of a series of a classic histogram or summary to the data points being
+// reassembled, and returns the keys of the data points appended to classicKeys.
+func (prw *prometheusRemoteWriteReceiver) addClassicSeries(
+	classicKeys []classicGroupKey,
+	ls labels.Labels,
+	ts writev2.TimeSeries,
+	unit, description string,
+	stats *promremote.WriteResponseStats,
+) []classicGroupKey {
+	if prw.reassembler == nil {
+		prw.settings.Logger.Debug("Dropping classic histogram or summary series, as reassembly is disabled",
+			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get(labels.MetricName)})
+		return classicKeys
+	}
+
+	s, err := parseClassicSeries(ls, ts.Metadata.Type)
+	if err != nil {
+		prw.settings.Logger.Debug("Dropping classic histogram or summary series", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+		return classicKeys
+	}
+
+	now := time.Now()
+	for _, sample := range ts.Samples {
+		classicKeys = append(classicKeys, prw.reassembler.add(s, ts.Metadata.Type, unit, description, ts.CreatedTimestamp, sample, now))
+	}
+	stats.Samples += len(ts.Samples)
+	return classicKeys
+}
+
+// appendClassicGroups appends the reassembled data points of classic histograms and summaries to the metrics
+// of the resources returned by resourceFor.
+func (prw *prometheusRemoteWriteReceiver) appendClassicGroups(groups []*classicGroup, resourceFor func(labels.Labels) pmetric.ResourceMetrics, metricCache map[uint64]pmetric.Metric) {
+	for _, g := range groups {
+		rm := resourceFor(g.labels)
+		scopeName, scopeVersion := prw.extractScopeInfo(g.labels)
+		scope := getOrCreateScope(rm, scopeName, scopeVersion)
+		metricName := g.labels.Get(labels.MetricName)
+		metricKey := createMetricIdentity(
+			identity.OfResource(rm.Resource()).String(),
+			scopeName,
+			scopeVersion,
+			metricName,
+			g.unit,
+			g.metricType,
+		).Hash()
+
+		metric, exists := metricCache[metricKey]
+		if !exists {
+			metric = setMetric(scope, metricName, g.unit, g.description)
+			if g.metricType == writev2.Metadata_METRIC_TYPE_HISTOGRAM {
+				metric.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			} else {
+				metric.SetEmptySummary()
+			}
+			metricCache[metricKey] = metric
+		} else if len(metric.Description()) < len(g.description) {
+			metric.SetDescription(g.description)
+		}
+
+		var added bool
+		if g.metricType == writev2.Metadata_METRIC_TYPE_HISTOGRAM {
+			added = addClassicHistogramDatapoint(metric.Histogram().DataPoints(), g)
+		} else {
+			added = addClassicSummaryDatapoint(metric.Summary().DataPoints(), g)
+		}
+		if !added {
+			prw.settings.Logger.Debug("Dropping classic histogram or summary without count",
+				zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: metricName})
+		}
+	}
+}
+
+// consumeClassicGroups sends the data points of classic histograms and summaries which were not complete when
+// their last series was received.
+func (prw *prometheusRemoteWriteReceiver) consumeClassicGroups(ctx context.Context, groups []*classicGroup) {
+	if len(groups) == 0 {
+		return
+	}
+
+	// The resources are copied from the cache, as the cached resources belong to the metrics of previous requests.
+	m := pmetric.NewMetrics()
+	resources := make(map[uint64]pmetric.ResourceMetrics)
+	prw.appendClassicGroups(groups, func(ls labels.Labels) pmetric.ResourceMetrics {
+		hashedLabels := xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
+		if rm, ok := resources[hashedLabels]; ok {
+			return rm
+		}
+		rm := m.ResourceMetrics().AppendEmpty()
+		if cachedRM, ok := prw.rmCache.Get(hashedLabels); ok {
+			cachedRM.Resource().CopyTo(rm.Resource())
+		} else {
+			parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
+		}
+		resources[hashedLabels] = rm
+		return rm
+	}, make(map[uint64]pmetric.Metric))
+	if m.DataPointCount() == 0 {
+		return
+	}
+
+	obsrecvCtx := prw.obsrecv.StartMetricsOp(ctx)
+	err := prw.nextConsumer.ConsumeMetrics(ctx, m)
+	if err != nil {
+		prw.settings.Logger.Error("Error consuming metrics", zapcore.Field{Key: "error", Type: zapcore.ErrorType, Interface: err})
+	}
+	prw.obsrecv.EndMetricsOp(obsrecvCtx, "prometheusremotewritereceiver", m.ResourceMetrics().Len(), err)
+}
+
 // processHistogramTimeSeries handles all histogram processing, including validation and mixed schemas.
 func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 	otelMetrics pmetric.Metrics,
@@ -394,15 +573,8 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 	metricCache map[uint64]pmetric.Metric,
 	stats *promremote.WriteResponseStats,
 ) {
-	// Drop classic histogram series (those with samples)
-	if len(ts.Samples) != 0 {
-		prw.settings.Logger.Info("Dropping classic histogram series. Please configure Prometheus to convert classic histograms into Native Histograms Custom Buckets",
-			zapcore.Field{Key: "timeseries", Type: zapcore.StringType, String: ls.Get("__name__")})
-		return
-	}
-
 	var rm pmetric.ResourceMetrics
-	var hashedLabels uint64
+	var hasResource bool
 	var resourceID identity.Resource
 	var scope pmetric.ScopeMetrics
 
@@ -425,34 +597,14 @@ func (prw *prometheusRemoteWriteReceiver) processHistogramTimeSeries(
 			continue
 		}
 		// Create resource if needed (only for the first valid histogram)
-		if hashedLabels == 0 {
-			hashedLabels = xxhash.Sum64String(ls.Get("job") + string([]byte{'\xff'}) + ls.Get("instance"))
-			existingRM, ok := prw.rmCache.Get(hashedLabels)
-			if ok {
-				rm = existingRM
-			} else {
-				rm = otelMetrics.ResourceMetrics().AppendEmpty()
-				parseJobAndInstance(rm.Resource().Attributes(), ls.Get("job"), ls.Get("instance"))
-				prw.rmCache.Add(hashedLabels, rm)
-			}
+		if !hasResource {
+			rm = prw.getOrCreateResource(otelMetrics, ls)
 			resourceID = identity.OfResource(rm.Resource())
+			hasResource = true
 		}
 
 		// Find or create scope (search each time since different histograms might need different scopes)
-		var foundScope bool
-		for i := 0; i < rm.ScopeMetrics().Len(); i++ {
-			s := rm.ScopeMetrics().At(i)
-			if s.Scope().Name() == scopeName && s.Scope().Version() == scopeVersion {
-				scope = s
-				foundScope = true
-				break
-			}
-		}
-		if !foundScope {
-			scope = rm.ScopeMetrics().AppendEmpty()
-			scope.Scope().SetName(scopeName)
-			scope.Scope().SetVersion(scopeVersion)
-		}
+		scope = getOrCreateScope(rm, scopeName, scopeVersion)
 
 		metricID := fmt.Sprintf("%s:%s:%s:%s:%s:%s:%s",
 			resourceID.String(),
@@ -19,6 +19,7 @@ import (
 	"github.com/golang/snappy"
 	promconfig "github.com/prometheus/prometheus/config"
 	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
 	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
 	"github.com/prometheus/prometheus/storage/remote"
 	"github.com/stretchr/testify/assert"
@@ -119,7 +120,7 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/no proto parameter",
 			contentType:  "application/x-protobuf",
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
 				Confirmed:  false,
 				Samples:    0,
@@ -130,7 +131,7 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 		{
 			name:         "x-protobuf/v1 proto parameter",
 			contentType:  fmt.Sprintf("application/x-protobuf;proto=%s", promconfig.RemoteWriteProtoMsgV1),
-			expectedCode: http.StatusUnsupportedMediaType,
+			expectedCode: http.StatusNoContent,
 			expectedStats: remote.WriteResponseStats{
 				Confirmed:  false,
 				Samples:    0,
@@ -170,7 +171,7 @@ func TestHandlePRWContentTypeNegotiation(t *testing.T) {
 			resp := w.Result()
 
 			assert.Equal(t, tc.expectedCode, resp.StatusCode)
-			if tc.expectedCode == http.StatusNoContent { // We went until the end
+			if tc.expectedStats.Confirmed { // We went until the end of a v2 request
 				assert.NotEmpty(t, resp.Header.Get("X-Prometheus-Remote-Write-Samples-Written"))
 				assert.NotEmpty(t, resp.Header.Get("X-Prometheus-Remote-Write-Histograms-Written"))
 				assert.NotEmpty(t, resp.Header.Get("X-Prometheus-Remote-Write-Exemplars-Written"))
@@ -999,7 +1000,7 @@ func TestTranslateV2(t *testing.T) {
 			expectedMetrics: pmetric.NewMetrics(), // Reset hint gauge should be dropped completely, no resources should be created
 		},
 		{
-			name: "classic histogram - should be dropped",
+			name: "classic histogram - series without suffix should be dropped",
 			request: &writev2.Request{
 				Symbols: []string{
 					"",
@@ -1015,7 +1016,7 @@ func TestTranslateV2(t *testing.T) {
 						Metadata: writev2.Metadata{
 							Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM,
 						},
-						// Classic histograms populate samples instead of histograms. Those should be dropped.
+						// Classic histograms populate samples instead of histograms. Those without _bucket, _sum or _count suffix should be dropped.
 						Histograms: []writev2.Histogram{},
 						Samples: []writev2.Sample{
 							{
@@ -1036,7 +1037,7 @@ func TestTranslateV2(t *testing.T) {
 			expectedMetrics: pmetric.NewMetrics(), // Classic histograms should be dropped completely, no resources should be created
 		},
 		{
-			name: "summary - should be dropped",
+			name: "summary - series without quantile or suffix should be dropped",
 			request: &writev2.Request{
 				Symbols: []string{
 					"",
@@ -1067,20 +1068,7 @@ func TestTranslateV2(t *testing.T) {
 				Histograms: 0,
 				Exemplars:  0,
 			},
-			expectedMetrics: func() pmetric.Metrics {
-				metrics := pmetric.NewMetrics()
-				rm := metrics.ResourceMetrics().AppendEmpty()
-				attrs := rm.Resource().Attributes()
-				attrs.PutStr("service.namespace", "service-x")
-				attrs.PutStr("service.name", "test")
-				attrs.PutStr("service.instance.id", "107cn001")
-
-				sm := rm.ScopeMetrics().AppendEmpty()
-				sm.Scope().SetName("scope1")
-				sm.Scope().SetVersion("v1")
-
-				return metrics
-			}(),
+			expectedMetrics: pmetric.NewMetrics(), // Invalid summary series should be dropped completely, no resources should be created
 		},
 		{
 			name: "NHCB translation",
@@ -1755,3 +1743,201 @@ func TestLRUCacheResourceMetrics(t *testing.T) {
 	// As just have 1 slot in the cache, but the cache for metric1 was evicted, this metric1_1 should generate a new resource metric, even having the same job/instance than the metric1.
 	assert.NoError(t, pmetrictest.CompareMetrics(expectedMetrics1_1, mockConsumer.metrics[3]))
 }
+
+func TestTranslateV2ClassicHistogramReassembly(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	mockConsumer := new(mockConsumer)
+	prwReceiver.nextConsumer = mockConsumer
+
+	requestAt := func(timestamp int64) *writev2.Request {
+		return &writev2.Request{
+			Symbols: []string{
+				"",
+				"__name__", "latency_bucket", // 1, 2
+				"job", "service-x/test", // 3, 4
+				"instance", "107cn001", // 5, 6
+				"le", "1", // 7, 8
+				"+Inf",                         // 9
+				"latency_sum", "latency_count", // 10, 11
+				"Request latency", "s", // 12, 13
+			},
+			Timeseries: []writev2.TimeSeries{
+				{
+					Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12, UnitRef: 13},
+					LabelsRefs:       []uint32{1, 2, 3, 4, 5, 6, 7, 8},
+					Samples:          []writev2.Sample{{Value: 2, Timestamp: timestamp}},
+					CreatedTimestamp: 1,
+				},
+				{
+					Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12, UnitRef: 13},
+					LabelsRefs:       []uint32{1, 2, 3, 4, 5, 6, 7, 9},
+					Samples:          []writev2.Sample{{Value: 3, Timestamp: timestamp}},
+					CreatedTimestamp: 1,
+				},
+				{
+					Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12, UnitRef: 13},
+					LabelsRefs:       []uint32{1, 10, 3, 4, 5, 6},
+					Samples:          []writev2.Sample{{Value: 1.5, Timestamp: timestamp}},
+					CreatedTimestamp: 1,
+				},
+				{
+					Metadata:         writev2.Metadata{Type: writev2.Metadata_METRIC_TYPE_HISTOGRAM, HelpRef: 12, UnitRef: 13},
+					LabelsRefs:       []uint32{1, 11, 3, 4, 5, 6},
+					Samples:          []writev2.Sample{{Value: 3, Timestamp: timestamp}},
+					CreatedTimestamp: 1,
+				},
+			},
+		}
+	}
+	expectedAt := func(timestamp int64) pmetric.Metrics {
+		metrics := pmetric.NewMetrics()
+		rm := metrics.ResourceMetrics().AppendEmpty()
+		attrs := rm.Resource().Attributes()
+		attrs.PutStr("service.namespace", "service-x")
+		attrs.PutStr("service.name", "test")
+		attrs.PutStr("service.instance.id", "107cn001")
+
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("OpenTelemetry Collector")
+		sm.Scope().SetVersion("latest")
+
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("latency")
+		m.SetUnit("s")
+		m.SetDescription("Request latency")
+		hist := m.SetEmptyHistogram()
+		hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dp := hist.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+		dp.SetTimestamp(pcommon.Timestamp(timestamp * int64(time.Millisecond)))
+		dp.ExplicitBounds().FromRaw([]float64{1})
+		dp.BucketCounts().FromRaw([]uint64{2, 1})
+		dp.SetCount(3)
+		dp.SetSum(1.5)
+		return metrics
+	}
+
+	// The number of buckets of the first data point is not known, so it is sent once max wait is reached.
+	metrics, stats, err := prwReceiver.translateV2(t.Context(), requestAt(2))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, metrics.DataPointCount())
+	assert.Equal(t, 4, stats.Samples)
+	prwReceiver.consumeClassicGroups(t.Context(), prwReceiver.reassembler.expire(time.Now().Add(time.Hour)))
+	assert.Len(t, mockConsumer.metrics, 1)
+	assert.NoError(t, pmetrictest.CompareMetrics(expectedAt(2), mockConsumer.metrics[0]))
+
+	// The next data points are sent as soon as they have all their series.
+	metrics, _, err = prwReceiver.translateV2(t.Context(), requestAt(3))
+	assert.NoError(t, err)
+	assert.NoError(t, pmetrictest.CompareMetrics(expectedAt(3), metrics))
+}
+
+func TestTranslateV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	mockConsumer := new(mockConsumer)
+	prwReceiver.nextConsumer = mockConsumer
+
+	labelsOf := func(name string, extra ...string) []prompb.Label {
+		ls := []prompb.Label{
+			{Name: "__name__", Value: name},
+			{Name: "job", Value: "service-x/test"},
+			{Name: "instance", Value: "107cn001"},
+		}
+		for i := 0; i < len(extra); i += 2 {
+			ls = append(ls, prompb.Label{Name: extra[i], Value: extra[i+1]})
+		}
+		return ls
+	}
+	req := &prompb.WriteRequest{
+		Metadata: []prompb.MetricMetadata{
+			{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Number of requests"},
+		},
+		Timeseries: []prompb.TimeSeries{
+			{Labels: labelsOf("requests_total"), Samples: []prompb.Sample{{Value: 10, Timestamp: 1}}},
+			{Labels: labelsOf("temperature"), Samples: []prompb.Sample{{Value: 20, Timestamp: 1}}},
+			// The summary has no metadata: its type is inferred from the quantile label, even for the series before
+			{Labels: labelsOf("latency_count"), Samples: []prompb.Sample{{Value: 4, Timestamp: 1}}},
+			{Labels: labelsOf("latency_sum"), Samples: []prompb.Sample{{Value: 2, Timestamp: 1}}},
+			{Labels: labelsOf("latency", "quantile", "0.5"), Samples: []prompb.Sample{{Value: 0.4, Timestamp: 1}}},
+		},
+	}
+
+	expectedResource := func(metrics pmetric.Metrics) pmetric.ScopeMetrics {
+		rm := metrics.ResourceMetrics().AppendEmpty()
+		attrs := rm.Resource().Attributes()
+		attrs.PutStr("service.namespace", "service-x")
+		attrs.PutStr("service.name", "test")
+		attrs.PutStr("service.instance.id", "107cn001")
+
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName("OpenTelemetry Collector")
+		sm.Scope().SetVersion("latest")
+		return sm
+	}
+
+	expected := pmetric.NewMetrics()
+	sm := expectedResource(expected)
+	counter := sm.Metrics().AppendEmpty()
+	counter.SetName("requests_total")
+	counter.SetDescription("Number of requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetDoubleValue(10)
+	dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("temperature")
+	dp = gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetDoubleValue(20)
+	dp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+
+	metrics, err := prwReceiver.translateV1(t.Context(), req)
+	assert.NoError(t, err)
+	assert.NoError(t, pmetrictest.CompareMetrics(expected, metrics))
+
+	expectedSummary := pmetric.NewMetrics()
+	summary := expectedResource(expectedSummary).Metrics().AppendEmpty()
+	summary.SetName("latency")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(pcommon.Timestamp(1 * int64(time.Millisecond)))
+	sdp.SetCount(4)
+	sdp.SetSum(2)
+	qv := sdp.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(0.4)
+
+	prwReceiver.consumeClassicGroups(t.Context(), prwReceiver.reassembler.flush())
+	assert.Len(t, mockConsumer.metrics, 1)
+	assert.NoError(t, pmetrictest.CompareMetrics(expectedSummary, mockConsumer.metrics[0]))
+}
+
+func TestHandlePRWV1(t *testing.T) {
+	prwReceiver := setupMetricsReceiver(t)
+	mockConsumer := new(mockConsumer)
+	prwReceiver.nextConsumer = mockConsumer
+
+	ts := httptest.NewServer(http.HandlerFunc(prwReceiver.handlePRW))
+	defer ts.Close()
+
+	pBuf := proto.NewBuffer(nil)
+	assert.NoError(t, pBuf.Marshal(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{
+				Labels:  []prompb.Label{{Name: "__name__", Value: "temperature"}, {Name: "job", Value: "test"}},
+				Samples: []prompb.Sample{{Value: 20, Timestamp: 1}},
+			},
+		},
+	}))
+
+	resp, err := http.Post(ts.URL, "application/x-protobuf", bytes.NewBuffer(pBuf.Bytes()))
+	assert.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode, string(body))
+	// Remote-write v1 doesn't define the written response headers
+	assert.Empty(t, resp.Header.Get("X-Prometheus-Remote-Write-Samples-Written"))
+
+	assert.Len(t, mockConsumer.metrics, 1)
+	assert.Equal(t, 1, mockConsumer.dataPoints)
+}
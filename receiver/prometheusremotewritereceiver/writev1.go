@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/prompb"
+	writev2 "github.com/prometheus/prometheus/prompb/io/prometheus/write/v2"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// metadataCacheSize is the number of metric families whose metadata is kept for v1 requests.
+const metadataCacheSize = 10000
+
+// translateV1 translates a remote-write v1 request into metrics. Unlike v2, the series of a v1 request don't hold
+// their metadata: it is sent separately, for each metric family, and is kept in a cache to type the series of the
+// following requests.
+func (prw *prometheusRemoteWriteReceiver) translateV1(ctx context.Context, req *prompb.WriteRequest) (pmetric.Metrics, error) {
+	for _, md := range req.Metadata {
+		prw.metadataCache.Add(md.MetricFamilyName, md)
+	}
+	// The types are inferred before translating the series, as the _sum and _count series of classic histograms
+	// and summaries can come first.
+	for _, ts := range req.Timeseries {
+		prw.inferMetadataV1(ts.Labels)
+	}
+
+	var (
+		symbols   = writev2.NewSymbolTable()
+		v2Req     = writev2.Request{Timeseries: make([]writev2.TimeSeries, 0, len(req.Timeseries))}
+		lsBuilder = labels.NewScratchBuilder(0)
+	)
+	for _, ts := range req.Timeseries {
+		lsBuilder.Reset()
+		for _, l := range ts.Labels {
+			lsBuilder.Add(l.Name, l.Value)
+		}
+		lsBuilder.Sort()
+		ls := lsBuilder.Labels()
+
+		md := prw.metadataV1(ls.Get(labels.MetricName))
+		v2TS := writev2.TimeSeries{
+			LabelsRefs: symbols.SymbolizeLabels(ls, nil),
+			Metadata: writev2.Metadata{
+				Type:    metricTypeV1(md.Type),
+				UnitRef: symbols.Symbolize(md.Unit),
+				HelpRef: symbols.Symbolize(md.Help),
+			},
+		}
+		if len(ts.Histograms) != 0 {
+			// Only native histograms are sent as histograms
+			v2TS.Metadata.Type = writev2.Metadata_METRIC_TYPE_HISTOGRAM
+		}
+
+		if len(ts.Samples) != 0 {
+			v2TS.Samples = make([]writev2.Sample, 0, len(ts.Samples))
+			for _, s := range ts.Samples {
+				v2TS.Samples = append(v2TS.Samples, writev2.Sample{Value: s.Value, Timestamp: s.Timestamp})
+			}
+		}
+		if len(ts.Histograms) != 0 {
+			v2TS.Histograms = make([]writev2.Histogram, 0, len(ts.Histograms))
+			for _, h := range ts.Histograms {
+				if h.IsFloatHistogram() {
+					v2TS.Histograms = append(v2TS.Histograms, writev2.FromFloatHistogram(h.Timestamp, h.ToFloatHistogram()))
+				} else {
+					v2TS.Histograms = append(v2TS.Histograms, writev2.FromIntHistogram(h.Timestamp, h.ToIntHistogram()))
+				}
+			}
+		}
+		v2Req.Timeseries = append(v2Req.Timeseries, v2TS)
+	}
+	v2Req.Symbols = symbols.Symbols()
+
+	m, _, err := prw.translateV2(ctx, &v2Req)
+	return m, err
+}
+
+// inferMetadataV1 infers the type of the metric family of the series of a classic histogram or summary from its
+// labels, for the metric families without metadata.
+func (prw *prometheusRemoteWriteReceiver) inferMetadataV1(ls []prompb.Label) {
+	var name string
+	var hasBucket, hasQuantile bool
+	for _, l := range ls {
+		switch l.Name {
+		case labels.MetricName:
+			name = l.Value
+		case labels.BucketLabel:
+			hasBucket = true
+		case model.QuantileLabel:
+			hasQuantile = true
+		}
+	}
+
+	switch {
+	case name == "":
+		return
+	case hasBucket && strings.HasSuffix(name, "_bucket"):
+		family := strings.TrimSuffix(name, "_bucket")
+		prw.metadataCache.ContainsOrAdd(family, prompb.MetricMetadata{
+			Type:             prompb.MetricMetadata_HISTOGRAM,
+			MetricFamilyName: family,
+		})
+	case hasQuantile:
+		prw.metadataCache.ContainsOrAdd(name, prompb.MetricMetadata{
+			Type:             prompb.MetricMetadata_SUMMARY,
+			MetricFamilyName: name,
+		})
+	}
+}
+
+// metadataV1 returns the metadata of the metric family of a series, from its metric name.
+func (prw *prometheusRemoteWriteReceiver) metadataV1(name string) prompb.MetricMetadata {
+	if md, ok := prw.metadataCache.Get(name); ok {
+		return md
+	}
+
+	for suffix, types := range map[string][]prompb.MetricMetadata_MetricType{
+		"_bucket": {prompb.MetricMetadata_HISTOGRAM},
+		"_sum":    {prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_SUMMARY},
+		"_count":  {prompb.MetricMetadata_HISTOGRAM, prompb.MetricMetadata_SUMMARY},
+		"_total":  {prompb.MetricMetadata_COUNTER},
+	} {
+		family, found := strings.CutSuffix(name, suffix)
+		if !found {
+			continue
+		}
+		if md, ok := prw.metadataCache.Get(family); ok {
+			for _, t := range types {
+				if md.Type == t {
+					return md
+				}
+			}
+		}
+	}
+
+	return prompb.MetricMetadata{Type: prompb.MetricMetadata_UNKNOWN, MetricFamilyName: name}
+}
+
+// metricTypeV1 returns the v2 metric type of a v1 metric type. Unknown metric types, and the ones without OTLP
+// equivalent, are translated into gauges.
+func metricTypeV1(t prompb.MetricMetadata_MetricType) writev2.Metadata_MetricType {
+	switch t {
+	case prompb.MetricMetadata_COUNTER:
+		return writev2.Metadata_METRIC_TYPE_COUNTER
+	case prompb.MetricMetadata_HISTOGRAM:
+		return writev2.Metadata_METRIC_TYPE_HISTOGRAM
+	case prompb.MetricMetadata_SUMMARY:
+		return writev2.Metadata_METRIC_TYPE_SUMMARY
+	default:
+		return writev2.Metadata_METRIC_TYPE_GAUGE
+	}
+}